@@ -0,0 +1,7 @@
+//go:build !windows
+
+package fang
+
+func enableVirtualTerminal(uintptr) bool { return true }
+
+func isLegacyConsole() bool { return false }
@@ -0,0 +1,33 @@
+//go:build windows
+
+package fang
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal tries to enable the processing of ANSI escape
+// sequences in the given console, returning false if it isn't available, as
+// is the case in older versions of the Windows console host.
+func enableVirtualTerminal(fd uintptr) bool {
+	var mode uint32
+	h := windows.Handle(fd)
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		// not a console.
+		return true
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(h, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+// isLegacyConsole reports whether we are running in the Windows console host
+// (conhost), as opposed to Windows Terminal, ConEmu, VS Code, etc.
+func isLegacyConsole() bool {
+	return os.Getenv("WT_SESSION") == "" &&
+		os.Getenv("ConEmuANSI") != "ON" &&
+		os.Getenv("TERM_PROGRAM") == ""
+}
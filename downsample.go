@@ -0,0 +1,133 @@
+package fang
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// minChroma is the chroma below which a color is considered a shade of gray
+// when downsampling to 16 colors.
+const minChroma = 0.1
+
+// Downsample returns a copy of the colorscheme with its colors converted to
+// the given profile.
+//
+// Unlike the conversion done by [colorprofile.Writer], the 256 colors
+// approximations are picked by perceptual distance, and never use the first
+// 16 colors, as those depend on the terminal's theme. The 16 colors
+// approximations keep the hue of the original colors, so pastel colors
+// don't all turn into white or gray.
+//
+// Colors that already fit the given profile are kept as is, so custom
+// approximations can be set in a [ColorSchemeFunc].
+func (cs ColorScheme) Downsample(p colorprofile.Profile) ColorScheme {
+	if p == colorprofile.TrueColor || p <= colorprofile.Ascii {
+		return cs
+	}
+	c := func(c color.Color) color.Color {
+		return downsampleColor(c, p)
+	}
+	return ColorScheme{
+		Base:           c(cs.Base),
+		Title:          c(cs.Title),
+		Description:    c(cs.Description),
+		Codeblock:      c(cs.Codeblock),
+		Program:        c(cs.Program),
+		DimmedArgument: c(cs.DimmedArgument),
+		Comment:        c(cs.Comment),
+		Flag:           c(cs.Flag),
+		FlagDefault:    c(cs.FlagDefault),
+		Command:        c(cs.Command),
+		QuotedString:   c(cs.QuotedString),
+		Argument:       c(cs.Argument),
+		Help:           c(cs.Help),
+		Dash:           c(cs.Dash),
+		ErrorHeader:    [2]color.Color{c(cs.ErrorHeader[0]), c(cs.ErrorHeader[1])},
+		ErrorDetails:   c(cs.ErrorDetails),
+		Logo:           [2]color.Color{c(cs.Logo[0]), c(cs.Logo[1])},
+	}
+}
+
+func downsampleColor(c color.Color, p colorprofile.Profile) color.Color {
+	switch c := c.(type) {
+	case nil:
+		return nil
+	case ansi.BasicColor:
+		return c
+	case ansi.ExtendedColor:
+		if p == colorprofile.ANSI256 {
+			return c
+		}
+	}
+
+	cc, ok := colorful.MakeColor(c)
+	if !ok {
+		return c
+	}
+	if p == colorprofile.ANSI {
+		return toANSI(cc)
+	}
+	return toANSI256(cc)
+}
+
+// toANSI256 returns the perceptually nearest color in the 16-255 range.
+func toANSI256(c colorful.Color) ansi.ExtendedColor {
+	var best ansi.ExtendedColor
+	dist := math.MaxFloat64
+	for i := 16; i <= 255; i++ {
+		candidate := ansi.ExtendedColor(i) //nolint:gosec
+		cc, _ := colorful.MakeColor(candidate)
+		if d := c.DistanceCIEDE2000(cc); d < dist {
+			dist = d
+			best = candidate
+		}
+	}
+	return best
+}
+
+// toANSI returns the basic color with the nearest hue, picking between its
+// normal and bright variants by lightness. Grays are picked by lightness
+// only.
+func toANSI(c colorful.Color) ansi.BasicColor {
+	h, chroma, l := c.Hcl()
+	if chroma < minChroma {
+		return nearestLightness(l, ansi.Black, ansi.BrightBlack, ansi.White, ansi.BrightWhite)
+	}
+
+	best := ansi.Red
+	dist := math.MaxFloat64
+	for i := ansi.Red; i <= ansi.Cyan; i++ {
+		bh, _, _ := basicHcl(i)
+		d := math.Abs(h - bh)
+		if d > 180 {
+			d = 360 - d
+		}
+		if d < dist {
+			dist = d
+			best = i
+		}
+	}
+	return nearestLightness(l, best, best+8)
+}
+
+func nearestLightness(l float64, colors ...ansi.BasicColor) ansi.BasicColor {
+	best := colors[0]
+	dist := math.MaxFloat64
+	for _, c := range colors {
+		_, _, cl := basicHcl(c)
+		if d := math.Abs(l - cl); d < dist {
+			dist = d
+			best = c
+		}
+	}
+	return best
+}
+
+func basicHcl(c ansi.BasicColor) (float64, float64, float64) {
+	cc, _ := colorful.MakeColor(c)
+	return cc.Hcl()
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"strings"
 
 	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/lipgloss/v2"
@@ -28,6 +29,10 @@ type ErrorHandler = func(w io.Writer, styles Styles, err error)
 // ColorSchemeFunc gets a [lipgloss.LightDarkFunc] and returns a [ColorScheme].
 type ColorSchemeFunc = func(lipgloss.LightDarkFunc) ColorScheme
 
+// BannerFunc gets the [Styles] in use and returns the banner to be printed
+// above the root command's help.
+type BannerFunc = func(styles Styles) string
+
 type settings struct {
 	completions bool
 	manpages    bool
@@ -37,6 +42,11 @@ type settings struct {
 	colorscheme ColorSchemeFunc
 	errHandler  ErrorHandler
 	signals     []os.Signal
+	banner      BannerFunc
+	helpFooter  string
+	logoHeader  bool
+	noBgs       bool
+	screenshots bool
 }
 
 // Option changes fang settings.
@@ -110,6 +120,62 @@ func WithNotifySignal(signals ...os.Signal) Option {
 	}
 }
 
+// WithBanner sets a banner, such as a logo or a tagline, to be printed above
+// the root command's help.
+//
+// The banner is printed as-is, so ASCII art won't get wrapped.
+func WithBanner(banner string) Option {
+	return WithBannerFunc(func(styles Styles) string {
+		return styles.Banner.Render(banner)
+	})
+}
+
+// WithBannerFunc sets a function that renders the banner printed above the
+// root command's help.
+func WithBannerFunc(fn BannerFunc) Option {
+	return func(s *settings) {
+		s.banner = fn
+	}
+}
+
+// WithHelpFooter sets a footer, such as links to the docs or to the issue
+// tracker, to be printed at the bottom of every help screen.
+//
+// URLs in the footer are hyperlinked if the terminal supports it.
+func WithHelpFooter(footer string) Option {
+	return func(s *settings) {
+		s.helpFooter = footer
+	}
+}
+
+// WithLogoHeader prints the program name in a large font, colored with the
+// colorscheme's logo gradient, above the root command's help and the version.
+func WithLogoHeader() Option {
+	return func(s *settings) {
+		s.logoHeader = true
+	}
+}
+
+// WithoutBackgrounds disables the background fills in the usage and examples
+// blocks, which get a left border instead. Foreground colors are kept.
+func WithoutBackgrounds() Option {
+	return func(s *settings) {
+		s.noBgs = true
+	}
+}
+
+// WithScreenshots adds a hidden `docs screenshots` command, which renders the
+// help of every command as a SVG image, at a fixed width and color profile.
+//
+// This is meant to keep the help screenshots in READMEs up to date, e.g. by
+// running `app docs screenshots -o ./docs` in CI. The SVGs can be converted to
+// PNG with tools such as `rsvg-convert`.
+func WithScreenshots() Option {
+	return func(s *settings) {
+		s.screenshots = true
+	}
+}
+
 // Execute applies fang to the command and executes it.
 func Execute(ctx context.Context, root *cobra.Command, options ...Option) error {
 	opts := settings{
@@ -124,8 +190,8 @@ func Execute(ctx context.Context, root *cobra.Command, options ...Option) error
 	}
 
 	helpFunc := func(c *cobra.Command, _ []string) {
-		w := colorprofile.NewWriter(c.OutOrStdout(), os.Environ())
-		helpFn(c, w, makeStyles(mustColorscheme(opts.colorscheme)))
+		w := newWriter(c.OutOrStdout())
+		opts.writeHelp(c, w, opts.styles(w.Profile), terminalWidth())
 	}
 
 	root.SilenceUsage = true
@@ -135,6 +201,18 @@ func Execute(ctx context.Context, root *cobra.Command, options ...Option) error
 	}
 	root.SetHelpFunc(helpFunc)
 
+	if opts.logoHeader {
+		// the version template can't be given a writer, so the logo is
+		// rendered lazily by a template function instead.
+		logos.Store(root, func(p colorprofile.Profile) Logo {
+			return opts.styles(p).Logo
+		})
+		defer logos.Delete(root)
+		if tmpl := "{{" + logoTemplateFunc + " .}}"; !strings.HasPrefix(root.VersionTemplate(), tmpl) {
+			root.SetVersionTemplate(tmpl + root.VersionTemplate())
+		}
+	}
+
 	if opts.manpages {
 		root.AddCommand(&cobra.Command{
 			Use:                   "man",
@@ -156,6 +234,10 @@ func Execute(ctx context.Context, root *cobra.Command, options ...Option) error
 		})
 	}
 
+	if opts.screenshots {
+		root.AddCommand(docsCmd(opts))
+	}
+
 	if !opts.completions {
 		root.CompletionOptions.DisableDefaultCmd = true
 	}
@@ -175,13 +257,51 @@ func Execute(ctx context.Context, root *cobra.Command, options ...Option) error
 				return err //nolint:wrapcheck
 			}
 		}
-		w := colorprofile.NewWriter(root.ErrOrStderr(), os.Environ())
-		opts.errHandler(w, makeStyles(mustColorscheme(opts.colorscheme)), err)
+		w := newWriter(root.ErrOrStderr())
+		opts.errHandler(w, opts.styles(w.Profile), err)
 		return err //nolint:wrapcheck
 	}
 	return nil
 }
 
+// writeHelp writes the full help of the given command, including the root
+// command's logo and banner, and the footer, fitting it in the given width.
+func (s settings) writeHelp(c *cobra.Command, w *colorprofile.Writer, styles Styles, width int) {
+	if s.logoHeader && !c.HasParent() {
+		writeLogo(w, c.Name(), styles.Logo)
+	}
+	if s.banner != nil && !c.HasParent() {
+		writeBanner(w, s.banner(styles))
+	}
+	helpFn(c, w, styles, width)
+	writeHelpFooter(w, styles, s.helpFooter, width)
+}
+
+func (s settings) styles(p colorprofile.Profile) Styles {
+	// the legacy Windows console doesn't render padded background fills
+	// properly.
+	return s.stylesFor(mustColorscheme(s.colorscheme), p, p > colorprofile.NoTTY && isLegacyConsole(), terminalWidth())
+}
+
+func (s settings) stylesFor(cs ColorScheme, p colorprofile.Profile, noBgs bool, width int) Styles {
+	cs = cs.Downsample(p)
+	styles := makeStyles(cs, width)
+	if s.noBgs || noBgs {
+		styles = withoutBackgrounds(styles, cs)
+	}
+	return styles
+}
+
+// newWriter creates a [colorprofile.Writer] for the given writer, falling
+// back to plain text if it is a console that can't process escape sequences.
+func newWriter(w io.Writer) *colorprofile.Writer {
+	cw := colorprofile.NewWriter(w, os.Environ())
+	if f, ok := w.(term.File); ok && term.IsTerminal(f.Fd()) && !enableVirtualTerminal(f.Fd()) {
+		cw.Profile = colorprofile.NoTTY
+	}
+	return cw
+}
+
 func buildVersion(opts settings) string {
 	commit := opts.commit
 	version := opts.version
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/charmbracelet/fang"
@@ -115,6 +117,105 @@ func TestSetup(t *testing.T) {
 		)
 	})
 
+	t.Run("with banner", func(t *testing.T) {
+		mkroot := func() *cobra.Command {
+			cmd := &cobra.Command{
+				Use:   "simple",
+				Short: "Short help",
+			}
+			cmd.AddCommand(&cobra.Command{
+				Use:   "sub",
+				Short: "a sub command",
+			})
+			return cmd
+		}
+		banner := fang.WithBanner(` _____ _                 _
+|   __|_|_____ ___ ___  | |___
+|__   | |     | . | | |_| | -_|
+|_____|_|_|_|_|  _|___|___|___|
+              |_|`)
+
+		exercise(t, mkroot, banner)
+
+		t.Run("help-sub", func(t *testing.T) {
+			doExercise(
+				t,
+				mkroot,
+				[]string{"sub", "--help"},
+				assertNoError,
+				banner,
+			)
+		})
+	})
+
+	t.Run("with help footer", func(t *testing.T) {
+		mkroot := func() *cobra.Command {
+			cmd := &cobra.Command{
+				Use:   "simple",
+				Short: "Short help",
+			}
+			cmd.AddCommand(&cobra.Command{
+				Use:   "sub",
+				Short: "a sub command",
+			})
+			return cmd
+		}
+		footer := fang.WithHelpFooter("Docs: https://example.com • Report bugs: https://example.com/issues")
+
+		exercise(t, mkroot, footer)
+
+		t.Run("help-sub", func(t *testing.T) {
+			doExercise(
+				t,
+				mkroot,
+				[]string{"sub", "--help"},
+				assertNoError,
+				footer,
+			)
+		})
+	})
+
+	t.Run("with logo header", func(t *testing.T) {
+		mkroot := func() *cobra.Command {
+			cmd := &cobra.Command{
+				Use:   "simple",
+				Short: "Short help",
+			}
+			cmd.AddCommand(&cobra.Command{
+				Use:   "sub",
+				Short: "a sub command",
+			})
+			return cmd
+		}
+
+		exercise(t, mkroot, fang.WithLogoHeader(), fang.WithVersion("v1.2.3"))
+
+		t.Run("help-sub", func(t *testing.T) {
+			doExercise(
+				t,
+				mkroot,
+				[]string{"sub", "--help"},
+				assertNoError,
+				fang.WithLogoHeader(),
+			)
+		})
+	})
+
+	t.Run("without backgrounds", func(t *testing.T) {
+		exercise(
+			t,
+			toMkroot(&cobra.Command{
+				Use:   "simple",
+				Short: "Short help",
+				Example: `
+# Run it:
+simple --flag "quoted value"
+`,
+			}),
+			fang.WithoutBackgrounds(),
+		)
+	})
+
 	t.Run("with flags", func(t *testing.T) {
 		mkroot := func() *cobra.Command {
 			cmd := &cobra.Command{
@@ -281,6 +382,30 @@ echo 'foo' |
 	})
 }
 
+func TestScreenshots(t *testing.T) {
+	t.Setenv("__FANG_TEST_WIDTH", "45")
+	dir := t.TempDir()
+
+	root := &cobra.Command{Use: "app", Short: "An app"}
+	root.AddCommand(
+		&cobra.Command{Use: "sub", Short: "A sub command"},
+		&cobra.Command{Use: "secret", Hidden: true},
+	)
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetArgs([]string{"docs", "screenshots", "-o", dir, "--width", "60"})
+
+	require.NoError(t, fang.Execute(t.Context(), root, fang.WithScreenshots()))
+	require.FileExists(t, filepath.Join(dir, "app.svg"))
+	require.FileExists(t, filepath.Join(dir, "app-sub.svg"))
+	require.NoFileExists(t, filepath.Join(dir, "app-secret.svg"))
+	require.NoFileExists(t, filepath.Join(dir, "app-docs.svg"))
+
+	svg, err := os.ReadFile(filepath.Join(dir, "app-sub.svg"))
+	require.NoError(t, err)
+	require.Contains(t, string(svg), "A sub command")
+}
+
 func exercise(t *testing.T, mkroot func() *cobra.Command, options ...fang.Option) {
 	t.Helper()
 
@@ -27,7 +27,9 @@ const (
 	longPad  = 4
 )
 
-var width = sync.OnceValue(func() int {
+// terminalWidth is the width the help is rendered at, at most 120 columns.
+// Rendering takes it as an argument, so screenshots can use their own.
+var terminalWidth = sync.OnceValue(func() int {
 	if s := os.Getenv("__FANG_TEST_WIDTH"); s != "" {
 		w, _ := strconv.Atoi(s)
 		return w
@@ -36,20 +38,24 @@ var width = sync.OnceValue(func() int {
 	if err != nil {
 		return 120
 	}
+	if isLegacyConsole() {
+		// the legacy Windows console wraps when writing to the last column.
+		w--
+	}
 	return min(w, 120)
 })
 
-func helpFn(c *cobra.Command, w *colorprofile.Writer, styles Styles) {
-	writeLongShort(w, styles, cmp.Or(c.Long, c.Short))
+func helpFn(c *cobra.Command, w *colorprofile.Writer, styles Styles, width int) {
+	writeLongShort(w, styles, cmp.Or(c.Long, c.Short), width)
 	usage := styleUsage(c, styles.Codeblock.Program, true)
 	examples := styleExamples(c, styles)
 
-	padding := styles.Codeblock.Base.GetHorizontalPadding()
+	padding := styles.Codeblock.Base.GetHorizontalPadding() + styles.Codeblock.Base.GetHorizontalBorderSize()
 	blockWidth := lipgloss.Width(usage)
 	for _, ex := range examples {
 		blockWidth = max(blockWidth, lipgloss.Width(ex))
 	}
-	blockWidth = min(width()-padding, blockWidth+padding)
+	blockWidth = min(width-padding, blockWidth+padding)
 	blockStyle := styles.Codeblock.Base.Width(blockWidth)
 
 	// if the color profile is ascii or notty, or if the block has no
@@ -61,7 +67,7 @@ func helpFn(c *cobra.Command, w *colorprofile.Writer, styles Styles) {
 	_, _ = fmt.Fprintln(w, styles.Title.Render("usage"))
 	_, _ = fmt.Fprintln(w, blockStyle.Render(usage))
 	if len(examples) > 0 {
-		cw := blockStyle.GetWidth() - blockStyle.GetHorizontalPadding()
+		cw := blockStyle.GetWidth() - padding
 		_, _ = fmt.Fprintln(w, styles.Title.Render("examples"))
 		for i, example := range examples {
 			if lipgloss.Width(example) > cw {
@@ -141,12 +147,65 @@ func isUsageError(err error) bool {
 	return false
 }
 
-func writeLongShort(w *colorprofile.Writer, styles Styles, longShort string) {
+func writeBanner(w io.Writer, banner string) {
+	if banner == "" {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, banner)
+}
+
+var urlRe = regexp.MustCompile(`https?://[^\s\x1b]+`)
+
+func writeHelpFooter(w *colorprofile.Writer, styles Styles, footer string, width int) {
+	if footer == "" {
+		return
+	}
+	if w.Profile > colorprofile.Ascii {
+		footer = hyperlinkURLs(footer)
+	}
+	// links go in before wrapping, and only at spaces, so a URL is never cut
+	// in two and its link covers it whole.
+	footer = wrapWords(footer, width-longPad)
+	_, _ = fmt.Fprintln(w, styles.HelpFooter.Render(footer))
+	_, _ = fmt.Fprintln(w)
+}
+
+// wrapWords wraps the given string at spaces only, leaving words wider than
+// the limit on a line of their own.
+func wrapWords(s string, limit int) string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		var line string
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case ansi.StringWidth(line)+1+ansi.StringWidth(word) <= limit:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hyperlinkURLs wraps all the URLs in the given string in OSC 8 hyperlinks.
+func hyperlinkURLs(s string) string {
+	return urlRe.ReplaceAllStringFunc(s, func(url string) string {
+		return ansi.SetHyperlink(url) + url + ansi.ResetHyperlink()
+	})
+}
+
+func writeLongShort(w *colorprofile.Writer, styles Styles, longShort string, width int) {
 	if longShort == "" {
 		return
 	}
 	_, _ = fmt.Fprintln(w)
-	_, _ = fmt.Fprintln(w, styles.Text.Width(width()).PaddingLeft(shortPad).Render(longShort))
+	_, _ = fmt.Fprintln(w, styles.Text.Width(width).PaddingLeft(shortPad).Render(longShort))
 }
 
 var otherArgsRe = regexp.MustCompile(`(\[.*\])`)
@@ -1,8 +1,13 @@
 package fang
 
 import (
+	"bytes"
 	"errors"
 	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
 )
 
 func TestIsUsageError(t *testing.T) {
@@ -26,3 +31,20 @@ func TestIsUsageError(t *testing.T) {
 		}
 	})
 }
+
+func TestHyperlinkURLs(t *testing.T) {
+	got := hyperlinkURLs("Docs: https://example.com • Bugs: http://example.com/issues")
+	expected := "Docs: \x1b]8;;https://example.com\x07https://example.com\x1b]8;;\x07 • " +
+		"Bugs: \x1b]8;;http://example.com/issues\x07http://example.com/issues\x1b]8;;\x07"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestHelpFooterLongURL(t *testing.T) {
+	var buf bytes.Buffer
+	w := &colorprofile.Writer{Forward: &buf, Profile: colorprofile.TrueColor}
+	styles := makeStyles(DefaultColorScheme(lipgloss.LightDark(true)), 45)
+	writeHelpFooter(w, styles, "Docs: https://example.com/docs/getting-started/installation • Bugs: https://example.com/issues", 45)
+	golden.RequireEqual(t, buf.Bytes())
+}
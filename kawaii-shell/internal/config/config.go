@@ -0,0 +1,283 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the kawaii shell configuration
+type Config struct {
+	Prompt  PromptConfig      `yaml:"prompt"`
+	Aliases map[string]string `yaml:"aliases"`
+	Notify  NotifyConfig      `yaml:"notify"`
+	Sandbox SandboxConfig     `yaml:"sandbox"`
+	Pet     PetConfig         `yaml:"pet"`
+
+	// Quiet keeps the shell from making any sound, no bell and no pet
+	// sounds
+	Quiet bool `yaml:"quiet"`
+
+	// Scrollback is how many lines of output each pane keeps, the oldest
+	// ones going first, 0 keeps them all
+	Scrollback int `yaml:"scrollback"`
+
+	// Theme is the theme the shell looks like, like ocean, sakura when
+	// it's empty
+	Theme string `yaml:"theme"`
+
+	// ThemeSchedule switches between a day and a night theme
+	ThemeSchedule ThemeScheduleConfig `yaml:"theme_schedule"`
+
+	// Background is light or dark, the terminal's background the themes
+	// are made to read on, asking the terminal when it's empty
+	Background string `yaml:"background"`
+
+	// ThemeRegistry is where theme install gets themes by their name
+	ThemeRegistry string `yaml:"theme_registry"`
+
+	// Components change styles on top of every theme, by the names theme
+	// files use, like pet_box
+	Components map[string]themes.StyleFile `yaml:"components"`
+
+	// SyncBackground gives the terminal the background of the theme while
+	// the shell runs, with OSC 11
+	SyncBackground bool `yaml:"sync_background"`
+
+	// ColorVision is deuteranopia, protanopia or tritanopia, the built-in
+	// themes taking colors and shapes for it that keep success, warnings
+	// and errors apart
+	ColorVision string `yaml:"color_vision"`
+
+	// Seasons dresses the shell up for Halloween, the winter holidays and
+	// the cherry blossoms while they last
+	Seasons bool `yaml:"seasons"`
+}
+
+// PromptConfig configures the prompt segments
+type PromptConfig struct {
+	// Segments are shown in order, the available ones are cwd, git, time,
+	// status, pet and symbol
+	Segments   []string `yaml:"segments"`
+	Separator  string   `yaml:"separator"`
+	Symbol     string   `yaml:"symbol"`
+	TimeFormat string   `yaml:"time_format"`
+}
+
+// NotifyConfig configures how long commands tell they're done
+type NotifyConfig struct {
+	// After is how long a command runs before it's worth telling when it
+	// finishes
+	After time.Duration `yaml:"after"`
+
+	// Desktop and Bell are used while the terminal isn't focused
+	Desktop bool `yaml:"desktop"`
+	Bell    bool `yaml:"bell"`
+}
+
+// SandboxConfig configures the sandbox commands can be tried out in
+type SandboxConfig struct {
+	// Tool is bwrap, firejail, podman or docker, the first one installed
+	// is used when it's empty
+	Tool string `yaml:"tool"`
+
+	// Image is the container image podman and docker use
+	Image string `yaml:"image"`
+}
+
+// PetConfig configures the pets
+type PetConfig struct {
+	// Evolution is the levels pets grow up and turn mythic at
+	Evolution []int `yaml:"evolution"`
+
+	// FocusHours are the times of day pets keep quiet, only reacting to
+	// some commands
+	FocusHours []Hours `yaml:"focus_hours"`
+
+	// Sounds is how pets are heard on level-ups, warnings and greetings:
+	// off, bell for patterns of the terminal bell or system for the
+	// system's sounds
+	Sounds string `yaml:"sounds"`
+}
+
+// ThemeScheduleConfig configures the switching between a day and a night
+// theme, which is off unless both are set
+type ThemeScheduleConfig struct {
+	Day   string `yaml:"day"`
+	Night string `yaml:"night"`
+
+	// DayHours are the hours of the day theme
+	DayHours Hours `yaml:"day_hours"`
+
+	// System follows the system's dark mode instead of the hours, when it
+	// can be told
+	System bool `yaml:"system"`
+}
+
+// Hours is a time of day, like 09:00-12:00, which goes past midnight when
+// it ends before it starts
+type Hours struct {
+	// From and To are how long after midnight the hours start and end
+	From, To time.Duration
+}
+
+// UnmarshalYAML decodes hours written like 09:00-12:00
+func (h *Hours) UnmarshalYAML(node *yaml.Node) error {
+	from, to, ok := strings.Cut(node.Value, "-")
+	if !ok {
+		return fmt.Errorf("invalid hours %q, write them like 09:00-12:00", node.Value)
+	}
+	var err error
+	if h.From, err = parseClock(from); err != nil {
+		return err
+	}
+	h.To, err = parseClock(to)
+	return err
+}
+
+// parseClock returns how long after midnight the time of day is
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, write it like 09:00", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether the time is within the hours
+func (h Hours) Contains(t time.Time) bool {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if h.From <= h.To {
+		return clock >= h.From && clock < h.To
+	}
+	return clock >= h.From || clock < h.To
+}
+
+// Default returns the default configuration
+func Default() Config {
+	return Config{
+		Prompt: PromptConfig{
+			Segments:   []string{"cwd", "git", "status", "symbol"},
+			Separator:  " ",
+			Symbol:     "🌸>",
+			TimeFormat: "15:04",
+		},
+		Notify: NotifyConfig{
+			After:   10 * time.Second,
+			Desktop: true,
+			Bell:    true,
+		},
+		Pet: PetConfig{
+			Evolution: []int{5, 15},
+			Sounds:    "off",
+		},
+		ThemeSchedule: ThemeScheduleConfig{
+			DayHours: Hours{From: 7 * time.Hour, To: 19 * time.Hour},
+		},
+		Scrollback:    10000,
+		ThemeRegistry: themes.DefaultRegistry,
+		Seasons:       true,
+	}
+}
+
+// Dir returns the kawaii configuration directory, honoring XDG_CONFIG_HOME
+func Dir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "kawaii")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "kawaii")
+}
+
+// Path returns the path of the configuration file
+func Path() string {
+	return filepath.Join(Dir(), "config.yaml")
+}
+
+// Load reads the configuration file, using the defaults for anything it
+// doesn't set. A missing file isn't an error.
+func Load() (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(Path())
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Default(), fmt.Errorf("invalid config %s: %w", Path(), err)
+	}
+	return cfg, nil
+}
+
+// SaveAliases replaces the aliases in the configuration file, keeping the
+// rest of it and its comments as they are
+func SaveAliases(aliases map[string]string) error {
+	return save("aliases", aliases)
+}
+
+// SaveTheme sets the theme in the configuration file, keeping the rest of
+// it and its comments as they are
+func SaveTheme(theme string) error {
+	return save("theme", theme)
+}
+
+// save sets the key of the configuration file to the value, keeping the
+// rest of it and its comments as they are
+func save(key string, v any) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(Path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config %s: %w", Path(), err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config %s: not a mapping", Path())
+	}
+
+	var value yaml.Node
+	if err := value.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	replaced := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1] = &value
+			replaced = true
+		}
+	}
+	if !replaced {
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+	}
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(Path(), out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
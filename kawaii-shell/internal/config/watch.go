@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long files are left to settle once they change before
+// it's told, as editors save them in a few steps
+const watchSettle = 150 * time.Millisecond
+
+// Change is what changed in the configuration directory
+type Change struct {
+	// Config is whether the configuration file changed, and Themes whether
+	// a theme file did
+	Config, Themes bool
+}
+
+// Watcher tells when the configuration file or a theme file changes
+type Watcher struct {
+	fs        *fsnotify.Watcher
+	themesDir string
+}
+
+// Watch starts watching the configuration file and the theme files, nil
+// when there's no configuration directory to watch yet. The directories
+// are watched rather than the files, which editors often replace.
+func Watch() (*Watcher, error) {
+	dir := Dir()
+	if _, err := os.Stat(dir); dir == "" || errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch config: %w", err)
+	}
+	w := &Watcher{fs: fs, themesDir: filepath.Join(dir, "themes")}
+	if err := fs.Add(dir); err != nil {
+		fs.Close()
+		return nil, fmt.Errorf("failed to watch config: %w", err)
+	}
+	// the themes directory is watched once it's made otherwise
+	if err := fs.Add(w.themesDir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fs.Close()
+		return nil, fmt.Errorf("failed to watch themes: %w", err)
+	}
+	return w, nil
+}
+
+// Next waits for the files to change, returning what changed once they
+// settled, and an error once the watcher stopped. Changes that went
+// unseen count as changes to all of them.
+func (w *Watcher) Next() (Change, error) {
+	var change Change
+	var settled <-chan time.Time
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return change, errors.New("stopped watching config")
+			}
+			if w.note(event, &change) && settled == nil {
+				settled = time.After(watchSettle)
+			}
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return change, errors.New("stopped watching config")
+			}
+			change = Change{Config: true, Themes: true}
+			if settled == nil {
+				settled = time.After(watchSettle)
+			}
+		case <-settled:
+			return change, nil
+		}
+	}
+}
+
+// note adds the event to the change, reporting whether it's one
+func (w *Watcher) note(event fsnotify.Event, change *Change) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	switch {
+	case event.Name == Path():
+		change.Config = true
+	case event.Name == w.themesDir:
+		if event.Has(fsnotify.Create) {
+			// it may be made with the theme files already in it
+			_ = w.fs.Add(w.themesDir)
+		}
+		change.Themes = true
+	case filepath.Dir(event.Name) == w.themesDir && filepath.Ext(event.Name) == ".yaml":
+		change.Themes = true
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package shell
+
+import (
+	"maps"
+	"strings"
+)
+
+// Aliases maps short names to the commands they stand for
+type Aliases map[string]string
+
+// Expand replaces the commands that are aliases, the first word of the line
+// and the ones after ;, &&, || and |. Aliases can use other aliases, but not
+// themselves, so alias ls='ls -G' works.
+func (a Aliases) Expand(command string) string {
+	return a.expand(command, map[string]bool{})
+}
+
+// expand replaces the aliases in the command, leaving the ones already
+// being expanded alone
+func (a Aliases) expand(command string, seen map[string]bool) string {
+	tokens := Tokenize(command)
+	var sb strings.Builder
+	for i, token := range tokens {
+		value, ok := a[token.Text]
+		// a command partly quoted, like l"s", isn't an alias
+		whole := i+1 == len(tokens) || tokens[i+1].Kind == TokenSpace || tokens[i+1].Kind == TokenOperator
+		if token.Kind != TokenCommand || !ok || !whole || seen[token.Text] {
+			sb.WriteString(token.Text)
+			continue
+		}
+		inner := maps.Clone(seen)
+		inner[token.Text] = true
+		sb.WriteString(a.expand(value, inner))
+	}
+	return sb.String()
+}
+
+// ParseAlias parses a name=value definition as given to the alias command,
+// with the quotes already removed
+func ParseAlias(definition string) (string, string, bool) {
+	name, value, ok := strings.Cut(definition, "=")
+	if !ok || name == "" || strings.ContainsAny(name, " \t/'\"$`;|&") {
+		return "", "", false
+	}
+	return name, value, true
+}
@@ -0,0 +1,54 @@
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ReadAppearance reports whether the system is in dark mode, from the
+// interface style on macOS, the apps theme on Windows and the GNOME color
+// scheme elsewhere. ok is false when it can't be told.
+func ReadAppearance() (dark, ok bool) {
+	switch runtime.GOOS {
+	case "darwin":
+		// the key only exists in dark mode
+		out, err := exec.Command("defaults", "read", "-g", "AppleInterfaceStyle").Output()
+		return err == nil && strings.Contains(string(out), "Dark"), true
+	case "windows":
+		out, err := exec.Command("reg", "query", `HKCU\Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`, "/v", "AppsUseLightTheme").Output()
+		if err != nil {
+			return false, false
+		}
+		return strings.Contains(string(out), "0x0"), true
+	default:
+		out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output()
+		if err != nil {
+			return false, false
+		}
+		return strings.Contains(string(out), "dark"), true
+	}
+}
+
+// HasLightBackground reports whether the terminal has a light background,
+// asking the terminal, or going by COLORFGBG when it doesn't answer. It
+// reads from the terminal, so it's called before the shell starts.
+func HasLightBackground() bool {
+	if bg, err := lipgloss.BackgroundColor(os.Stdin, os.Stdout); err == nil && bg != nil {
+		if c, ok := colorful.MakeColor(bg); ok {
+			_, _, l := c.Hsl()
+			return l >= 0.5
+		}
+	}
+	// COLORFGBG is like 0;15, the background coming last
+	colors := strings.Split(os.Getenv("COLORFGBG"), ";")
+	switch colors[len(colors)-1] {
+	case "7", "15":
+		return true
+	}
+	return false
+}
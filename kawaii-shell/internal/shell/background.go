@@ -0,0 +1,40 @@
+package shell
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// SetTerminalColors sets the terminal's default background and foreground
+// with OSC 11 and OSC 10, the unset ones going back to the terminal's own
+func SetTerminalColors(w io.Writer, bg, fg color.Color) error {
+	seq := ansi.ResetBackgroundColor
+	if isSet(bg) {
+		seq = ansi.SetBackgroundColor(bg)
+	}
+	if isSet(fg) {
+		seq += ansi.SetForegroundColor(fg)
+	} else {
+		seq += ansi.ResetForegroundColor
+	}
+	if _, err := io.WriteString(w, seq); err != nil {
+		return fmt.Errorf("failed to set the terminal's colors: %w", err)
+	}
+	return nil
+}
+
+// ResetTerminalColors gives the terminal its own default background and
+// foreground back
+func ResetTerminalColors(w io.Writer) error {
+	return SetTerminalColors(w, nil, nil)
+}
+
+// isSet reports whether the color is set
+func isSet(c color.Color) bool {
+	_, unset := c.(lipgloss.NoColor)
+	return c != nil && !unset
+}
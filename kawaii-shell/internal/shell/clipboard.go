@@ -0,0 +1,29 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// screenPassthroughLimit is the longest chunk GNU screen passes through
+const screenPassthroughLimit = 768
+
+// CopyToClipboard sets the system clipboard of the terminal with OSC 52,
+// which works over ssh too. Inside tmux and screen the sequence is wrapped
+// so it reaches the terminal around them.
+func CopyToClipboard(w io.Writer, text string) error {
+	seq := ansi.SetSystemClipboard(text)
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = ansi.TmuxPassthrough(seq)
+	case os.Getenv("STY") != "":
+		seq = ansi.ScreenPassthrough(seq, screenPassthroughLimit)
+	}
+	if _, err := io.WriteString(w, seq); err != nil {
+		return fmt.Errorf("failed to copy to the clipboard: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectCommandsFile is where a project describes its own commands, relative
+// to the project's directory or one of its parents
+var ProjectCommandsFile = filepath.Join(".kawaii", "commands.yaml")
+
+// CommandEntry describes a command in a commands file
+type CommandEntry struct {
+	CommandInfo `yaml:",inline"`
+
+	// Dangerous commands need to be confirmed before they run
+	Dangerous bool `yaml:"dangerous"`
+}
+
+// commandsFile is the format of the commands file
+type commandsFile struct {
+	Commands map[string]CommandEntry `yaml:"commands"`
+}
+
+// Commands describes commands in a cute way: the ones of the project the
+// shell is in come first, then the commands file, the plugins and CommandMap
+type Commands struct {
+	user    map[string]CommandEntry
+	project map[string]CommandEntry
+	plugins map[string]CommandEntry
+
+	// projectPath is the project's commands file that was loaded, and
+	// projectModTime when it was last changed
+	projectPath    string
+	projectModTime time.Time
+}
+
+// LoadCommands loads the commands file, CommandMap is used alone when it
+// doesn't exist
+func LoadCommands(path string) (*Commands, error) {
+	c := &Commands{}
+	entries, err := readCommands(path)
+	if err != nil {
+		return c, err
+	}
+	c.user = entries
+	return c, nil
+}
+
+// LoadProject picks up the commands of the project dir is in, when it has
+// a commands file. Nothing is read again while the file doesn't change.
+func (c *Commands) LoadProject(dir string) error {
+	path, modTime := findProjectCommands(dir)
+	if path == c.projectPath && modTime.Equal(c.projectModTime) {
+		return nil
+	}
+	c.project, c.projectPath, c.projectModTime = nil, path, modTime
+	if path == "" {
+		return nil
+	}
+	entries, err := readCommands(path)
+	if err != nil {
+		return err
+	}
+	c.project = entries
+	return nil
+}
+
+// AddPlugins describes the commands the plugins add
+func (c *Commands) AddPlugins(plugins Plugins) {
+	c.plugins = map[string]CommandEntry{}
+	for _, plugin := range plugins {
+		for _, command := range plugin.Commands {
+			emoji := command.Emoji
+			if emoji == "" {
+				emoji = "🧩"
+			}
+			c.plugins[command.Name] = CommandEntry{CommandInfo: CommandInfo{plugin.Name, emoji, command.Description}}
+		}
+	}
+}
+
+// Info returns cute info about a command
+func (c *Commands) Info(command string) CommandInfo {
+	info := GetCommandInfo(command)
+	entry, ok := c.lookup(command)
+	if !ok {
+		return info
+	}
+	// the file may only change some of the fields
+	if entry.FriendlyName != "" {
+		info.FriendlyName = entry.FriendlyName
+	}
+	if entry.Emoji != "" {
+		info.Emoji = entry.Emoji
+	}
+	if entry.Description != "" {
+		info.Description = entry.Description
+	}
+	return info
+}
+
+// Check returns a rule asking to confirm the command when one of the
+// commands chained in it is flagged as dangerous
+func (c *Commands) Check(command string) (DangerRule, bool) {
+	for _, part := range splitCommands(command)[1:] {
+		if entry, ok := c.lookup(part); ok && entry.Dangerous {
+			name := strings.Fields(part)[0]
+			return DangerRule{Pattern: name, Severity: SeverityConfirm, Reason: name + " is marked as dangerous, be careful!"}, true
+		}
+	}
+	return DangerRule{}, false
+}
+
+// lookup returns the entry of the command's name from the project, the
+// commands file or the plugins
+func (c *Commands) lookup(command string) (CommandEntry, bool) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return CommandEntry{}, false
+	}
+	for _, entries := range []map[string]CommandEntry{c.project, c.user, c.plugins} {
+		if entry, ok := entries[fields[0]]; ok {
+			return entry, true
+		}
+	}
+	return CommandEntry{}, false
+}
+
+// readCommands reads the entries of a commands file, none when it doesn't
+// exist
+func readCommands(path string) (map[string]CommandEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commands: %w", err)
+	}
+	var file commandsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid commands %s: %w", path, err)
+	}
+	return file.Commands, nil
+}
+
+// findProjectCommands returns the project commands file in dir or the
+// closest of its parents, and when it was last changed
+func findProjectCommands(dir string) (string, time.Time) {
+	if dir == "" {
+		return "", time.Time{}
+	}
+	for {
+		path := filepath.Join(dir, ProjectCommandsFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, info.ModTime()
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", time.Time{}
+		}
+		dir = parent
+	}
+}
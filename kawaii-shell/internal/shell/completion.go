@@ -0,0 +1,316 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCompletions is the maximum number of completion candidates returned
+const MaxCompletions = 50
+
+// Completion is a candidate to complete the word under the cursor with
+type Completion struct {
+	Value   string
+	Display string
+	IsDir   bool
+}
+
+// Completer completes executable names from PATH, aliases, flags and file
+// paths
+type Completer struct {
+	path        string
+	executables []string
+	aliases     Aliases
+	dirs        *Dirs
+	flags       *Flags
+
+	// extra are commands that aren't in PATH, like the ones plugins add
+	extra []string
+}
+
+// NewCompleter creates a new completer
+func NewCompleter() *Completer {
+	return &Completer{flags: NewFlags()}
+}
+
+// SetAliases sets the aliases completed along with the executables
+func (c *Completer) SetAliases(aliases Aliases) {
+	c.aliases = aliases
+}
+
+// SetExtraCommands sets commands completed along with the executables
+// which aren't in PATH
+func (c *Completer) SetExtraCommands(names []string) {
+	c.extra = names
+	c.executables = nil
+}
+
+// SetDirs sets the bookmarks and visited directories jump completes
+func (c *Completer) SetDirs(dirs *Dirs) {
+	c.dirs = dirs
+}
+
+// Complete returns the candidates for the word under the cursor, along with
+// the byte offset where that word starts in the input. When it's a flag of
+// a command whose flags haven't been found yet, it returns the words of
+// that command to pass to FindFlags instead.
+func (c *Completer) Complete(input string, cursor int, cwd string) (start int, completions []Completion, find []string) {
+	cursor = min(max(cursor, 0), len(input))
+	start = strings.LastIndexAny(input[:cursor], " \t|;&") + 1
+	word := input[start:cursor]
+
+	// the first word of a command is an executable, unless it looks like a path
+	before := strings.TrimRight(input[:start], " \t")
+	first := before == "" || strings.ContainsAny(before[len(before)-1:], "|;&")
+	if first && !strings.ContainsRune(word, '/') {
+		return start, c.completeExecutable(word), nil
+	}
+	switch fields := strings.Fields(before); {
+	case c.dirs == nil || len(fields) == 0 || strings.ContainsAny(before, "|;&"):
+	case fields[0] == "jump":
+		return start, c.completeJump(word, fields[1:]), nil
+	case fields[0] == "unmark":
+		return start, c.completeMark(word), nil
+	}
+	if strings.HasPrefix(word, "-") && c.flags != nil {
+		// only the command the word belongs to, not the ones before it
+		command := strings.Fields(before[strings.LastIndexAny(before, "|;&")+1:])
+		flags, ok := c.flags.Lookup(command, word)
+		if !ok {
+			return start, nil, command
+		}
+		if completions := completeFlag(flags); len(completions) > 0 {
+			return start, completions, nil
+		}
+	}
+	return start, completePath(word, cwd), nil
+}
+
+// FindFlags looks for the flags of the command Complete asked for. It can
+// take a while, so it's to be run away from the UI, and what it found passed
+// to StoreFlags.
+func (c *Completer) FindFlags(words []string, cwd string) FoundFlags {
+	return c.flags.Find(words, cwd)
+}
+
+// StoreFlags keeps the flags FindFlags found for the next completions
+func (c *Completer) StoreFlags(found FoundFlags) {
+	c.flags.Store(found)
+}
+
+// completeFlag turns the flags from the completion specs into candidates
+func completeFlag(flags []Flag) []Completion {
+	var completions []Completion
+	for _, flag := range flags {
+		display := flag.Name
+		if flag.Description != "" {
+			display += "  " + flag.Description
+		}
+		completions = append(completions, Completion{Value: flag.Name, Display: display})
+		if len(completions) == MaxCompletions {
+			break
+		}
+	}
+	return completions
+}
+
+// completeMark completes bookmark names
+func (c *Completer) completeMark(prefix string) []Completion {
+	var completions []Completion
+	for _, name := range c.dirs.MarkNames() {
+		if strings.HasPrefix(name, prefix) {
+			completions = append(completions, Completion{Value: name, Display: "🔖 " + name + " → " + c.dirs.Marks[name]})
+		}
+	}
+	return completions
+}
+
+// completeJump completes bookmark names, then the visited directories
+// matching what was typed, the best ones first
+func (c *Completer) completeJump(word string, before []string) []Completion {
+	var completions []Completion
+	if len(before) == 0 {
+		completions = c.completeMark(word)
+	}
+	for _, dir := range c.dirs.Matches(append(before, word), time.Now()) {
+		if len(completions) == MaxCompletions {
+			break
+		}
+		completions = append(completions, Completion{Value: dir, Display: "📂 " + dir, IsDir: true})
+	}
+	return completions
+}
+
+// Suggest returns the input completed with the first known command starting
+// with it, while only the command name has been typed
+func (c *Completer) Suggest(input string) (string, bool) {
+	if input == "" || strings.ContainsAny(input, " \t|;&/") {
+		return "", false
+	}
+	for _, completion := range c.completeExecutable(input) {
+		if len(completion.Value) > len(input) {
+			return completion.Value, true
+		}
+	}
+	return "", false
+}
+
+// completeExecutable completes executable names from PATH, kawaii commands
+// and common shell builtins
+func (c *Completer) completeExecutable(prefix string) []Completion {
+	c.refresh()
+	var completions []Completion
+	aliases := make([]string, 0, len(c.aliases))
+	for name := range c.aliases {
+		if strings.HasPrefix(name, prefix) {
+			aliases = append(aliases, name)
+		}
+	}
+	slices.Sort(aliases)
+	for _, name := range aliases {
+		completions = append(completions, Completion{Value: name, Display: name + " → " + c.aliases[name]})
+	}
+
+	for _, name := range c.executables {
+		if len(completions) == MaxCompletions {
+			break
+		}
+		if _, isAlias := c.aliases[name]; !isAlias && strings.HasPrefix(name, prefix) {
+			completions = append(completions, Completion{Value: name, Display: name})
+		}
+	}
+	return completions
+}
+
+// Commands returns the names of all the commands that can be run, aliases
+// included
+func (c *Completer) Commands() []string {
+	c.refresh()
+	commands := slices.Clone(c.executables)
+	for name := range c.aliases {
+		if _, found := slices.BinarySearch(c.executables, name); !found {
+			commands = append(commands, name)
+		}
+	}
+	return commands
+}
+
+// refresh lists the executables again when PATH changed
+func (c *Completer) refresh() {
+	if path := os.Getenv("PATH"); c.executables == nil || path != c.path {
+		c.path = path
+		c.executables = findExecutables(path, c.extra)
+	}
+}
+
+// findExecutables lists the executables in the directories of PATH, along
+// with the commands that don't live there and the extra ones
+func findExecutables(path string, extra []string) []string {
+	seen := map[string]bool{}
+	for _, name := range []string{"help", "kawaii", "pet", "pets", "wardrobe", "achievements", "theme", "history", "stats", "filter", "record", "split", "mark", "unmark", "jump", "sandbox", "queue", "trash", "undo", "restore", "alias", "unalias", "jobs", "fg", "bg", "cd", "exit"} {
+		seen[name] = true
+	}
+	for _, name := range extra {
+		seen[name] = true
+	}
+	for _, dir := range filepath.SplitList(path) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || seen[entry.Name()] {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(dir, entry.Name()))
+			if err != nil || !isExecutable(info) {
+				continue
+			}
+			seen[entry.Name()] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// isExecutable reports whether the file can be run
+func isExecutable(info os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd", ".com", ".ps1":
+			return true
+		}
+		return false
+	}
+	return info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+// completePath completes file paths relative to cwd
+func completePath(word, cwd string) []Completion {
+	dir, base := filepath.Split(word)
+	searchDir := dir
+	if strings.HasPrefix(searchDir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			searchDir = filepath.Join(home, strings.TrimPrefix(searchDir, "~"))
+		}
+	}
+	if !filepath.IsAbs(searchDir) {
+		searchDir = filepath.Join(cwd, searchDir)
+	}
+
+	entries, err := os.ReadDir(searchDir)
+	if err != nil {
+		return nil
+	}
+
+	var completions []Completion
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(searchDir, name)); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+		display := name
+		if isDir {
+			display += "/"
+		}
+		completions = append(completions, Completion{Value: dir + display, Display: display, IsDir: isDir})
+		if len(completions) == MaxCompletions {
+			break
+		}
+	}
+	return completions
+}
+
+// CommonPrefix returns the longest prefix shared by all completions
+func CommonPrefix(completions []Completion) string {
+	if len(completions) == 0 {
+		return ""
+	}
+	prefix := completions[0].Value
+	for _, c := range completions[1:] {
+		for !strings.HasPrefix(c.Value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	// don't cut a multi-byte character in half
+	for !utf8.ValidString(prefix) {
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
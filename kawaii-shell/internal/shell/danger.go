@@ -0,0 +1,229 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how dangerous a command is
+type Severity int
+
+const (
+	// SeveritySafe commands run right away
+	SeveritySafe Severity = iota
+	// SeverityWarn commands run with a warning
+	SeverityWarn
+	// SeverityConfirm commands only run once the user confirms them
+	SeverityConfirm
+	// SeverityBlock commands never run
+	SeverityBlock
+)
+
+var severityNames = map[Severity]string{
+	SeveritySafe:    "safe",
+	SeverityWarn:    "warn",
+	SeverityConfirm: "confirm",
+	SeverityBlock:   "block",
+}
+
+// String returns the name used for the severity in the rules file
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// UnmarshalText parses the name of a severity
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if strings.EqualFold(string(text), name) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// DangerRule flags the commands matching a glob pattern or a regular
+// expression.
+//
+// Glob patterns must match a whole command, where * matches anything and ?
+// a single character. Commands chained with ;, &&, || and | are checked one
+// by one. Regular expressions can match anywhere in the input.
+type DangerRule struct {
+	Pattern  string   `yaml:"pattern,omitempty"`
+	Regex    string   `yaml:"regex,omitempty"`
+	Severity Severity `yaml:"severity"`
+	Reason   string   `yaml:"reason,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// UnmarshalYAML decodes a rule from the rules file, where rules without a
+// severity need confirmation
+func (r *DangerRule) UnmarshalYAML(node *yaml.Node) error {
+	type rule DangerRule
+	decoded := rule{Severity: SeverityConfirm}
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+	*r = DangerRule(decoded)
+	return nil
+}
+
+// DangerRules decides how dangerous commands are
+type DangerRules struct {
+	rules []DangerRule
+}
+
+// defaultDangerRules are used unless the rules file replaces them
+var defaultDangerRules = []DangerRule{
+	{Regex: `(^|[;&|]\s*)(sudo\s+)?rm\s+(-[-\w]+\s+)*-\w*[rR]\w*\s+(-[-\w]+\s+)*(/|/\*|~|~/|~/\*)(\s|$)`, Severity: SeverityBlock, Reason: "This would delete everything!"},
+	{Pattern: "mkfs*", Severity: SeverityBlock, Reason: "This erases a whole disk!"},
+	{Regex: `\bdd\b.*\bof=/dev/(sd|hd|nvme|disk|mmcblk)`, Severity: SeverityBlock, Reason: "This overwrites a whole disk!"},
+	{Pattern: ":(){ :|:& };:", Severity: SeverityBlock, Reason: "This fork bomb would freeze your computer!"},
+	{Pattern: "rm *", Severity: SeverityConfirm, Reason: "Removing files (be careful!)"},
+	{Pattern: "sudo *", Severity: SeverityConfirm, Reason: "Using special powers! Be careful! ✨"},
+	{Pattern: "git push --force*", Severity: SeverityConfirm, Reason: "Force pushing can overwrite your friends' work!"},
+	{Pattern: "git push -f*", Severity: SeverityConfirm, Reason: "Force pushing can overwrite your friends' work!"},
+	{Pattern: "git reset --hard*", Severity: SeverityConfirm, Reason: "Uncommitted changes will be lost!"},
+	{Pattern: "git clean -*f*", Severity: SeverityConfirm, Reason: "Untracked files will be deleted!"},
+	{Pattern: "del /s*", Severity: SeverityConfirm, Reason: "Removing files everywhere (be careful!)"},
+	{Pattern: "rmdir /s*", Severity: SeverityConfirm, Reason: "Removing folders and everything in them!"},
+	{Regex: `(?i)\bdrop\s+(table|database)\b`, Severity: SeverityConfirm, Reason: "Dropped data is gone for good!"},
+	{Pattern: "chmod -R 777 *", Severity: SeverityWarn, Reason: "Everyone will be able to change these files!"},
+}
+
+// dangerRulesFile is the format of the rules file
+type dangerRulesFile struct {
+	// ReplaceDefaults drops the built-in rules instead of adding to them
+	ReplaceDefaults bool         `yaml:"replace_defaults"`
+	Rules           []DangerRule `yaml:"rules"`
+}
+
+// NewDangerRules creates rules from the given list, compiling their patterns
+func NewDangerRules(rules []DangerRule) (*DangerRules, error) {
+	d := &DangerRules{rules: make([]DangerRule, 0, len(rules))}
+	for _, rule := range rules {
+		var expr string
+		switch {
+		case rule.Regex != "":
+			expr = rule.Regex
+		case rule.Pattern != "":
+			expr = globToRegex(rule.Pattern)
+		default:
+			return nil, errors.New("danger rules need a pattern or a regex")
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid danger rule %q: %w", expr, err)
+		}
+		rule.re = re
+		if rule.Reason == "" {
+			rule.Reason = "This matches one of your danger rules!"
+		}
+		d.rules = append(d.rules, rule)
+	}
+	return d, nil
+}
+
+// DefaultDangerRules returns the built-in rules
+func DefaultDangerRules() *DangerRules {
+	rules, err := NewDangerRules(defaultDangerRules)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// LoadDangerRules loads the rules file, adding its rules to the built-in
+// ones. The built-in rules are used alone when the file doesn't exist.
+func LoadDangerRules(path string) (*DangerRules, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultDangerRules(), nil
+	}
+	if err != nil {
+		return DefaultDangerRules(), fmt.Errorf("failed to read danger rules: %w", err)
+	}
+
+	var file dangerRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return DefaultDangerRules(), fmt.Errorf("invalid danger rules %s: %w", path, err)
+	}
+	rules := file.Rules
+	if !file.ReplaceDefaults {
+		// rules from the file come first, so they win ties
+		rules = append(rules, defaultDangerRules...)
+	}
+	d, err := NewDangerRules(rules)
+	if err != nil {
+		return DefaultDangerRules(), err
+	}
+	return d, nil
+}
+
+// Check returns the most severe rule matching the command
+func (d *DangerRules) Check(command string) (DangerRule, bool) {
+	commands := splitCommands(command)
+	var match DangerRule
+	var found bool
+	for _, rule := range d.rules {
+		if found && rule.Severity <= match.Severity {
+			continue
+		}
+		if rule.matches(command, commands) {
+			match, found = rule, true
+		}
+	}
+	return match, found
+}
+
+func (r DangerRule) matches(input string, commands []string) bool {
+	if r.Regex != "" {
+		return r.re.MatchString(input)
+	}
+	for _, command := range commands {
+		if r.re.MatchString(command) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCommands splits chained commands, without caring about quotes since
+// false alarms are better than missed ones
+func splitCommands(input string) []string {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ';' || r == '&' || r == '|' || r == '\n'
+	})
+	commands := make([]string, 0, len(fields)+1)
+	commands = append(commands, strings.Join(strings.Fields(input), " "))
+	for _, field := range fields {
+		if command := strings.Join(strings.Fields(field), " "); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// globToRegex turns a glob pattern into an anchored regular expression
+func globToRegex(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
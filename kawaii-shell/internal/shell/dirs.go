@@ -0,0 +1,250 @@
+package shell
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxDirsRank is the total rank of the visited directories before they age,
+// so directories that aren't visited anymore fade away like in zoxide
+const maxDirsRank = 10000
+
+// Dirs remembers the directories the shell visits, to jump back to them with
+// a few letters of their path, and the ones bookmarked by name
+type Dirs struct {
+	path string
+
+	Marks  map[string]string    `json:"marks"`
+	Visits map[string]*DirVisit `json:"visits"`
+}
+
+// DirVisit is how often and how recently a directory was visited
+type DirVisit struct {
+	Rank float64   `json:"rank"`
+	Last time.Time `json:"last"`
+}
+
+// DefaultDirsPath returns where the directories are stored, honoring
+// XDG_DATA_HOME
+func DefaultDirsPath() string {
+	return dataFile("dirs.json")
+}
+
+// NewDirs loads the directories stored in the given file. An empty path
+// keeps them in memory only.
+func NewDirs(path string) (*Dirs, error) {
+	d := &Dirs{path: path, Marks: map[string]string{}, Visits: map[string]*DirVisit{}}
+	if path == "" {
+		return d, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return d, fmt.Errorf("failed to read directories: %w", err)
+	}
+	if err := json.Unmarshal(data, d); err != nil {
+		return d, fmt.Errorf("invalid directories %s: %w", path, err)
+	}
+	if d.Marks == nil {
+		d.Marks = map[string]string{}
+	}
+	if d.Visits == nil {
+		d.Visits = map[string]*DirVisit{}
+	}
+	return d, nil
+}
+
+// Visit records that the shell went to the directory
+func (d *Dirs) Visit(dir string, now time.Time) error {
+	visit, ok := d.Visits[dir]
+	if !ok {
+		visit = &DirVisit{}
+		d.Visits[dir] = visit
+	}
+	visit.Rank++
+	visit.Last = now
+
+	total := 0.0
+	for _, v := range d.Visits {
+		total += v.Rank
+	}
+	if total > maxDirsRank {
+		for dir, v := range d.Visits {
+			v.Rank *= 0.9
+			if v.Rank < 1 {
+				delete(d.Visits, dir)
+			}
+		}
+	}
+	return d.save()
+}
+
+// Mark bookmarks the directory under the name
+func (d *Dirs) Mark(name, dir string) error {
+	d.Marks[name] = dir
+	return d.save()
+}
+
+// Unmark forgets the bookmark, reporting whether there was one
+func (d *Dirs) Unmark(name string) (bool, error) {
+	if _, ok := d.Marks[name]; !ok {
+		return false, nil
+	}
+	delete(d.Marks, name)
+	return true, d.save()
+}
+
+// MarkNames returns the names of the bookmarks, sorted
+func (d *Dirs) MarkNames() []string {
+	names := make([]string, 0, len(d.Marks))
+	for name := range d.Marks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Find returns where jumping with the query goes: the bookmark named like
+// it, or else the best visited directory matching it, leaving out cwd
+func (d *Dirs) Find(query []string, cwd string, now time.Time) (string, bool) {
+	if len(query) == 1 {
+		if dir, ok := d.Marks[query[0]]; ok {
+			return dir, true
+		}
+	}
+	for _, dir := range d.Matches(query, now) {
+		if dir != cwd {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// Matches returns the visited directories matching the query, the best ones
+// first. Each word of the query has to appear in the path, in order, and the
+// last one in the directory's own name. When none do, the letters of the
+// last word only have to appear in order in the name, so kwsh finds
+// kawaii-shell.
+func (d *Dirs) Matches(query []string, now time.Time) []string {
+	type match struct {
+		dir   string
+		score float64
+	}
+	var exact, fuzzy []match
+	for dir, visit := range d.Visits {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		m := match{dir, visit.frecency(now)}
+		switch {
+		case matchesWords(dir, query):
+			exact = append(exact, m)
+		case len(query) > 0 && isSubsequence(strings.ToLower(query[len(query)-1]), strings.ToLower(filepath.Base(dir))):
+			fuzzy = append(fuzzy, m)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = fuzzy
+	}
+	slices.SortFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(b.score, a.score), cmp.Compare(a.dir, b.dir))
+	})
+
+	dirs := make([]string, len(matches))
+	for i, m := range matches {
+		dirs[i] = m.dir
+	}
+	return dirs
+}
+
+// frecency scores the directory by how often and how recently it was
+// visited, the way zoxide does
+func (v *DirVisit) frecency(now time.Time) float64 {
+	switch age := now.Sub(v.Last); {
+	case age < time.Hour:
+		return v.Rank * 4
+	case age < 24*time.Hour:
+		return v.Rank * 2
+	case age < 7*24*time.Hour:
+		return v.Rank / 2
+	default:
+		return v.Rank / 4
+	}
+}
+
+// matchesWords reports whether the words appear in the path in order, the
+// last one in its last element
+func matchesWords(path string, words []string) bool {
+	path = strings.ToLower(path)
+	rest := path
+	for i, word := range words {
+		word = strings.ToLower(word)
+		at := strings.Index(rest, word)
+		if at < 0 {
+			return false
+		}
+		if i == len(words)-1 && !strings.Contains(strings.ToLower(filepath.Base(path)), word) {
+			return false
+		}
+		rest = rest[at+len(word):]
+	}
+	return true
+}
+
+// isSubsequence reports whether the letters of s appear in t in order
+func isSubsequence(s, t string) bool {
+	want := []rune(s)
+	for _, r := range t {
+		if len(want) > 0 && r == want[0] {
+			want = want[1:]
+		}
+	}
+	return len(want) == 0
+}
+
+// save writes the directories to the file, if there's one
+func (d *Dirs) save() error {
+	if d.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save directories: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save directories: %w", err)
+	}
+	if err := os.WriteFile(d.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save directories: %w", err)
+	}
+	return nil
+}
+
+// Cd changes the working directory of the shell
+func (s *Shell) Cd(dir string) error {
+	return s.Write(cdCommand(GetDefaultShell(), dir) + "\n")
+}
+
+// cdCommand returns the command changing to the directory in the given shell
+func cdCommand(shell, dir string) string {
+	switch shellName(shell) {
+	case "fish":
+		return "cd " + fishQuote(dir)
+	case "cmd":
+		return `cd /d "` + dir + `"`
+	case "powershell", "pwsh":
+		return "Set-Location " + powershellQuote(dir)
+	default:
+		return "cd -- " + posixQuote(dir)
+	}
+}
@@ -0,0 +1,137 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// EnvVar is a variable of the environment commands run with
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// Environ returns the environment kawaii started with, along with the
+// changes made with Setenv and Unsetenv, sorted by name. What's exported in
+// the shell session itself isn't in it.
+func Environ() []EnvVar {
+	var vars []EnvVar
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			// Windows keeps the working directory of each drive in
+			// variables like =C:, they aren't meant to be seen
+			continue
+		}
+		vars = append(vars, EnvVar{name, value})
+	}
+	slices.SortFunc(vars, func(a, b EnvVar) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return vars
+}
+
+// ValidEnvName reports whether the name can be used for an environment
+// variable in every shell
+func ValidEnvName(name string) bool {
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Setenv sets the variable for the commands that come next, both in the
+// shell session and in the jobs started from now on
+func (s *Shell) Setenv(name, value string) error {
+	if !ValidEnvName(name) {
+		return fmt.Errorf("%q isn't a valid variable name", name)
+	}
+	// cmd can't quote quotes, and expands % and ends the command at line
+	// breaks even in quotes
+	if shellName(GetDefaultShell()) == "cmd" && strings.ContainsAny(value, "\"%\r\n") {
+		return fmt.Errorf("cmd can't set %s to a value with quotes, %% or line breaks", name)
+	}
+	if err := s.Write(exportCommand(GetDefaultShell(), name, value) + "\n"); err != nil {
+		return fmt.Errorf("failed to export %s: %w", name, err)
+	}
+	return os.Setenv(name, value)
+}
+
+// Unsetenv removes the variable for the commands that come next, both in the
+// shell session and in the jobs started from now on
+func (s *Shell) Unsetenv(name string) error {
+	if !ValidEnvName(name) {
+		return fmt.Errorf("%q isn't a valid variable name", name)
+	}
+	if err := s.Write(unsetCommand(GetDefaultShell(), name) + "\n"); err != nil {
+		return fmt.Errorf("failed to unset %s: %w", name, err)
+	}
+	return os.Unsetenv(name)
+}
+
+// exportCommand returns the command setting the variable in the given shell.
+// It starts with a space, so shells ignoring those keep it out of their
+// history. Values for cmd have no quotes, see Setenv.
+func exportCommand(shell, name, value string) string {
+	switch shellName(shell) {
+	case "fish":
+		return " set -gx " + name + " " + fishQuote(value)
+	case "cmd":
+		return ` set "` + name + "=" + value + `"`
+	case "powershell", "pwsh":
+		return " $env:" + name + " = " + powershellQuote(value)
+	default:
+		return " export " + name + "=" + posixQuote(value)
+	}
+}
+
+// unsetCommand returns the command removing the variable in the given shell
+func unsetCommand(shell, name string) string {
+	switch shellName(shell) {
+	case "fish":
+		return " set -e " + name
+	case "cmd":
+		return ` set "` + name + `="`
+	case "powershell", "pwsh":
+		return " Remove-Item Env:" + name
+	default:
+		return " unset " + name
+	}
+}
+
+// shellName returns the name of the shell program, like bash or cmd
+func shellName(shell string) string {
+	return strings.ToLower(strings.TrimSuffix(filepath.Base(shell), filepath.Ext(shell)))
+}
+
+// quoteFor returns the function quoting arguments for the given shell
+func quoteFor(shell string) func(string) string {
+	switch shellName(shell) {
+	case "fish":
+		return fishQuote
+	case "powershell", "pwsh":
+		return powershellQuote
+	default:
+		return posixQuote
+	}
+}
+
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,197 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrorHint explains an error recognized in the output of a failed command,
+// with what to try next
+type ErrorHint struct {
+	Regex       string   `yaml:"regex"`
+	Title       string   `yaml:"title"`
+	Explanation string   `yaml:"explanation,omitempty"`
+	Steps       []string `yaml:"steps,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// ErrorHints recognizes common errors in the output of commands
+type ErrorHints struct {
+	hints []ErrorHint
+}
+
+// defaultErrorHints are used unless the hints file replaces them. The more
+// specific ones come first, the first match wins.
+var defaultErrorHints = []ErrorHint{
+	{
+		Regex:       `(?i)automatic merge failed|CONFLICT \(|fix conflicts and then`,
+		Title:       "Merge conflict!",
+		Explanation: "Git couldn't combine the changes on its own, some lines were changed on both sides.",
+		Steps: []string{
+			"git status shows the conflicted files",
+			"Pick what to keep between the <<<<<<< and >>>>>>> markers",
+			"git add the fixed files, then git commit",
+			"Or git merge --abort to go back to how things were",
+		},
+	},
+	{
+		Regex:       `(?i)\[rejected\].*\((non-fast-forward|fetch first)\)`,
+		Title:       "The remote has changes you don't have",
+		Explanation: "Someone pushed first, so git won't overwrite their work.",
+		Steps: []string{
+			"git pull --rebase puts your commits on top of theirs",
+			"Then git push again",
+		},
+	},
+	{
+		Regex:       `(?i)not a git repository`,
+		Title:       "This isn't a git repository",
+		Explanation: "Git commands only work inside a repository.",
+		Steps: []string{
+			"cd into your project first",
+			"Or git init to start a new repository here",
+		},
+	},
+	{
+		Regex:       `(?i)no space left on device|ENOSPC`,
+		Title:       "The disk is full!",
+		Explanation: "There's no room left to write files.",
+		Steps: []string{
+			"df -h shows how full each disk is",
+			"du -sh * | sort -h finds what takes the most space here",
+			"Emptying caches and old builds frees space quickly",
+		},
+	},
+	{
+		Regex:       `(?i)cannot find module|no module named|ModuleNotFoundError|cannot find package|no required module provides package`,
+		Title:       "A module is missing",
+		Explanation: "The program needs a dependency that isn't installed.",
+		Steps: []string{
+			"npm install for JavaScript projects",
+			"pip install with the module's name for Python",
+			"go mod tidy for Go modules",
+		},
+	},
+	{
+		Regex:       `(?i)address already in use|EADDRINUSE`,
+		Title:       "The port is taken",
+		Explanation: "Another program is already listening on that port.",
+		Steps: []string{
+			"lsof -i :PORT shows who's using it",
+			"Stop that program or pick another port",
+		},
+	},
+	{
+		Regex:       `(?i)connection refused|ECONNREFUSED`,
+		Title:       "Nobody answered",
+		Explanation: "Nothing is listening at the address the command tried to reach.",
+		Steps: []string{
+			"Make sure the server is running",
+			"Double check the host and the port",
+		},
+	},
+	{
+		Regex:       `(?i)too many open files|EMFILE`,
+		Title:       "Too many open files",
+		Explanation: "The program hit the limit of files it may have open at once.",
+		Steps: []string{
+			"ulimit -n shows the limit, ulimit -n 4096 raises it for this shell",
+		},
+	},
+	{
+		Regex:       `(?i)permission denied|EACCES|operation not permitted|EPERM`,
+		Title:       "Permission denied",
+		Explanation: "You aren't allowed to touch that file or run that program.",
+		Steps: []string{
+			"ls -l shows who owns the file and who may use it",
+			"chmod +x lets you run a script",
+			"sudo gives you special powers, only use it if you trust the command",
+		},
+	},
+	{
+		Regex:       `(?i)no such file or directory|ENOENT`,
+		Title:       "It isn't there",
+		Explanation: "The file or folder the command looked for doesn't exist.",
+		Steps: []string{
+			"pwd and ls show where you are and what's around",
+			"Tab completes paths so typos can't sneak in",
+		},
+	},
+}
+
+// errorHintsFile is the format of the hints file
+type errorHintsFile struct {
+	// ReplaceDefaults drops the built-in hints instead of adding to them
+	ReplaceDefaults bool        `yaml:"replace_defaults"`
+	Hints           []ErrorHint `yaml:"hints"`
+}
+
+// NewErrorHints creates hints from the given list, compiling their regular
+// expressions
+func NewErrorHints(hints []ErrorHint) (*ErrorHints, error) {
+	h := &ErrorHints{hints: make([]ErrorHint, 0, len(hints))}
+	for _, hint := range hints {
+		if hint.Regex == "" || hint.Title == "" {
+			return nil, errors.New("error hints need a regex and a title")
+		}
+		re, err := regexp.Compile(hint.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid error hint %q: %w", hint.Regex, err)
+		}
+		hint.re = re
+		h.hints = append(h.hints, hint)
+	}
+	return h, nil
+}
+
+// DefaultErrorHints returns the built-in hints
+func DefaultErrorHints() *ErrorHints {
+	hints, err := NewErrorHints(defaultErrorHints)
+	if err != nil {
+		panic(err)
+	}
+	return hints
+}
+
+// LoadErrorHints loads the hints file, adding its hints to the built-in
+// ones. The built-in hints are used alone when the file doesn't exist.
+func LoadErrorHints(path string) (*ErrorHints, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultErrorHints(), nil
+	}
+	if err != nil {
+		return DefaultErrorHints(), fmt.Errorf("failed to read error hints: %w", err)
+	}
+
+	var file errorHintsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return DefaultErrorHints(), fmt.Errorf("invalid error hints %s: %w", path, err)
+	}
+	hints := file.Hints
+	if !file.ReplaceDefaults {
+		// hints from the file come first, so they win
+		hints = append(hints, defaultErrorHints...)
+	}
+	h, err := NewErrorHints(hints)
+	if err != nil {
+		return DefaultErrorHints(), err
+	}
+	return h, nil
+}
+
+// Match returns the first hint recognizing the output, which should be
+// free of escape sequences
+func (h *ErrorHints) Match(output string) (ErrorHint, bool) {
+	for _, hint := range h.hints {
+		if hint.re.MatchString(output) {
+			return hint, true
+		}
+	}
+	return ErrorHint{}, false
+}
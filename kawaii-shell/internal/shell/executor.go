@@ -1,58 +1,86 @@
 package shell
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"runtime"
 	"strings"
-
-	"github.com/creack/pty"
 )
 
 // CommandInfo holds cute information about commands
 type CommandInfo struct {
-	FriendlyName string
-	Emoji        string
-	Description  string
-	IsDangerous  bool
+	FriendlyName string `yaml:"name"`
+	Emoji        string `yaml:"emoji"`
+	Description  string `yaml:"description"`
+}
+
+// terminal is the pseudo terminal the shell runs in, see startPTY
+type terminal interface {
+	io.ReadWriteCloser
+	Resize(cols, rows int) error
+	Pid() int
+	Wait() (int, error)
 }
 
 // Shell represents the kawaii shell wrapper
 type Shell struct {
-	pty        *os.File
-	cmd        *exec.Cmd
-	output     chan string
+	pty        terminal
+	output     chan []byte
 	input      chan string
 	done       chan bool
-	lastOutput string
+	cols, rows int
+
+	// integrationDir holds the shell integration scripts
+	integrationDir string
 }
 
-// Command translation map - making scary commands cute!
+// Command translation map - making scary commands cute! The commands file
+// adds to it, see LoadCommands.
 var CommandMap = map[string]CommandInfo{
-	"ls":     {"Looking around", "📂", "Let's see what files are here!", false},
-	"cd":     {"Moving", "🚶‍♀️", "Going to a new place!", false},
-	"pwd":    {"Where am I?", "📍", "Showing our current location!", false},
-	"mkdir":  {"Creating", "📁✨", "Making a new folder!", false},
-	"rm":     {"Cleaning up", "🗑️", "Removing files (be careful!)", true},
-	"cp":     {"Copying", "📋", "Making a copy of something!", false},
-	"mv":     {"Moving", "📦", "Relocating files!", false},
-	"cat":    {"Reading", "📖", "Let's see what's inside!", false},
-	"grep":   {"Searching", "🔍", "Looking for something specific!", false},
-	"find":   {"Exploring", "🗺️", "Searching everywhere!", false},
-	"sudo":   {"Super powers", "💪", "Using special powers! Be careful! ✨", true},
-	"git":    {"Version magic", "🪄", "Managing code history!", false},
-	"npm":    {"Package magic", "📦", "Working with packages!", false},
-	"python": {"Snake magic", "🐍", "Running Python code!", false},
-	"node":   {"JavaScript magic", "⚡", "Running Node.js!", false},
+	"ls":           {"Looking around", "📂", "Let's see what files are here!"},
+	"cd":           {"Moving", "🚶‍♀️", "Going to a new place!"},
+	"pwd":          {"Where am I?", "📍", "Showing our current location!"},
+	"mkdir":        {"Creating", "📁✨", "Making a new folder!"},
+	"rm":           {"Cleaning up", "🗑️", "Removing files (be careful!)"},
+	"cp":           {"Copying", "📋", "Making a copy of something!"},
+	"mv":           {"Moving", "📦", "Relocating files!"},
+	"cat":          {"Reading", "📖", "Let's see what's inside!"},
+	"grep":         {"Searching", "🔍", "Looking for something specific!"},
+	"find":         {"Exploring", "🗺️", "Searching everywhere!"},
+	"sudo":         {"Super powers", "💪", "Using special powers! Be careful! ✨"},
+	"git":          {"Version magic", "🪄", "Managing code history!"},
+	"npm":          {"Package magic", "📦", "Working with packages!"},
+	"python":       {"Snake magic", "🐍", "Running Python code!"},
+	"node":         {"JavaScript magic", "⚡", "Running Node.js!"},
+	"trash":        {"Peeking in the trash", "🗑️", "Let's see what was removed!"},
+	"undo":         {"Undoing", "↩️", "Bringing back what rm removed!"},
+	"alias":        {"Nicknames", "💕", "Giving commands cute nicknames!"},
+	"unalias":      {"Forgetting", "👋", "Saying bye bye to a nickname!"},
+	"restore":      {"Restoring", "✨", "Bringing something back from the trash!"},
+	"jobs":         {"Checking in", "⚙️", "Seeing what's running in the background!"},
+	"fg":           {"Bringing it back", "🎮", "Bringing a job to the foreground!"},
+	"bg":           {"Carrying on", "▶️", "Letting a job carry on in the background!"},
+	"stats":        {"Counting", "📊", "Looking at how you use your shell!"},
+	"filter":       {"Sifting", "🔎", "Finding the lines that matter!"},
+	"record":       {"Lights, camera", "🎬", "Recording your adorable session!"},
+	"split":        {"Making room", "🪟", "Opening another cozy shell!"},
+	"mark":         {"Bookmarking", "🔖", "Remembering this cozy spot!"},
+	"unmark":       {"Forgetting", "👋", "Letting go of a bookmark!"},
+	"jump":         {"Hopping", "🦘", "Jumping to a favorite directory!"},
+	"sandbox":      {"Playing safe", "🏖️", "Trying things out in the sandbox!"},
+	"queue":        {"Lining up", "📋", "Running commands one after the other!"},
+	"pets":         {"Pet party", "🐾", "Gathering all your pets!"},
+	"wardrobe":     {"Dressing up", "👗", "Opening the wardrobe!"},
+	"achievements": {"Trophy time", "🏆", "Looking at everything you achieved!"},
+	"theme":        {"Redecorating", "🎨", "Trying on a new look!"},
 }
 
 // NewShell creates a new kawaii shell instance
 func NewShell() (*Shell, error) {
 	shell := &Shell{
-		output: make(chan string, 100),
+		output: make(chan []byte, 100),
 		input:  make(chan string, 10),
 		done:   make(chan bool),
 	}
@@ -64,7 +92,7 @@ func NewShell() (*Shell, error) {
 func GetCommandInfo(command string) CommandInfo {
 	parts := strings.Fields(command)
 	if len(parts) == 0 {
-		return CommandInfo{"Unknown", "❓", "I'm not sure what this does!", false}
+		return CommandInfo{"Unknown", "❓", "I'm not sure what this does!"}
 	}
 
 	baseCmd := parts[0]
@@ -72,7 +100,7 @@ func GetCommandInfo(command string) CommandInfo {
 		return info
 	}
 
-	return CommandInfo{"Running command", "⚡", fmt.Sprintf("Executing: %s", baseCmd), false}
+	return CommandInfo{"Running command", "⚡", fmt.Sprintf("Executing: %s", baseCmd)}
 }
 
 // GetDefaultShell returns the default shell for the current OS
@@ -91,17 +119,44 @@ func GetDefaultShell() string {
 	return "/bin/bash"
 }
 
-// Start starts the shell session
-func (s *Shell) Start() error {
+// Start starts the shell session in dir, or in our own working directory
+// when it's empty
+func (s *Shell) Start(dir string) error {
 	shell := GetDefaultShell()
+	integrationDir, err := os.MkdirTemp("", "kawaii-shell-")
+	if err != nil {
+		return fmt.Errorf("failed to create integration directory: %w", err)
+	}
+	s.integrationDir = integrationDir
+	args, integrationEnv, err := shellIntegration(shell, integrationDir)
+	if err != nil {
+		return err
+	}
+
+	return s.start(shell, args, append(s.env(), integrationEnv...), dir)
+}
+
+// StartCommand runs a single command with the default shell in dir, rather
+// than an interactive session
+func (s *Shell) StartCommand(command, dir string) error {
+	shell := GetDefaultShell()
+	args := []string{"-c", command}
+	if runtime.GOOS == "windows" {
+		args = []string{"/c", command}
+	}
+	return s.start(shell, args, s.env(), dir)
+}
 
-	s.cmd = exec.Command(shell)
-	s.cmd.Env = os.Environ()
+func (s *Shell) start(shell string, args, env []string, dir string) error {
+	cols, rows := 80, 24
+	if s.cols > 0 && s.rows > 0 {
+		cols, rows = s.cols, s.rows
+	}
 
 	var err error
-	s.pty, err = pty.Start(s.cmd)
+	s.pty, err = startPTY(shell, args, env, dir, cols, rows)
 	if err != nil {
-		return fmt.Errorf("failed to start pty: %w", err)
+		return err
 	}
 
 	// Start reading output from the shell
@@ -113,29 +168,86 @@ func (s *Shell) Start() error {
 	return nil
 }
 
-// ExecuteCommand sends a command to the shell
-func (s *Shell) ExecuteCommand(command string) error {
+// env returns the environment programs run with
+func (s *Shell) env() []string {
+	env := append(os.Environ(), "CLICOLOR=1")
+	if os.Getenv("TERM") == "" {
+		// let programs know they can use colors, we keep them in the output
+		env = append(env, "TERM=xterm-256color")
+	}
+	return env
+}
+
+// Write sends raw input to the shell, such as control characters
+func (s *Shell) Write(input string) error {
 	if s.pty == nil {
 		return fmt.Errorf("shell not started")
 	}
 
 	select {
-	case s.input <- command + "\n":
+	case s.input <- input:
 		return nil
 	default:
 		return fmt.Errorf("input buffer full")
 	}
 }
 
-// GetOutput returns the latest output from the shell
-func (s *Shell) GetOutput() string {
-	select {
-	case output := <-s.output:
-		s.lastOutput = output
-		return output
-	default:
-		return s.lastOutput
+// Wait waits for the shell to exit, returning its exit code
+func (s *Shell) Wait() (int, error) {
+	if s.pty == nil {
+		return -1, fmt.Errorf("shell not started")
+	}
+	return s.pty.Wait()
+}
+
+// ExecuteCommand sends a command to the shell
+func (s *Shell) ExecuteCommand(command string) error {
+	return s.Write(command + "\n")
+}
+
+// ReadOutput returns the raw output written by the shell since the last
+// call, without waiting for more
+func (s *Shell) ReadOutput() []byte {
+	var data []byte
+	for {
+		select {
+		case chunk := <-s.output:
+			data = append(data, chunk...)
+		default:
+			return data
+		}
+	}
+}
+
+// Resize sets the size of the PTY, so programs wrap their output at the
+// right width
+func (s *Shell) Resize(cols, rows int) error {
+	s.cols, s.rows = cols, rows
+	if s.pty == nil {
+		return nil
 	}
+	return s.pty.Resize(cols, rows)
+}
+
+// Cwd returns the working directory of the shell process, falling back to
+// our own when it can't be determined
+func (s *Shell) Cwd() string {
+	if dir, ok := s.ProcessCwd(); ok {
+		return dir
+	}
+	dir, _ := os.Getwd()
+	return dir
+}
+
+// ProcessCwd returns the working directory of the shell process, false when
+// it can't be determined
+func (s *Shell) ProcessCwd() (string, bool) {
+	if s.pty == nil {
+		return "", false
+	}
+	// only available on Linux
+	dir, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", s.pty.Pid()))
+	return dir, err == nil
 }
 
 // Close closes the shell session
@@ -143,20 +255,27 @@ func (s *Shell) Close() error {
 	if s.pty != nil {
 		s.pty.Close()
 	}
-	if s.cmd != nil && s.cmd.Process != nil {
-		s.cmd.Process.Kill()
+	if s.integrationDir != "" {
+		os.RemoveAll(s.integrationDir)
 	}
 	close(s.done)
 	return nil
 }
 
-// readOutput reads output from the PTY
+// readOutput reads output from the PTY in chunks, as soon as it's written,
+// so partial lines and carriage return updates aren't held back
 func (s *Shell) readOutput() {
-	scanner := bufio.NewScanner(s.pty)
-	for scanner.Scan() {
-		select {
-		case s.output <- scanner.Text():
-		case <-s.done:
+	buf := make([]byte, 4096)
+	for {
+		n, err := s.pty.Read(buf)
+		if n > 0 {
+			select {
+			case s.output <- bytes.Clone(buf[:n]):
+			case <-s.done:
+				return
+			}
+		}
+		if err != nil {
 			return
 		}
 	}
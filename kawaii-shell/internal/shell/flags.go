@@ -0,0 +1,327 @@
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// flagTimeout is how long a tool gets to list its flags
+const flagTimeout = time.Second
+
+// maxSubcommands is how many subcommands deep flags are looked up, like
+// gh pr create
+const maxSubcommands = 2
+
+// subcommandName matches the words taken to be subcommands rather than
+// arguments like paths, names or numbers
+var subcommandName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// cobraTools are tools built with cobra, which list their flags through the
+// hidden __complete command even without a completion script installed
+var cobraTools = []string{
+	"argocd", "docker", "flux", "gh", "glab", "goreleaser", "helm", "hugo",
+	"istioctl", "k3d", "k9s", "kind", "kubectl", "minikube", "mods", "podman",
+	"skaffold", "soft", "tilt",
+}
+
+// longFlag matches the long flags listed in bash completion scripts
+var longFlag = regexp.MustCompile(`(?:^|[\s'"(|=])(--[a-zA-Z0-9][a-zA-Z0-9-]*=?)`)
+
+// Flag is an option of a command
+type Flag struct {
+	Name        string
+	Description string
+}
+
+// Flags finds the flags of commands in the completion specs of the installed
+// tools: cobra's __complete command, fish completions and bash completion
+// scripts, in that order
+type Flags struct {
+	fishDirs []string
+	bashDirs []string
+
+	// cache holds the flags found for a command and its subcommands
+	cache map[string][]Flag
+}
+
+// FoundFlags are the flags found for a command and its subcommands, to be
+// kept with Store
+type FoundFlags struct {
+	key   string
+	flags []Flag
+}
+
+// NewFlags creates flags looking in the usual completion directories
+func NewFlags() *Flags {
+	f := &Flags{
+		fishDirs: []string{"/usr/share/fish/vendor_completions.d", "/usr/share/fish/completions", "/usr/local/share/fish/vendor_completions.d"},
+		bashDirs: []string{"/usr/share/bash-completion/completions", "/usr/local/share/bash-completion/completions", "/etc/bash_completion.d"},
+		cache:    map[string][]Flag{},
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		f.fishDirs = append(f.fishDirs, filepath.Join(home, ".config", "fish", "completions"))
+		f.bashDirs = append(f.bashDirs, filepath.Join(home, ".local", "share", "bash-completion", "completions"))
+	}
+	return f
+}
+
+// Lookup returns the flags of the command, given as the words typed before
+// the flag being completed, starting with the prefix. ok is false when the
+// flags of the command haven't been found yet, see Find.
+func (f *Flags) Lookup(words []string, prefix string) (matching []Flag, ok bool) {
+	name, subcommands := splitCommand(words)
+	if name == "" {
+		return nil, true
+	}
+	flags, ok := f.cache[flagKey(name, subcommands)]
+	for _, flag := range flags {
+		if strings.HasPrefix(flag.Name, prefix) {
+			matching = append(matching, flag)
+		}
+	}
+	return matching, ok
+}
+
+// Find looks for the flags of the command, which can mean running it, so
+// it's to be called away from the UI. It leaves the cache alone, so it can
+// run alongside Lookup.
+func (f *Flags) Find(words []string, cwd string) FoundFlags {
+	name, subcommands := splitCommand(words)
+	if name == "" {
+		return FoundFlags{}
+	}
+	return FoundFlags{key: flagKey(name, subcommands), flags: f.find(name, subcommands, cwd)}
+}
+
+// Store keeps the flags found for Lookup
+func (f *Flags) Store(found FoundFlags) {
+	if found.key != "" {
+		f.cache[found.key] = found.flags
+	}
+}
+
+// splitCommand returns the name of the command and its subcommands: the
+// words before the first flag that look like one. Arguments after them
+// don't change the flags, so they're left out of the lookup and the cache.
+func splitCommand(words []string) (name string, subcommands []string) {
+	if len(words) == 0 || strings.ContainsRune(words[0], '/') {
+		return "", nil
+	}
+	for _, word := range words[1:] {
+		if len(subcommands) == maxSubcommands || !subcommandName.MatchString(word) {
+			break
+		}
+		subcommands = append(subcommands, word)
+	}
+	return words[0], subcommands
+}
+
+// flagKey returns the cache key of the command and its subcommands
+func flagKey(name string, subcommands []string) string {
+	return strings.Join(append([]string{name}, subcommands...), " ")
+}
+
+// find looks for the flags of the command in the completion specs
+func (f *Flags) find(name string, subcommands []string, cwd string) []Flag {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil
+	}
+	bash := readSpec(f.bashDirs, name)
+	if slices.Contains(cobraTools, name) || bytes.Contains(bash, []byte("__complete")) {
+		return cobraFlags(name, subcommands, cwd)
+	}
+	if fish := readSpec(f.fishDirs, name+".fish"); fish != nil {
+		if bytes.Contains(fish, []byte("__complete")) {
+			return cobraFlags(name, subcommands, cwd)
+		}
+		if flags := fishFlags(fish, name, subcommands); len(flags) > 0 {
+			return flags
+		}
+	}
+	if bash != nil {
+		return bashFlags(bash, name, subcommands, cwd)
+	}
+	return nil
+}
+
+// readSpec reads the first completion spec with the name in the
+// directories, nil when there's none
+func readSpec(dirs []string, name string) []byte {
+	for _, dir := range dirs {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return data
+		}
+	}
+	return nil
+}
+
+// cobraFlags asks a cobra tool for its flags with its hidden __complete
+// command, which prints a candidate and its description per line and ends
+// with a :directive line
+func cobraFlags(name string, subcommands []string, cwd string) []Flag {
+	ctx, cancel := context.WithTimeout(context.Background(), flagTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, append(append([]string{"__complete"}, subcommands...), "-")...)
+	cmd.Dir = cwd
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var flags []Flag
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		value, description, _ := strings.Cut(scanner.Text(), "\t")
+		if strings.HasPrefix(value, "-") {
+			flags = append(flags, Flag{Name: value, Description: description})
+		}
+	}
+	return flags
+}
+
+// fishFlags reads the flags of the command from its fish completions. Flags
+// only offered after a subcommand, with __fish_seen_subcommand_from, are
+// left out unless that subcommand was typed.
+func fishFlags(spec []byte, name string, subcommands []string) []Flag {
+	var flags []Flag
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(spec))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "complete ") {
+			continue
+		}
+		words, ok := SplitWords(line)
+		if !ok {
+			continue
+		}
+
+		var command, condition, description string
+		var names []string
+		for i := 1; i < len(words)-1; i++ {
+			value := words[i+1].Value
+			switch words[i].Value {
+			case "-c", "--command":
+				command = value
+			case "-n", "--condition":
+				condition = value
+			case "-d", "--description":
+				description = value
+			case "-l", "--long-option":
+				names = append(names, "--"+value)
+			case "-s", "--short-option", "-o", "--old-option":
+				names = append(names, "-"+value)
+			default:
+				continue
+			}
+			i++
+		}
+		if command != name || !fishConditionHolds(condition, subcommands) {
+			continue
+		}
+		for _, flag := range names {
+			if !seen[flag] {
+				seen[flag] = true
+				flags = append(flags, Flag{Name: flag, Description: description})
+			}
+		}
+	}
+	return flags
+}
+
+// fishConditionHolds reports whether the subcommands a fish completion is
+// limited to were typed. Other conditions are taken to hold.
+func fishConditionHolds(condition string, subcommands []string) bool {
+	_, rest, ok := strings.Cut(condition, "__fish_seen_subcommand_from")
+	if !ok {
+		return true
+	}
+	for _, word := range strings.Fields(rest) {
+		word = strings.TrimRight(word, ";")
+		if word == "and" || word == "or" || word == "&&" || word == "||" || strings.HasPrefix(word, "__fish") {
+			break
+		}
+		if slices.Contains(subcommands, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// bashFlags picks the long flags out of the bash completion script, from
+// the function completing the subcommand when there's one like git's
+// _git_commit. git's own helper lists the options of its builtins.
+func bashFlags(spec []byte, name string, subcommands []string, cwd string) []Flag {
+	script := string(spec)
+	if len(subcommands) > 0 {
+		if body, ok := bashFunction(script, "_"+strings.ReplaceAll(name, "-", "_")+"_"+strings.ReplaceAll(subcommands[0], "-", "_")); ok {
+			script = body
+			if name == "git" && strings.Contains(body, "__gitcomp_builtin") {
+				if flags := gitBuiltinFlags(subcommands[0], cwd); len(flags) > 0 {
+					return flags
+				}
+			}
+		}
+	}
+
+	var flags []Flag
+	seen := map[string]bool{}
+	for _, match := range longFlag.FindAllStringSubmatch(script, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			flags = append(flags, Flag{Name: match[1]})
+		}
+	}
+	slices.SortFunc(flags, func(a, b Flag) int { return strings.Compare(a.Name, b.Name) })
+	return flags
+}
+
+// bashFunction returns the body of the function in the script, up to the
+// closing brace at the start of a line
+func bashFunction(script, name string) (string, bool) {
+	start := -1
+	for _, header := range []string{"\n" + name + " ()", "\n" + name + "()", "\nfunction " + name} {
+		if i := strings.Index(script, header); i >= 0 {
+			start = i + len(header)
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+	end := strings.Index(script[start:], "\n}")
+	if end < 0 {
+		return script[start:], true
+	}
+	return script[start : start+end], true
+}
+
+// gitBuiltinFlags lists the options of a git builtin with the helper git's
+// bash completion uses
+func gitBuiltinFlags(subcommand, cwd string) []Flag {
+	ctx, cancel := context.WithTimeout(context.Background(), flagTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", subcommand, "--git-completion-helper")
+	cmd.Dir = cwd
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var flags []Flag
+	for _, field := range strings.Fields(string(out)) {
+		// -- separates the options git only completes once asked for more
+		if field != "--" && strings.HasPrefix(field, "-") {
+			flags = append(flags, Flag{Name: field})
+		}
+	}
+	return flags
+}
@@ -0,0 +1,78 @@
+package shell
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// fuzzyMatchScore is what each matched letter is worth, with bonuses
+	// for letters right after the previous one or at the start of a word
+	fuzzyMatchScore       = 16
+	fuzzyConsecutiveBonus = 8
+	fuzzyWordStartBonus   = 8
+)
+
+// FuzzyMatch reports whether the letters of the query appear in order in
+// the text, and how well they do: close together and at the start of words
+// score higher. The positions are the byte offsets of the matched letters.
+// The case is ignored unless the query has capitals.
+func FuzzyMatch(query, text string) (int, []int, bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+	fold := strings.ToLower(query) == query
+	q := []rune(query)
+	best, bestPositions, found := 0, []int(nil), false
+	for start, r := range text {
+		if !sameLetter(q[0], r, fold) {
+			continue
+		}
+		score, positions, ok := fuzzyFrom(q, text, start, fold)
+		if ok && (!found || score > best) {
+			best, bestPositions, found = score, positions, true
+		}
+	}
+	return best, bestPositions, found
+}
+
+// fuzzyFrom matches the query greedily from the start offset of the text
+func fuzzyFrom(q []rune, text string, start int, fold bool) (int, []int, bool) {
+	positions := make([]int, 0, len(q))
+	// end is where the previous matched letter ends
+	score, end := 0, -1
+	i := 0
+	for offset, r := range text[start:] {
+		if i == len(q) {
+			break
+		}
+		offset += start
+		if !sameLetter(q[i], r, fold) {
+			continue
+		}
+		score += fuzzyMatchScore
+		switch {
+		case offset == end:
+			score += fuzzyConsecutiveBonus
+		case end >= 0:
+			// the further apart, the less it looks like what was meant
+			score -= min(offset-end, fuzzyMatchScore)
+		}
+		if before, _ := utf8.DecodeLastRuneInString(text[:offset]); offset == 0 || !unicode.IsLetter(before) && !unicode.IsDigit(before) {
+			score += fuzzyWordStartBonus
+		}
+		positions = append(positions, offset)
+		end = offset + utf8.RuneLen(r)
+		i++
+	}
+	return score, positions, i == len(q)
+}
+
+// sameLetter compares the letters, ignoring the case when folding
+func sameLetter(a, b rune, fold bool) bool {
+	if fold {
+		return a == unicode.ToLower(b)
+	}
+	return a == b
+}
@@ -0,0 +1,98 @@
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitTimeout bounds how long we wait for git status in big repositories
+const gitTimeout = 2 * time.Second
+
+// GitStatus is the state of the git repository the shell is in
+type GitStatus struct {
+	// Branch is the checked out branch, or the abbreviated commit when the
+	// HEAD is detached
+	Branch   string
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	Staged    int
+	Modified  int
+	Untracked int
+	Conflicts int
+}
+
+// Dirty reports whether there are uncommitted changes
+func (g GitStatus) Dirty() bool {
+	return g.Changed() > 0
+}
+
+// Changed returns how many files have uncommitted changes
+func (g GitStatus) Changed() int {
+	return g.Staged + g.Modified + g.Untracked + g.Conflicts
+}
+
+// ReadGitStatus runs git status in dir. It returns false when dir isn't in a
+// git repository or git isn't installed.
+func ReadGitStatus(dir string) (GitStatus, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain=v2", "--branch")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return GitStatus{}, false
+	}
+	return parseGitStatus(out), true
+}
+
+// parseGitStatus parses the output of git status --porcelain=v2 --branch
+func parseGitStatus(out []byte) GitStatus {
+	var status GitStatus
+	var oid string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "#":
+			switch {
+			case fields[1] == "branch.oid" && len(fields) > 2:
+				oid = fields[2]
+			case fields[1] == "branch.head" && len(fields) > 2:
+				status.Branch = fields[2]
+			case fields[1] == "branch.upstream" && len(fields) > 2:
+				status.Upstream = fields[2]
+			case fields[1] == "branch.ab" && len(fields) > 3:
+				fmt.Sscanf(fields[2], "+%d", &status.Ahead)
+				fmt.Sscanf(fields[3], "-%d", &status.Behind)
+			}
+		case "1", "2":
+			// the XY field holds the staged and the worktree states
+			if fields[1][0] != '.' {
+				status.Staged++
+			}
+			if len(fields[1]) > 1 && fields[1][1] != '.' {
+				status.Modified++
+			}
+		case "u":
+			status.Conflicts++
+		case "?":
+			status.Untracked++
+		}
+	}
+	if status.Branch == "(detached)" && len(oid) >= 7 {
+		status.Branch = oid[:7]
+	}
+	return status
+}
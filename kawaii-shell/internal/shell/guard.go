@@ -0,0 +1,164 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultGuardPhrase is what has to be typed to run a protected command,
+// unless the guard file says otherwise
+const defaultGuardPhrase = "I know what I'm doing"
+
+// defaultGuardRules are the commands guard mode protects unless the guard
+// file replaces them
+var defaultGuardRules = []DangerRule{
+	{Regex: `\bgit\s+push\b.*\s(-f|--force|--force-with-lease)(\s|=|$).*\b(main|master)\b`, Reason: "This force pushes to the main branch!"},
+	{Regex: `\bgit\s+push\b.*\b(main|master)\b.*\s(-f|--force|--force-with-lease)(\s|=|$)`, Reason: "This force pushes to the main branch!"},
+	{Regex: `\bgit\s+push\b.*\s\+(main|master)\b`, Reason: "This force pushes to the main branch!"},
+	{Regex: `\b(kubectl|helm)\b.*\s--(kube-)?context[= ]\S*prod`, Reason: "This talks to a production cluster!"},
+	{Regex: `\bkubectl\s+config\s+use-context\s+\S*prod`, Reason: "This points kubectl at a production cluster!"},
+}
+
+// Guard protects the commands matching its rules while guard mode is on,
+// only running them once the confirmation phrase is typed. Every approval is
+// written down in the log.
+type Guard struct {
+	// On turns guard mode on from the start
+	On bool
+
+	// Phrase is what has to be typed to run a protected command
+	Phrase string
+
+	rules *DangerRules
+	log   string
+}
+
+// GuardApproval is a protected command that was run after all
+type GuardApproval struct {
+	Time    time.Time
+	Dir     string
+	Command string
+}
+
+// guardFile is the format of the guard file
+type guardFile struct {
+	On     bool   `yaml:"on"`
+	Phrase string `yaml:"phrase"`
+
+	// ReplaceDefaults drops the built-in rules instead of adding to them
+	ReplaceDefaults bool         `yaml:"replace_defaults"`
+	Rules           []DangerRule `yaml:"rules"`
+}
+
+// DefaultGuardLogPath returns where the guard approvals are written down,
+// honoring XDG_DATA_HOME
+func DefaultGuardLogPath() string {
+	return dataFile("guard.log")
+}
+
+// DefaultGuard returns the guard with the built-in rules, off
+func DefaultGuard(log string) *Guard {
+	rules, err := NewDangerRules(defaultGuardRules)
+	if err != nil {
+		panic(err)
+	}
+	return &Guard{Phrase: defaultGuardPhrase, rules: rules, log: log}
+}
+
+// LoadGuard loads the guard file, adding its rules to the built-in ones,
+// and writes the approvals down in log. The built-in rules are used alone
+// when the file doesn't exist.
+func LoadGuard(path, log string) (*Guard, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultGuard(log), nil
+	}
+	if err != nil {
+		return DefaultGuard(log), fmt.Errorf("failed to read guard rules: %w", err)
+	}
+
+	var file guardFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return DefaultGuard(log), fmt.Errorf("invalid guard rules %s: %w", path, err)
+	}
+	rules := file.Rules
+	if !file.ReplaceDefaults {
+		rules = append(rules, defaultGuardRules...)
+	}
+	d, err := NewDangerRules(rules)
+	if err != nil {
+		return DefaultGuard(log), err
+	}
+	guard := &Guard{On: file.On, Phrase: strings.TrimSpace(file.Phrase), rules: d, log: log}
+	if guard.Phrase == "" {
+		guard.Phrase = defaultGuardPhrase
+	}
+	return guard, nil
+}
+
+// Check returns the rule protecting the command, if any
+func (g *Guard) Check(command string) (DangerRule, bool) {
+	return g.rules.Check(command)
+}
+
+// Confirms reports whether the input is the confirmation phrase
+func (g *Guard) Confirms(input string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(input), " "), g.Phrase)
+}
+
+// Approve writes down that the protected command was run in the directory
+func (g *Guard) Approve(command, dir string, now time.Time) error {
+	if g.log == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(g.log), 0o755); err != nil {
+		return fmt.Errorf("failed to write guard log: %w", err)
+	}
+	f, err := os.OpenFile(g.log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write guard log: %w", err)
+	}
+	defer f.Close()
+	command = strings.Join(strings.Fields(command), " ")
+	if _, err := fmt.Fprintf(f, "%s\t%s\t%s\n", now.Format(time.RFC3339), dir, command); err != nil {
+		return fmt.Errorf("failed to write guard log: %w", err)
+	}
+	return nil
+}
+
+// Approvals returns the last n approvals written down, the latest last
+func (g *Guard) Approvals(n int) ([]GuardApproval, error) {
+	f, err := os.Open(g.log)
+	if errors.Is(err, os.ErrNotExist) || g.log == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read guard log: %w", err)
+	}
+	defer f.Close()
+
+	var approvals []GuardApproval
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, fields[0])
+		if err != nil {
+			continue
+		}
+		approvals = append(approvals, GuardApproval{Time: t, Dir: fields[1], Command: fields[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read guard log: %w", err)
+	}
+	return approvals[max(len(approvals)-n, 0):], nil
+}
@@ -0,0 +1,298 @@
+package shell
+
+import (
+	"runtime"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenKind is what a piece of a command line is, for highlighting it
+type TokenKind int
+
+const (
+	TokenSpace TokenKind = iota
+	TokenCommand
+	TokenArgument
+	TokenFlag
+	TokenPath
+	TokenQuoted
+	TokenVariable
+
+	// TokenOperator is a pipe, a list operator or a redirection
+	TokenOperator
+	TokenComment
+)
+
+// Token is a piece of a command line
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// Tokenize splits a command line into the pieces to highlight while it's
+// typed. It doesn't need the line to be complete or valid, and joining the
+// tokens gives back the line exactly.
+//
+// It highlights like fang does the examples in help: pipes, list
+// operators, a lone - and line continuations are operators, and so are
+// redirections along with the file they go to. Fang splits a finished
+// example at its spaces and knows the commands of the program, here the
+// line is being typed, so it's read character by character to keep every
+// space and unclosed quote in place, and the first word of each command is
+// the command.
+func Tokenize(input string) []Token {
+	t := tokenizer{input: input, expectCommand: true}
+	for t.pos < len(input) {
+		r, _ := utf8.DecodeRuneInString(input[t.pos:])
+		switch {
+		case t.continuation():
+		case r == ' ' || r == '\t' || r == '\n':
+			t.space()
+		case r == '#':
+			end := strings.IndexByte(input[t.pos:], '\n')
+			if end < 0 {
+				end = len(input) - t.pos
+			}
+			t.emit(TokenComment, t.pos+end)
+		case t.redirect():
+		case strings.ContainsRune("|&;()", r):
+			t.operator()
+		default:
+			t.word()
+		}
+	}
+	return t.tokens
+}
+
+type tokenizer struct {
+	input  string
+	pos    int
+	tokens []Token
+
+	// expectCommand is set where the next word is the name of a command
+	expectCommand bool
+
+	// redirecting is set where the next word is the file of a redirection
+	redirecting bool
+}
+
+// emit adds the input up to end as a token of the given kind, merging it
+// with the previous token when they're the same kind
+func (t *tokenizer) emit(kind TokenKind, end int) {
+	if end <= t.pos {
+		return
+	}
+	text := t.input[t.pos:end]
+	t.pos = end
+	if n := len(t.tokens); n > 0 && t.tokens[n-1].Kind == kind {
+		t.tokens[n-1].Text += text
+		return
+	}
+	t.tokens = append(t.tokens, Token{kind, text})
+}
+
+func (t *tokenizer) space() {
+	end := t.pos
+	for end < len(t.input) && strings.IndexByte(" \t\n", t.input[end]) >= 0 {
+		if t.input[end] == '\n' {
+			// a new line starts a new command, escaped ones are eaten by
+			// the word before them
+			t.expectCommand = true
+		}
+		end++
+	}
+	t.emit(TokenSpace, end)
+}
+
+// continuation reads a backslash ending the line, which carries the command
+// on to the next one, reporting whether there was one
+func (t *tokenizer) continuation() bool {
+	if runtime.GOOS == "windows" || t.input[t.pos] != '\\' {
+		return false
+	}
+	switch rest := t.input[t.pos+1:]; {
+	case rest == "":
+		t.emit(TokenOperator, t.pos+1)
+	case rest[0] == '\n':
+		t.emit(TokenOperator, t.pos+2)
+	default:
+		return false
+	}
+	return true
+}
+
+func (t *tokenizer) operator() {
+	end := t.pos + 1
+	if end < len(t.input) && (t.input[t.pos] == '|' || t.input[t.pos] == '&') && t.input[end] == t.input[t.pos] {
+		// || and &&
+		end++
+	}
+	t.expectCommand = t.input[t.pos] != ')'
+	t.emit(TokenOperator, end)
+}
+
+// redirect reads a redirection like >, 2>>, &> or 2>&1, reporting whether
+// there was one
+func (t *tokenizer) redirect() bool {
+	end := t.pos
+	for end < len(t.input) && t.input[end] >= '0' && t.input[end] <= '9' {
+		end++
+	}
+	if end == t.pos && end < len(t.input) && t.input[end] == '&' {
+		end++
+	}
+	arrows := end
+	for end < len(t.input) && (t.input[end] == '<' || t.input[end] == '>') {
+		end++
+	}
+	if end == arrows {
+		return false
+	}
+	if end < len(t.input) && t.input[end] == '&' {
+		// duplicating a file descriptor, like 2>&1
+		end++
+		for end < len(t.input) && (t.input[end] >= '0' && t.input[end] <= '9' || t.input[end] == '-') {
+			end++
+		}
+	} else {
+		t.redirecting = true
+	}
+	t.emit(TokenOperator, end)
+	return true
+}
+
+// word reads a word, which can be made of plain, quoted and variable parts
+func (t *tokenizer) word() {
+	start := t.pos
+	end := wordEnd(t.input, start)
+	word := t.input[start:end]
+
+	kind := TokenArgument
+	switch {
+	case t.redirecting:
+		t.redirecting = false
+		t.emit(TokenOperator, end)
+		return
+	case word == "-":
+		// standing for the standard input or output
+		t.emit(TokenOperator, end)
+		return
+	case t.expectCommand && isAssignment(word):
+		// VAR=value in front of a command
+		name, _, _ := strings.Cut(word, "=")
+		t.emit(TokenFlag, start+len(name)+1)
+	case t.expectCommand:
+		kind = TokenCommand
+		t.expectCommand = false
+	case strings.HasPrefix(word, "-"):
+		kind = TokenFlag
+		if name, _, ok := strings.Cut(word, "="); ok {
+			// --flag=value
+			t.emit(TokenFlag, start+len(name)+1)
+			kind = TokenArgument
+		}
+	}
+	if kind == TokenArgument && isPath(word) {
+		kind = TokenPath
+	}
+
+	escapes := runtime.GOOS != "windows"
+	for t.pos < end {
+		i := t.pos
+		switch c := t.input[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			t.emit(TokenQuoted, quoteEnd(t.input, i, end))
+		case c == '$':
+			t.emit(TokenVariable, variableEnd(t.input, i, end))
+		default:
+			for i < end && strings.IndexByte("'\"`$", t.input[i]) < 0 {
+				if t.input[i] == '\\' && escapes {
+					i++
+				}
+				i++
+			}
+			t.emit(kind, min(i, end))
+		}
+	}
+}
+
+// wordEnd returns where the word starting at start ends, which is at the
+// first space or operator outside of quotes
+func wordEnd(input string, start int) int {
+	escapes := runtime.GOOS != "windows"
+	var quote byte
+	for i := start; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '\\' && escapes && quote != '\'':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case strings.IndexByte(" \t\n|&;()<>", c) >= 0:
+			return i
+		}
+	}
+	return len(input)
+}
+
+// quoteEnd returns where the quoted string starting at start ends, the end
+// of the word when it's not closed yet
+func quoteEnd(input string, start, end int) int {
+	quote := input[start]
+	for i := start + 1; i < end; i++ {
+		switch {
+		case input[i] == '\\' && quote != '\'' && runtime.GOOS != "windows":
+			i++
+		case input[i] == quote:
+			return i + 1
+		}
+	}
+	return end
+}
+
+// variableEnd returns where the variable starting at start ends, such as
+// $HOME, ${HOME}, $? or $1
+func variableEnd(input string, start, end int) int {
+	i := start + 1
+	switch {
+	case i < end && input[i] == '{':
+		if close := strings.IndexByte(input[i:end], '}'); close >= 0 {
+			return i + close + 1
+		}
+		return end
+	case i < end && strings.IndexByte("?$!#@*-0123456789", input[i]) >= 0:
+		return i + 1
+	}
+	for i < end && (input[i] == '_' || input[i] >= 'a' && input[i] <= 'z' ||
+		input[i] >= 'A' && input[i] <= 'Z' || input[i] >= '0' && input[i] <= '9') {
+		i++
+	}
+	return i
+}
+
+// isAssignment reports whether the word sets a variable, like FOO=bar
+func isAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	if !ok || name == "" || name[0] >= '0' && name[0] <= '9' {
+		return false
+	}
+	for _, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isPath reports whether the word looks like a path rather than a plain
+// argument
+func isPath(word string) bool {
+	return strings.HasPrefix(word, "~") ||
+		strings.HasPrefix(word, ".") ||
+		strings.ContainsRune(word, '/') ||
+		(runtime.GOOS == "windows" && strings.ContainsRune(word, '\\'))
+}
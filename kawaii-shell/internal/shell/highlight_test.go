@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type testCase struct {
+		input string
+		want  []Token
+	}
+	tests := []testCase{
+		{"ls -la ~/src", []Token{
+			{TokenCommand, "ls"}, {TokenSpace, " "}, {TokenFlag, "-la"}, {TokenSpace, " "}, {TokenPath, "~/src"},
+		}},
+		{"FOO=bar go test --run=Foo", []Token{
+			{TokenFlag, "FOO="}, {TokenArgument, "bar"}, {TokenSpace, " "}, {TokenCommand, "go"},
+			{TokenSpace, " "}, {TokenArgument, "test"}, {TokenSpace, " "}, {TokenFlag, "--run="}, {TokenArgument, "Foo"},
+		}},
+		{"echo 'hi there' $HOME | wc -l", []Token{
+			{TokenCommand, "echo"}, {TokenSpace, " "}, {TokenQuoted, "'hi there'"}, {TokenSpace, " "},
+			{TokenVariable, "$HOME"}, {TokenSpace, " "}, {TokenOperator, "|"}, {TokenSpace, " "},
+			{TokenCommand, "wc"}, {TokenSpace, " "}, {TokenFlag, "-l"},
+		}},
+		{`echo "unclosed`, []Token{
+			{TokenCommand, "echo"}, {TokenSpace, " "}, {TokenQuoted, `"unclosed`},
+		}},
+		// the file of a redirection goes with it, like in fang's examples
+		{"make 2>&1 > build.log", []Token{
+			{TokenCommand, "make"}, {TokenSpace, " "}, {TokenOperator, "2>&1"}, {TokenSpace, " "},
+			{TokenOperator, ">"}, {TokenSpace, " "}, {TokenOperator, "build.log"},
+		}},
+		{"> out.txt echo hi", []Token{
+			{TokenOperator, ">"}, {TokenSpace, " "}, {TokenOperator, "out.txt"}, {TokenSpace, " "}, {TokenCommand, "echo"}, {TokenSpace, " "}, {TokenArgument, "hi"},
+		}},
+		{"cat - && echo done # finished", []Token{
+			{TokenCommand, "cat"}, {TokenSpace, " "}, {TokenOperator, "-"}, {TokenSpace, " "}, {TokenOperator, "&&"}, {TokenSpace, " "},
+			{TokenCommand, "echo"}, {TokenSpace, " "}, {TokenArgument, "done"}, {TokenSpace, " "}, {TokenComment, "# finished"},
+		}},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, testCase{"tar -cf x.tar \\\n  dir \\", []Token{
+			{TokenCommand, "tar"}, {TokenSpace, " "}, {TokenFlag, "-cf"}, {TokenSpace, " "}, {TokenArgument, "x.tar"},
+			{TokenSpace, " "}, {TokenOperator, "\\\n"}, {TokenSpace, "  "}, {TokenArgument, "dir"}, {TokenSpace, " "},
+			{TokenOperator, "\\"},
+		}})
+	}
+	for _, test := range tests {
+		got := Tokenize(test.input)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("Tokenize(%q) = %v, want %v", test.input, got, test.want)
+		}
+		var joined strings.Builder
+		for _, token := range got {
+			joined.WriteString(token.Text)
+		}
+		if joined.String() != test.input {
+			t.Errorf("Tokenize(%q) joins back into %q", test.input, joined.String())
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxHistory is the maximum number of commands kept in the history
+const MaxHistory = 1000
+
+// History keeps track of executed commands and persists them to disk
+type History struct {
+	path    string
+	entries []string
+	index   int
+	draft   string
+}
+
+// DefaultHistoryPath returns where the history is stored, honoring XDG_DATA_HOME
+func DefaultHistoryPath() string {
+	return dataFile("history")
+}
+
+// DefaultPetsPath returns where the pets are kept, honoring XDG_DATA_HOME
+func DefaultPetsPath() string {
+	return dataFile("pets.json")
+}
+
+// dataFile returns the path of a file kawaii keeps in its data directory,
+// honoring XDG_DATA_HOME, or nothing when there's no home to keep it in
+func dataFile(name string) string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "kawaii", name)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "kawaii", name)
+}
+
+// NewHistory creates a history backed by the given file, loading previous
+// entries if it exists. An empty path keeps the history in memory only.
+func NewHistory(path string) (*History, error) {
+	h := &History{path: path}
+	if path == "" {
+		return h, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	// multi-line commands are saved with a backslash at the end of all but
+	// their last line, like zsh does, see encodeLine
+	var entry strings.Builder
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, continued := decodeLine(scanner.Text())
+		if continued {
+			entry.WriteString(line + "\n")
+			continue
+		}
+		entry.WriteString(line)
+		if command := strings.TrimSpace(entry.String()); command != "" {
+			h.add(command)
+		}
+		entry.Reset()
+	}
+	h.index = len(h.entries)
+	if err := scanner.Err(); err != nil {
+		return h, fmt.Errorf("failed to read history: %w", err)
+	}
+	return h, nil
+}
+
+// Entries returns all commands in the history, oldest first
+func (h *History) Entries() []string {
+	return slices.Clone(h.entries)
+}
+
+// Add records a command, moving it to the end if it was already there,
+// and saves the history
+func (h *History) Add(command string) error {
+	command = strings.TrimSpace(command)
+	h.index = len(h.entries)
+	h.draft = ""
+	if command == "" {
+		return nil
+	}
+	h.add(command)
+	h.index = len(h.entries)
+	return h.save()
+}
+
+func (h *History) add(command string) {
+	h.entries = slices.DeleteFunc(h.entries, func(e string) bool {
+		return e == command
+	})
+	h.entries = append(h.entries, command)
+	if len(h.entries) > MaxHistory {
+		h.entries = h.entries[len(h.entries)-MaxHistory:]
+	}
+}
+
+// save writes the whole history to disk
+func (h *History) save() error {
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	var sb strings.Builder
+	for _, entry := range h.entries {
+		lines := strings.Split(entry, "\n")
+		for i, line := range lines {
+			sb.WriteString(encodeLine(line, i < len(lines)-1) + "\n")
+		}
+	}
+	if err := os.WriteFile(h.path, []byte(sb.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to save history: %w", err)
+	}
+	return nil
+}
+
+// encodeLine returns the line of a command as it's saved: the backslashes
+// it ends with doubled, and one more when the command goes on to the next
+// line, so one ending with a backslash like dir C:\ isn't taken for one
+// going on
+func encodeLine(line string, continued bool) string {
+	trimmed := strings.TrimRight(line, "\\")
+	line = trimmed + strings.Repeat("\\", 2*(len(line)-len(trimmed)))
+	if continued {
+		line += "\\"
+	}
+	return line
+}
+
+// decodeLine returns the line of a command from how it's saved, and whether
+// the command goes on to the next line, see encodeLine
+func decodeLine(line string) (string, bool) {
+	trimmed := strings.TrimRight(line, "\\")
+	n := len(line) - len(trimmed)
+	return trimmed + strings.Repeat("\\", n/2), n%2 == 1
+}
+
+// Previous moves back in the history. The current input is kept as a
+// draft so it can be restored when moving forward again.
+func (h *History) Previous(current string) (string, bool) {
+	if h.index == 0 {
+		return current, false
+	}
+	if h.index == len(h.entries) {
+		h.draft = current
+	}
+	h.index--
+	return h.entries[h.index], true
+}
+
+// Next moves forward in the history, returning the draft at the end
+func (h *History) Next() (string, bool) {
+	if h.index >= len(h.entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.index], true
+}
+
+// Expand performs bash-like history expansion on the command:
+//
+//	!!      the last command
+//	!n      the n-th command
+//	!-n     the n-th last command
+//	!$      the last argument of the last command
+//	!prefix the last command starting with prefix
+func (h *History) Expand(command string) (string, error) {
+	if !strings.Contains(command, "!") {
+		return command, nil
+	}
+
+	var sb strings.Builder
+	var quoted bool
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		if c == '\'' {
+			quoted = !quoted
+		}
+		if c != '!' || quoted || i+1 >= len(command) || strings.ContainsRune(" \t=(", rune(command[i+1])) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		rest := command[i+1:]
+		var event string
+		var entry string
+		var ok bool
+		switch {
+		case rest[0] == '!':
+			event = "!"
+			entry, ok = h.last(1)
+		case rest[0] == '$':
+			event = "$"
+			entry, ok = h.last(1)
+			if ok {
+				fields := strings.Fields(entry)
+				entry = fields[len(fields)-1]
+			}
+		case rest[0] == '-' || (rest[0] >= '0' && rest[0] <= '9'):
+			end := 1
+			for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+				end++
+			}
+			event = rest[:end]
+			n, err := strconv.Atoi(event)
+			if err != nil {
+				return command, fmt.Errorf("!%s: event not found", event)
+			}
+			if n < 0 {
+				entry, ok = h.last(-n)
+			} else if n > 0 && n <= len(h.entries) {
+				entry, ok = h.entries[n-1], true
+			}
+		default:
+			end := strings.IndexAny(rest, " \t;|&")
+			if end < 0 {
+				end = len(rest)
+			}
+			event = rest[:end]
+			for j := len(h.entries) - 1; j >= 0 && !ok; j-- {
+				if strings.HasPrefix(h.entries[j], event) {
+					entry, ok = h.entries[j], true
+				}
+			}
+		}
+		if !ok {
+			return command, fmt.Errorf("!%s: event not found", event)
+		}
+		sb.WriteString(entry)
+		i += len(event)
+	}
+	return sb.String(), nil
+}
+
+// Suggest returns the most recent command starting with the input, for
+// completing it inline as it's typed
+func (h *History) Suggest(input string) (string, bool) {
+	if strings.TrimSpace(input) == "" {
+		return "", false
+	}
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if entry := h.entries[i]; len(entry) > len(input) && strings.HasPrefix(entry, input) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// HistoryMatch is a past command matching a history search
+type HistoryMatch struct {
+	Command string
+
+	// Positions are the byte offsets of the letters matching the search
+	Positions []int
+	score     int
+}
+
+// Search returns the past commands fuzzy matching the query, each once, the
+// best matches first and the most recent first among equally good ones
+func (h *History) Search(query string) []HistoryMatch {
+	var matches []HistoryMatch
+	seen := map[string]bool{}
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		entry := h.entries[i]
+		if seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		if score, positions, ok := FuzzyMatch(query, entry); ok {
+			matches = append(matches, HistoryMatch{Command: entry, Positions: positions, score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b HistoryMatch) int { return b.score - a.score })
+	return matches
+}
+
+// last returns the n-th last command
+func (h *History) last(n int) (string, bool) {
+	if n < 1 || n > len(h.entries) {
+		return "", false
+	}
+	return h.entries[len(h.entries)-n], true
+}
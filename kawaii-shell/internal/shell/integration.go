@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Shell integration scripts make the shell print an OSC 133;D sequence with
+// the exit code of each command before showing the prompt, like FinalTerm
+// and most modern terminals do, and an OSC 7 sequence with its working
+// directory. The directory is percent-encoded like in any file URL, so a %
+// in its name comes back as it is.
+
+const bashIntegration = `[ -f ~/.bashrc ] && . ~/.bashrc
+` + bashStatus
+
+const bashStatus = `__kawaii_urlencode() { local LC_ALL=C s=$1 c i out=; for ((i = 0; i < ${#s}; i++)); do c=${s:i:1}; case $c in [-/._~A-Za-z0-9]) out+=$c ;; *) printf -v c '%%%02X' "'$c"; out+=$c ;; esac; done; printf '%s' "$out"; }
+__kawaii_status() { local status=$?; printf '\033]133;D;%s\007\033]7;file://%s%s\007' "$status" "$HOSTNAME" "$(__kawaii_urlencode "$PWD")"; return $status; }
+PROMPT_COMMAND="__kawaii_status${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+`
+
+const zshEnvIntegration = `[ -f "${KAWAII_ZDOTDIR:-$HOME}/.zshenv" ] && . "${KAWAII_ZDOTDIR:-$HOME}/.zshenv"
+`
+
+const zshIntegration = `ZDOTDIR="${KAWAII_ZDOTDIR:-$HOME}"
+unset KAWAII_ZDOTDIR
+[ -f "$ZDOTDIR/.zshrc" ] && . "$ZDOTDIR/.zshrc"
+__kawaii_urlencode() { setopt localoptions nomultibyte; local LC_ALL=C s=$1 c i out=; for ((i = 0; i < ${#s}; i++)); do c=${s:i:1}; case $c in [-/._~A-Za-z0-9]) out+=$c ;; *) printf -v c '%%%02X' "'$c"; out+=$c ;; esac; done; printf '%s' "$out" }
+__kawaii_status() { printf '\033]133;D;%s\007\033]7;file://%s%s\007' "$?" "$HOST" "$(__kawaii_urlencode "$PWD")" }
+precmd_functions=(__kawaii_status $precmd_functions)
+`
+
+const fishIntegration = `function __kawaii_status --on-event fish_postexec; printf '\e]133;D;%s\a' $status; end
+function __kawaii_cwd --on-variable PWD; printf '\e]7;file://%s%s\a' $hostname (string escape --style=url -- $PWD); end
+__kawaii_cwd`
+
+// shellIntegration returns the arguments and environment variables that set
+// up the integration for the given shell, writing its scripts to dir. Shells
+// that aren't supported run without it, and won't report exit codes.
+func shellIntegration(shell, dir string) ([]string, []string, error) {
+	switch name := strings.TrimSuffix(filepath.Base(shell), ".exe"); name {
+	case "bash":
+		rc := filepath.Join(dir, "bashrc")
+		if err := os.WriteFile(rc, []byte(bashIntegration), 0o600); err != nil {
+			return nil, nil, fmt.Errorf("failed to write shell integration: %w", err)
+		}
+		return []string{"--rcfile", rc, "-i"}, nil, nil
+
+	case "zsh":
+		files := map[string]string{".zshenv": zshEnvIntegration, ".zshrc": zshIntegration}
+		for file, script := range files {
+			if err := os.WriteFile(filepath.Join(dir, file), []byte(script), 0o600); err != nil {
+				return nil, nil, fmt.Errorf("failed to write shell integration: %w", err)
+			}
+		}
+		return nil, []string{"ZDOTDIR=" + dir, "KAWAII_ZDOTDIR=" + os.Getenv("ZDOTDIR")}, nil
+
+	case "fish":
+		return []string{"--init-command", fishIntegration}, nil, nil
+	}
+	return nil, nil, nil
+}
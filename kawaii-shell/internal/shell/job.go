@@ -0,0 +1,165 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxJobLines is the most output lines a job keeps while nobody looks at it
+const maxJobLines = 1000
+
+// JobState is whether a job is running, stopped or done
+type JobState int
+
+const (
+	JobRunning JobState = iota
+	JobStopped
+	JobDone
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobStopped:
+		return "stopped"
+	case JobDone:
+		return "done"
+	default:
+		return "running"
+	}
+}
+
+// Job is a command running in the background in its own pseudo terminal,
+// so it can keep going while other commands run in the shell
+type Job struct {
+	ID       int
+	Command  string
+	State    JobState
+	ExitCode int
+	Started  time.Time
+	Finished time.Time
+
+	shell  *Shell
+	screen *Screen
+	lines  []string
+	exited chan int
+}
+
+// StartJob starts the command as a job in dir, with a terminal of the given
+// size
+func StartJob(id int, command, dir string, cols, rows int) (*Job, error) {
+	sh, err := NewShell()
+	if err != nil {
+		return nil, err
+	}
+	sh.Resize(cols, rows)
+	if err := sh.StartCommand(command, dir); err != nil {
+		return nil, fmt.Errorf("failed to start job: %w", err)
+	}
+
+	j := &Job{
+		ID:      id,
+		Command: command,
+		Started: time.Now(),
+		shell:   sh,
+		screen:  NewScreen(),
+		exited:  make(chan int, 1),
+	}
+	go func() {
+		code, err := sh.Wait()
+		if err != nil {
+			code = -1
+		}
+		j.exited <- code
+	}()
+	return j, nil
+}
+
+// ParseBackground strips the & asking to run a command in the background,
+// reporting whether it was there
+func ParseBackground(command string) (string, bool) {
+	trimmed := strings.TrimRight(command, " \t")
+	rest, ok := strings.CutSuffix(trimmed, "&")
+	if !ok || strings.HasSuffix(rest, "&") || strings.HasSuffix(rest, "\\") ||
+		strings.HasSuffix(rest, ">") || !IsComplete(rest) {
+		// && at the end, an escaped &, a redirection like >& or one inside
+		// quotes
+		return command, false
+	}
+	rest = strings.TrimSpace(rest)
+	return rest, rest != ""
+}
+
+// Poll picks up the output written since the last call, reporting whether
+// the job finished in the meantime
+func (j *Job) Poll() bool {
+	if data := j.shell.ReadOutput(); len(data) > 0 {
+		j.lines = append(j.lines, j.screen.Write(data)...)
+		if len(j.lines) > maxJobLines {
+			j.lines = j.lines[len(j.lines)-maxJobLines:]
+		}
+	}
+	if j.State == JobDone {
+		return false
+	}
+	select {
+	case code := <-j.exited:
+		j.State = JobDone
+		j.ExitCode = code
+		j.Finished = time.Now()
+		return true
+	default:
+		return false
+	}
+}
+
+// TakeLines returns the output lines nobody has seen yet
+func (j *Job) TakeLines() []string {
+	lines := j.lines
+	j.lines = nil
+	return lines
+}
+
+// Current returns the output line being written
+func (j *Job) Current() string {
+	return j.screen.Current()
+}
+
+// Send sends typed input to the job
+func (j *Job) Send(input string) error {
+	return j.shell.Write(input)
+}
+
+// Suspend stops the job until it's resumed
+func (j *Job) Suspend() error {
+	if j.State != JobRunning {
+		return nil
+	}
+	if err := signalStop(j.shell.pty.Pid()); err != nil {
+		return fmt.Errorf("failed to stop job %d: %w", j.ID, err)
+	}
+	j.State = JobStopped
+	return nil
+}
+
+// Resume lets a stopped job carry on
+func (j *Job) Resume() error {
+	if j.State != JobStopped {
+		return nil
+	}
+	if err := signalContinue(j.shell.pty.Pid()); err != nil {
+		return fmt.Errorf("failed to resume job %d: %w", j.ID, err)
+	}
+	j.State = JobRunning
+	return nil
+}
+
+// Resize sets the size of the job's terminal
+func (j *Job) Resize(cols, rows int) error {
+	return j.shell.Resize(cols, rows)
+}
+
+// Close kills the job if it's still running
+func (j *Job) Close() error {
+	return j.shell.Close()
+}
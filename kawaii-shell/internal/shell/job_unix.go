@@ -0,0 +1,16 @@
+//go:build !windows
+
+package shell
+
+import "syscall"
+
+// signalStop stops the process group of the job, which is led by its shell.
+// SIGTSTP would be ignored, the group has no parent in its session.
+func signalStop(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGSTOP)
+}
+
+// signalContinue resumes the process group of the job
+func signalContinue(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGCONT)
+}
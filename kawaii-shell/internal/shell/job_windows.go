@@ -0,0 +1,16 @@
+//go:build windows
+
+package shell
+
+import "errors"
+
+// errNoSuspend is returned when stopping jobs, Windows has no way to
+var errNoSuspend = errors.New("jobs can't be stopped on Windows")
+
+func signalStop(pid int) error {
+	return errNoSuspend
+}
+
+func signalContinue(pid int) error {
+	return errNoSuspend
+}
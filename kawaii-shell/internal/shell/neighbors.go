@@ -0,0 +1,295 @@
+package shell
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// neighborTimeout is how long a visitor stays without hearing from
+	// their shell, which says hello more often than that
+	neighborTimeout = 15 * time.Second
+
+	// neighborDial is how long reaching another shell may take
+	neighborDial = 200 * time.Millisecond
+
+	// maxNeighborMessage is how big a message from another shell may be
+	maxNeighborMessage = 4096
+)
+
+// Visitor is the pet of another shell visiting this one
+type Visitor struct {
+	// ID is the shell the pet lives in
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Icon  string `json:"icon"`
+	Level int    `json:"level"`
+}
+
+// Gift is a treat a visitor brought over
+type Gift struct {
+	From  Visitor
+	Treat string
+}
+
+// Reaction is news from outside the shell for the pets to react to, sent
+// by integrations like CI webhooks or build watchers
+type Reaction struct {
+	Kind      string  `json:"kind"`
+	Intensity float64 `json:"intensity"`
+	Message   string  `json:"message,omitempty"`
+}
+
+// NeighborNews is what happened in the neighborhood since it was last
+// polled
+type NeighborNews struct {
+	Arrived   []Visitor
+	Left      []Visitor
+	Gifts     []Gift
+	Reactions []Reaction
+}
+
+// neighborMessage is what shells tell each other, one per connection.
+// Reactions come from outside the shells, without a pet.
+type neighborMessage struct {
+	Pet      Visitor   `json:"pet"`
+	Gift     string    `json:"gift,omitempty"`
+	Leaving  bool      `json:"leaving,omitempty"`
+	Reaction *Reaction `json:"reaction,omitempty"`
+}
+
+// neighborVisit is a visitor and when their shell was last heard from
+type neighborVisit struct {
+	Visitor
+	seen time.Time
+}
+
+// Neighbors are the other kawaii shells running on the machine, which find
+// each other by the unix sockets they listen on in a shared directory
+type Neighbors struct {
+	dir      string
+	id       string
+	listener net.Listener
+	messages chan neighborMessage
+	visitors map[string]neighborVisit
+}
+
+// DefaultNeighborsDir returns where the shells find each other, in
+// XDG_RUNTIME_DIR when there's one
+func DefaultNeighborsDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "kawaii")
+	}
+	return filepath.Join(os.TempDir(), "kawaii-"+strconv.Itoa(os.Getuid()))
+}
+
+// OpenNeighbors moves into the neighborhood in dir, listening for the
+// other shells
+func OpenNeighbors(dir string) (*Neighbors, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to meet the neighbors: %w", err)
+	}
+	if err := privateDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to meet the neighbors: %w", err)
+	}
+	n := &Neighbors{
+		dir:      dir,
+		id:       strconv.Itoa(os.Getpid()),
+		messages: make(chan neighborMessage, 64),
+		visitors: make(map[string]neighborVisit),
+	}
+	// a shell that crashed with the same pid may have left it behind
+	os.Remove(n.socket(n.id))
+	listener, err := net.Listen("unix", n.socket(n.id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to meet the neighbors: %w", err)
+	}
+	n.listener = listener
+	go n.accept()
+	return n, nil
+}
+
+// socket returns the path of the socket the shell with the ID listens on
+func (n *Neighbors) socket(id string) string {
+	return filepath.Join(n.dir, id+".sock")
+}
+
+// accept takes the messages of the other shells until the neighbors are
+// closed
+func (n *Neighbors) accept() {
+	for {
+		conn, err := n.listener.Accept()
+		if err != nil {
+			return
+		}
+		go n.receive(conn)
+	}
+}
+
+// receive reads the message on the connection, dropping it when too many
+// are waiting already
+func (n *Neighbors) receive(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(time.Second))
+	var msg neighborMessage
+	if err := json.NewDecoder(io.LimitReader(conn, maxNeighborMessage)).Decode(&msg); err != nil {
+		return
+	}
+	if msg.Reaction == nil && (msg.Pet.ID == "" || msg.Pet.ID == n.id) {
+		return
+	}
+	select {
+	case n.messages <- msg:
+	default:
+	}
+}
+
+// send delivers the message to the shell with the ID
+func (n *Neighbors) send(id string, msg neighborMessage) error {
+	return sendNeighbor(n.socket(id), msg)
+}
+
+// sendNeighbor delivers the message to the shell listening on the socket
+func sendNeighbor(socket string, msg neighborMessage) error {
+	conn, err := net.DialTimeout("unix", socket, neighborDial)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(neighborDial))
+	return json.NewEncoder(conn).Encode(msg)
+}
+
+// others returns the IDs of the other shells in the neighborhood
+func (n *Neighbors) others() []string {
+	matches, _ := filepath.Glob(filepath.Join(n.dir, "*.sock"))
+	var ids []string
+	for _, match := range matches {
+		if id := strings.TrimSuffix(filepath.Base(match), ".sock"); id != n.id {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Announce has the pet say hello to the other shells in the background,
+// visiting their pet boxes. Sockets nobody listens on anymore are cleaned
+// up, while a shell that's only busy keeps its own.
+func (n *Neighbors) Announce(pet Visitor) {
+	pet.ID = n.id
+	go func() {
+		for _, id := range n.others() {
+			if err := n.send(id, neighborMessage{Pet: pet}); abandoned(err) {
+				os.Remove(n.socket(id))
+			}
+		}
+	}()
+}
+
+// abandoned reports whether the error dialing a socket means nobody
+// listens on it anymore
+func abandoned(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, fs.ErrNotExist)
+}
+
+// Poll returns who came to visit, who went home and the gifts brought
+// since the last time
+func (n *Neighbors) Poll(now time.Time) NeighborNews {
+	var news NeighborNews
+	for {
+		select {
+		case msg := <-n.messages:
+			if msg.Reaction != nil {
+				news.Reactions = append(news.Reactions, *msg.Reaction)
+				continue
+			}
+			if msg.Leaving {
+				if visit, ok := n.visitors[msg.Pet.ID]; ok {
+					delete(n.visitors, msg.Pet.ID)
+					news.Left = append(news.Left, visit.Visitor)
+				}
+				continue
+			}
+			if _, ok := n.visitors[msg.Pet.ID]; !ok {
+				news.Arrived = append(news.Arrived, msg.Pet)
+			}
+			n.visitors[msg.Pet.ID] = neighborVisit{Visitor: msg.Pet, seen: now}
+			if msg.Gift != "" {
+				news.Gifts = append(news.Gifts, Gift{From: msg.Pet, Treat: msg.Gift})
+			}
+		default:
+			for id, visit := range n.visitors {
+				if now.Sub(visit.seen) > neighborTimeout {
+					delete(n.visitors, id)
+					news.Left = append(news.Left, visit.Visitor)
+				}
+			}
+			return news
+		}
+	}
+}
+
+// Visitors returns the pets visiting from the other shells, by name
+func (n *Neighbors) Visitors() []Visitor {
+	visitors := make([]Visitor, 0, len(n.visitors))
+	for _, visit := range n.visitors {
+		visitors = append(visitors, visit.Visitor)
+	}
+	slices.SortFunc(visitors, func(a, b Visitor) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.ID, b.ID))
+	})
+	return visitors
+}
+
+// Give has the pet bring the treat over to the visitor's shell
+func (n *Neighbors) Give(to Visitor, pet Visitor, treat string) error {
+	pet.ID = n.id
+	if err := n.send(to.ID, neighborMessage{Pet: pet, Gift: treat}); err != nil {
+		return fmt.Errorf("failed to visit %s: %w", to.Name, err)
+	}
+	return nil
+}
+
+// Close moves out of the neighborhood, the pet saying goodbye to the
+// visitors' shells
+func (n *Neighbors) Close(pet Visitor) error {
+	pet.ID = n.id
+	for id := range n.visitors {
+		n.send(id, neighborMessage{Pet: pet, Leaving: true})
+	}
+	n.visitors = make(map[string]neighborVisit)
+	return n.listener.Close()
+}
+
+// React sends the reaction to all the shells in the neighborhood in dir,
+// returning how many got it
+func React(dir string, reaction Reaction) (int, error) {
+	if err := privateDir(dir); errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to find the shells: %w", err)
+	}
+	sockets, err := filepath.Glob(filepath.Join(dir, "*.sock"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the shells: %w", err)
+	}
+	reached := 0
+	for _, socket := range sockets {
+		if sendNeighbor(socket, neighborMessage{Reaction: &reaction}) == nil {
+			reached++
+		}
+	}
+	return reached, nil
+}
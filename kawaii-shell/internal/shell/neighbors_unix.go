@@ -0,0 +1,29 @@
+//go:build !windows
+
+package shell
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// privateDir makes sure the directory is ours and closed to everyone else,
+// so nobody can listen in place of the shells or plant sockets in it. In a
+// shared temporary directory another user may have made it first.
+func privateDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	switch {
+	case !info.IsDir():
+		return fmt.Errorf("%s isn't a directory", dir)
+	case !ok || int(stat.Uid) != os.Getuid():
+		return fmt.Errorf("%s belongs to someone else", dir)
+	case info.Mode().Perm() != 0o700:
+		return fmt.Errorf("%s can be opened by others, it should have mode 0700", dir)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package shell
+
+// privateDir leaves the directory alone, it's in the user's own profile on
+// Windows
+func privateDir(dir string) error {
+	return nil
+}
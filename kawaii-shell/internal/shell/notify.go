@@ -0,0 +1,53 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Notify shows a desktop notification with notify-send on Linux and
+// osascript on macOS. Elsewhere, or when they're missing, it asks the
+// terminal to show one with OSC 777, which terminals without support
+// ignore.
+func Notify(w io.Writer, title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + strconv.Quote(body) + " with title " + strconv.Quote(title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+	default:
+		if path, err := exec.LookPath("notify-send"); err == nil {
+			cmd = exec.Command(path, "--app-name=Kawaii Shell", title, body)
+		}
+	}
+	if cmd != nil {
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+	}
+
+	// semicolons separate the fields of the sequence
+	clean := strings.NewReplacer(";", ",", "\a", "", "\x1b", "")
+	seq := "\x1b]777;notify;" + clean.Replace(title) + ";" + clean.Replace(body) + "\a"
+	if os.Getenv("TMUX") != "" {
+		seq = ansi.TmuxPassthrough(seq)
+	}
+	if _, err := io.WriteString(w, seq); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
+
+// Bell rings the terminal bell
+func Bell(w io.Writer) error {
+	_, err := io.WriteString(w, "\a")
+	return err
+}
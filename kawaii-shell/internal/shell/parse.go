@@ -0,0 +1,119 @@
+package shell
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// IsComplete reports whether the input is a whole command, rather than one
+// that continues on the next line because of an unclosed quote or a trailing
+// backslash. Backslashes are path separators for cmd.exe, so they don't
+// escape anything on Windows.
+func IsComplete(input string) bool {
+	escapes := runtime.GOOS != "windows"
+	var quote rune
+	var escaped, comment bool
+	prev := ' '
+	for _, r := range input {
+		switch {
+		case comment:
+			comment = r != '\n'
+		case escaped:
+			escaped = false
+		case r == '\\' && escapes && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '#' && (prev == ' ' || prev == '\t' || prev == '\n' || prev == ';'):
+			comment = true
+		}
+		prev = r
+	}
+	return quote == 0 && !escaped
+}
+
+// Word is a word of a command line after quote removal
+type Word struct {
+	Value string
+
+	// Glob is set when the word has unquoted wildcards
+	Glob bool
+}
+
+// SplitWords splits a simple command line into words, removing quotes and
+// escapes. It returns false when the command uses anything only the shell
+// can make sense of, such as variables, substitutions, redirections or
+// several commands.
+func SplitWords(input string) ([]Word, bool) {
+	var words []Word
+	var word strings.Builder
+	var quote rune
+	var inWord, escaped, glob bool
+	for _, r := range input {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'' && runtime.GOOS != "windows":
+			escaped, inWord = true, true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == '\'':
+			word.WriteRune(r)
+		case r == '$' || r == '`':
+			return nil, false
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case strings.ContainsRune(";&|<>(){}\n", r):
+			return nil, false
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, Word{word.String(), glob})
+				word.Reset()
+				inWord, glob = false, false
+			}
+		default:
+			if r == '*' || r == '?' || r == '[' {
+				glob = true
+			}
+			if r == '~' && !inWord {
+				// only ~ and ~/ are expanded, ~user is left to the shell
+				word.WriteString(homeMarker)
+			} else {
+				word.WriteRune(r)
+			}
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, false
+	}
+	if inWord {
+		words = append(words, Word{word.String(), glob})
+	}
+	for i, w := range words {
+		rest, ok := strings.CutPrefix(w.Value, homeMarker)
+		if !ok {
+			continue
+		}
+		if rest != "" && rest[0] != '/' {
+			return nil, false
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, false
+		}
+		words[i].Value = home + rest
+	}
+	return words, true
+}
+
+// homeMarker stands for an unquoted ~ while splitting words
+const homeMarker = "\x00~"
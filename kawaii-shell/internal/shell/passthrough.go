@@ -0,0 +1,139 @@
+package shell
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/cancelreader"
+)
+
+// altScreenExits are the sequences programs use to leave the alternate screen
+var altScreenExits = [][]byte{
+	[]byte("\x1b[?1049l"),
+	[]byte("\x1b[?1047l"),
+	[]byte("\x1b[?47l"),
+}
+
+// maxAltScreenExit is the length of the longest of altScreenExits
+var maxAltScreenExit = len(altScreenExits[0])
+
+// Passthrough hands the whole terminal over to a full-screen program running
+// in the shell, such as vim, htop or less, until it leaves the alternate
+// screen.
+//
+// It implements tea.ExecCommand, so it can be run with tea.Exec, which
+// suspends the UI in the meantime.
+type Passthrough struct {
+	shell   *Shell
+	initial []byte
+	stdin   io.Reader
+	stdout  io.Writer
+	rest    []byte
+
+	// tail is the end of the output written so far, where the start of a
+	// sequence leaving the alternate screen may be waiting for the rest
+	tail []byte
+}
+
+// Passthrough creates a passthrough starting with the given output, which
+// should begin with the sequence entering the alternate screen
+func (s *Shell) Passthrough(initial []byte) *Passthrough {
+	return &Passthrough{
+		shell:   s,
+		initial: initial,
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+	}
+}
+
+// SetStdin sets the input forwarded to the program
+func (p *Passthrough) SetStdin(r io.Reader) {
+	if r != nil {
+		p.stdin = r
+	}
+}
+
+// SetStdout sets where the program output is written
+func (p *Passthrough) SetStdout(w io.Writer) {
+	if w != nil {
+		p.stdout = w
+	}
+}
+
+// SetStderr is a no-op, the program output all comes from the PTY
+func (p *Passthrough) SetStderr(io.Writer) {}
+
+// Rest returns the output written after the program left the alternate
+// screen, which belongs to the kawaii UI again
+func (p *Passthrough) Rest() []byte {
+	return p.rest
+}
+
+// Run proxies input and output between the terminal and the PTY until the
+// program leaves the alternate screen or the shell exits
+func (p *Passthrough) Run() error {
+	if f, ok := p.stdin.(term.File); ok && term.IsTerminal(f.Fd()) {
+		state, err := term.MakeRaw(f.Fd())
+		if err == nil {
+			defer term.Restore(f.Fd(), state)
+		}
+	}
+
+	// let the program use the whole terminal
+	if f, ok := p.stdout.(term.File); ok {
+		if w, h, err := term.GetSize(f.Fd()); err == nil {
+			cols, rows := p.shell.cols, p.shell.rows
+			_ = p.shell.Resize(w, h)
+			defer func() { _ = p.shell.Resize(cols, rows) }()
+		}
+	}
+
+	input, err := cancelreader.NewReader(p.stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	defer input.Close()
+	defer input.Cancel()
+	go func() {
+		_, _ = io.Copy(p.shell.pty, input)
+	}()
+
+	if p.write(p.initial) {
+		return nil
+	}
+	for {
+		select {
+		case chunk := <-p.shell.output:
+			if p.write(chunk) {
+				return nil
+			}
+		case <-p.shell.done:
+			return nil
+		}
+	}
+}
+
+// write forwards the output to the terminal, returning whether the program
+// left the alternate screen. The sequence leaving it can be split across
+// chunks, so it's looked for in the end of the last one too.
+func (p *Passthrough) write(chunk []byte) bool {
+	data := append(p.tail, chunk...)
+	end := -1
+	for _, seq := range altScreenExits {
+		if i := bytes.Index(data, seq); i >= 0 && (end < 0 || i+len(seq) < end) {
+			end = i + len(seq)
+		}
+	}
+	if end < 0 {
+		_, _ = p.stdout.Write(chunk)
+		p.tail = bytes.Clone(data[max(len(data)-maxAltScreenExit+1, 0):])
+		return false
+	}
+	// the tail was written with the chunk before
+	_, _ = p.stdout.Write(data[len(p.tail):end])
+	p.rest = bytes.Clone(data[end:])
+	return true
+}
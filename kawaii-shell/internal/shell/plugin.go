@@ -0,0 +1,216 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// pluginTimeout is how long a plugin gets to describe itself or handle an
+// event before it's stopped
+const pluginTimeout = 5 * time.Second
+
+// Plugin events, sent to the plugins subscribed to them
+const (
+	// EventStart is sent once the shell is up
+	EventStart = "start"
+	// EventCommand is sent when a command is sent to the shell
+	EventCommand = "command"
+	// EventDone is sent when a command finishes, with its exit code
+	EventDone = "done"
+	// EventCd is sent when the shell changes directory
+	EventCd = "cd"
+)
+
+// Plugin is an executable in the plugins directory extending the kawaii
+// shell. It's run with describe to get its manifest, a JSON object with
+// its name, the kawaii commands it adds, the decorators for the output and
+// the events it wants. It's run with run, the command and its arguments
+// when one of its commands is typed, and with event and the event as JSON
+// on stdin for the events it subscribed to. What it prints is shown in the
+// output.
+type Plugin struct {
+	Path       string            `json:"-"`
+	Name       string            `json:"name"`
+	Commands   []PluginCommand   `json:"commands,omitempty"`
+	Decorators []PluginDecorator `json:"decorators,omitempty"`
+	Events     []string          `json:"events,omitempty"`
+}
+
+// PluginCommand is a kawaii command added by a plugin
+type PluginCommand struct {
+	Name        string `json:"name"`
+	Emoji       string `json:"emoji,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PluginDecorator changes the output lines matching its regular expression,
+// adding a prefix or a suffix and coloring them
+type PluginDecorator struct {
+	Regex  string `json:"regex"`
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Bold   bool   `json:"bold,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// PluginEvent is something that happened in the shell
+type PluginEvent struct {
+	Event   string `json:"event"`
+	Cwd     string `json:"cwd"`
+	Command string `json:"command,omitempty"`
+
+	// ExitCode and Duration, in seconds, are set for done events
+	ExitCode *int    `json:"exit_code,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// Plugins are the plugins that were loaded
+type Plugins []*Plugin
+
+// LoadPlugins describes the executables in dir, which doesn't have to
+// exist. The plugins that work are returned even when others fail.
+func LoadPlugins(dir string) (Plugins, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins: %w", err)
+	}
+
+	var plugins Plugins
+	var errs []error
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || !isExecutable(info) {
+			continue
+		}
+		plugin, err := describePlugin(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, errors.Join(errs...)
+}
+
+// describePlugin runs the plugin to get its manifest
+func describePlugin(path string) (*Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "describe").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe plugin %s: %w", filepath.Base(path), err)
+	}
+
+	plugin := &Plugin{Path: path}
+	if err := json.Unmarshal(out, plugin); err != nil {
+		return nil, fmt.Errorf("invalid plugin %s: %w", filepath.Base(path), err)
+	}
+	if plugin.Name == "" {
+		plugin.Name = filepath.Base(path)
+	}
+	for i, decorator := range plugin.Decorators {
+		re, err := regexp.Compile(decorator.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decorator in plugin %s: %w", plugin.Name, err)
+		}
+		plugin.Decorators[i].re = re
+	}
+	return plugin, nil
+}
+
+// Run runs one of the plugin's commands in dir, returning what it printed
+// and its exit code
+func (p *Plugin) Run(command string, args []string, dir string) ([]string, int, error) {
+	cmd := exec.Command(p.Path, append([]string{"run", command}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, -1, fmt.Errorf("failed to run %s: %w", command, err)
+	}
+	return outputLines(out), cmd.ProcessState.ExitCode(), nil
+}
+
+// Send tells the plugin about the event, returning what it printed
+func (p *Plugin) Send(event PluginEvent) ([]string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send event: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, p.Path, "event")
+	cmd.Dir = event.Cwd
+	cmd.Stdin = bytes.NewReader(data)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s failed on %s: %w", p.Name, event.Event, err)
+	}
+	return outputLines(out), nil
+}
+
+// Subscribed reports whether the plugin wants the event
+func (p *Plugin) Subscribed(event string) bool {
+	return slices.Contains(p.Events, event)
+}
+
+// Command returns the plugin and its command with the given name
+func (ps Plugins) Command(name string) (*Plugin, PluginCommand, bool) {
+	for _, plugin := range ps {
+		for _, command := range plugin.Commands {
+			if command.Name == name {
+				return plugin, command, true
+			}
+		}
+	}
+	return nil, PluginCommand{}, false
+}
+
+// CommandNames returns the names of the commands the plugins add
+func (ps Plugins) CommandNames() []string {
+	var names []string
+	for _, plugin := range ps {
+		for _, command := range plugin.Commands {
+			names = append(names, command.Name)
+		}
+	}
+	return names
+}
+
+// Decorator returns the first decorator matching the line, which should be
+// free of escape sequences
+func (ps Plugins) Decorator(line string) (PluginDecorator, bool) {
+	for _, plugin := range ps {
+		for _, decorator := range plugin.Decorators {
+			if decorator.re.MatchString(line) {
+				return decorator, true
+			}
+		}
+	}
+	return PluginDecorator{}, false
+}
+
+// outputLines splits the output of a plugin into lines
+func outputLines(out []byte) []string {
+	text := strings.TrimRight(string(out), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,129 @@
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Project is the project a directory belongs to, found by the files marking
+// its root
+type Project struct {
+	Name     string
+	Language string
+	Emoji    string
+
+	// Root is the directory with the marker
+	Root string
+}
+
+// projectMarker is a file marking the root of a project, and how to read
+// the project's name from it
+type projectMarker struct {
+	file     string
+	language string
+	emoji    string
+	name     func(data []byte) string
+}
+
+// projectMarkers are looked for in order in each directory, so a Go module
+// inside a git repository is a Go project
+var projectMarkers = []projectMarker{
+	{"go.mod", "Go", "🐹", goModuleName},
+	{"Cargo.toml", "Rust", "🦀", func(data []byte) string { return tomlName(data, "package") }},
+	{"package.json", "JavaScript", "🟨", packageJSONName},
+	{"pyproject.toml", "Python", "🐍", func(data []byte) string { return tomlName(data, "project", "tool.poetry") }},
+	{".git", "", "📁", nil},
+}
+
+// DetectProject looks for the project dir is in, from dir up to the root of
+// the file system
+func DetectProject(dir string) (Project, bool) {
+	for {
+		for _, marker := range projectMarkers {
+			project, ok := readMarker(dir, marker)
+			if ok {
+				return project, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Project{}, false
+		}
+		dir = parent
+	}
+}
+
+// readMarker reads the project marked by the marker in dir, if it's there
+func readMarker(dir string, marker projectMarker) (Project, bool) {
+	file := filepath.Join(dir, marker.file)
+	if _, err := os.Stat(file); err != nil {
+		return Project{}, false
+	}
+	project := Project{Language: marker.language, Emoji: marker.emoji, Root: dir}
+	if marker.name != nil {
+		if data, err := os.ReadFile(file); err == nil {
+			project.Name = marker.name(data)
+		}
+	}
+	if marker.file == "package.json" {
+		if _, err := os.Stat(filepath.Join(dir, "tsconfig.json")); err == nil {
+			project.Language, project.Emoji = "TypeScript", "🟦"
+		}
+	}
+	if project.Name == "" {
+		project.Name = filepath.Base(dir)
+	}
+	return project, true
+}
+
+// goModuleName returns the last element of the module path in go.mod
+func goModuleName(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return path.Base(strings.Trim(strings.TrimSpace(module), `"`))
+		}
+	}
+	return ""
+}
+
+// packageJSONName returns the name in package.json
+func packageJSONName(data []byte) string {
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Name
+}
+
+// tomlName returns the name key of the first of the TOML sections that has
+// one. It only reads simple name = "value" lines, which is all manifests use.
+func tomlName(data []byte, sections ...string) string {
+	names := map[string]string{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "name" {
+			names[section] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	for _, section := range sections {
+		if name := names[section]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
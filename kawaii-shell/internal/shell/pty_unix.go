@@ -0,0 +1,71 @@
+//go:build !windows
+
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/creack/pty"
+)
+
+// unixPTY runs the shell in a Unix pseudo terminal
+type unixPTY struct {
+	*os.File
+	cmd *exec.Cmd
+}
+
+// startPTY starts the given shell in a new pseudo terminal, in dir unless
+// it's empty
+func startPTY(name string, args, env []string, dir string, cols, rows int) (terminal, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = env
+	cmd.Dir = dir
+
+	f, err := pty.StartWithSize(cmd, &pty.Winsize{Rows: uint16(rows), Cols: uint16(cols)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start pty: %w", err)
+	}
+	return &unixPTY{File: f, cmd: cmd}, nil
+}
+
+// Resize sets the size of the pseudo terminal
+func (p *unixPTY) Resize(cols, rows int) error {
+	if err := pty.Setsize(p.File, &pty.Winsize{Rows: uint16(rows), Cols: uint16(cols)}); err != nil {
+		return fmt.Errorf("failed to resize pty: %w", err)
+	}
+	return nil
+}
+
+// Pid returns the process id of the shell
+func (p *unixPTY) Pid() int {
+	return p.cmd.Process.Pid
+}
+
+// Wait waits for the shell to exit, returning its exit code. Being killed by
+// a signal gives 128 plus the signal, like shells report it.
+func (p *unixPTY) Wait() (int, error) {
+	err := p.cmd.Wait()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return -1, err
+	}
+	if status, ok := p.cmd.ProcessState.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), nil
+	}
+	return p.cmd.ProcessState.ExitCode(), nil
+}
+
+// Close closes the pseudo terminal and kills the shell along with the
+// processes it started, which share its process group
+func (p *unixPTY) Close() error {
+	err := p.File.Close()
+	// the shell leads its own session, see pty.Start
+	if syscall.Kill(-p.cmd.Process.Pid, syscall.SIGKILL) != nil {
+		p.cmd.Process.Kill()
+	}
+	return err
+}
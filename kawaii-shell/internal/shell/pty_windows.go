@@ -0,0 +1,168 @@
+//go:build windows
+
+package shell
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// conPTY runs the shell in a Windows pseudo console (ConPTY), available
+// since Windows 10 1809
+type conPTY struct {
+	console windows.Handle
+	process windows.Handle
+	pid     int
+	input   *os.File
+	output  *os.File
+}
+
+// startPTY starts the given shell in a new pseudo console, in dir unless it's
+// empty
+func startPTY(name string, args, env []string, dir string, cols, rows int) (terminal, error) {
+	// the console reads what we write to input, and writes to output
+	ptyIn, input, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipe: %w", err)
+	}
+	output, ptyOut, err := os.Pipe()
+	if err != nil {
+		ptyIn.Close()
+		input.Close()
+		return nil, fmt.Errorf("failed to create pipe: %w", err)
+	}
+
+	var console windows.Handle
+	err = windows.CreatePseudoConsole(coord(cols, rows), windows.Handle(ptyIn.Fd()), windows.Handle(ptyOut.Fd()), 0, &console)
+	// the console keeps its own copies of its ends of the pipes
+	ptyIn.Close()
+	ptyOut.Close()
+	if err != nil {
+		input.Close()
+		output.Close()
+		return nil, fmt.Errorf("failed to create pseudo console: %w", err)
+	}
+
+	p := &conPTY{console: console, input: input, output: output}
+	if err := p.start(name, args, env, dir); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// start creates the shell process attached to the pseudo console
+func (p *conPTY) start(name string, args, env []string, dir string) error {
+	attrs, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		return fmt.Errorf("failed to create attribute list: %w", err)
+	}
+	defer attrs.Delete()
+
+	// the attribute value is the console handle itself, not a pointer to it
+	if err := attrs.Update(
+		windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE,
+		*(*unsafe.Pointer)(unsafe.Pointer(&p.console)),
+		unsafe.Sizeof(p.console),
+	); err != nil {
+		return fmt.Errorf("failed to attach pseudo console: %w", err)
+	}
+
+	si := windows.StartupInfoEx{ProcThreadAttributeList: attrs.List()}
+	si.Cb = uint32(unsafe.Sizeof(si))
+	// don't let the shell inherit our own console handles
+	si.Flags = windows.STARTF_USESTDHANDLES
+
+	cmdline, err := windows.UTF16PtrFromString(windows.ComposeCommandLine(append([]string{name}, args...)))
+	if err != nil {
+		return fmt.Errorf("invalid shell: %w", err)
+	}
+	var currentDir *uint16
+	if dir != "" {
+		if currentDir, err = windows.UTF16PtrFromString(dir); err != nil {
+			return fmt.Errorf("invalid directory: %w", err)
+		}
+	}
+
+	var pi windows.ProcessInformation
+	if err := windows.CreateProcess(
+		nil,
+		cmdline,
+		nil,
+		nil,
+		false,
+		windows.EXTENDED_STARTUPINFO_PRESENT|windows.CREATE_UNICODE_ENVIRONMENT,
+		envBlock(env),
+		currentDir,
+		&si.StartupInfo,
+		&pi,
+	); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	windows.CloseHandle(pi.Thread)
+
+	p.process = pi.Process
+	p.pid = int(pi.ProcessId)
+	return nil
+}
+
+// envBlock creates the environment block expected by CreateProcess
+func envBlock(env []string) *uint16 {
+	block := utf16.Encode([]rune(strings.Join(env, "\x00") + "\x00\x00"))
+	return &block[0]
+}
+
+func coord(cols, rows int) windows.Coord {
+	return windows.Coord{X: int16(cols), Y: int16(rows)}
+}
+
+// Read reads the output of the pseudo console
+func (p *conPTY) Read(b []byte) (int, error) {
+	return p.output.Read(b)
+}
+
+// Write writes input to the pseudo console
+func (p *conPTY) Write(b []byte) (int, error) {
+	return p.input.Write(b)
+}
+
+// Resize sets the size of the pseudo console
+func (p *conPTY) Resize(cols, rows int) error {
+	if err := windows.ResizePseudoConsole(p.console, coord(cols, rows)); err != nil {
+		return fmt.Errorf("failed to resize pseudo console: %w", err)
+	}
+	return nil
+}
+
+// Pid returns the process id of the shell
+func (p *conPTY) Pid() int {
+	return p.pid
+}
+
+// Wait waits for the shell to exit, returning its exit code
+func (p *conPTY) Wait() (int, error) {
+	if _, err := windows.WaitForSingleObject(p.process, windows.INFINITE); err != nil {
+		return -1, fmt.Errorf("failed to wait for the shell: %w", err)
+	}
+	var code uint32
+	if err := windows.GetExitCodeProcess(p.process, &code); err != nil {
+		return -1, fmt.Errorf("failed to get the exit code: %w", err)
+	}
+	return int(code), nil
+}
+
+// Close closes the pseudo console and kills the shell
+func (p *conPTY) Close() error {
+	if p.process != 0 {
+		windows.TerminateProcess(p.process, 1)
+		windows.CloseHandle(p.process)
+	}
+	windows.ClosePseudoConsole(p.console)
+	p.input.Close()
+	return p.output.Close()
+}
@@ -0,0 +1,188 @@
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// Recording writes what's shown on the terminal to an asciicast v2 file, the
+// format asciinema plays and uploads
+type Recording struct {
+	Path    string
+	Started time.Time
+
+	mu   sync.Mutex
+	file *os.File
+
+	// partial is the start of a character cut in two by the last write,
+	// asciicast events hold whole characters
+	partial []byte
+}
+
+// asciicastHeader is the first line of an asciicast v2 file
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// StartRecording creates the asciicast file for a terminal of the given size
+func StartRecording(path string, cols, rows int) (*Recording, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	r := &Recording{Path: path, Started: time.Now(), file: f}
+	header, err := json.Marshal(asciicastHeader{
+		Version:   2,
+		Width:     cols,
+		Height:    rows,
+		Timestamp: r.Started.Unix(),
+		Title:     "🌸 Kawaii Shell",
+		Env:       map[string]string{"SHELL": GetDefaultShell(), "TERM": os.Getenv("TERM")},
+	})
+	if err == nil {
+		_, err = f.Write(append(header, '\n'))
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	return r, nil
+}
+
+// Write records output shown on the terminal
+func (r *Recording) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := append(r.partial, p...)
+	end := len(data)
+	// hold back a character that isn't complete yet
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	r.partial = append([]byte(nil), data[end:]...)
+	if end == 0 {
+		return len(p), nil
+	}
+	if err := r.event("o", strings.ToValidUTF8(string(data[:end]), "�")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize records that the terminal changed size
+func (r *Recording) Resize(cols, rows int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// Duration returns how long the recording has been going
+func (r *Recording) Duration() time.Duration {
+	return time.Since(r.Started)
+}
+
+// Close finishes the recording
+func (r *Recording) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to save recording: %w", err)
+	}
+	return nil
+}
+
+// event writes an event line, [time, code, data]
+func (r *Recording) event(code, data string) error {
+	if r.file == nil {
+		return errors.New("the recording is over")
+	}
+	line, err := json.Marshal([]any{time.Since(r.Started).Seconds(), code, data})
+	if err != nil {
+		return fmt.Errorf("failed to record: %w", err)
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record: %w", err)
+	}
+	return nil
+}
+
+// TerminalOutput is the terminal the UI is drawn on, which also goes to the
+// recording while there's one. It's still the terminal's file, so programs
+// can tell its size. Each write goes out whole, so the sequences sent from
+// commands running in the background never land in the middle of a frame.
+type TerminalOutput struct {
+	*os.File
+	recording atomic.Pointer[Recording]
+
+	mu sync.Mutex
+}
+
+// NewTerminalOutput wraps the terminal's file
+func NewTerminalOutput(f *os.File) *TerminalOutput {
+	return &TerminalOutput{File: f}
+}
+
+// Record sends the output to the recording too, or stops when it's nil
+func (t *TerminalOutput) Record(r *Recording) {
+	t.recording.Store(r)
+}
+
+// Write writes to the terminal and the recording
+func (t *TerminalOutput) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if r := t.recording.Load(); r != nil {
+		// a broken recording shouldn't break the terminal
+		_, _ = r.Write(p)
+	}
+	return t.File.Write(p)
+}
+
+// WriteString writes to the terminal and the recording
+func (t *TerminalOutput) WriteString(s string) (int, error) {
+	return t.Write([]byte(s))
+}
+
+// ReadFrom copies to the terminal and the recording, rather than letting the
+// file's own ReadFrom skip the recording
+func (t *TerminalOutput) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(struct{ io.Writer }{t}, r)
+}
+
+// ExportGIF turns the asciicast file into a GIF with agg, asciinema's GIF
+// generator, when it's installed
+func ExportGIF(cast, gif string) error {
+	agg, err := exec.LookPath("agg")
+	if err != nil {
+		return errors.New("making GIFs needs agg, see https://github.com/asciinema/agg")
+	}
+	if out, err := exec.Command(agg, cast, gif).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to make the GIF: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
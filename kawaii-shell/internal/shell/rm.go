@@ -0,0 +1,116 @@
+package shell
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RmCommand is an rm command that can be handled by moving its targets to
+// the trash
+type RmCommand struct {
+	// Paths are the absolute paths of the targets
+	Paths     []string
+	Recursive bool
+	Force     bool
+}
+
+// reallyFlag asks rm to really delete files instead of trashing them
+var reallyFlag = regexp.MustCompile(`\s--really(\s|$)`)
+
+// StripReally removes the --really flag from an rm command, reporting
+// whether it was there
+func StripReally(command string) (string, bool) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != "rm" {
+		return command, false
+	}
+	loc := reallyFlag.FindStringIndex(command)
+	if loc == nil {
+		return command, false
+	}
+	return command[:loc[0]] + " " + command[loc[1]:], true
+}
+
+// ParseRm parses an rm command run in dir. It returns false for other
+// commands and for rm commands using options or shell features it doesn't
+// know, which are left to the real rm, as well as for relative paths when
+// dir is empty because it's unknown.
+func ParseRm(command, dir string) (RmCommand, bool) {
+	words, ok := SplitWords(command)
+	if !ok || len(words) < 2 || words[0].Value != "rm" {
+		return RmCommand{}, false
+	}
+
+	var rm RmCommand
+	options := true
+	for _, word := range words[1:] {
+		value := word.Value
+		switch {
+		case options && value == "--":
+			options = false
+		case options && value == "--recursive":
+			rm.Recursive = true
+		case options && value == "--force":
+			rm.Force = true
+		case options && (value == "--verbose" || value == "--interactive" || value == "--dir"):
+		case options && len(value) > 2 && value[:2] == "--":
+			return RmCommand{}, false
+		case options && len(value) > 1 && value[0] == '-':
+			for _, flag := range value[1:] {
+				switch flag {
+				case 'r', 'R':
+					rm.Recursive = true
+				case 'f':
+					rm.Force = true
+				case 'i', 'I', 'v', 'd':
+					// asking or being verbose isn't needed, trashing is undoable
+				default:
+					return RmCommand{}, false
+				}
+			}
+		case dir == "" && !filepath.IsAbs(value):
+			return RmCommand{}, false
+		default:
+			rm.Paths = append(rm.Paths, expand(word, dir)...)
+		}
+	}
+	return rm, len(rm.Paths) > 0
+}
+
+// expand makes the word an absolute path, expanding its wildcards like the
+// shell does
+func expand(word Word, dir string) []string {
+	path := word.Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if word.Glob {
+		matches, err := filepath.Glob(path)
+		if err == nil {
+			matches = slices.DeleteFunc(matches, func(match string) bool { return hidden(path, match) })
+		}
+		if len(matches) > 0 {
+			return matches
+		}
+	}
+	return []string{path}
+}
+
+// hidden reports whether the match of the pattern is a dotfile the shell
+// leaves out, named with a leading dot where the pattern has none
+func hidden(pattern, match string) bool {
+	patterns := strings.Split(pattern, string(filepath.Separator))
+	names := strings.Split(match, string(filepath.Separator))
+	if len(patterns) != len(names) {
+		return false
+	}
+	for i, name := range names {
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(patterns[i], ".") {
+			return true
+		}
+	}
+	return false
+}
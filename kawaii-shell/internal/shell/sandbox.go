@@ -0,0 +1,114 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// DefaultSandboxImage is the container image docker and podman sandboxes use
+const DefaultSandboxImage = "alpine"
+
+// sandboxTools are the sandboxes that can be used, the first one found in
+// PATH wins
+var sandboxTools = []string{"bwrap", "firejail", "podman", "docker"}
+
+// sandboxBypass are the commands that change the shell itself, they would
+// have no effect inside the sandbox so they run outside of it. source and .
+// run whatever's in the file, and export, set and alias can have the shell
+// run anything later, through PROMPT_COMMAND, PS1, BASH_ENV or the alias,
+// so they're never among them.
+var sandboxBypass = []string{
+	"bg", "cd", "exit", "fg", "jobs", "popd", "pushd", "unalias", "unset",
+}
+
+// sandboxSeparators are what chains, pipes, backgrounds or substitutes
+// commands, or redirects to files: a line with any of them isn't a single
+// command changing the shell
+var sandboxSeparators = []string{";", "&", "|", "\n", "`", "$(", "<", ">"}
+
+// Sandbox runs commands shut away from the rest of the computer: only the
+// working directory can be changed, the home directory is hidden where the
+// tool allows it and there's no network
+type Sandbox struct {
+	// Tool is bwrap, firejail, podman or docker
+	Tool string
+
+	// Image is the container image podman and docker use
+	Image string
+}
+
+// FindSandbox returns the sandbox using the given tool, or the first one
+// installed when it's empty
+func FindSandbox(tool, image string) (*Sandbox, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("the sandbox only works on Linux and macOS for now")
+	}
+	if image == "" {
+		image = DefaultSandboxImage
+	}
+	if tool != "" {
+		if !slices.Contains(sandboxTools, tool) {
+			return nil, fmt.Errorf("unknown sandbox %s, try one of %s", tool, strings.Join(sandboxTools, ", "))
+		}
+		if _, err := exec.LookPath(tool); err != nil {
+			return nil, fmt.Errorf("%s isn't installed", tool)
+		}
+		return &Sandbox{Tool: tool, Image: image}, nil
+	}
+	for _, tool := range sandboxTools {
+		if _, err := exec.LookPath(tool); err == nil {
+			return &Sandbox{Tool: tool, Image: image}, nil
+		}
+	}
+	return nil, fmt.Errorf("no sandbox found, install bubblewrap, firejail, podman or docker")
+}
+
+// Wrap returns the command running the given one in the sandbox with dir
+// mounted, for the default shell. A single command changing the shell
+// itself is returned as it is, anything else on the line with it runs in the
+// sandbox along with it.
+func (s *Sandbox) Wrap(command, dir string) string {
+	if bypassesSandbox(command) {
+		return command
+	}
+
+	var args []string
+	switch s.Tool {
+	case "bwrap":
+		args = []string{"bwrap", "--ro-bind", "/", "/", "--dev", "/dev", "--proc", "/proc", "--tmpfs", "/tmp"}
+		if home, err := os.UserHomeDir(); err == nil {
+			args = append(args, "--tmpfs", home)
+		}
+		args = append(args, "--bind", dir, dir, "--unshare-all", "--die-with-parent", "--chdir", dir)
+	case "firejail":
+		args = []string{"firejail", "--quiet", "--noprofile", "--net=none", "--private-tmp", "--read-only=/", "--read-write=" + dir}
+	default:
+		args = []string{s.Tool, "run", "--rm", "-it", "--network", "none", "-v", dir + ":" + dir, "-w", dir, s.Image}
+	}
+	args = append(args, "sh", "-c", command)
+
+	quote := quoteFor(GetDefaultShell())
+	for i, arg := range args[1:] {
+		args[i+1] = quote(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// bypassesSandbox reports whether the command is a single simple command
+// changing the shell itself
+func bypassesSandbox(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || !slices.Contains(sandboxBypass, fields[0]) {
+		return false
+	}
+	for _, sep := range sandboxSeparators {
+		if strings.Contains(command, sep) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSandboxWrap(t *testing.T) {
+	s := &Sandbox{Tool: "bwrap"}
+	tests := []struct {
+		command string
+		bypass  bool
+	}{
+		{"cd /tmp", true},
+		{"unset FOO", true},
+		{"rm -rf ~/x", false},
+		{"cd /tmp; rm -rf ~/x", false},
+		{"cd $(rm -rf ~/x)", false},
+		{"source ./evil.sh", false},
+		{". ./evil.sh", false},
+		// the shell would run these later, outside of the sandbox
+		{"export PROMPT_COMMAND='rm -rf ~/x'", false},
+		{"export BASH_ENV=./evil.sh", false},
+		{"export PS1='\\$(rm -rf ~/x)'", false},
+		{"set -o vi", false},
+		{"alias ls='rm -rf ~/x'", false},
+	}
+	for _, test := range tests {
+		got := s.Wrap(test.command, "/tmp")
+		if bypass := got == test.command; bypass != test.bypass {
+			t.Errorf("Wrap(%q) = %q, want it bypassing the sandbox: %v", test.command, got, test.bypass)
+		}
+		if !test.bypass && !strings.HasPrefix(got, "bwrap ") {
+			t.Errorf("Wrap(%q) = %q, want it run with bwrap", test.command, got)
+		}
+	}
+}
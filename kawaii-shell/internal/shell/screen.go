@@ -0,0 +1,280 @@
+package shell
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// tabWidth is the number of columns a tab advances to
+	tabWidth = 8
+
+	// maxPending is the most bytes held back waiting for the rest of an
+	// escape sequence or character split across reads
+	maxPending = 4096
+)
+
+// cell is a single column of the line being written
+type cell struct {
+	content string
+	width   int
+	style   string
+}
+
+// Screen turns the raw bytes written by programs into styled lines.
+//
+// Only the line being written can be changed, which is enough for carriage
+// return overwrites used by progress bars and spinners. Colors and text styles
+// are kept, while other escape sequences such as window titles and mode
+// changes are dropped. Full-screen programs are detected when they enter the
+// alternate screen, see [Screen.TakeAltScreen].
+type Screen struct {
+	line      []cell
+	col       int
+	sgr       sgrStyle
+	style     string
+	pending   []byte
+	alt       []byte
+	exitCodes []int
+	cwd       string
+	cwdHost   string
+}
+
+// NewScreen creates an empty screen
+func NewScreen() *Screen {
+	return &Screen{}
+}
+
+// Write processes the given bytes, returning the lines that were completed
+func (s *Screen) Write(p []byte) []string {
+	data := append(s.pending, p...)
+	s.pending = nil
+	if n := incompleteTail(data); n > 0 && n <= maxPending {
+		s.pending = bytes.Clone(data[len(data)-n:])
+		data = data[:len(data)-n]
+	}
+
+	var lines []string
+	var state byte
+	parser := ansi.GetParser()
+	defer ansi.PutParser(parser)
+
+	for len(data) > 0 {
+		parser.Reset()
+		seq, width, n, newState := ansi.DecodeSequence(data, state, parser)
+		data, state = data[n:], newState
+
+		switch {
+		case len(seq) == 1 && seq[0] == '\n':
+			lines = append(lines, s.Current())
+			s.line, s.col = nil, 0
+		case len(seq) == 1 && seq[0] == '\r':
+			s.col = 0
+		case len(seq) == 1 && seq[0] == '\b':
+			s.col = max(s.col-1, 0)
+		case len(seq) == 1 && seq[0] == '\t':
+			for range tabWidth - s.col%tabWidth {
+				s.put(" ", 1)
+			}
+		case ansi.HasOscPrefix(seq) && parser.Command() == 133:
+			// shell integration, see shellIntegration
+			if code, ok := parseExitCode(parser.Data()); ok {
+				s.exitCodes = append(s.exitCodes, code)
+			}
+		case ansi.HasOscPrefix(seq) && parser.Command() == 7:
+			// the shell reporting its working directory
+			if dir, host, ok := parseCwd(parser.Data()); ok {
+				s.cwd, s.cwdHost = dir, host
+			}
+		case ansi.HasCsiPrefix(seq) && isAltScreenEnter(parser):
+			// hand the rest over to the full-screen program
+			s.alt = append(append(append(s.alt, seq...), data...), s.pending...)
+			s.pending = nil
+			return lines
+		case ansi.HasCsiPrefix(seq):
+			s.handleCsi(parser)
+		case width > 0:
+			s.put(string(seq), width)
+		}
+	}
+	return lines
+}
+
+// TakeAltScreen returns the output starting with a program entering the
+// alternate screen, if any. That output should be written to the terminal
+// as is, see [Passthrough].
+func (s *Screen) TakeAltScreen() ([]byte, bool) {
+	alt := s.alt
+	s.alt = nil
+	return alt, alt != nil
+}
+
+// TakeExitCodes returns the exit codes of the commands that finished, as
+// reported by the shell integration
+func (s *Screen) TakeExitCodes() []int {
+	codes := s.exitCodes
+	s.exitCodes = nil
+	return codes
+}
+
+// TakeCwd returns the working directory the shell reported since the last
+// call, if any, and the host it's on
+func (s *Screen) TakeCwd() (string, string, bool) {
+	cwd, host := s.cwd, s.cwdHost
+	s.cwd, s.cwdHost = "", ""
+	return cwd, host, cwd != ""
+}
+
+// parseCwd parses the file URL of an OSC 7 sequence into the path and the
+// host name
+func parseCwd(data []byte) (string, string, bool) {
+	_, location, ok := strings.Cut(string(data), ";")
+	if !ok {
+		return "", "", false
+	}
+	location, ok = strings.CutPrefix(location, "file://")
+	if !ok {
+		return "", "", false
+	}
+	i := strings.IndexByte(location, '/')
+	if i < 0 {
+		return "", "", false
+	}
+	host, path := location[:i], location[i:]
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	return path, host, true
+}
+
+// parseExitCode parses the data of an OSC 133;D sequence
+func parseExitCode(data []byte) (int, bool) {
+	parts := strings.Split(string(data), ";")
+	if len(parts) < 3 || parts[1] != "D" {
+		return 0, false
+	}
+	code, err := strconv.Atoi(parts[2])
+	return code, err == nil
+}
+
+// isAltScreenEnter reports whether the parsed sequence enters the alternate
+// screen
+func isAltScreenEnter(p *ansi.Parser) bool {
+	cmd := ansi.Cmd(p.Command())
+	if cmd.Final() != 'h' || cmd.Prefix() != '?' {
+		return false
+	}
+	for _, param := range p.Params() {
+		switch param.Param(0) {
+		case 47, 1047, 1049:
+			return true
+		}
+	}
+	return false
+}
+
+// handleCsi applies the styles and the cursor movements within the line
+func (s *Screen) handleCsi(p *ansi.Parser) {
+	n, _ := p.Param(0, 0)
+	switch p.Command() {
+	case 'm': // SGR
+		s.sgr.apply(p.Params())
+		s.style = s.sgr.String()
+	case 'K': // EL
+		switch n {
+		case 0:
+			s.line = s.line[:min(s.col, len(s.line))]
+		case 1:
+			for i := 0; i <= s.col && i < len(s.line); i++ {
+				s.line[i] = cell{content: " ", width: 1}
+			}
+		case 2:
+			s.line = nil
+		}
+	case 'G': // CHA
+		s.col = max(n, 1) - 1
+	case 'C': // CUF
+		s.col += max(n, 1)
+	case 'D': // CUB
+		s.col = max(s.col-max(n, 1), 0)
+	}
+}
+
+// put writes a character at the cursor, overwriting what was there
+func (s *Screen) put(content string, width int) {
+	for len(s.line) < s.col+width {
+		s.line = append(s.line, cell{content: " ", width: 1})
+	}
+	s.line[s.col] = cell{content: content, width: width, style: s.style}
+	for i := 1; i < width; i++ {
+		s.line[s.col+i] = cell{style: s.style}
+	}
+	s.col += width
+}
+
+// Current returns the line being written
+func (s *Screen) Current() string {
+	var sb strings.Builder
+	var style string
+	for i, c := range s.line {
+		if c.style != style {
+			if style != "" {
+				sb.WriteString(ansi.ResetStyle)
+			}
+			sb.WriteString(c.style)
+			style = c.style
+		}
+		switch {
+		case c.width > 0:
+			sb.WriteString(c.content)
+		case i == 0 || s.line[i-1].width < 2:
+			// the wide character this column belonged to was overwritten
+			sb.WriteByte(' ')
+		}
+	}
+	if style != "" {
+		sb.WriteString(ansi.ResetStyle)
+	}
+	return sb.String()
+}
+
+// incompleteTail returns the length of the escape sequence or character cut
+// at the end of the given bytes
+func incompleteTail(b []byte) int {
+	if i := bytes.LastIndexByte(b, ansi.ESC); i >= 0 && !sequenceComplete(b[i:]) {
+		return len(b) - i
+	}
+	for i := 1; i <= utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return 0
+}
+
+// sequenceComplete reports whether the given escape sequence is terminated
+func sequenceComplete(seq []byte) bool {
+	if len(seq) < 2 {
+		return false
+	}
+	switch seq[1] {
+	case '[':
+		for _, c := range seq[2:] {
+			if c >= 0x40 && c <= 0x7e {
+				return true
+			}
+		}
+		return false
+	case ']', 'P', '_', '^', 'X':
+		return bytes.IndexByte(seq, ansi.BEL) > 0 || bytes.Contains(seq[2:], []byte{ansi.ESC, '\\'})
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ScriptExt is the extension of kawaii script files
+const ScriptExt = ".kawaii"
+
+// ScriptStep is a command of a kawaii script
+type ScriptStep struct {
+	Command string
+
+	// Annotation is the comment right above the command, shown while it runs
+	Annotation string
+
+	// Line is where the command starts in the file
+	Line int
+}
+
+// LoadScript reads a kawaii script. Scripts have a command per line, which
+// can continue on the next ones like in the shell, and comments starting
+// with # describe the command below them:
+//
+//	# 🧹 Tidying up the modules
+//	go mod tidy
+//	# 🧪 Making sure everything works
+//	go test ./...
+func LoadScript(path string) ([]ScriptStep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open script: %w", err)
+	}
+	defer f.Close()
+
+	var steps []ScriptStep
+	var annotation string
+	var command strings.Builder
+	start := 0
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if command.Len() == 0 {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case trimmed == "":
+				annotation = ""
+				continue
+			case strings.HasPrefix(trimmed, "#!"):
+				continue
+			case strings.HasPrefix(trimmed, "#"):
+				annotation = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+				continue
+			}
+			start = n
+		} else {
+			command.WriteByte('\n')
+		}
+
+		command.WriteString(line)
+		if !IsComplete(command.String()) {
+			continue
+		}
+		steps = append(steps, ScriptStep{
+			Command:    strings.TrimSpace(command.String()),
+			Annotation: annotation,
+			Line:       start,
+		})
+		command.Reset()
+		annotation = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	if command.Len() > 0 {
+		return nil, fmt.Errorf("%s:%d: the command never ends, is a quote missing?", path, start)
+	}
+	return steps, nil
+}
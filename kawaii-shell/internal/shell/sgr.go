@@ -0,0 +1,134 @@
+package shell
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// the attributes of a text style, each one a parameter of its own
+const (
+	sgrBold = iota
+	sgrFaint
+	sgrItalic
+	sgrUnderline
+	sgrBlink
+	sgrReverse
+	sgrConceal
+	sgrStrike
+	sgrOverline
+	sgrForeground
+	sgrBackground
+	sgrUnderlineColor
+	sgrAttributes
+)
+
+// sgrStyle is the text style SGR sequences set, holding the parameter that
+// set each attribute. A program changing colors over and over only ever
+// has the last one kept.
+type sgrStyle [sgrAttributes]string
+
+// apply changes the style with the parameters of an SGR sequence, none of
+// them meaning a reset
+func (st *sgrStyle) apply(params ansi.Params) {
+	if len(params) == 0 {
+		*st = sgrStyle{}
+		return
+	}
+	for i := 0; i < len(params); i++ {
+		code := params[i].Param(0)
+		// the parameter along with its subparameters, like 38:2::255:0:0
+		param := strconv.Itoa(code)
+		sub := false
+		for params[i].HasMore() && i+1 < len(params) {
+			i++
+			sub = true
+			param += ":"
+			if n := params[i].Param(-1); n >= 0 {
+				param += strconv.Itoa(n)
+			}
+		}
+		// colors in the older form take the parameters after them
+		if !sub && (code == 38 || code == 48 || code == 58) && i+1 < len(params) {
+			n := 0
+			switch params[i+1].Param(0) {
+			case 5:
+				n = 2
+			case 2:
+				n = 4
+			}
+			for _, p := range params[i+1 : min(i+1+n, len(params))] {
+				param += ";" + strconv.Itoa(p.Param(0))
+			}
+			i += n
+		}
+
+		switch {
+		case code == 0:
+			*st = sgrStyle{}
+		case code == 1:
+			st[sgrBold] = param
+		case code == 2:
+			st[sgrFaint] = param
+		case code == 3:
+			st[sgrItalic] = param
+		case code == 4 && param == "4:0":
+			st[sgrUnderline] = ""
+		case code == 4 || code == 21:
+			st[sgrUnderline] = param
+		case code == 5 || code == 6:
+			st[sgrBlink] = param
+		case code == 7:
+			st[sgrReverse] = param
+		case code == 8:
+			st[sgrConceal] = param
+		case code == 9:
+			st[sgrStrike] = param
+		case code == 22:
+			st[sgrBold], st[sgrFaint] = "", ""
+		case code == 23:
+			st[sgrItalic] = ""
+		case code == 24:
+			st[sgrUnderline] = ""
+		case code == 25:
+			st[sgrBlink] = ""
+		case code == 27:
+			st[sgrReverse] = ""
+		case code == 28:
+			st[sgrConceal] = ""
+		case code == 29:
+			st[sgrStrike] = ""
+		case code >= 30 && code <= 38, code >= 90 && code <= 97:
+			st[sgrForeground] = param
+		case code == 39:
+			st[sgrForeground] = ""
+		case code >= 40 && code <= 48, code >= 100 && code <= 107:
+			st[sgrBackground] = param
+		case code == 49:
+			st[sgrBackground] = ""
+		case code == 53:
+			st[sgrOverline] = param
+		case code == 55:
+			st[sgrOverline] = ""
+		case code == 58:
+			st[sgrUnderlineColor] = param
+		case code == 59:
+			st[sgrUnderlineColor] = ""
+		}
+	}
+}
+
+// String returns the SGR sequence setting the style, empty for none
+func (st *sgrStyle) String() string {
+	var params []string
+	for _, param := range st {
+		if param != "" {
+			params = append(params, param)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
@@ -0,0 +1,96 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Sound is something worth hearing about
+type Sound int
+
+const (
+	SoundGreeting Sound = iota
+	SoundLevelUp
+	SoundWarning
+)
+
+// bellPatterns are the pauses before each ring of the bell for the sounds
+var bellPatterns = map[Sound][]time.Duration{
+	SoundGreeting: {0},
+	SoundLevelUp:  {0, 150 * time.Millisecond, 150 * time.Millisecond},
+	SoundWarning:  {0, 400 * time.Millisecond},
+}
+
+// systemSounds are the sound files played for the sounds on each system
+var systemSounds = map[string]map[Sound]string{
+	"darwin": {
+		SoundGreeting: "/System/Library/Sounds/Glass.aiff",
+		SoundLevelUp:  "/System/Library/Sounds/Hero.aiff",
+		SoundWarning:  "/System/Library/Sounds/Basso.aiff",
+	},
+	"linux": {
+		SoundGreeting: "/usr/share/sounds/freedesktop/stereo/message-new-instant.oga",
+		SoundLevelUp:  "/usr/share/sounds/freedesktop/stereo/complete.oga",
+		SoundWarning:  "/usr/share/sounds/freedesktop/stereo/dialog-warning.oga",
+	},
+	"windows": {
+		SoundGreeting: `C:\Windows\Media\chimes.wav`,
+		SoundLevelUp:  `C:\Windows\Media\tada.wav`,
+		SoundWarning:  `C:\Windows\Media\chord.wav`,
+	},
+}
+
+// PlaySound plays the sound the way asked for: bell rings the terminal bell
+// in a pattern for each sound, and system plays the system's sounds, falling
+// back to the bell when there's nothing to play them with
+func PlaySound(w io.Writer, how string, sound Sound) error {
+	switch how {
+	case "bell":
+		return ringBells(w, sound)
+	case "system":
+		if err := playSystemSound(sound); err == nil {
+			return nil
+		}
+		return ringBells(w, sound)
+	default:
+		return fmt.Errorf("there are no %s sounds, try off, bell or system", how)
+	}
+}
+
+// ringBells rings the bell in the pattern for the sound
+func ringBells(w io.Writer, sound Sound) error {
+	for _, pause := range bellPatterns[sound] {
+		time.Sleep(pause)
+		if err := Bell(w); err != nil {
+			return fmt.Errorf("failed to ring the bell: %w", err)
+		}
+	}
+	return nil
+}
+
+// playSystemSound plays the system's sound for the sound, with afplay on
+// macOS, paplay or pw-play on Linux and PowerShell on Windows
+func playSystemSound(sound Sound) error {
+	file, ok := systemSounds[runtime.GOOS][sound]
+	if !ok {
+		return fmt.Errorf("no system sounds on %s", runtime.GOOS)
+	}
+	var players [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		players = [][]string{{"afplay", file}}
+	case "windows":
+		players = [][]string{{"powershell", "-NoProfile", "-Command", "(New-Object Media.SoundPlayer '" + file + "').PlaySync()"}}
+	default:
+		players = [][]string{{"paplay", file}, {"pw-play", file}}
+	}
+	for _, player := range players {
+		if path, err := exec.LookPath(player[0]); err == nil {
+			return exec.Command(path, player[1:]...).Run()
+		}
+	}
+	return fmt.Errorf("nothing to play sounds with")
+}
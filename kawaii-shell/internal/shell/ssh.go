@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// bashLoginIntegration sets up bash on the remote host like a login shell
+// would, since --rcfile only works for interactive ones
+const bashLoginIntegration = `[ -f /etc/profile ] && . /etc/profile
+for __kawaii_profile in ~/.bash_profile ~/.bash_login ~/.profile; do
+	[ -f "$__kawaii_profile" ] && { . "$__kawaii_profile"; break; }
+done
+unset __kawaii_profile
+` + bashStatus
+
+const zshProfileIntegration = `[ -f "${KAWAII_ZDOTDIR:-$HOME}/.zprofile" ] && . "${KAWAII_ZDOTDIR:-$HOME}/.zprofile"
+`
+
+// sshBootstrap runs on the remote host with sh. It writes the shell
+// integration for the user's login shell to a temporary directory, removed
+// once the shell has read it, and starts the shell with it.
+var sshBootstrap = `rm -f "$0"
+d=$(mktemp -d) || exec "${SHELL:-sh}" -l
+case "${SHELL##*/}" in
+bash)
+	cat >"$d/bashrc" <<'KAWAII'
+` + bashLoginIntegration + `KAWAII
+	printf 'rm -rf -- %s\n' "$d" >>"$d/bashrc"
+	exec bash --rcfile "$d/bashrc" -i ;;
+zsh)
+	cat >"$d/.zshenv" <<'KAWAII'
+` + zshEnvIntegration + `KAWAII
+	cat >"$d/.zprofile" <<'KAWAII'
+` + zshProfileIntegration + `KAWAII
+	cat >"$d/.zshrc" <<'KAWAII'
+` + zshIntegration + `KAWAII
+	printf 'rm -rf -- %s\n' "$d" >>"$d/.zshrc"
+	KAWAII_ZDOTDIR=$ZDOTDIR ZDOTDIR=$d exec zsh -l ;;
+fish)
+	cat >"$d/init.fish" <<'KAWAII'
+` + fishIntegration + `
+KAWAII
+	exec fish -l --init-command "source $d/init.fish; rm -rf -- $d" ;;
+*)
+	rmdir "$d"
+	exec "${SHELL:-sh}" -l ;;
+esac
+`
+
+// SSHCommand returns the command that opens an ssh session with the
+// arguments, ending with the host. The remote shell is set up with the same
+// integration as ours, so it reports its exit codes and working directory
+// through the session.
+func (s *Shell) SSHCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("which host? Try kawaii ssh example.com")
+	}
+	if runtime.GOOS == "windows" {
+		return "", fmt.Errorf("kawaii ssh only works on Linux and macOS for now")
+	}
+	if s.integrationDir == "" {
+		return "", fmt.Errorf("shell not started")
+	}
+
+	// the remote login shell may be fish, so the bootstrap is handed over
+	// in a way any shell runs the same. It's run from a script so the
+	// shell doesn't echo all of it.
+	bootstrap := base64.StdEncoding.EncodeToString([]byte(sshBootstrap))
+	remote := "exec sh -c 'f=$(mktemp) && echo " + bootstrap + " | base64 -d >$f && exec sh $f'"
+	path := filepath.Join(s.integrationDir, "ssh")
+	script := "exec ssh -t \"$@\" " + posixQuote(remote) + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write ssh script: %w", err)
+	}
+
+	quote := quoteFor(GetDefaultShell())
+	words := []string{"sh", quote(path)}
+	for _, arg := range args {
+		words = append(words, quote(arg))
+	}
+	return strings.Join(words, " "), nil
+}
+
+// IsLocalHost reports whether the host name the shell integration reported
+// is this computer
+func IsLocalHost(host string) bool {
+	short := func(name string) string {
+		name, _, _ = strings.Cut(strings.ToLower(name), ".")
+		return name
+	}
+	if host == "" || short(host) == "localhost" {
+		return true
+	}
+	local, err := os.Hostname()
+	return err != nil || short(host) == short(local)
+}
@@ -0,0 +1,176 @@
+package shell
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxStats is the number of finished commands kept for the statistics
+const maxStats = 10000
+
+// StatsEntry is a command that finished, with how long it took
+type StatsEntry struct {
+	Command  string        `json:"command"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`
+}
+
+// Name returns the name of the command that was run
+func (e StatsEntry) Name() string {
+	fields := strings.Fields(e.Command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// Stats records the commands that finished to tell which ones are used the
+// most and which ones are slow
+type Stats struct {
+	path    string
+	entries []StatsEntry
+}
+
+// CommandCount is how many times a command was run
+type CommandCount struct {
+	Name  string
+	Count int
+}
+
+// Summary is what the statistics say about the commands
+type Summary struct {
+	Total     int
+	TotalTime time.Duration
+	Failed    int
+
+	// Top are the most used commands and Slowest the slowest runs, the
+	// first ones first
+	Top     []CommandCount
+	Slowest []StatsEntry
+
+	// ByHour is how many commands were run at each hour of the day
+	ByHour [24]int
+}
+
+// DefaultStatsPath returns where the statistics are stored, honoring
+// XDG_DATA_HOME
+func DefaultStatsPath() string {
+	return dataFile("stats.jsonl")
+}
+
+// NewStats opens the statistics stored in the given file, one JSON entry per
+// line. An empty path keeps them in memory only.
+func NewStats(path string) (*Stats, error) {
+	s := &Stats{path: path}
+	if path == "" {
+		return s, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to open stats: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry StatsEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// skip lines cut short by a crash
+			continue
+		}
+		s.entries = append(s.entries, entry)
+	}
+	if len(s.entries) > maxStats {
+		s.entries = s.entries[len(s.entries)-maxStats:]
+	}
+	if err := scanner.Err(); err != nil {
+		return s, fmt.Errorf("failed to read stats: %w", err)
+	}
+	return s, nil
+}
+
+// Record adds a finished command to the statistics
+func (s *Stats) Record(entry StatsEntry) error {
+	entry.Command = strings.TrimSpace(entry.Command)
+	if entry.Command == "" {
+		return nil
+	}
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > maxStats {
+		s.entries = s.entries[len(s.entries)-maxStats:]
+	}
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to save stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save stats: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to save stats: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save stats: %w", err)
+	}
+	return f.Close()
+}
+
+// Runs returns how many times the command finished and its last run
+func (s *Stats) Runs(command string) (int, StatsEntry) {
+	command = strings.TrimSpace(command)
+	count, last := 0, StatsEntry{}
+	for _, entry := range s.entries {
+		if entry.Command == command {
+			count++
+			last = entry
+		}
+	}
+	return count, last
+}
+
+// Summarize sums the statistics up, with up to n top and slowest commands
+func (s *Stats) Summarize(n int) Summary {
+	summary := Summary{Total: len(s.entries)}
+	counts := map[string]int{}
+	for _, entry := range s.entries {
+		summary.TotalTime += entry.Duration
+		if entry.ExitCode != 0 {
+			summary.Failed++
+		}
+		summary.ByHour[entry.Started.Local().Hour()]++
+		counts[entry.Name()]++
+	}
+
+	for name, count := range counts {
+		summary.Top = append(summary.Top, CommandCount{name, count})
+	}
+	slices.SortFunc(summary.Top, func(a, b CommandCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	summary.Top = summary.Top[:min(n, len(summary.Top))]
+
+	summary.Slowest = slices.Clone(s.entries)
+	slices.SortStableFunc(summary.Slowest, func(a, b StatsEntry) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	summary.Slowest = summary.Slowest[:min(n, len(summary.Slowest))]
+	return summary
+}
@@ -0,0 +1,85 @@
+package shell
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CPUTimes is how long the CPUs were busy, and in total, since boot
+type CPUTimes struct {
+	Busy  uint64
+	Total uint64
+}
+
+// ReadCPUTimes reads how long the CPUs were busy from /proc/stat, which
+// only Linux has
+func ReadCPUTimes() (CPUTimes, bool) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return CPUTimes{}, false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return CPUTimes{}, false
+	}
+	var times CPUTimes
+	for i, field := range fields[1:] {
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return CPUTimes{}, false
+		}
+		times.Total += n
+		// idle and iowait are the 4th and 5th
+		if i != 3 && i != 4 {
+			times.Busy += n
+		}
+	}
+	return times, true
+}
+
+// Usage returns how busy the CPUs were since the earlier times, from 0 to 1
+func (t CPUTimes) Usage(earlier CPUTimes) float64 {
+	if t.Total <= earlier.Total || t.Busy < earlier.Busy {
+		return 0
+	}
+	return float64(t.Busy-earlier.Busy) / float64(t.Total-earlier.Total)
+}
+
+// ReadMemory reads how much memory is used and how much there is, in bytes,
+// from /proc/meminfo, which only Linux has
+func ReadMemory() (used, total uint64, ok bool) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+
+	var available uint64
+	found := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() && found < 2 {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = kb * 1024
+			found++
+		case "MemAvailable:":
+			available = kb * 1024
+			found++
+		}
+	}
+	if found < 2 || total == 0 || available > total {
+		return 0, 0, false
+	}
+	return total - available, total, true
+}
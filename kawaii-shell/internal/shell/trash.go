@@ -0,0 +1,287 @@
+package shell
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// TrashEntry is a file or directory moved to the trash
+type TrashEntry struct {
+	ID        string    `json:"id"`
+	Path      string    `json:"path"`
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// Batch groups the entries removed by the same command
+	Batch int64 `json:"batch"`
+}
+
+// Trash keeps removed files so they can be restored
+type Trash struct {
+	dir     string
+	entries []TrashEntry
+}
+
+// DefaultTrashDir returns where removed files are kept, honoring XDG_DATA_HOME
+func DefaultTrashDir() string {
+	return filepath.Join(filepath.Dir(DefaultHistoryPath()), "trash")
+}
+
+// NewTrash opens the trash in dir, loading its index if it exists
+func NewTrash(dir string) (*Trash, error) {
+	t := &Trash{dir: dir}
+	data, err := os.ReadFile(t.indexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, fmt.Errorf("failed to read trash: %w", err)
+	}
+	if err := json.Unmarshal(data, &t.entries); err != nil {
+		return t, fmt.Errorf("failed to read trash: %w", err)
+	}
+	return t, nil
+}
+
+// Entries returns the entries in the trash, oldest first
+func (t *Trash) Entries() []TrashEntry {
+	return slices.Clone(t.entries)
+}
+
+// Move moves the given absolute paths to the trash as a single batch
+func (t *Trash) Move(paths []string) ([]TrashEntry, error) {
+	if err := os.MkdirAll(t.filesDir(), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create trash: %w", err)
+	}
+
+	now := time.Now()
+	batch := now.UnixNano()
+	var moved []TrashEntry
+	var errs []error
+	for i, path := range paths {
+		entry := TrashEntry{
+			ID:        strconv.FormatInt(batch, 36) + "-" + strconv.Itoa(i),
+			Path:      path,
+			DeletedAt: now,
+			Batch:     batch,
+		}
+		err := os.Rename(path, t.file(entry))
+		switch {
+		case err == nil:
+			t.entries = append(t.entries, entry)
+		case crossDevice(err):
+			err = t.copyIn(entry)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to trash %s: %w", path, err))
+			continue
+		}
+		moved = append(moved, entry)
+	}
+	if err := t.save(); err != nil {
+		errs = append(errs, err)
+	}
+	return moved, errors.Join(errs...)
+}
+
+// Undo restores the entries removed by the last command
+func (t *Trash) Undo() ([]TrashEntry, error) {
+	if len(t.entries) == 0 {
+		return nil, errors.New("the trash is empty")
+	}
+	batch := t.entries[len(t.entries)-1].Batch
+	return t.restore(func(e TrashEntry) bool { return e.Batch == batch })
+}
+
+// copyIn copies the entry's file into the trash from another file system.
+// The entry is saved before the original is removed, so undo finds it
+// whatever happens. When the original can't be removed, what's gone of it
+// is put back from the copy, which then leaves the trash.
+func (t *Trash) copyIn(entry TrashEntry) error {
+	file := t.file(entry)
+	if err := copyTree(entry.Path, file); err != nil {
+		os.RemoveAll(file)
+		return err
+	}
+	t.entries = append(t.entries, entry)
+	if err := t.save(); err != nil {
+		t.drop(entry)
+		os.RemoveAll(file)
+		return err
+	}
+	if err := os.RemoveAll(entry.Path); err != nil {
+		if putErr := putBack(file, entry.Path); putErr != nil {
+			return fmt.Errorf("%w, and what's gone of it is still in the trash: %w", err, putErr)
+		}
+		t.drop(entry)
+		os.RemoveAll(file)
+		return err
+	}
+	return nil
+}
+
+// drop forgets the entry, leaving its file alone
+func (t *Trash) drop(entry TrashEntry) {
+	t.entries = slices.DeleteFunc(t.entries, func(e TrashEntry) bool { return e.ID == entry.ID })
+}
+
+// Restore restores the most recently removed entry with the given ID, path
+// or file name, paths being relative to dir
+func (t *Trash) Restore(name, dir string) ([]TrashEntry, error) {
+	abs := name
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(dir, name)
+	}
+	for i := len(t.entries) - 1; i >= 0; i-- {
+		e := t.entries[i]
+		if e.ID == name || e.Path == abs || filepath.Base(e.Path) == name {
+			return t.restore(func(other TrashEntry) bool { return other.ID == e.ID })
+		}
+	}
+	return nil, fmt.Errorf("%s isn't in the trash", name)
+}
+
+func (t *Trash) restore(match func(TrashEntry) bool) ([]TrashEntry, error) {
+	var restored []TrashEntry
+	var errs []error
+	t.entries = slices.DeleteFunc(t.entries, func(e TrashEntry) bool {
+		if !match(e) {
+			return false
+		}
+		if _, err := os.Lstat(e.Path); err == nil {
+			errs = append(errs, fmt.Errorf("can't restore %s, it already exists", e.Path))
+			return false
+		}
+		if err := os.MkdirAll(filepath.Dir(e.Path), 0o755); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", e.Path, err))
+			return false
+		}
+		if err := move(t.file(e), e.Path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", e.Path, err))
+			return false
+		}
+		restored = append(restored, e)
+		return true
+	})
+	if err := t.save(); err != nil {
+		errs = append(errs, err)
+	}
+	return restored, errors.Join(errs...)
+}
+
+func (t *Trash) filesDir() string {
+	return filepath.Join(t.dir, "files")
+}
+
+func (t *Trash) file(e TrashEntry) string {
+	return filepath.Join(t.filesDir(), e.ID)
+}
+
+func (t *Trash) indexPath() string {
+	return filepath.Join(t.dir, "index.json")
+}
+
+// save writes the index of the trash
+func (t *Trash) save() error {
+	data, err := json.MarshalIndent(t.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save trash: %w", err)
+	}
+	if err := os.WriteFile(t.indexPath(), data, 0o600); err != nil {
+		return fmt.Errorf("failed to save trash: %w", err)
+	}
+	return nil
+}
+
+// move renames the file, copying it when it's on another file system. It's
+// for files leaving the trash, where a copy that can't be removed is only
+// left behind.
+func move(from, to string) error {
+	err := os.Rename(from, to)
+	if !crossDevice(err) {
+		return err
+	}
+	if err := copyTree(from, to); err != nil {
+		os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+// copyTree copies a file or a directory with everything in it
+func copyTree(from, to string) error {
+	return filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		return copyEntry(path, filepath.Join(to, rel), d)
+	})
+}
+
+// putBack copies what's missing at to from the copy of it, after removing
+// it went only part of the way
+func putBack(from, to string) error {
+	return filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, rel)
+		if _, err := os.Lstat(target); err == nil {
+			return nil
+		}
+		return copyEntry(path, target, d)
+	})
+}
+
+// copyEntry copies a single file, directory or symlink, without what's in
+// the directory
+func copyEntry(path, target string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	switch {
+	case d.IsDir():
+		return os.MkdirAll(target, info.Mode().Perm())
+	case d.Type()&fs.ModeSymlink != 0:
+		link, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	default:
+		return copyFile(path, target, info.Mode().Perm())
+	}
+}
+
+func copyFile(from, to string, perm fs.FileMode) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
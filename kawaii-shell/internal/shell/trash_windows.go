@@ -0,0 +1,15 @@
+//go:build windows
+
+package shell
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+)
+
+// crossDevice reports whether the rename failed because the paths are on
+// different drives
+func crossDevice(err error) bool {
+	return errors.Is(err, windows.ERROR_NOT_SAME_DEVICE)
+}
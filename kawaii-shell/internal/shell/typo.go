@@ -0,0 +1,124 @@
+package shell
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// IsNotFound reports whether the exit code means the shell couldn't find the
+// command
+func IsNotFound(code int) bool {
+	if runtime.GOOS == "windows" {
+		// cmd.exe's "is not recognized as an internal or external command"
+		return code == 9009
+	}
+	return code == 127
+}
+
+// shellBuiltins are commands the shell runs itself, which aren't in PATH
+var shellBuiltins = []string{
+	".", "[", "bg", "builtin", "command", "dirs", "echo", "eval", "exec",
+	"export", "false", "fg", "jobs", "local", "popd", "printf", "pushd",
+	"read", "return", "set", "shift", "source", "test", "trap", "true",
+	"type", "ulimit", "umask", "unset", "wait",
+}
+
+// Correction is a command with a misspelled command name fixed
+type Correction struct {
+	// From is the misspelled name and To what it should be
+	From, To string
+
+	// Command is the corrected command
+	Command string
+}
+
+// Correct looks for the command names in the command that aren't known and
+// fixes the first one with a close match among the known commands. The
+// history is used to break ties, commands used before win.
+func Correct(command string, known, history []string) (Correction, bool) {
+	used := map[string]int{}
+	for _, entry := range history {
+		if fields := strings.Fields(entry); len(fields) > 0 {
+			used[fields[0]]++
+		}
+	}
+
+	var b strings.Builder
+	var correction Correction
+	for _, token := range Tokenize(command) {
+		if token.Kind != TokenCommand || correction.To != "" ||
+			strings.ContainsRune(token.Text, '/') ||
+			slices.Contains(shellBuiltins, token.Text) ||
+			slices.Contains(known, token.Text) {
+			b.WriteString(token.Text)
+			continue
+		}
+		if to, ok := closest(token.Text, known, used); ok {
+			correction.From, correction.To = token.Text, to
+			b.WriteString(to)
+			continue
+		}
+		b.WriteString(token.Text)
+	}
+	correction.Command = b.String()
+	return correction, correction.To != ""
+}
+
+// closest returns the known command closest to the name, if one is close
+// enough to be a typo
+func closest(name string, known []string, used map[string]int) (string, bool) {
+	limit := 1
+	if len(name) > 4 {
+		limit = 2
+	}
+
+	best, bestDistance := "", limit+1
+	for _, candidate := range known {
+		if abs(len(candidate)-len(name)) > limit {
+			continue
+		}
+		d := distance(name, candidate)
+		switch {
+		case d < bestDistance,
+			d == bestDistance && used[candidate] > used[best],
+			d == bestDistance && used[candidate] == used[best] && len(candidate) < len(best):
+			best, bestDistance = candidate, d
+		}
+	}
+	return best, best != ""
+}
+
+// distance is the number of edits turning a into b, where swapping two
+// letters counts as one, like in gti and git
+func distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
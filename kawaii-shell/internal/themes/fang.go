@@ -0,0 +1,217 @@
+package themes
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/charmbracelet/fang"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// FromFang returns a theme in the colors of the fang color scheme, so a
+// CLI built with fang and its kawaii shell share one palette
+func FromFang(scheme fang.ColorScheme) *KawaiiTheme {
+	// color schemes may leave colors out, like the ANSI one
+	for _, c := range []*color.Color{
+		&scheme.Base, &scheme.Title, &scheme.Description, &scheme.Codeblock, &scheme.Program,
+		&scheme.DimmedArgument, &scheme.Comment, &scheme.Flag, &scheme.FlagDefault, &scheme.Command,
+		&scheme.QuotedString, &scheme.Argument, &scheme.Help, &scheme.Dash, &scheme.ErrorDetails,
+		&scheme.ErrorHeader[0], &scheme.ErrorHeader[1], &scheme.Logo[0], &scheme.Logo[1],
+	} {
+		if *c == nil {
+			*c = lipgloss.NoColor{}
+		}
+	}
+
+	var gradientColors []string
+	for _, c := range scheme.Logo {
+		if col, ok := makeColor(c); ok {
+			gradientColors = append(gradientColors, col.Hex())
+		}
+	}
+
+	return &KawaiiTheme{
+		id:             "fang",
+		Name:           "Fang 🦇✨",
+		GradientColors: gradientColors,
+		AnimationTime:  time.Now(),
+		Roles: Roles{
+			Success: scheme.Flag,
+			Warning: scheme.QuotedString,
+			Error:   scheme.ErrorHeader[1],
+			Info:    scheme.Description,
+			Accent:  scheme.Title,
+			Muted:   scheme.Comment,
+		},
+		Styles: KawaiiStyles{
+			Prompt: lipgloss.NewStyle().
+				Foreground(scheme.Title).
+				Bold(true).
+				Padding(0, 1).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Program).
+				MarginRight(1),
+
+			Input: lipgloss.NewStyle().
+				Foreground(scheme.Base).
+				Padding(0, 1),
+
+			Cursor: lipgloss.NewStyle().
+				Foreground(scheme.Codeblock).
+				Background(scheme.Title).
+				Bold(true),
+
+			OutputBox: lipgloss.NewStyle().
+				Padding(1, 2).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Comment).
+				Foreground(scheme.Base).
+				MarginBottom(1),
+
+			InputBox: lipgloss.NewStyle().
+				Padding(1, 2).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Title),
+
+			CommandInfo: lipgloss.NewStyle().
+				Foreground(scheme.Command).
+				Bold(true).
+				Padding(0, 2).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Command).
+				MarginBottom(1),
+
+			Warning: lipgloss.NewStyle().
+				Foreground(scheme.ErrorHeader[0]).
+				Background(scheme.ErrorHeader[1]).
+				Bold(true).
+				Padding(0, 1),
+
+			Help: lipgloss.NewStyle().
+				Foreground(scheme.Comment).
+				Padding(0, 1).
+				Italic(true),
+
+			Info: lipgloss.NewStyle().
+				Foreground(scheme.Description).
+				Padding(0, 1),
+
+			Pet: lipgloss.NewStyle().
+				Foreground(scheme.Program).
+				Bold(true).
+				Padding(0, 1),
+
+			PetBox: lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Command).
+				Padding(1, 2).
+				Align(lipgloss.Center).
+				MarginTop(1),
+
+			Title: lipgloss.NewStyle().
+				Foreground(scheme.Title).
+				Bold(true).
+				Padding(0, 1),
+
+			Success: lipgloss.NewStyle().
+				Foreground(scheme.Flag).
+				Bold(true).
+				Padding(0, 1),
+
+			Error: lipgloss.NewStyle().
+				Foreground(scheme.ErrorHeader[0]).
+				Background(scheme.ErrorHeader[1]).
+				Bold(true).
+				Padding(0, 1),
+
+			Sparkle: lipgloss.NewStyle().
+				Foreground(scheme.QuotedString).
+				Bold(true),
+
+			Highlight: lipgloss.NewStyle().
+				Foreground(scheme.Base).
+				Background(scheme.Codeblock).
+				Bold(true).
+				Padding(0, 1),
+
+			Glow: lipgloss.NewStyle().
+				Foreground(scheme.Title).
+				Bold(true).
+				Italic(true),
+
+			Rainbow: lipgloss.NewStyle().
+				Foreground(scheme.Program).
+				Bold(true),
+
+			FloatingBox: lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(scheme.Title).
+				Padding(1, 2).
+				Align(lipgloss.Center),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(scheme.Flag).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(scheme.ErrorHeader[1]).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(scheme.Title),
+		},
+	}
+}
+
+// ToFang returns the fang color scheme in the theme's colors, for the help
+// and errors of a CLI built with fang to look like the kawaii shell
+func (kt *KawaiiTheme) ToFang() fang.ColorScheme {
+	s := kt.Styles
+	var logo [2]color.Color
+	if len(kt.GradientColors) > 0 {
+		logo = [2]color.Color{
+			lipgloss.Color(kt.GradientColors[0]),
+			lipgloss.Color(kt.GradientColors[len(kt.GradientColors)-1]),
+		}
+	}
+	base := firstColor(s.Input.GetForeground(), s.OutputBox.GetForeground())
+	title := firstColor(s.Prompt.GetForeground(), s.Title.GetForeground())
+	comment := firstColor(s.Help.GetForeground(), base)
+	return fang.ColorScheme{
+		Base:           base,
+		Title:          title,
+		Description:    firstColor(s.Info.GetForeground(), base),
+		Codeblock:      firstColor(s.OutputBox.GetBackground(), s.Input.GetBackground()),
+		Program:        firstColor(s.Pet.GetForeground(), title),
+		DimmedArgument: comment,
+		Comment:        comment,
+		Flag:           firstColor(s.ExitSuccess.GetForeground(), title),
+		FlagDefault:    comment,
+		Command:        firstColor(s.CommandInfo.GetForeground(), title),
+		QuotedString:   firstColor(s.Glow.GetForeground(), s.Sparkle.GetForeground(), title),
+		Argument:       base,
+		Help:           comment,
+		Dash:           comment,
+		ErrorHeader: [2]color.Color{
+			firstColor(s.Error.GetForeground(), s.Warning.GetForeground()),
+			firstColor(s.Error.GetBackground(), s.Warning.GetBackground(), s.ExitFailure.GetForeground()),
+		},
+		ErrorDetails: firstColor(s.ExitFailure.GetForeground(), base),
+		Logo:         logo,
+	}
+}
+
+// firstColor returns the first of the colors that's set
+func firstColor(colors ...color.Color) color.Color {
+	for _, c := range colors {
+		if _, unset := c.(lipgloss.NoColor); !unset && c != nil {
+			return c
+		}
+	}
+	return lipgloss.NoColor{}
+}
@@ -0,0 +1,144 @@
+package themes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"image/color"
+	"strings"
+	"unicode"
+)
+
+// FangFile is a fang color scheme as it's written in JSON, the fields named
+// after the ones of fang.ColorScheme and the colors written like #ff66cc,
+// for fang.ThemeFromFile as it's proposed. Colors left out are fang's own.
+type FangFile struct {
+	Name           string    `json:"name,omitempty"`
+	Base           string    `json:"base,omitempty"`
+	Title          string    `json:"title,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	Codeblock      string    `json:"codeblock,omitempty"`
+	Program        string    `json:"program,omitempty"`
+	DimmedArgument string    `json:"dimmed_argument,omitempty"`
+	Comment        string    `json:"comment,omitempty"`
+	Flag           string    `json:"flag,omitempty"`
+	FlagDefault    string    `json:"flag_default,omitempty"`
+	Command        string    `json:"command,omitempty"`
+	QuotedString   string    `json:"quoted_string,omitempty"`
+	Argument       string    `json:"argument,omitempty"`
+	Help           string    `json:"help,omitempty"`
+	Dash           string    `json:"dash,omitempty"`
+	ErrorHeader    [2]string `json:"error_header"`
+	ErrorDetails   string    `json:"error_details,omitempty"`
+	Logo           [2]string `json:"logo"`
+}
+
+// FangFile returns the fang color scheme of the theme, see ToFang, as it's
+// written in JSON
+func (kt *KawaiiTheme) FangFile() FangFile {
+	s := kt.ToFang()
+	return FangFile{
+		Name:           kt.Name,
+		Base:           hexOf(s.Base),
+		Title:          hexOf(s.Title),
+		Description:    hexOf(s.Description),
+		Codeblock:      hexOf(s.Codeblock),
+		Program:        hexOf(s.Program),
+		DimmedArgument: hexOf(s.DimmedArgument),
+		Comment:        hexOf(s.Comment),
+		Flag:           hexOf(s.Flag),
+		FlagDefault:    hexOf(s.FlagDefault),
+		Command:        hexOf(s.Command),
+		QuotedString:   hexOf(s.QuotedString),
+		Argument:       hexOf(s.Argument),
+		Help:           hexOf(s.Help),
+		Dash:           hexOf(s.Dash),
+		ErrorHeader:    [2]string{hexOf(s.ErrorHeader[0]), hexOf(s.ErrorHeader[1])},
+		ErrorDetails:   hexOf(s.ErrorDetails),
+		Logo:           [2]string{hexOf(s.Logo[0]), hexOf(s.Logo[1])},
+	}
+}
+
+// ExportFangJSON returns the fang color scheme of the theme as a JSON file
+func (kt *KawaiiTheme) ExportFangJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(kt.FangFile(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export theme: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// ExportFangGo returns Go code declaring the fang color scheme of the theme
+// in the package, for fang.WithTheme
+func (kt *KawaiiTheme) ExportFangGo(pkg string) ([]byte, error) {
+	f := kt.FangFile()
+	name := goName(kt.ID()) + "ColorScheme"
+	var fields bytes.Buffer
+	for _, field := range []struct{ name, hex string }{
+		{"Base", f.Base}, {"Title", f.Title}, {"Description", f.Description}, {"Codeblock", f.Codeblock},
+		{"Program", f.Program}, {"DimmedArgument", f.DimmedArgument}, {"Comment", f.Comment},
+		{"Flag", f.Flag}, {"FlagDefault", f.FlagDefault}, {"Command", f.Command},
+		{"QuotedString", f.QuotedString}, {"Argument", f.Argument}, {"Help", f.Help},
+		{"Dash", f.Dash}, {"ErrorDetails", f.ErrorDetails},
+	} {
+		if field.hex != "" {
+			fmt.Fprintf(&fields, "%s: lipgloss.Color(%q),\n", field.name, field.hex)
+		}
+	}
+	pairs := false
+	for _, field := range []struct {
+		name string
+		hex  [2]string
+	}{{"ErrorHeader", f.ErrorHeader}, {"Logo", f.Logo}} {
+		if field.hex[0] != "" && field.hex[1] != "" {
+			fmt.Fprintf(&fields, "%s: [2]color.Color{lipgloss.Color(%q), lipgloss.Color(%q)},\n", field.name, field.hex[0], field.hex[1])
+			pairs = true
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by kawaii-shell theme export-fang. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	if pairs {
+		b.WriteString("\"image/color\"\n\n")
+	}
+	b.WriteString("\"github.com/charmbracelet/fang\"\n\"github.com/charmbracelet/lipgloss/v2\"\n)\n\n")
+	fmt.Fprintf(&b, "// %s is the %s kawaii theme as a fang color scheme, for\n// fang.WithTheme\n", name, kt.Name)
+	fmt.Fprintf(&b, "var %s = fang.ColorScheme{\n%s}\n", name, fields.Bytes())
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to export theme: %w", err)
+	}
+	return code, nil
+}
+
+// hexOf returns the color written like #ff66cc, empty when it's unset
+func hexOf(c color.Color) string {
+	if col, ok := makeColor(c); ok {
+		return col.Hex()
+	}
+	return ""
+}
+
+// goName returns the theme ID as an exported Go name, like SakuraNight for
+// sakura-night
+func goName(id string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range id {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "Kawaii" + name
+	}
+	return name
+}
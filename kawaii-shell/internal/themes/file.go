@@ -0,0 +1,330 @@
+package themes
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/charmtone"
+	"gopkg.in/yaml.v3"
+)
+
+// hexColor matches colors written like #ff66cc or #f6c
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// borders are the borders theme files can use, by name
+var borders = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"block":   lipgloss.BlockBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+	"ascii":   lipgloss.ASCIIBorder(),
+}
+
+// aligns are the alignments theme files can use, by name
+var aligns = map[string]lipgloss.Position{
+	"left":   lipgloss.Left,
+	"center": lipgloss.Center,
+	"right":  lipgloss.Right,
+}
+
+// custom are the themes loaded from theme files, in the order they're
+// offered after the built-in ones
+var custom []*KawaiiTheme
+
+// File is how a theme is written in a YAML file, named after the theme's
+// ID, like mint.yaml
+type File struct {
+	// Name is the name the theme is shown by, the ID when it's empty
+	Name string `yaml:"name,omitempty"`
+
+	// Base is the built-in theme the styles start from, like ocean, plain
+	// styles when it's empty
+	Base string `yaml:"base,omitempty"`
+
+	// Gradient are the colors of the theme's gradients
+	Gradient []string `yaml:"gradient,omitempty"`
+
+	// ANSI are the ANSI colors, 0 to 15, the theme's colors fall back to
+	// on terminals with 16 colors, adding to the base theme's
+	ANSI map[string]int `yaml:"ansi,omitempty"`
+
+	// Styles are the styles the theme changes, by the snake case name of
+	// the KawaiiStyles field, like pet_box
+	Styles map[string]StyleFile `yaml:"styles,omitempty"`
+
+	// Roles are the colors the theme changes by what they mean, like
+	// accent, the plain styles' own colors when there's no base
+	Roles map[string]string `yaml:"roles,omitempty"`
+}
+
+// StyleFile is how a style is written in a theme file, anything left out
+// staying as it is in the base theme. Colors are written like #ff66cc, as
+// an ANSI color number or as a charmtone name like Coral.
+type StyleFile struct {
+	Foreground       string `yaml:"foreground,omitempty"`
+	Background       string `yaml:"background,omitempty"`
+	BorderForeground string `yaml:"border_foreground,omitempty"`
+	BorderBackground string `yaml:"border_background,omitempty"`
+
+	// Border is normal, rounded, thick, double, block, hidden, ascii or
+	// none
+	Border string `yaml:"border,omitempty"`
+
+	// Padding and Margin are one to four sizes, like [1, 2], going around
+	// like in CSS
+	Padding []int `yaml:"padding,omitempty"`
+	Margin  []int `yaml:"margin,omitempty"`
+
+	// Align is left, center or right
+	Align string `yaml:"align,omitempty"`
+
+	Bold      *bool `yaml:"bold,omitempty"`
+	Italic    *bool `yaml:"italic,omitempty"`
+	Underline *bool `yaml:"underline,omitempty"`
+	Faint     *bool `yaml:"faint,omitempty"`
+	Blink     *bool `yaml:"blink,omitempty"`
+}
+
+// LoadThemes adds the themes described by the YAML files in dir, which may
+// not exist. Themes named like a built-in one replace it.
+func LoadThemes(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to read themes: %w", err)
+	}
+	var errs []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read theme: %w", err))
+			continue
+		}
+		id := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".yaml"))
+		theme, err := parseTheme(id, data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid theme %s: %w", file, err))
+			continue
+		}
+		AddTheme(theme)
+	}
+	return errors.Join(errs...)
+}
+
+// ReloadThemes loads the themes in dir again, in place of all the ones
+// loaded before, for when the theme files changed
+func ReloadThemes(dir string) error {
+	custom = nil
+	return LoadThemes(dir)
+}
+
+// parseTheme parses and checks the theme file of the theme with the ID
+func parseTheme(id string, data []byte) (*KawaiiTheme, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.Theme(id)
+}
+
+// Theme checks the theme file and returns the theme it describes, with the
+// ID
+func (file File) Theme(id string) (*KawaiiTheme, error) {
+	theme := &KawaiiTheme{id: id, Name: id, AnimationTime: time.Now()}
+	if file.Base != "" {
+		base := builtinTheme(file.Base)
+		if base == nil {
+			return nil, fmt.Errorf("there's no %s theme to start from", file.Base)
+		}
+		theme.Styles, theme.GradientColors, theme.Roles = base.Styles, base.GradientColors, base.Roles
+		theme.ANSI = maps.Clone(base.ANSI)
+	}
+	if file.Name != "" {
+		theme.Name = file.Name
+	}
+	if len(file.Gradient) > 0 {
+		theme.GradientColors = nil
+		for _, c := range file.Gradient {
+			value, err := parseColor(c)
+			if err != nil {
+				return nil, fmt.Errorf("gradient: %w", err)
+			}
+			theme.GradientColors = append(theme.GradientColors, value)
+		}
+	}
+
+	for c, basic := range file.ANSI {
+		value, err := parseColor(c)
+		if err != nil {
+			return nil, fmt.Errorf("ansi: %w", err)
+		}
+		if basic < 0 || basic > 15 {
+			return nil, fmt.Errorf("ansi: %s falls back to %d, ANSI colors go from 0 to 15", c, basic)
+		}
+		if theme.ANSI == nil {
+			theme.ANSI = make(map[string]ansi.BasicColor)
+		}
+		theme.ANSI[value] = ansi.BasicColor(basic)
+	}
+
+	if err := theme.Styles.apply(file.Styles); err != nil {
+		return nil, err
+	}
+	if file.Base == "" {
+		theme.Roles = rolesOf(theme.Styles)
+	}
+	if err := theme.Roles.apply(file.Roles); err != nil {
+		return nil, fmt.Errorf("roles: %w", err)
+	}
+	return theme, nil
+}
+
+// builtinTheme returns the built-in theme by its ID, or nil
+func builtinTheme(id string) *KawaiiTheme {
+	for _, theme := range builtinThemes() {
+		if strings.EqualFold(theme.ID(), id) {
+			return theme
+		}
+	}
+	return nil
+}
+
+// byName returns the styles by the snake case names theme files use
+func (s *KawaiiStyles) byName() map[string]*lipgloss.Style {
+	return map[string]*lipgloss.Style{
+		"prompt":       &s.Prompt,
+		"input":        &s.Input,
+		"cursor":       &s.Cursor,
+		"output_box":   &s.OutputBox,
+		"input_box":    &s.InputBox,
+		"command_info": &s.CommandInfo,
+		"warning":      &s.Warning,
+		"help":         &s.Help,
+		"info":         &s.Info,
+		"pet":          &s.Pet,
+		"pet_box":      &s.PetBox,
+		"title":        &s.Title,
+		"success":      &s.Success,
+		"error":        &s.Error,
+		"sparkle":      &s.Sparkle,
+		"highlight":    &s.Highlight,
+		"glow":         &s.Glow,
+		"rainbow":      &s.Rainbow,
+		"floating_box": &s.FloatingBox,
+		"exit_success": &s.ExitSuccess,
+		"exit_failure": &s.ExitFailure,
+		"focus":        &s.Focus,
+		"focus_ring":   &s.FocusRing,
+	}
+}
+
+// apply changes the styles, by the snake case names theme files use
+func (s *KawaiiStyles) apply(styles map[string]StyleFile) error {
+	fields := s.byName()
+	for name, styleFile := range styles {
+		style, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("there's no %s style", name)
+		}
+		var err error
+		if *style, err = styleFile.apply(*style); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// apply changes the style by what the file sets
+func (f StyleFile) apply(style lipgloss.Style) (lipgloss.Style, error) {
+	colors := []struct {
+		value string
+		set   func(lipgloss.Style, string) lipgloss.Style
+	}{
+		{f.Foreground, func(s lipgloss.Style, c string) lipgloss.Style { return s.Foreground(lipgloss.Color(c)) }},
+		{f.Background, func(s lipgloss.Style, c string) lipgloss.Style { return s.Background(lipgloss.Color(c)) }},
+		{f.BorderForeground, func(s lipgloss.Style, c string) lipgloss.Style { return s.BorderForeground(lipgloss.Color(c)) }},
+		{f.BorderBackground, func(s lipgloss.Style, c string) lipgloss.Style { return s.BorderBackground(lipgloss.Color(c)) }},
+	}
+	for _, c := range colors {
+		if c.value == "" {
+			continue
+		}
+		value, err := parseColor(c.value)
+		if err != nil {
+			return style, err
+		}
+		style = c.set(style, value)
+	}
+
+	switch border, ok := borders[f.Border]; {
+	case f.Border == "":
+	case f.Border == "none":
+		style = style.UnsetBorderStyle().UnsetBorderTop().UnsetBorderRight().UnsetBorderBottom().UnsetBorderLeft()
+	case !ok:
+		return style, fmt.Errorf("there's no %s border, try normal, rounded, thick, double, block, hidden, ascii or none", f.Border)
+	default:
+		style = style.Border(border)
+	}
+
+	if len(f.Padding) > 4 || len(f.Margin) > 4 {
+		return style, fmt.Errorf("padding and margin take one to four sizes")
+	}
+	if len(f.Padding) > 0 {
+		style = style.Padding(f.Padding...)
+	}
+	if len(f.Margin) > 0 {
+		style = style.Margin(f.Margin...)
+	}
+
+	if f.Align != "" {
+		align, ok := aligns[f.Align]
+		if !ok {
+			return style, fmt.Errorf("there's no %s alignment, try left, center or right", f.Align)
+		}
+		style = style.Align(align)
+	}
+
+	if f.Bold != nil {
+		style = style.Bold(*f.Bold)
+	}
+	if f.Italic != nil {
+		style = style.Italic(*f.Italic)
+	}
+	if f.Underline != nil {
+		style = style.Underline(*f.Underline)
+	}
+	if f.Faint != nil {
+		style = style.Faint(*f.Faint)
+	}
+	if f.Blink != nil {
+		style = style.Blink(*f.Blink)
+	}
+	return style, nil
+}
+
+// parseColor returns the color as lipgloss takes it, from a hex color, an
+// ANSI color number or a charmtone name
+func parseColor(s string) (string, error) {
+	if hexColor.MatchString(s) {
+		return s, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return s, nil
+	}
+	for _, key := range charmtone.Keys() {
+		if strings.EqualFold(key.String(), s) {
+			return key.Hex(), nil
+		}
+	}
+	return "", fmt.Errorf("invalid color %q, write it like #ff66cc, as an ANSI number or a charmtone name like Coral", s)
+}
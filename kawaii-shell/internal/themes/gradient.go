@@ -0,0 +1,73 @@
+package themes
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// gradientCycle is how long the gradient takes to flow all the way along
+const gradientCycle = 6 * time.Second
+
+// GradientAt returns n colors along the theme's gradient, blending from one
+// of the GradientColors into the next and back to the first. The colors
+// flow along with time, going all the way around every gradientCycle.
+// Terminals with 16 colors get the colors themselves in turn, as blends
+// of them can't be shown.
+func (kt *KawaiiTheme) GradientAt(n int, now time.Time) []string {
+	stops := make([]colorful.Color, 0, len(kt.GradientColors))
+	names := make([]string, 0, len(kt.GradientColors))
+	for _, c := range kt.GradientColors {
+		if stop, ok := colorful.MakeColor(lipgloss.Color(c)); ok {
+			stops, names = append(stops, stop), append(names, c)
+		}
+	}
+	if len(stops) == 0 || n <= 0 {
+		return nil
+	}
+
+	offset := float64(now.Sub(kt.AnimationTime)%gradientCycle) / float64(gradientCycle)
+	colors := make([]string, n)
+	for i := range colors {
+		pos := math.Mod(float64(i)/float64(n)+offset, 1) * float64(len(stops))
+		from := int(pos)
+		if profile == colorprofile.ANSI {
+			colors[i] = names[from]
+			continue
+		}
+		to := (from + 1) % len(stops)
+		colors[i] = stops[from].BlendLuv(stops[to], pos-float64(from)).Clamped().Hex()
+	}
+	return colors
+}
+
+// GradientText renders the text in the colors of the gradient, one color
+// for each column, on the background of the style. Text without a gradient
+// to color it with is rendered in the style.
+func (kt *KawaiiTheme) GradientText(text string, style lipgloss.Style, now time.Time) string {
+	colors := kt.GradientAt(ansi.StringWidth(text), now)
+	if len(colors) == 0 {
+		return style.Render(text)
+	}
+	rainbow := make([]color.Color, len(colors))
+	for i, c := range colors {
+		rainbow[i] = lipgloss.Color(c)
+	}
+	return NewRainbow(style, rainbow...).Paint(text, 0)
+}
+
+// GradientBorder colors the sides of the style's border along the
+// gradient, the colors going around as they flow
+func (kt *KawaiiTheme) GradientBorder(style lipgloss.Style, now time.Time) lipgloss.Style {
+	colors := kt.GradientAt(4, now)
+	if len(colors) == 0 {
+		return style
+	}
+	return style.BorderForeground(
+		lipgloss.Color(colors[0]), lipgloss.Color(colors[1]), lipgloss.Color(colors[2]), lipgloss.Color(colors[3]))
+}
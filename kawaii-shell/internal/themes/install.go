@@ -0,0 +1,83 @@
+package themes
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultRegistry is where themes installed by name come from, each one a
+// theme file named after it
+const DefaultRegistry = "https://raw.githubusercontent.com/pcstyle/kawaii-themes/main/themes/"
+
+const (
+	// installTimeout is how long downloading a theme takes at most
+	installTimeout = 15 * time.Second
+
+	// maxThemeSize is the biggest theme file that's downloaded
+	maxThemeSize = 1 << 20
+)
+
+// themeName matches the names themes are installed by from the registry
+var themeName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Install downloads the theme file from the URL, or the theme by its name
+// from the registry, and saves it in dir once it's checked. The theme is
+// named after the file. AddTheme makes it available.
+func Install(source, registry, dir string) (*KawaiiTheme, error) {
+	url := source
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		name := strings.ToLower(source)
+		if !themeName.MatchString(name) {
+			return nil, fmt.Errorf("%q isn't a theme name or a URL", source)
+		}
+		url = strings.TrimSuffix(registry, "/") + "/" + name + ".yaml"
+	}
+	id := strings.ToLower(path.Base(url))
+	id = strings.TrimSuffix(strings.TrimSuffix(id, ".yaml"), ".yml")
+	if !themeName.MatchString(id) {
+		return nil, fmt.Errorf("can't name a theme after %s, the file should be named like mint.yaml", url)
+	}
+
+	client := http.Client{Timeout: installTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download theme: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download theme %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxThemeSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download theme: %w", err)
+	}
+	if len(data) > maxThemeSize {
+		return nil, fmt.Errorf("theme %s is too big to be a theme file", url)
+	}
+
+	theme, err := parseTheme(id, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", url, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to save theme: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+".yaml"), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to save theme: %w", err)
+	}
+	return theme, nil
+}
+
+// AddTheme makes the theme available, in place of the one with its ID
+func AddTheme(theme *KawaiiTheme) {
+	custom = slices.DeleteFunc(custom, func(t *KawaiiTheme) bool { return t.ID() == theme.ID() })
+	custom = append(custom, theme)
+}
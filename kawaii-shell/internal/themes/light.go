@@ -0,0 +1,105 @@
+package themes
+
+import (
+	"image/color"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// light is whether the built-in themes are their light variants, for
+// terminals with a light background
+var light bool
+
+// SetLight switches the built-in themes to their light variants, or back
+// to the dark ones. Theme files starting from a built-in theme start from
+// the variant, so it's set before they're loaded.
+func SetLight(on bool) {
+	light = on
+}
+
+// Light returns the variant of the theme for light backgrounds. Dark
+// backgrounds turn pale, and the colors of text on them or straight on the
+// terminal turn deep, each keeping its hue.
+func (kt *KawaiiTheme) Light() *KawaiiTheme {
+	theme := *kt
+	theme.GradientColors = make([]string, len(kt.GradientColors))
+	for i, c := range kt.GradientColors {
+		theme.GradientColors[i] = c
+		if deep, ok := makeColor(deepen(lipgloss.Color(c))); ok {
+			theme.GradientColors[i] = deep.Hex()
+		}
+	}
+	for _, style := range theme.Styles.byName() {
+		*style = lightStyle(*style)
+	}
+	theme.Roles = kt.Roles.recolor(deepen)
+	return &theme
+}
+
+// lightStyle turns the style's colors around for a light background
+func lightStyle(style lipgloss.Style) lipgloss.Style {
+	bg, hasBackground := makeColor(style.GetBackground())
+	darkBackground := hasBackground && isDark(bg)
+	if darkBackground {
+		style = style.Background(paler(style.GetBackground()))
+	}
+	// text on a light background of its own reads as it is
+	if !hasBackground || darkBackground {
+		style = style.Foreground(deepen(style.GetForeground()))
+	}
+	return style.
+		BorderForeground(
+			deepen(style.GetBorderTopForeground()),
+			deepen(style.GetBorderRightForeground()),
+			deepen(style.GetBorderBottomForeground()),
+			deepen(style.GetBorderLeftForeground())).
+		BorderBackground(
+			paler(style.GetBorderTopBackground()),
+			paler(style.GetBorderRightBackground()),
+			paler(style.GetBorderBottomBackground()),
+			paler(style.GetBorderLeftBackground()))
+}
+
+// makeColor converts the color for blending, ok being false for unset
+// colors
+func makeColor(c color.Color) (col colorful.Color, ok bool) {
+	if _, unset := c.(lipgloss.NoColor); unset || c == nil {
+		return col, false
+	}
+	return colorful.MakeColor(c)
+}
+
+// isDark is whether the color is on the dark side
+func isDark(c colorful.Color) bool {
+	_, _, l := c.Hcl()
+	return l < 0.5
+}
+
+// paler turns a dark color into a pale one of the same hue, leaving the
+// others and unset colors as they are
+func paler(c color.Color) color.Color {
+	col, ok := makeColor(c)
+	if !ok {
+		return c
+	}
+	h, chroma, l := col.Hcl()
+	if l >= 0.5 {
+		return c
+	}
+	return colorful.Hcl(h, chroma, 1-l).Clamped()
+}
+
+// deepen turns a light color into a deep one of the same hue, which reads
+// on a light background, leaving the others and unset colors as they are
+func deepen(c color.Color) color.Color {
+	col, ok := makeColor(c)
+	if !ok {
+		return c
+	}
+	h, chroma, l := col.Hcl()
+	if l <= 0.55 {
+		return c
+	}
+	return colorful.Hcl(h, chroma, max(1-l, 0.3)).Clamped()
+}
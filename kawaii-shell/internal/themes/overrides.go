@@ -0,0 +1,27 @@
+package themes
+
+// overrides are the styles changed on top of every theme
+var overrides map[string]StyleFile
+
+// SetOverrides changes the styles on top of every theme, by the snake case
+// names theme files use, like pet_box. Nothing changes when some of them
+// are wrong.
+func SetOverrides(styles map[string]StyleFile) error {
+	var check KawaiiStyles
+	if err := check.apply(styles); err != nil {
+		return err
+	}
+	overrides = styles
+	return nil
+}
+
+// withOverrides returns the theme with the overridden styles changed
+func (kt *KawaiiTheme) withOverrides() *KawaiiTheme {
+	if len(overrides) == 0 {
+		return kt
+	}
+	theme := *kt
+	// checked by SetOverrides
+	_ = theme.Styles.apply(overrides)
+	return &theme
+}
@@ -0,0 +1,73 @@
+package themes
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// profile is the colors the terminal can show
+var profile = colorprofile.TrueColor
+
+// SetProfile sets the colors the terminal can show. On terminals with the
+// 16 ANSI colors only, the themes take their ANSI fallbacks.
+func SetProfile(p colorprofile.Profile) {
+	profile = p
+}
+
+// Basic returns the variant of the theme for terminals with the 16 ANSI
+// colors only, its colors replaced by their ANSI fallbacks. The colors
+// without one are left for the terminal's output to turn into the nearest
+// ANSI color.
+func (kt *KawaiiTheme) Basic() *KawaiiTheme {
+	fallbacks := make(map[string]ansi.BasicColor, len(kt.ANSI))
+	for c, basic := range kt.ANSI {
+		if col, ok := makeColor(lipgloss.Color(c)); ok {
+			fallbacks[col.Hex()] = basic
+		}
+	}
+	fallback := func(c color.Color) color.Color {
+		col, ok := makeColor(c)
+		if !ok {
+			return c
+		}
+		if basic, ok := fallbacks[col.Hex()]; ok {
+			return basic
+		}
+		return c
+	}
+
+	theme := *kt
+	theme.GradientColors = make([]string, len(kt.GradientColors))
+	for i, c := range kt.GradientColors {
+		theme.GradientColors[i] = c
+		if basic, ok := fallback(lipgloss.Color(c)).(ansi.BasicColor); ok {
+			theme.GradientColors[i] = strconv.Itoa(int(basic))
+		}
+	}
+	for _, style := range theme.Styles.byName() {
+		*style = recolor(*style, fallback)
+	}
+	theme.Roles = kt.Roles.recolor(fallback)
+	return &theme
+}
+
+// recolor changes every color of the style, its borders' too
+func recolor(style lipgloss.Style, change func(color.Color) color.Color) lipgloss.Style {
+	return style.
+		Foreground(change(style.GetForeground())).
+		Background(change(style.GetBackground())).
+		BorderForeground(
+			change(style.GetBorderTopForeground()),
+			change(style.GetBorderRightForeground()),
+			change(style.GetBorderBottomForeground()),
+			change(style.GetBorderLeftForeground())).
+		BorderBackground(
+			change(style.GetBorderTopBackground()),
+			change(style.GetBorderRightBackground()),
+			change(style.GetBorderBottomBackground()),
+			change(style.GetBorderLeftBackground()))
+}
@@ -0,0 +1,79 @@
+package themes
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+)
+
+// Rainbow paints text in bands of colors, a color for each column so the
+// bands line up from one line to the next. The sequences turning each color
+// on are made once, rather than a style rendered for every character.
+type Rainbow struct {
+	// on are the sequences turning each color on, in the order of the
+	// colors
+	on []string
+
+	// background is whether the colors come on a background, which the
+	// spaces take too
+	background bool
+}
+
+// NewRainbow returns the rainbow of the colors, on the background of the
+// style and bold when it is
+func NewRainbow(style lipgloss.Style, colors ...color.Color) *Rainbow {
+	var base ansi.Style
+	if style.GetBold() {
+		base = base.Bold()
+	}
+	bg := style.GetBackground()
+	_, unset := bg.(lipgloss.NoColor)
+	background := bg != nil && !unset
+	if background {
+		base = base.BackgroundColor(bg)
+	}
+	r := &Rainbow{on: make([]string, len(colors)), background: background}
+	for i, c := range colors {
+		r.on[i] = slices.Clip(base).ForegroundColor(c).String()
+	}
+	return r
+}
+
+// Paint renders the text in the rainbow's colors, offset colors along. The
+// spaces keep the color before them, and every line starts over.
+func (r *Rainbow) Paint(text string, offset int) string {
+	if len(r.on) == 0 {
+		return text
+	}
+	var b strings.Builder
+	b.Grow(len(text) * 8)
+	col, current, state := 0, -1, -1
+	for len(text) > 0 {
+		var cluster string
+		var width int
+		cluster, text, width, state = uniseg.FirstGraphemeClusterInString(text, state)
+		switch {
+		case cluster == "\n" || cluster == "\r\n":
+			if current >= 0 {
+				b.WriteString(ansi.ResetStyle)
+			}
+			col, current = 0, -1
+		case cluster != " " || current < 0 && r.background:
+			i := ((col+offset)%len(r.on) + len(r.on)) % len(r.on)
+			if i != current {
+				b.WriteString(r.on[i])
+				current = i
+			}
+		}
+		b.WriteString(cluster)
+		col += width
+	}
+	if current >= 0 {
+		b.WriteString(ansi.ResetStyle)
+	}
+	return b.String()
+}
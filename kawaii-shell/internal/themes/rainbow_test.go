@@ -0,0 +1,73 @@
+package themes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRainbowPaint(t *testing.T) {
+	r := NewRainbow(lipgloss.NewStyle(), ansi.Red, ansi.Green, ansi.Blue)
+	red, green, blue := "\x1b[31m", "\x1b[32m", "\x1b[34m"
+
+	tests := []struct {
+		text   string
+		offset int
+		want   string
+	}{
+		{"", 0, ""},
+		{"abc", 0, red + "a" + green + "b" + blue + "c" + ansi.ResetStyle},
+		{"abc", 1, green + "a" + blue + "b" + red + "c" + ansi.ResetStyle},
+		{"abc", -1, blue + "a" + red + "b" + green + "c" + ansi.ResetStyle},
+		// spaces keep the color before them, but take up a column
+		{"a b", 0, red + "a " + blue + "b" + ansi.ResetStyle},
+		// wide characters take up two columns
+		{"🌸a", 0, red + "🌸" + blue + "a" + ansi.ResetStyle},
+		// every line starts over
+		{"ab\nab", 0, red + "a" + green + "b" + ansi.ResetStyle + "\n" + red + "a" + green + "b" + ansi.ResetStyle},
+	}
+	for _, test := range tests {
+		if got := r.Paint(test.text, test.offset); got != test.want {
+			t.Errorf("Paint(%q, %d) = %q, want %q", test.text, test.offset, got, test.want)
+		}
+		if got := ansi.Strip(r.Paint(test.text, test.offset)); got != test.text {
+			t.Errorf("Paint(%q, %d) reads %q", test.text, test.offset, got)
+		}
+	}
+}
+
+// rainbowText is a line like the startup's logo
+var rainbowText = strings.Repeat("🌸✨ KAWAII SHELL v0.1.0 ✨🌸 ", 3)
+
+func BenchmarkRainbowPaint(b *testing.B) {
+	r := NewRainbow(lipgloss.NewStyle().Bold(true),
+		lipgloss.Color("#ff0000"), lipgloss.Color("#ff8000"), lipgloss.Color("#ffff00"),
+		lipgloss.Color("#00ff00"), lipgloss.Color("#0080ff"), lipgloss.Color("#8000ff"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Paint(rainbowText, i)
+	}
+}
+
+// BenchmarkStylePerRune is how rainbows were painted before Rainbow, a
+// style rendered for every character
+func BenchmarkStylePerRune(b *testing.B) {
+	colors := []string{"#ff0000", "#ff8000", "#ffff00", "#00ff00", "#0080ff", "#8000ff"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var result strings.Builder
+		for j, char := range rainbowText {
+			if char == ' ' {
+				result.WriteRune(char)
+				continue
+			}
+			result.WriteString(lipgloss.NewStyle().
+				Foreground(lipgloss.Color(colors[(i+j)%len(colors)])).
+				Bold(true).
+				Render(string(char)))
+		}
+		_ = result.String()
+	}
+}
@@ -0,0 +1,72 @@
+package themes
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Roles are the theme's colors by what they mean, for the components, the
+// pets and the decorations that have no style of their own
+type Roles struct {
+	Success color.Color
+	Warning color.Color
+	Error   color.Color
+	Info    color.Color
+
+	// Accent is what stands out, like the command to run, and Muted what
+	// stays in the background, like disabled buttons
+	Accent color.Color
+	Muted  color.Color
+}
+
+// byName returns the roles by the names theme files use
+func (r *Roles) byName() map[string]*color.Color {
+	return map[string]*color.Color{
+		"success": &r.Success,
+		"warning": &r.Warning,
+		"error":   &r.Error,
+		"info":    &r.Info,
+		"accent":  &r.Accent,
+		"muted":   &r.Muted,
+	}
+}
+
+// rolesOf returns the roles the styles' colors play, for themes that don't
+// pick them
+func rolesOf(s KawaiiStyles) Roles {
+	return Roles{
+		Success: firstColor(s.ExitSuccess.GetForeground(), s.Success.GetForeground()),
+		Warning: firstColor(s.Warning.GetBackground(), s.Warning.GetForeground()),
+		Error:   firstColor(s.ExitFailure.GetForeground(), s.Error.GetForeground()),
+		Info:    firstColor(s.Info.GetForeground(), s.Title.GetForeground()),
+		Accent:  firstColor(s.Prompt.GetForeground(), s.Title.GetForeground()),
+		Muted:   firstColor(s.Help.GetForeground(), s.Input.GetForeground()),
+	}
+}
+
+// apply changes the roles written in a theme file, by their names
+func (r *Roles) apply(roles map[string]string) error {
+	byName := r.byName()
+	for name, c := range roles {
+		role, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("there's no %s role", name)
+		}
+		value, err := parseColor(c)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		*role = lipgloss.Color(value)
+	}
+	return nil
+}
+
+// recolor changes every role's color
+func (r Roles) recolor(change func(color.Color) color.Color) Roles {
+	for _, role := range r.byName() {
+		*role = change(*role)
+	}
+	return r
+}
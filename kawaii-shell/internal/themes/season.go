@@ -0,0 +1,143 @@
+package themes
+
+import (
+	"image/color"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Season is an event the shell dresses up for while it lasts, restyling
+// the borders of every theme, the particles and what pets say
+type Season struct {
+	// ID is the short name pet voices have the season's lines by, like
+	// halloween
+	ID   string
+	Name string
+
+	// From and To are the first and the last day of the season, which goes
+	// past the new year when it ends before it starts
+	From, To Day
+
+	// Border and BorderColors are the borders themes take, the colors going
+	// top and bottom, then left and right
+	Border       lipgloss.Border
+	BorderColors []string
+
+	// ANSI are the ANSI fallbacks of the border colors
+	ANSI map[string]ansi.BasicColor
+
+	// Particles are the emojis sparkles and petals turn into
+	Particles []string
+
+	// Greeting is what the shell says when it starts in the season
+	Greeting string
+}
+
+// Day is a day of the year
+type Day struct {
+	Month time.Month
+	Day   int
+}
+
+// before reports whether the day comes before the other one in the year
+func (d Day) before(other Day) bool {
+	return d.Month < other.Month || d.Month == other.Month && d.Day < other.Day
+}
+
+// Seasons are the seasons the shell dresses up for
+var Seasons = []Season{
+	{
+		ID:           "halloween",
+		Name:         "Halloween 🎃",
+		From:         Day{time.October, 24},
+		To:           Day{time.October, 31},
+		Border:       lipgloss.ThickBorder(),
+		BorderColors: []string{"#ff7518", "#8a2be2"},
+		ANSI:         map[string]ansi.BasicColor{"#ff7518": ansi.Yellow, "#8a2be2": ansi.Magenta},
+		Particles:    []string{"🎃", "👻", "🦇", "🕸️", "🍬", "🕯️"},
+		Greeting:     "🎃 Happy Halloween! The shell dressed up as something spooky",
+	},
+	{
+		ID:           "winter",
+		Name:         "Winter Holidays ❄️",
+		From:         Day{time.December, 15},
+		To:           Day{time.January, 6},
+		Border:       lipgloss.DoubleBorder(),
+		BorderColors: []string{"#d42426", "#2e8b57"},
+		ANSI:         map[string]ansi.BasicColor{"#d42426": ansi.Red, "#2e8b57": ansi.Green},
+		Particles:    []string{"❄️", "⛄", "🎄", "🎁", "⭐", "🔔"},
+		Greeting:     "❄️ Happy holidays! The shell is all wrapped up for the winter",
+	},
+	{
+		ID:           "blossom",
+		Name:         "Cherry Blossoms 🌸",
+		From:         Day{time.March, 20},
+		To:           Day{time.April, 30},
+		Border:       lipgloss.RoundedBorder(),
+		BorderColors: []string{"#ffb7c5", "#ff69b4"},
+		ANSI:         map[string]ansi.BasicColor{"#ffb7c5": ansi.BrightMagenta, "#ff69b4": ansi.Magenta},
+		Particles:    []string{"🌸", "💮", "🌸", "🍡", "🌷", "🦋"},
+		Greeting:     "🌸 The cherry trees are blooming! The shell is full of petals",
+	},
+}
+
+// SeasonAt returns the season the time is in, or nil when it's in none
+func SeasonAt(t time.Time) *Season {
+	today := Day{t.Month(), t.Day()}
+	for i, s := range Seasons {
+		in := !today.before(s.From) && !s.To.before(today)
+		if s.To.before(s.From) {
+			in = !today.before(s.From) || !s.To.before(today)
+		}
+		if in {
+			return &Seasons[i]
+		}
+	}
+	return nil
+}
+
+// season is the season the themes are dressed up for, nil outside of one
+var season *Season
+
+// SetSeason dresses the themes up for the season, or undresses them for
+// nil
+func SetSeason(s *Season) {
+	season = s
+}
+
+// inSeason returns the theme with its borders in the season's style
+func (kt *KawaiiTheme) inSeason() *KawaiiTheme {
+	if season == nil {
+		return kt
+	}
+	theme := *kt
+	theme.ANSI = make(map[string]ansi.BasicColor, len(kt.ANSI)+len(season.ANSI))
+	maps.Copy(theme.ANSI, kt.ANSI)
+	colors := make([]color.Color, len(season.BorderColors))
+	for i, c := range season.BorderColors {
+		colors[i] = lipgloss.Color(c)
+		if light {
+			colors[i] = deepen(colors[i])
+		}
+		if col, ok := makeColor(colors[i]); ok {
+			if basic, ok := season.ANSI[c]; ok {
+				theme.ANSI[col.Hex()] = basic
+			}
+		}
+	}
+
+	for name, style := range theme.Styles.byName() {
+		// borderless styles stay that way, focus keeps standing out and the
+		// states keep the shapes they're told apart by
+		if style.GetBorderStyle() == (lipgloss.Border{}) || name == "focus_ring" ||
+			vision != "" && slices.Contains(stateStyles, name) {
+			continue
+		}
+		*style = style.Border(season.Border).BorderForeground(colors...)
+	}
+	return &theme
+}
@@ -0,0 +1,188 @@
+package themes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"gopkg.in/yaml.v3"
+)
+
+// minContrast is the contrast text has at least with what it's on, what
+// WCAG asks of text
+const minContrast = 4.5
+
+// harmonies are the hues, in degrees from a first one, of the color schemes
+// surprise themes pick from
+var harmonies = [][3]float64{
+	{0, 30, -30},  // analogous
+	{0, 120, 240}, // triadic
+	{0, 150, 210}, // split complementary
+	{0, 180, 30},  // complementary, with a neighbor
+}
+
+// surpriseNames are the words surprise themes are named with
+var surpriseNames = [2][]string{
+	{"Sparkly", "Dreamy", "Fuzzy", "Cozy", "Bubbly", "Starry", "Sleepy", "Peachy", "Misty", "Sugary"},
+	{"Mochi", "Boba", "Petal", "Comet", "Jelly", "Cloud", "Marshmallow", "Lagoon", "Meadow", "Lantern"},
+}
+
+// Surprise makes up a theme file out of the random source: the layout of a
+// built-in theme, in colors of hues that go together, its text reading on
+// its backgrounds at a contrast of at least 4.5:1. The states keep the
+// colors of the base theme with a color vision set.
+func Surprise(r *rand.Rand) File {
+	bases := builtinThemes()
+	base := bases[r.Intn(len(bases))]
+
+	hue := r.Float64() * 360
+	harmony := harmonies[r.Intn(len(harmonies))]
+	chroma := 0.35 + r.Float64()*0.3
+	// dark backgrounds with light colors, or the other way around
+	bgLight, surfaceLight, textLight, accentLight, mutedLight := 0.14, 0.2, 0.92, 0.72, 0.65
+	if light {
+		bgLight, surfaceLight, textLight, accentLight, mutedLight = 0.97, 0.92, 0.2, 0.5, 0.45
+	}
+	bg := colorful.Hcl(hue, 0.06, bgLight).Clamped()
+	surface := colorful.Hcl(hue, 0.08, surfaceLight).Clamped()
+	text := colorful.Hcl(hue, 0.04, textLight).Clamped()
+	muted := colorful.Hcl(hue, 0.1, mutedLight).Clamped()
+	var accents [3]colorful.Color
+	for i, offset := range harmony {
+		accents[i] = colorful.Hcl(hue+offset, chroma, accentLight).Clamped()
+	}
+	state := func(hue float64) colorful.Color {
+		return readable(colorful.Hcl(hue, 0.5, accentLight).Clamped(), bg)
+	}
+	success, warning, failure, info := state(135), state(85), state(25), accents[2]
+
+	file := File{
+		Name:   surpriseNames[0][r.Intn(len(surpriseNames[0]))] + " " + surpriseNames[1][r.Intn(len(surpriseNames[1]))],
+		Base:   base.ID(),
+		Styles: make(map[string]StyleFile),
+		Roles:  map[string]string{"accent": accents[0].Hex(), "muted": readable(muted, bg).Hex(), "info": info.Hex()},
+	}
+	for _, c := range []colorful.Color{accents[0], accents[0].BlendHcl(accents[1], 0.5).Clamped(), accents[1], accents[2]} {
+		file.Gradient = append(file.Gradient, c.Hex())
+	}
+
+	styles := base.Styles.byName()
+	// paint colors the style, its background only when it has one
+	paint := func(name string, fg, background, border colorful.Color) {
+		style := StyleFile{BorderForeground: border.Hex()}
+		on := bg
+		if _, ok := makeColor(styles[name].GetBackground()); ok {
+			style.Background, on = background.Hex(), background
+		}
+		style.Foreground = readable(fg, on).Hex()
+		file.Styles[name] = style
+	}
+	// fill colors the style's background, its text in the color when it
+	// has none
+	fill := func(name string, c colorful.Color) {
+		if _, ok := makeColor(styles[name].GetBackground()); ok {
+			paint(name, bg, c, c)
+			return
+		}
+		paint(name, c, c, c)
+	}
+	paint("prompt", accents[0], surface, accents[1])
+	paint("input", text, surface, accents[0])
+	fill("cursor", accents[0])
+	paint("output_box", text, bg, accents[1])
+	paint("input_box", text, surface, accents[0])
+	paint("command_info", accents[2], surface, accents[2])
+	paint("help", muted, bg, muted)
+	paint("info", accents[2], bg, accents[2])
+	paint("pet", accents[1], bg, accents[1])
+	paint("pet_box", text, surface, accents[1])
+	paint("title", accents[0], surface, accents[0])
+	paint("sparkle", accents[1], surface, accents[1])
+	fill("highlight", accents[1])
+	paint("glow", accents[0], bg, accents[0])
+	paint("rainbow", accents[2], bg, accents[2])
+	paint("floating_box", text, surface, accents[0])
+	paint("focus", accents[1], surface, accents[1])
+	paint("focus_ring", accents[2], bg, accents[2])
+	if vision == "" {
+		fill("success", success)
+		fill("warning", warning)
+		fill("error", failure)
+		paint("exit_success", success, bg, success)
+		paint("exit_failure", failure, bg, failure)
+		file.Roles["success"], file.Roles["warning"], file.Roles["error"] = success.Hex(), warning.Hex(), failure.Hex()
+	}
+	return file
+}
+
+// readable returns the color, lighter or darker as it leans, once it reads
+// on the background
+func readable(c, bg colorful.Color) colorful.Color {
+	h, chroma, l := c.Hcl()
+	_, _, bgL := bg.Hcl()
+	step := 0.04
+	if l < bgL {
+		step = -step
+	}
+	for range 25 {
+		if contrast(c, bg) >= minContrast {
+			return c
+		}
+		l = math.Min(math.Max(l+step, 0), 1)
+		c = colorful.Hcl(h, chroma, l).Clamped()
+	}
+	// there's no room left that way
+	if isDark(bg) {
+		return colorful.Color{R: 1, G: 1, B: 1}
+	}
+	return colorful.Color{}
+}
+
+// contrast is the contrast ratio of the colors, from 1 to 21
+func contrast(a, b colorful.Color) float64 {
+	la, lb := luminance(a), luminance(b)
+	return (math.Max(la, lb) + 0.05) / (math.Min(la, lb) + 0.05)
+}
+
+// luminance is the relative luminance of the color
+func luminance(c colorful.Color) float64 {
+	r, g, b := c.LinearRgb()
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// Save writes the theme file in dir, named after the ID, unless there's a
+// theme by that name already
+func (file File) Save(dir, id string) error {
+	if !themeName.MatchString(id) {
+		return fmt.Errorf("can't name a theme %s, names are lowercase letters, digits, - and _", id)
+	}
+	if builtinTheme(id) != nil {
+		return fmt.Errorf("there's a %s theme already, pick another name", id)
+	}
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, id+".yaml"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("there's a %s theme already, pick another name", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to save theme: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package themes
+
+import "image/color"
+
+// TerminalColors returns the background and the foreground for the
+// terminal to take under the theme, the ones of its output boxes, so they
+// don't float on a background of another color. Both are unset for themes
+// whose boxes have no background.
+func (kt *KawaiiTheme) TerminalColors() (bg, fg color.Color) {
+	s := kt.Styles
+	bg = firstColor(s.OutputBox.GetBackground())
+	if _, ok := makeColor(bg); !ok {
+		return bg, bg
+	}
+	return bg, firstColor(s.OutputBox.GetForeground(), s.Input.GetForeground())
+}
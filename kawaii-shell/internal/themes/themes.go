@@ -2,10 +2,14 @@ package themes
 
 import (
 	"fmt"
-	"math"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/fang"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/exp/charmtone"
 )
 
@@ -15,6 +19,17 @@ type KawaiiTheme struct {
 	Styles         KawaiiStyles
 	GradientColors []string
 	AnimationTime  time.Time
+
+	// ANSI are the colors the theme's colors fall back to on terminals
+	// with the 16 ANSI colors only, by their hex value
+	ANSI map[string]ansi.BasicColor
+
+	// Roles are the theme's colors by what they mean
+	Roles Roles
+
+	// id is the short name of themes loaded from theme files, the name of
+	// the file
+	id string
 }
 
 // KawaiiStyles contains all the stunning kawaii styling
@@ -43,31 +58,57 @@ type KawaiiStyles struct {
 	Glow        lipgloss.Style
 	Rainbow     lipgloss.Style
 	FloatingBox lipgloss.Style
+
+	// Exit code indicators shown next to commands
+	ExitSuccess lipgloss.Style
+	ExitFailure lipgloss.Style
+
+	// Focus is the text of focused buttons, sliders, tabs and dropdowns,
+	// and FocusRing the border around them and focused modals
+	Focus     lipgloss.Style
+	FocusRing lipgloss.Style
 }
 
 // Create animated gradient colors
 func (kt *KawaiiTheme) GetAnimatedGradient() []string {
-	if len(kt.GradientColors) < 2 {
-		return kt.GradientColors
-	}
-	elapsed := time.Since(kt.AnimationTime).Seconds()
-	_ = math.Sin(elapsed*0.5)*0.5 + 0.5
-	return kt.GradientColors
+	return kt.GradientAt(len(kt.GradientColors), time.Now())
 }
 
 // NewSakuraTheme creates the most beautiful sakura theme ever
 func NewSakuraTheme() *KawaiiTheme {
 	gradientColors := []string{
-		string(charmtone.Coral),
-		string(charmtone.Salmon),
-		string(charmtone.Cherry),
-		string(charmtone.Pony),
+		charmtone.Coral.Hex(),
+		charmtone.Salmon.Hex(),
+		charmtone.Cherry.Hex(),
+		charmtone.Pony.Hex(),
 	}
 
 	return &KawaiiTheme{
 		Name:           "Sakura Dreams 🌸✨",
 		GradientColors: gradientColors,
 		AnimationTime:  time.Now(),
+		ANSI: map[string]ansi.BasicColor{
+			charmtone.Coral.Hex():    ansi.BrightMagenta,
+			charmtone.Salmon.Hex():   ansi.BrightRed,
+			charmtone.Cherry.Hex():   ansi.Red,
+			charmtone.Pony.Hex():     ansi.Magenta,
+			charmtone.Cheeky.Hex():   ansi.Magenta,
+			charmtone.Butter.Hex():   ansi.BrightYellow,
+			charmtone.Guac.Hex():     ansi.Green,
+			charmtone.Malibu.Hex():   ansi.BrightBlue,
+			charmtone.Guppy.Hex():    ansi.Blue,
+			charmtone.Charcoal.Hex(): ansi.Black,
+			"#1a0a0a":                ansi.Black,
+			charmtone.Squid.Hex():    ansi.BrightBlack,
+		},
+		Roles: Roles{
+			Success: charmtone.Guac,
+			Warning: charmtone.Butter,
+			Error:   charmtone.Cherry,
+			Info:    charmtone.Malibu,
+			Accent:  charmtone.Coral,
+			Muted:   charmtone.Squid,
+		},
 		Styles: KawaiiStyles{
 			// Enhanced prompt with glow effect
 			Prompt: lipgloss.NewStyle().
@@ -99,13 +140,13 @@ func NewSakuraTheme() *KawaiiTheme {
 				BorderForeground(charmtone.Salmon).
 				Background(lipgloss.Color("#fff5f5")).
 				Foreground(charmtone.Charcoal).
-				MarginBottom(1).
+				MarginBottom(1),
 
 			InputBox: lipgloss.NewStyle().
 				Padding(1, 2).
 				Border(lipgloss.DoubleBorder()).
 				BorderForeground(charmtone.Coral).
-				Background(lipgloss.Color("#ffe8e8")).
+				Background(lipgloss.Color("#ffe8e8")),
 
 			CommandInfo: lipgloss.NewStyle().
 				Foreground(charmtone.Malibu).
@@ -205,6 +246,22 @@ func NewSakuraTheme() *KawaiiTheme {
 				Background(lipgloss.Color("#fff8f8")).
 				Padding(1, 2).
 				Align(lipgloss.Center),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(charmtone.Guac).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(charmtone.Cherry).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(charmtone.Malibu),
 		},
 	}
 }
@@ -223,6 +280,31 @@ func NewGalaxyTheme() *KawaiiTheme {
 		Name:           "Galaxy Dreams 🌌⭐",
 		GradientColors: gradientColors,
 		AnimationTime:  time.Now(),
+		ANSI: map[string]ansi.BasicColor{
+			"#ff66ff": ansi.BrightMagenta,
+			"#8000ff": ansi.Magenta,
+			"#6600cc": ansi.Blue,
+			"#4d0080": ansi.Blue,
+			"#e6ccff": ansi.White,
+			"#ccccff": ansi.BrightWhite,
+			"#00ffff": ansi.BrightCyan,
+			"#99ffcc": ansi.BrightGreen,
+			"#ffcc99": ansi.BrightYellow,
+			"#ffff00": ansi.BrightYellow,
+			"#00ff66": ansi.BrightGreen,
+			"#ff3366": ansi.BrightRed,
+			"#1a0033": ansi.Black,
+			"#0d001a": ansi.Black,
+			"#8877aa": ansi.BrightBlack,
+		},
+		Roles: Roles{
+			Success: lipgloss.Color("#00ff66"),
+			Warning: lipgloss.Color("#ffff00"),
+			Error:   lipgloss.Color("#ff3366"),
+			Info:    lipgloss.Color("#00ffff"),
+			Accent:  lipgloss.Color("#ff66ff"),
+			Muted:   lipgloss.Color("#8877aa"),
+		},
 		Styles: KawaiiStyles{
 			Prompt: lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#ff66ff")).
@@ -250,13 +332,13 @@ func NewGalaxyTheme() *KawaiiTheme {
 				BorderForeground(lipgloss.Color("#6600cc")).
 				Background(lipgloss.Color("#0d001a")).
 				Foreground(lipgloss.Color("#ccccff")).
-				MarginBottom(1).
+				MarginBottom(1),
 
 			InputBox: lipgloss.NewStyle().
 				Padding(1, 2).
 				Border(lipgloss.DoubleBorder()).
 				BorderForeground(lipgloss.Color("#ff66ff")).
-				Background(lipgloss.Color("#1a0033")).
+				Background(lipgloss.Color("#1a0033")),
 
 			CommandInfo: lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#00ffff")).
@@ -324,6 +406,22 @@ func NewGalaxyTheme() *KawaiiTheme {
 				Foreground(lipgloss.Color("#ff66ff")).
 				Bold(true).
 				Italic(true),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00ff66")).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#ff3366")).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(lipgloss.Color("#00ffff")),
 		},
 	}
 }
@@ -341,6 +439,25 @@ func NewCyberTheme() *KawaiiTheme {
 		Name:           "Cyber Kawaii 🤖💫",
 		GradientColors: gradientColors,
 		AnimationTime:  time.Now(),
+		ANSI: map[string]ansi.BasicColor{
+			"#00ff80": ansi.BrightGreen,
+			"#00cc66": ansi.Green,
+			"#ccffcc": ansi.BrightWhite,
+			"#ff0080": ansi.BrightMagenta,
+			"#0080ff": ansi.BrightBlue,
+			"#8000ff": ansi.Magenta,
+			"#001a00": ansi.Black,
+			"#ffff00": ansi.BrightYellow,
+			"#ff0040": ansi.BrightRed,
+		},
+		Roles: Roles{
+			Success: lipgloss.Color("#00ff80"),
+			Warning: lipgloss.Color("#ffff00"),
+			Error:   lipgloss.Color("#ff0040"),
+			Info:    lipgloss.Color("#0080ff"),
+			Accent:  lipgloss.Color("#ff0080"),
+			Muted:   lipgloss.Color("#00cc66"),
+		},
 		Styles: KawaiiStyles{
 			Prompt: lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#00ff80")).
@@ -388,6 +505,22 @@ func NewCyberTheme() *KawaiiTheme {
 				Background(lipgloss.Color("#ff0080")).
 				Bold(true).
 				Blink(true),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00ff00")).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#ff0040")).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.DoubleBorder()).
+				BorderForeground(lipgloss.Color("#ff0080")),
 		},
 	}
 }
@@ -395,8 +528,8 @@ func NewCyberTheme() *KawaiiTheme {
 // NewOceanTheme creates an enhanced ocean theme
 func NewOceanTheme() *KawaiiTheme {
 	gradientColors := []string{
-		string(charmtone.Malibu),
-		string(charmtone.Guppy),
+		charmtone.Malibu.Hex(),
+		charmtone.Guppy.Hex(),
 		"#0066cc",
 		"#004499",
 	}
@@ -405,6 +538,27 @@ func NewOceanTheme() *KawaiiTheme {
 		Name:           "Ocean Breeze 🌊🐚",
 		GradientColors: gradientColors,
 		AnimationTime:  time.Now(),
+		ANSI: map[string]ansi.BasicColor{
+			charmtone.Malibu.Hex():   ansi.BrightBlue,
+			charmtone.Guppy.Hex():    ansi.Blue,
+			charmtone.Guac.Hex():     ansi.Green,
+			charmtone.Charcoal.Hex(): ansi.Black,
+			"#0066cc":                ansi.Blue,
+			"#004499":                ansi.Cyan,
+			"#00cc99":                ansi.Cyan,
+			"#ff6666":                ansi.BrightRed,
+			"#001a33":                ansi.Black,
+			charmtone.Butter.Hex():   ansi.BrightYellow,
+			charmtone.Squid.Hex():    ansi.BrightBlack,
+		},
+		Roles: Roles{
+			Success: lipgloss.Color("#00cc99"),
+			Warning: charmtone.Butter,
+			Error:   lipgloss.Color("#ff6666"),
+			Info:    charmtone.Guppy,
+			Accent:  charmtone.Malibu,
+			Muted:   charmtone.Squid,
+		},
 		Styles: KawaiiStyles{
 			Prompt: lipgloss.NewStyle().
 				Foreground(charmtone.Malibu).
@@ -425,7 +579,7 @@ func NewOceanTheme() *KawaiiTheme {
 				Border(lipgloss.ThickBorder()).
 				BorderForeground(charmtone.Guppy).
 				Background(lipgloss.Color("#f0f8ff")).
-				Foreground(charmtone.Charcoal).
+				Foreground(charmtone.Charcoal),
 
 			Pet: lipgloss.NewStyle().
 				Foreground(charmtone.Guac).
@@ -436,7 +590,23 @@ func NewOceanTheme() *KawaiiTheme {
 				BorderForeground(charmtone.Guppy).
 				Background(lipgloss.Color("#e6f3ff")).
 				Padding(1, 2).
-				Align(lipgloss.Center).
+				Align(lipgloss.Center),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#00cc99")).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#ff6666")).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(lipgloss.Color("#00cc99")),
 		},
 	}
 }
@@ -457,6 +627,27 @@ func NewRainbowTheme() *KawaiiTheme {
 		Name:           "Rainbow Magic 🌈✨",
 		GradientColors: gradientColors,
 		AnimationTime:  time.Now(),
+		ANSI: map[string]ansi.BasicColor{
+			"#ff0000":              ansi.BrightRed,
+			"#ff8000":              ansi.Yellow,
+			"#ffff00":              ansi.BrightYellow,
+			"#00ff00":              ansi.BrightGreen,
+			"#0000ff":              ansi.BrightBlue,
+			"#8000ff":              ansi.Magenta,
+			"#ff00ff":              ansi.BrightMagenta,
+			charmtone.Guac.Hex():   ansi.Green,
+			charmtone.Cherry.Hex(): ansi.Red,
+			"#0080ff":              ansi.BrightBlue,
+			charmtone.Squid.Hex():  ansi.BrightBlack,
+		},
+		Roles: Roles{
+			Success: charmtone.Guac,
+			Warning: lipgloss.Color("#ffff00"),
+			Error:   charmtone.Cherry,
+			Info:    lipgloss.Color("#0080ff"),
+			Accent:  lipgloss.Color("#ff00ff"),
+			Muted:   charmtone.Squid,
+		},
 		Styles: KawaiiStyles{
 			Prompt: lipgloss.NewStyle().
 				Bold(true).
@@ -467,7 +658,7 @@ func NewRainbowTheme() *KawaiiTheme {
 			OutputBox: lipgloss.NewStyle().
 				Padding(2, 3).
 				Border(lipgloss.ThickBorder()).
-				Background(lipgloss.Color("#fefefe")).
+				Background(lipgloss.Color("#fefefe")),
 
 			Rainbow: lipgloss.NewStyle().
 				Bold(true),
@@ -475,6 +666,22 @@ func NewRainbowTheme() *KawaiiTheme {
 			Sparkle: lipgloss.NewStyle().
 				Bold(true).
 				Blink(true),
+
+			// Inline exit code indicators
+			ExitSuccess: lipgloss.NewStyle().
+				Foreground(charmtone.Guac).
+				Bold(true),
+			ExitFailure: lipgloss.NewStyle().
+				Foreground(charmtone.Cherry).
+				Bold(true),
+
+			// Focus indicators of the interactive components
+			Focus: lipgloss.NewStyle().
+				Bold(true).
+				Underline(true),
+			FocusRing: lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(lipgloss.Color("#ff00ff")),
 		},
 	}
 }
@@ -484,25 +691,19 @@ func (kt *KawaiiTheme) ApplyRainbowEffect(text string) string {
 	if kt.Name != "Rainbow Magic 🌈✨" {
 		return text
 	}
-
-	colors := []string{
-		"#ff0000", // Red
-		"#ff8000", // Orange
-		"#ffff00", // Yellow
-		"#00ff00", // Green
-		"#0080ff", // Blue
-		"#8000ff", // Purple
-	}
-
-	result := ""
-	for i, char := range text {
-		color := colors[i%len(colors)]
-		styledChar := lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(string(char))
-		result += styledChar
-	}
-	return result
+	return magicRainbow.Paint(text, 0)
 }
 
+// magicRainbow is the rainbow of the Rainbow Magic theme
+var magicRainbow = NewRainbow(lipgloss.NewStyle().Bold(true),
+	lipgloss.Color("#ff0000"), // Red
+	lipgloss.Color("#ff8000"), // Orange
+	lipgloss.Color("#ffff00"), // Yellow
+	lipgloss.Color("#00ff00"), // Green
+	lipgloss.Color("#0080ff"), // Blue
+	lipgloss.Color("#8000ff"), // Purple
+)
+
 // CreateSparkleText creates sparkling animated text
 func (kt *KawaiiTheme) CreateSparkleText(text string) string {
 	sparkles := []string{"✨", "⭐", "💫", "🌟", "⚡", "💎"}
@@ -546,13 +747,68 @@ func (kt *KawaiiTheme) Update() {
 	kt.AnimationTime = time.Now()
 }
 
-// GetThemes returns all available stunning themes
+// GetThemes returns all available stunning themes, the built-in ones and
+// then the ones loaded from theme files, dressed up for the season, with
+// the overridden styles and their ANSI fallbacks on terminals with 16
+// colors
 func GetThemes() []*KawaiiTheme {
-	return []*KawaiiTheme{
+	themes := slices.DeleteFunc(builtinThemes(), func(theme *KawaiiTheme) bool {
+		return slices.ContainsFunc(custom, func(t *KawaiiTheme) bool { return t.ID() == theme.ID() })
+	})
+	themes = append(themes, custom...)
+	for i, theme := range themes {
+		themes[i] = theme.Dressed()
+	}
+	return themes
+}
+
+// Dressed returns the theme as the shell shows it, like GetThemes returns
+// them, for themes that aren't among them
+func (kt *KawaiiTheme) Dressed() *KawaiiTheme {
+	theme := kt.inSeason().withOverrides()
+	if profile == colorprofile.ANSI {
+		return theme.Basic()
+	}
+	return theme
+}
+
+// builtinThemes returns the themes bundled in and the one in fang's colors,
+// their variants for the color vision and their light variants on light
+// backgrounds
+func builtinThemes() []*KawaiiTheme {
+	themes := []*KawaiiTheme{
 		NewSakuraTheme(),
 		NewGalaxyTheme(),
 		NewCyberTheme(),
 		NewOceanTheme(),
 		NewRainbowTheme(),
 	}
+	for i, theme := range themes {
+		themes[i] = theme.ForColorVision(vision)
+		if light {
+			themes[i] = themes[i].Light()
+		}
+	}
+	// fang has light colors of its own
+	fangTheme := FromFang(fang.DefaultColorScheme(lipgloss.LightDark(!light)))
+	return append(themes, fangTheme.ForColorVision(vision))
+}
+
+// ID returns the short name the theme is picked by, like ocean
+func (kt *KawaiiTheme) ID() string {
+	if kt.id != "" {
+		return kt.id
+	}
+	id, _, _ := strings.Cut(kt.Name, " ")
+	return strings.ToLower(id)
+}
+
+// GetTheme returns the theme by its ID, or nil when there's no such theme
+func GetTheme(id string) *KawaiiTheme {
+	for _, theme := range GetThemes() {
+		if strings.EqualFold(theme.ID(), id) {
+			return theme
+		}
+	}
+	return nil
 }
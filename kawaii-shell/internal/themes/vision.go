@@ -0,0 +1,113 @@
+package themes
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/charmtone"
+)
+
+// ColorVision is how colors are seen by people with a kind of color
+// blindness, the built-in themes having a variant for each
+type ColorVision string
+
+const (
+	// Deuteranopia and Protanopia mix up reds and greens, Tritanopia blues
+	// and greens, and yellows and violets
+	Deuteranopia ColorVision = "deuteranopia"
+	Protanopia   ColorVision = "protanopia"
+	Tritanopia   ColorVision = "tritanopia"
+)
+
+// statePalette are the colors of success, warning, error and info, told
+// apart by their lightness as well as their hue
+type statePalette struct {
+	success, warning, err, info string
+
+	// ansi are the ANSI fallbacks of the colors
+	ansi map[string]ansi.BasicColor
+}
+
+// statePalettes are the colors states take with each color vision. They
+// were picked from the Okabe-Ito and IBM colors to stay far apart once
+// seen like that.
+var statePalettes = map[ColorVision]statePalette{
+	Deuteranopia: {
+		success: "#005ab5", warning: "#f0e442", err: "#c62828", info: "#9c7fe0",
+		ansi: map[string]ansi.BasicColor{"#005ab5": ansi.Blue, "#f0e442": ansi.BrightYellow, "#c62828": ansi.Red, "#9c7fe0": ansi.BrightMagenta},
+	},
+	Protanopia: {
+		success: "#005ab5", warning: "#f0e442", err: "#c62828", info: "#56b4e9",
+		ansi: map[string]ansi.BasicColor{"#005ab5": ansi.Blue, "#f0e442": ansi.BrightYellow, "#c62828": ansi.Red, "#56b4e9": ansi.BrightCyan},
+	},
+	Tritanopia: {
+		success: "#00897b", warning: "#f0e442", err: "#aa3377", info: "#9c7fe0",
+		ansi: map[string]ansi.BasicColor{"#00897b": ansi.Cyan, "#f0e442": ansi.BrightYellow, "#aa3377": ansi.Magenta, "#9c7fe0": ansi.BrightBlue},
+	},
+}
+
+// vision is the color vision the built-in themes are made for, the usual
+// one when it's empty
+var vision ColorVision
+
+// SetColorVision switches the built-in themes to their variants for the
+// color vision, or back for an empty one. Like with SetLight, it's set
+// before theme files starting from a built-in theme are loaded.
+func SetColorVision(v ColorVision) error {
+	if _, ok := statePalettes[v]; !ok && v != "" {
+		return fmt.Errorf("there's no %s color vision, try deuteranopia, protanopia or tritanopia", v)
+	}
+	vision = v
+	return nil
+}
+
+// ForColorVision returns the variant of the theme for the color vision.
+// Success, warning and error take colors that stay apart with it, and each
+// gets a shape of its own too: a rounded border for success, a double one
+// and bold text for warnings, and a thick one and underlined bold text for
+// errors.
+func (kt *KawaiiTheme) ForColorVision(v ColorVision) *KawaiiTheme {
+	palette, ok := statePalettes[v]
+	if !ok {
+		return kt
+	}
+	theme := *kt
+	theme.ANSI = make(map[string]ansi.BasicColor, len(kt.ANSI)+len(palette.ansi))
+	maps.Copy(theme.ANSI, kt.ANSI)
+	maps.Copy(theme.ANSI, palette.ansi)
+
+	success, warning, err := lipgloss.Color(palette.success), lipgloss.Color(palette.warning), lipgloss.Color(palette.err)
+	theme.Roles.Success, theme.Roles.Warning, theme.Roles.Error = success, warning, err
+	theme.Roles.Info = lipgloss.Color(palette.info)
+
+	s := &theme.Styles
+	s.Success = stateStyle(s.Success, success, lipgloss.RoundedBorder()).Underline(false)
+	s.Warning = stateStyle(s.Warning, warning, lipgloss.DoubleBorder()).Bold(true).Underline(false)
+	s.Error = stateStyle(s.Error, err, lipgloss.ThickBorder()).Bold(true).Underline(true)
+	s.ExitSuccess = s.ExitSuccess.Foreground(success)
+	s.ExitFailure = s.ExitFailure.Foreground(err).Bold(true)
+	return &theme
+}
+
+// stateStyle returns the style in the color of the state, on it when the
+// style has a background, with the border of the state when it has one
+func stateStyle(style lipgloss.Style, c color.Color, border lipgloss.Border) lipgloss.Style {
+	if style.GetBorderStyle() != (lipgloss.Border{}) {
+		style = style.BorderStyle(border).BorderForeground(c)
+	}
+	if _, ok := makeColor(style.GetBackground()); !ok {
+		return style.Foreground(c)
+	}
+	text := color.Color(charmtone.Salt)
+	if col, ok := makeColor(c); ok && !isDark(col) {
+		text = charmtone.Pepper
+	}
+	return style.Background(c).Foreground(text)
+}
+
+// stateStyles are the styles keeping their shapes through the seasons
+// while themes are made for a color vision
+var stateStyles = []string{"success", "warning", "error"}
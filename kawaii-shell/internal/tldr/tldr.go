@@ -0,0 +1,148 @@
+// Package tldr explains commands with the tldr-style pages bundled in the
+// binary
+package tldr
+
+import (
+	"bufio"
+	"embed"
+	"slices"
+	"strings"
+)
+
+//go:embed pages/*.md
+var pages embed.FS
+
+// Page is a tldr page
+type Page struct {
+	Name        string
+	Description string
+	Examples    []Example
+}
+
+// Example is a command from a page with what it does
+type Example struct {
+	Description string
+	Command     string
+}
+
+// Flag is a flag of the explained command with what it does
+type Flag struct {
+	Flag        string
+	Description string
+}
+
+// Explanation is the breakdown of a command
+type Explanation struct {
+	Page *Page
+
+	// Flags are the flags of the command the page knows about
+	Flags []Flag
+
+	// Unknown are the flags of the command the page doesn't mention
+	Unknown []string
+}
+
+// Lookup returns the page for a command, such as "ls" or "git push"
+func Lookup(name string) (*Page, bool) {
+	data, err := pages.ReadFile("pages/" + strings.ReplaceAll(name, " ", "-") + ".md")
+	if err != nil {
+		return nil, false
+	}
+	return parse(string(data)), true
+}
+
+// Explain breaks the command down, using the page of its subcommand when
+// there's one, like "git push"
+func Explain(command string) (Explanation, bool) {
+	words := strings.Fields(command)
+	if len(words) == 0 {
+		return Explanation{}, false
+	}
+
+	// skip sudo, unless it's all there is
+	if words[0] == "sudo" && len(words) > 1 {
+		words = words[1:]
+	}
+
+	var page *Page
+	var ok bool
+	if len(words) > 1 && !strings.HasPrefix(words[1], "-") {
+		page, ok = Lookup(words[0] + " " + words[1])
+	}
+	if !ok {
+		page, ok = Lookup(words[0])
+	}
+	if !ok {
+		return Explanation{}, false
+	}
+
+	explanation := Explanation{Page: page}
+	for _, word := range words[1:] {
+		if !strings.HasPrefix(word, "-") || word == "-" || word == "--" {
+			continue
+		}
+		flag, _, _ := strings.Cut(word, "=")
+		for _, f := range splitFlags(flag) {
+			if description, found := page.describe(f); found {
+				explanation.Flags = append(explanation.Flags, Flag{f, description})
+			} else if !slices.Contains(explanation.Unknown, f) {
+				explanation.Unknown = append(explanation.Unknown, f)
+			}
+		}
+	}
+	return explanation, true
+}
+
+// splitFlags splits combined short flags, like -rf
+func splitFlags(flag string) []string {
+	if strings.HasPrefix(flag, "--") || len(flag) <= 2 {
+		return []string{flag}
+	}
+	flags := make([]string, 0, len(flag)-1)
+	for _, c := range flag[1:] {
+		flags = append(flags, "-"+string(c))
+	}
+	return flags
+}
+
+// describe returns the description of the first example using the flag,
+// preferring examples where it's on its own
+func (p *Page) describe(flag string) (string, bool) {
+	for _, example := range p.Examples {
+		if slices.Contains(strings.Fields(example.Command), flag) {
+			return example.Description, true
+		}
+	}
+	for _, example := range p.Examples {
+		for _, word := range strings.Fields(example.Command) {
+			if strings.HasPrefix(word, "-") && !strings.HasPrefix(word, "--") &&
+				slices.Contains(splitFlags(word), flag) {
+				return example.Description, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parse parses a page in the tldr markdown format
+func parse(data string) *Page {
+	page := &Page{}
+	var description string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "# "):
+			page.Name = strings.TrimPrefix(line, "# ")
+		case strings.HasPrefix(line, "> "):
+			page.Description = strings.TrimSpace(page.Description + " " + strings.TrimPrefix(line, "> "))
+		case strings.HasPrefix(line, "- "):
+			description = strings.TrimSuffix(strings.TrimPrefix(line, "- "), ":")
+		case strings.HasPrefix(line, "`") && strings.HasSuffix(line, "`") && len(line) > 1:
+			command := strings.Trim(line, "`")
+			command = strings.NewReplacer("{{", "", "}}", "").Replace(command)
+			page.Examples = append(page.Examples, Example{description, command})
+		}
+	}
+	return page
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// abilityWidth is the widest ability widgets get
+const abilityWidth = 24
+
+// ability is something practical a type of pet does for the user, on top
+// of keeping them company. Abilities embed noAbility and implement only
+// what they use.
+type ability interface {
+	// name tells what the ability is, for the pet status
+	name() string
+
+	// tick keeps the ability up to date, every tick
+	tick(a *App, now time.Time)
+
+	// finished lets the ability see each command the shell finished
+	finished(a *App, command string, code int, took time.Duration)
+
+	// view renders the lines of the ability's sidebar widget, none to hide
+	// it
+	view(a *App) []string
+
+	// prompt dresses up the rendered prompt
+	prompt(prompt string) string
+}
+
+// noAbility does nothing, for abilities to embed
+type noAbility struct{}
+
+func (noAbility) tick(*App, time.Time)                      {}
+func (noAbility) finished(*App, string, int, time.Duration) {}
+func (noAbility) view(*App) []string                        { return nil }
+func (noAbility) prompt(prompt string) string               { return prompt }
+func (noAbility) name() string                              { return "" }
+
+// abilities make the ability of each pet type that has one
+var abilities = map[pet.PetType]func() ability{
+	pet.TypeRobot:   func() ability { return &systemMonitor{} },
+	pet.TypeCat:     func() ability { return gitNotes{} },
+	pet.TypeDragon:  func() ability { return &buildWatch{} },
+	pet.TypeUnicorn: func() ability { return rainbowPrompt{} },
+}
+
+// petAbility returns the ability of the companion, made again when another
+// kind of pet becomes the companion. Pets without one get noAbility.
+func (a *App) petAbility() ability {
+	if a.ability == nil || a.abilityType != a.pet.Type {
+		a.ability, a.abilityType = noAbility{}, a.pet.Type
+		if newAbility, ok := abilities[a.pet.Type]; ok {
+			a.ability = newAbility()
+		}
+	}
+	return a.ability
+}
+
+// abilityView renders the sidebar widget of the companion's ability, empty
+// when it has nothing to show
+func (a *App) abilityView() string {
+	lines := a.petAbility().view(a)
+	if len(lines) == 0 {
+		return ""
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(a.petText(line), abilityWidth, "…")
+	}
+	return a.theme.Styles.PetBox.
+		Height(petHeight).
+		Render(a.theme.Styles.Info.Render(strings.Join(lines, "\n")))
+}
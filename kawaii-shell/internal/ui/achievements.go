@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+const (
+	celebrationModalWidth  = 48
+	celebrationModalHeight = 14
+)
+
+// celebration is the modal cheering for an achievement, the ones earned at
+// the same time waiting their turn
+type celebration struct {
+	modal   *components.Modal
+	pending []pet.Achievement
+}
+
+// achievementsPanel lists the achievements in place of the output
+type achievementsPanel struct {
+	selected int
+}
+
+// checkAchievements celebrates the achievements earned since the last tick
+func (a *App) checkAchievements(now time.Time) {
+	earned := a.pets.CheckAchievements(now)
+	for _, achievement := range earned {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"🏆 Achievement unlocked: %s %s", achievement.Emoji, achievement.Name)))
+	}
+	if len(earned) == 0 {
+		return
+	}
+	if a.celebration != nil {
+		a.celebration.pending = append(a.celebration.pending, earned...)
+		return
+	}
+	a.celebrate(earned[0], earned[1:])
+}
+
+// celebrate opens the modal cheering for the achievement
+func (a *App) celebrate(achievement pet.Achievement, pending []pet.Achievement) {
+	lines := []string{
+		achievement.Emoji + " " + achievement.Name,
+		achievement.Description,
+		"",
+		fmt.Sprintf("✨ +%d XP for %s", achievement.XP, a.pet.Name),
+	}
+	if accessory, ok := pet.FindAccessory(achievement.Reward); ok {
+		lines = append(lines, fmt.Sprintf("🎁 %s %s for the wardrobe", accessory.Emoji, accessory.Name))
+	}
+	modal := components.NewModal("🏆 Achievement unlocked!", strings.Join(lines, "\n"),
+		celebrationModalWidth, celebrationModalHeight)
+	yay := components.NewButton("🎉 Yay!", 0, 0, 14)
+	yay.OnClick = a.nextCelebration
+	modal.AddButton(yay)
+	yay.Focus()
+	modal.Focus()
+	modal.Show()
+	a.celebration = &celebration{modal: modal, pending: pending}
+}
+
+// nextCelebration closes the modal, opening it for the next achievement
+// if there's one
+func (a *App) nextCelebration() {
+	c := a.celebration
+	if c == nil {
+		return
+	}
+	c.modal.Hide()
+	a.celebration = nil
+	if len(c.pending) > 0 {
+		a.celebrate(c.pending[0], c.pending[1:])
+	}
+}
+
+// handleCelebrationKey handles the keys while an achievement is celebrated,
+// nothing else gets them until it's closed
+func (a *App) handleCelebrationKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		_, cmd := a.celebration.modal.Update(msg)
+		return cmd
+	case "esc", " ":
+		a.nextCelebration()
+	}
+	return nil
+}
+
+// handleAchievementsKey handles the keys of the achievements list, nothing
+// else gets them until it's closed
+func (a *App) handleAchievementsKey(msg tea.KeyMsg) {
+	p := a.achievements
+	switch msg.String() {
+	case "esc", "q", "enter":
+		a.achievements = nil
+		return
+	case "up", "k", "ctrl+p":
+		p.selected--
+	case "down", "j", "ctrl+n":
+		p.selected++
+	}
+	p.selected = min(max(p.selected, 0), len(pet.Achievements)-1)
+}
+
+// achievementsView renders the achievements, when the earned ones were
+// and how far along the others are
+func (a *App) achievementsView(height int) string {
+	cols, _ := a.outputSize()
+	earned := 0
+	for _, achievement := range pet.Achievements {
+		if _, ok := a.pets.Achieved(achievement); ok {
+			earned++
+		}
+	}
+	lines := []string{a.theme.Styles.Help.Render(fmt.Sprintf("🏆 Achievements • %d of %d • 🔥 %d day streak",
+		earned, len(pet.Achievements), a.pets.Milestones.DayStreak))}
+
+	rows := max(a.outputRows(height)-1, 1)
+	start := max(a.achievements.selected-rows+1, 0)
+	for i, achievement := range pet.Achievements[start:min(start+rows, len(pet.Achievements))] {
+		when, ok := a.pets.Achieved(achievement)
+		var line string
+		if ok {
+			line = fmt.Sprintf("%s %s · %s · %s", achievement.Emoji, achievement.Name, achievement.Description, when.Format("Jan 2, 2006"))
+		} else {
+			done, total := achievement.Progress(a.pets)
+			line = fmt.Sprintf("🔒 %s · %s · %d/%d", achievement.Name, achievement.Description, done, total)
+		}
+		if accessory, ok := pet.FindAccessory(achievement.Reward); ok {
+			line += " · 🎁 " + accessory.Emoji
+		}
+		if start+i == a.achievements.selected {
+			lines = append(lines, a.theme.Styles.Highlight.Render(ansi.Truncate("▶ "+line, cols-2, "…")))
+			continue
+		}
+		if !ok {
+			line = lipgloss.NewStyle().Faint(true).Render(line)
+		}
+		lines = append(lines, ansi.Truncate("  "+line, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// achievementsInputView renders the help of the achievements list in place
+// of the input
+func (a *App) achievementsInputView() string {
+	return a.theme.Styles.Prompt.Render("🏆 achievements") +
+		lipgloss.NewStyle().Faint(true).Render("  ↑↓ scroll • esc close")
+}
@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+const (
+	adoptModalWidth  = 48
+	adoptModalHeight = 22
+	adoptFieldWidth  = 30
+	adoptButtonWidth = 18
+)
+
+// the fields of the adoption wizard, in the order tab goes through them
+const (
+	adoptFieldType = iota
+	adoptFieldName
+	adoptFieldTrait
+	adoptFieldAdopt
+	adoptFieldSkip
+	adoptFields
+)
+
+// adoptionWizard asks for the first pet on the first run: what it is, its
+// name and what its personality leans towards
+type adoptionWizard struct {
+	modal  *components.Modal
+	types  *components.Dropdown
+	traits *components.Dropdown
+	name   string
+	focus  int
+
+	// quizzing is set while the personality quiz is on, quiz holding the
+	// answers so far and choice the answer picked for the current question
+	quizzing bool
+	quiz     []int
+	choice   int
+}
+
+// adoptQuiz is picked in place of a trait to take the personality quiz
+type adoptQuiz struct{}
+
+// openAdoptionWizard shows the adoption wizard, the type having the focus
+func (a *App) openAdoptionWizard() {
+	types := components.NewDropdown("🐾 Who's coming home?", 0, 0, adoptFieldWidth)
+	for _, t := range pet.Types() {
+		types.AddOption(t.Icon()+" "+t.String(), t)
+	}
+	traits := components.NewDropdown("✨ What are they like?", 0, 0, adoptFieldWidth)
+	for _, t := range pet.Traits() {
+		traits.AddOption(t.Emoji()+" "+t.String(), t)
+	}
+	traits.AddOption("📝 Let's find out (quiz)", adoptQuiz{})
+
+	modal := components.NewModal("🏡 Adopt a pet", "", adoptModalWidth, adoptModalHeight)
+	adopt := components.NewButton("💕 Adopt", 0, 0, adoptButtonWidth)
+	adopt.OnClick = a.adoptFirstPet
+	modal.AddButton(adopt)
+	skip := components.NewButton("🐱 Keep Neko", 0, 0, adoptButtonWidth)
+	skip.OnClick = a.keepFirstPet
+	modal.AddButton(skip)
+	types.Focus()
+	modal.Focus()
+	modal.Show()
+
+	a.adoption = &adoptionWizard{modal: modal, types: types, traits: traits}
+}
+
+// petType returns the type picked in the wizard
+func (w *adoptionWizard) petType() pet.PetType {
+	t, _ := w.types.GetSelectedValue().(pet.PetType)
+	return t
+}
+
+// petName returns the name typed in the wizard, or the one suggested for
+// the type
+func (w *adoptionWizard) petName() string {
+	if name := strings.TrimSpace(w.name); name != "" {
+		return name
+	}
+	return w.petType().SuggestedName()
+}
+
+// setFocus moves the focus to the field
+func (w *adoptionWizard) setFocus(field int) {
+	w.types.Blur()
+	w.traits.Blur()
+	for _, button := range w.modal.Buttons {
+		button.Blur()
+	}
+	w.focus = (field + adoptFields) % adoptFields
+	switch w.focus {
+	case adoptFieldType:
+		w.types.Focus()
+	case adoptFieldTrait:
+		w.traits.Focus()
+	case adoptFieldAdopt, adoptFieldSkip:
+		w.modal.Buttons[w.focus-adoptFieldAdopt].Focus()
+	}
+}
+
+// handleAdoptionKey handles the keys of the adoption wizard, nothing else
+// gets them until the pet is home
+func (a *App) handleAdoptionKey(msg tea.KeyMsg) tea.Cmd {
+	w := a.adoption
+	if w.quizzing {
+		a.handleQuizKey(msg)
+		return nil
+	}
+	switch msg.String() {
+	case "tab", "down":
+		if !w.types.Open && !w.traits.Open || msg.String() == "tab" {
+			w.setFocus(w.focus + 1)
+			return nil
+		}
+	case "shift+tab", "up":
+		if !w.types.Open && !w.traits.Open || msg.String() == "shift+tab" {
+			w.setFocus(w.focus - 1)
+			return nil
+		}
+	case "esc":
+		if !w.types.Open && !w.traits.Open {
+			a.keepFirstPet()
+			return nil
+		}
+	}
+
+	switch w.focus {
+	case adoptFieldType:
+		_, cmd := w.types.Update(msg)
+		return cmd
+	case adoptFieldTrait:
+		_, cmd := w.traits.Update(msg)
+		return cmd
+	case adoptFieldName:
+		switch msg.String() {
+		case "enter":
+			w.setFocus(w.focus + 1)
+		case "backspace":
+			_, size := utf8.DecodeLastRuneInString(w.name)
+			w.name = w.name[:len(w.name)-size]
+		case "ctrl+u":
+			w.name = ""
+		case "ctrl+r":
+			if names := a.pets.SuggestNames(w.petType(), 1); len(names) > 0 {
+				w.name = names[0]
+			}
+		default:
+			if (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) && utf8.RuneCountInString(w.name) < pet.MaxNameLength {
+				w.name += string(msg.Runes)
+			}
+		}
+		return nil
+	}
+	_, cmd := w.modal.Update(msg)
+	return cmd
+}
+
+// handleQuizKey handles the keys of the personality quiz: the arrows or
+// numbers pick an answer and enter gives it, esc goes back to the wizard
+func (a *App) handleQuizKey(msg tea.KeyMsg) {
+	w := a.adoption
+	answers := len(pet.Quiz[len(w.quiz)].Answers)
+	switch key := msg.String(); key {
+	case "up", "shift+tab", "k":
+		w.choice = (w.choice + answers - 1) % answers
+	case "down", "tab", "j":
+		w.choice = (w.choice + 1) % answers
+	case "esc":
+		w.quizzing, w.quiz, w.choice = false, nil, 0
+	case "enter":
+		a.answerQuiz(w.choice)
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= answers {
+			a.answerQuiz(n - 1)
+		}
+	}
+}
+
+// answerQuiz gives the answer to the current quiz question, bringing the
+// pet home after the last one
+func (a *App) answerQuiz(answer int) {
+	w := a.adoption
+	w.quiz, w.choice = append(w.quiz, answer), 0
+	if len(w.quiz) == len(pet.Quiz) {
+		a.adoptFirstPet()
+	}
+}
+
+// adoptFirstPet brings the pet picked in the wizard home, in place of Neko.
+// Taking the quiz starts it first.
+func (a *App) adoptFirstPet() {
+	w := a.adoption
+	if w == nil {
+		return
+	}
+	trait, _ := w.traits.GetSelectedValue().(pet.Trait)
+	personality := pet.NewPersonality(trait)
+	if _, ok := w.traits.GetSelectedValue().(adoptQuiz); ok {
+		if len(w.quiz) < len(pet.Quiz) {
+			w.quizzing, w.quiz, w.choice = true, nil, 0
+			return
+		}
+		personality = pet.QuizPersonality(w.quiz)
+		trait = personality.Strongest()
+	}
+	p := pet.NewPet(w.petName(), w.petType())
+	p.Personality = personality
+	a.welcomePet(p)
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"%s Welcome home, %s the %s! They're feeling very %s %s",
+		p.Icon(), p.Name, p.StageName(), strings.ToLower(trait.String()), trait.Emoji())))
+}
+
+// keepFirstPet closes the wizard, Neko staying for good
+func (a *App) keepFirstPet() {
+	if a.adoption == nil {
+		return
+	}
+	a.welcomePet(a.pet)
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		"🐱 Neko is staying! pet adopt <type> <name> brings more friends home"))
+}
+
+// welcomePet closes the wizard and makes the pet the companion
+func (a *App) welcomePet(p *pet.Pet) {
+	a.adoption.modal.Hide()
+	a.adoption = nil
+	a.pet = p
+	if err := a.pets.Welcome(p); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	a.playSound(shell.SoundGreeting)
+}
+
+// adoptionView renders the wizard in its modal
+func (a *App) adoptionView() string {
+	w := a.adoption
+	if w.quizzing {
+		w.modal.Content = a.quizView()
+		return w.modal.Render()
+	}
+	nameStyle := lipgloss.NewStyle().
+		Width(adoptFieldWidth).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(a.theme.Styles.Help.GetForeground()).
+		Padding(0, 1)
+	name := lipgloss.NewStyle().Foreground(a.theme.Styles.Input.GetForeground()).Render(w.name)
+	if w.focus == adoptFieldName {
+		nameStyle = nameStyle.BorderForeground(a.theme.Roles.Accent)
+		name += a.theme.Styles.Cursor.Render(" ")
+	} else if w.name == "" {
+		name = lipgloss.NewStyle().Faint(true).Render(w.petType().SuggestedName())
+	}
+
+	w.modal.Content = lipgloss.JoinVertical(lipgloss.Left,
+		w.types.Render(),
+		"📝 What's their name? (ctrl+r rolls one)",
+		nameStyle.Render(name),
+		w.traits.Render(),
+		"",
+		lipgloss.NewStyle().Faint(true).Render("tab next • enter pick • esc keep Neko"),
+	)
+	return w.modal.Render()
+}
+
+// quizView renders the current question of the personality quiz
+func (a *App) quizView() string {
+	w := a.adoption
+	question := pet.Quiz[len(w.quiz)]
+	lines := []string{
+		lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("📝 Question %d of %d", len(w.quiz)+1, len(pet.Quiz))),
+		question.Question,
+		"",
+	}
+	for i, answer := range question.Answers {
+		line := fmt.Sprintf("%d. %s %s", i+1, answer.Emoji, answer.Text)
+		if i == w.choice {
+			lines = append(lines, a.theme.Styles.Highlight.Render("▶ "+line))
+			continue
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Faint(true).Render("↑↓ pick • enter answer • esc back"))
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
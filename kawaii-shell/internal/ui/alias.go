@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// aliases returns the aliases from the configuration
+func (a *App) aliases() shell.Aliases {
+	return shell.Aliases(a.config.Aliases)
+}
+
+// handleAliasCommand runs the alias and unalias kawaii commands, returning
+// whether the command was one of them
+func (a *App) handleAliasCommand(command string) bool {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) == 0 {
+		return false
+	}
+
+	switch words[0].Value {
+	case "alias":
+		if len(words) == 1 {
+			a.showAliases()
+			return true
+		}
+		changed := false
+		for _, word := range words[1:] {
+			name, value, ok := shell.ParseAlias(word.Value)
+			if !ok {
+				a.showAlias(word.Value)
+				continue
+			}
+			if a.config.Aliases == nil {
+				a.config.Aliases = map[string]string{}
+			}
+			a.config.Aliases[name] = value
+			a.output = append(a.output, a.theme.Styles.Success.Render("✨ "+name+" now means "+value))
+			changed = true
+		}
+		if changed {
+			a.saveAliases()
+		}
+
+	case "unalias":
+		if len(words) == 1 {
+			a.output = append(a.output, "🥺 Oops: try unalias <name>")
+			return true
+		}
+		changed := false
+		for _, word := range words[1:] {
+			if _, ok := a.config.Aliases[word.Value]; !ok {
+				a.output = append(a.output, "🥺 Oops: there's no alias called "+word.Value)
+				continue
+			}
+			delete(a.config.Aliases, word.Value)
+			a.output = append(a.output, a.theme.Styles.Info.Render("👋 Bye bye "+word.Value))
+			changed = true
+		}
+		if changed {
+			a.saveAliases()
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
+// saveAliases persists the aliases and lets the completer know about them
+func (a *App) saveAliases() {
+	a.completer.SetAliases(a.aliases())
+	if err := config.SaveAliases(a.config.Aliases); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+}
+
+// showAliases lists the aliases, sorted by name
+func (a *App) showAliases() {
+	if len(a.config.Aliases) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🌸 No aliases yet! Try alias gs='git status'"))
+		return
+	}
+	names := make([]string, 0, len(a.config.Aliases))
+	for name := range a.config.Aliases {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		a.showAlias(name)
+	}
+}
+
+// showAlias shows a single alias
+func (a *App) showAlias(name string) {
+	value, ok := a.config.Aliases[name]
+	if !ok {
+		a.output = append(a.output, "🥺 Oops: there's no alias called "+name)
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("💕 "+name+" → "+strings.TrimSpace(value)))
+}
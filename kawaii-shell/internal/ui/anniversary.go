@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// checkAnniversary celebrates the companion's anniversary once on the day,
+// with the startup animation when it's still running and a toast otherwise
+func (a *App) checkAnniversary(now time.Time) {
+	anniversary, ok := a.pets.CelebrateAnniversary(now)
+	if !ok {
+		return
+	}
+	title := anniversary.Title(a.pet.Name)
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"🎂 %s 🎁 +%d XP for %s", title, anniversary.XP(), a.pet.Name)))
+	if a.startup != nil && !a.startup.IsComplete() {
+		a.startup.Celebrate(title)
+		return
+	}
+	a.toasts.Push("🎂 "+title, now)
+}
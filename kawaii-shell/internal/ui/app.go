@@ -1,61 +1,358 @@
 package ui
 
 import (
+	"cmp"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/colorprofile"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/config"
 	"github.com/pcstyle/kawaii-shell/internal/shell"
 	"github.com/pcstyle/kawaii-shell/internal/themes"
 	"github.com/pcstyle/kawaii-shell/internal/ui/components"
 	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
 )
 
+const (
+	petHeight   = 4
+	petBoxWidth = 20
+	inputHeight = 3
+)
+
 // App is the main Bubble Tea application model
 type App struct {
-	shell       *shell.Shell
+	// pane is the shell session getting the input, one of panes, which
+	// split is how they're laid out
+	*pane
+	panes []*pane
+	split splitDirection
+
+	history     *shell.History
+	stats       *shell.Stats
+	dirs        *shell.Dirs
+	trash       *shell.Trash
+	completer   *shell.Completer
 	pet         *pet.Pet
+	pets        *pet.Roster
+	petsSavedAt time.Time
 	theme       *themes.KawaiiTheme
+	profile     colorprofile.Profile
+
+	// syncedTheme is the theme the terminal's colors were last synced to
+	syncedTheme *themes.KawaiiTheme
+
 	startup     *components.StartupSequence
-	input       string
-	output      []string
+	config      config.Config
+	dangerRules *shell.DangerRules
+	guard       *shell.Guard
+	errorHints  *shell.ErrorHints
+	commands    *shell.Commands
+	plugins     shell.Plugins
+	sandbox     *shell.Sandbox
+	input       *components.TextInput
 	prompt      string
-	cursor      int
 	width       int
 	height      int
 	ready       bool
 	lastCommand string
+
+	// git is the status of the repository the shell is in and project the
+	// project around it, refreshed in the background, see refreshGit
+	git          *shell.GitStatus
+	project      *shell.Project
+	gitDir       string
+	gitPending   bool
+	gitStale     bool
+	gitCheckedAt time.Time
+
+	// danger is the dangerous command waiting for confirmation, if any
+	danger *dangerConfirmation
+
+	// ability is what the companion does for the user, made for pets of
+	// abilityType
+	ability     ability
+	abilityType pet.PetType
+
+	// guarding has the pet stand in the way of protected commands
+	guarding bool
+
+	// guarded is the protected command waiting for the confirmation phrase,
+	// if any
+	guarded *guardedCommand
+
+	// script is the kawaii script being run, if any
+	script *scriptRun
+
+	// queue is the commands waiting for queue run
+	queue []string
+
+	// explaining shows the panel breaking down the typed command
+	explaining bool
+
+	// search is the search through the scrollback, if any
+	search *scrollSearch
+
+	// copy is the copy mode selecting output, if it's on
+	copy *copyMode
+
+	// jobs are the commands running in the background in their own
+	// terminals, foreground is the one getting the input if any
+	jobs       []*shell.Job
+	nextJobID  int
+	foreground *shell.Job
+
+	// focused is whether the terminal has the focus, unfocused terminals
+	// get notified when long commands finish
+	focused bool
+
+	// quiet keeps the shell from making any sound
+	quiet bool
+
+	// env is the environment panel shown in place of the output, if it's
+	// open
+	env *envPanel
+
+	// filter goes through the last output of the shell, if it's on
+	filter *outputFilter
+
+	// petSettings tune how the pets behave, read again when the file
+	// changes, which is checked every petSettingsCheck
+	petSettings          *pet.SettingsFile
+	petSettingsCheckedAt time.Time
+
+	// neighbors are the other shells on the machine the pets visit, when
+	// visiting is on
+	neighbors            *shell.Neighbors
+	neighborsAnnouncedAt time.Time
+
+	// roster lists the pets to pick the companion from, if it's open
+	roster *rosterPanel
+
+	// wardrobe lists the accessories to dress the companion up, if it's
+	// open
+	wardrobe *wardrobePanel
+
+	// themePicker lists the themes to pick from under the input, if it's
+	// open
+	themePicker *themePicker
+
+	// themeSchedule switches between a day and a night theme, if there's
+	// one
+	themeSchedule *themeSchedule
+
+	// surprise is the theme file of the theme theme surprise made up last,
+	// until it's saved
+	surprise *themes.File
+
+	// themeWatcher tells when the config file or a theme file changes, if
+	// they're watched
+	themeWatcher *config.Watcher
+
+	// achievements lists the achievements, if it's open
+	achievements *achievementsPanel
+
+	// quests lists the quests of the day, if it's open
+	quests *questsPanel
+
+	// petScreen shows the companion on the whole screen, if it's open
+	petScreen *petScreen
+
+	// gallery shows a sample of every theme on the whole screen, if it's
+	// open
+	gallery *themeGallery
+
+	// adoption asks for the first pet on the first run
+	adoption *adoptionWizard
+
+	// celebration cheers for the achievement just earned, if any
+	celebration *celebration
+
+	// toasts are the notices showing in the corner of the output, like the
+	// companion's needs
+	toasts *components.Toasts
+
+	// histSearch searches the history with Ctrl+R, if it's open
+	histSearch *historySearch
+
+	// recording is the asciicast being recorded from out, if any
+	out       *shell.TerminalOutput
+	recording *shell.Recording
+
+	// cmds are run once the current update is done, for changes made deep
+	// down that need one
+	cmds []tea.Cmd
+
+	completions     []shell.Completion
+	completionIndex int
+	completionStart int
 }
 
 // NewApp creates a new kawaii shell application
 func NewApp() *App {
 	sh, _ := shell.NewShell()
+	history, err := shell.NewHistory(shell.DefaultHistoryPath())
+	stats, statsErr := shell.NewStats(shell.DefaultStatsPath())
+	dirs, dirsErr := shell.NewDirs(shell.DefaultDirsPath())
+	trash, trashErr := shell.NewTrash(shell.DefaultTrashDir())
+	cfg, cfgErr := config.Load()
+	dangerRules, dangerErr := shell.LoadDangerRules(filepath.Join(config.Dir(), "danger.yaml"))
+	guard, guardErr := shell.LoadGuard(filepath.Join(config.Dir(), "guard.yaml"), shell.DefaultGuardLogPath())
+	errorHints, hintsErr := shell.LoadErrorHints(filepath.Join(config.Dir(), "errors.yaml"))
+	commands, commandsErr := shell.LoadCommands(filepath.Join(config.Dir(), "commands.yaml"))
+	plugins, pluginsErr := shell.LoadPlugins(filepath.Join(config.Dir(), "plugins"))
+	petPacksErr := pet.LoadPacks(filepath.Join(config.Dir(), "pets"))
+	petVoicesErr := pet.LoadVoices(filepath.Join(config.Dir(), "voices"))
+	themes.SetLight(cfg.Background == "light" || cfg.Background == "" && shell.HasLightBackground())
+	profile := colorprofile.Detect(os.Stdout, os.Environ())
+	themes.SetProfile(profile)
+	var season *themes.Season
+	if cfg.Seasons {
+		season = themes.SeasonAt(time.Now())
+	}
+	dressUp(season)
+	visionErr := themes.SetColorVision(themes.ColorVision(cfg.ColorVision))
+	componentsErr := themes.SetOverrides(cfg.Components)
+	themesErr := themes.LoadThemes(filepath.Join(config.Dir(), "themes"))
+	themeWatcher, watchErr := config.Watch()
+	pets, petsErr := pet.LoadRoster(shell.DefaultPetsPath())
+	petSettings := pet.NewSettingsFile(filepath.Join(config.Dir(), "pet.yaml"))
+	_, petSettingsErr := petSettings.Load()
+	pets.Configure(petSettings.Settings)
+	var themeErr error
+	theme := themes.GetTheme(cmp.Or(cfg.Theme, "sakura"))
+	if theme == nil {
+		theme, themeErr = themes.GetTheme("sakura"), fmt.Errorf("there's no %s theme, theme lists them", cfg.Theme)
+	}
+	themeSchedule, themeScheduleErr := newThemeSchedule(cfg.ThemeSchedule)
+	// the toasts are made in the theme's colors
+	components.SetRoles(theme.Roles)
 
-	return &App{
-		shell:  sh,
-		pet:    pet.NewPet("Neko", pet.TypeCat),
-		theme:  themes.NewSakuraTheme(),
-		prompt: "🌸> ",
-		output: []string{
-			"✨ Welcome to Kawaii Shell! ✨",
-			"Your adorable terminal companion! 🐱",
-			"",
-			"Type 'help' for cute commands, or any regular command!",
-		},
+	app := &App{
+		pane:          newPane(sh),
+		input:         components.NewTextInput(),
+		history:       history,
+		stats:         stats,
+		dirs:          dirs,
+		trash:         trash,
+		completer:     shell.NewCompleter(),
+		pet:           pets.Pet(),
+		pets:          pets,
+		petsSavedAt:   time.Now(),
+		petSettings:   petSettings,
+		theme:         theme,
+		profile:       profile,
+		themeSchedule: themeSchedule,
+		themeWatcher:  themeWatcher,
+		config:        cfg,
+		dangerRules:   dangerRules,
+		guard:         guard,
+		guarding:      guard.On,
+		errorHints:    errorHints,
+		commands:      commands,
+		plugins:       plugins,
+		focused:       true,
+		quiet:         cfg.Quiet,
+		toasts:        components.NewToasts(toastDuration, maxToasts),
+	}
+	app.panes = []*pane{app.pane}
+	app.output = []string{
+		"✨ Welcome to Kawaii Shell! ✨",
+		"Your adorable terminal companion! 🐱",
+		"",
+		"Type 'help' for cute commands, or any regular command!",
+	}
+	if season != nil {
+		app.output = append(app.output, "", season.Greeting)
+	}
+	if err != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load history: "+err.Error())
+	}
+	if statsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load stats: "+statsErr.Error())
+	}
+	if dirsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load directories: "+dirsErr.Error())
+	}
+	if trashErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load the trash: "+trashErr.Error())
+	}
+	if cfgErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load config: "+cfgErr.Error())
+	}
+	if themesErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load themes: "+themesErr.Error())
+	}
+	if visionErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load the color vision: "+visionErr.Error())
+	}
+	if watchErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't watch the theme files: "+watchErr.Error())
+	}
+	if componentsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load the component styles: "+componentsErr.Error())
+	}
+	if themeErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load the theme: "+themeErr.Error())
+	}
+	if themeScheduleErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load the theme schedule: "+themeScheduleErr.Error())
+	}
+	if dangerErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load danger rules: "+dangerErr.Error())
+	}
+	if guardErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load guard rules: "+guardErr.Error())
+	}
+	if hintsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load error hints: "+hintsErr.Error())
 	}
+	if commandsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load commands: "+commandsErr.Error())
+	}
+	if pluginsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load plugins: "+pluginsErr.Error())
+	}
+	if petPacksErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load pet packs: "+petPacksErr.Error())
+	}
+	if petVoicesErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load pet voices: "+petVoicesErr.Error())
+	}
+	if petsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load your pets: "+petsErr.Error())
+	}
+	if petSettingsErr != nil {
+		app.output = append(app.output, "🥺 Oops! Couldn't load pet settings: "+petSettingsErr.Error())
+	}
+	if pets.FirstRun() {
+		app.openAdoptionWizard()
+	}
+	app.followNeighbors()
+	app.commands.AddPlugins(plugins)
+	app.completer.SetExtraCommands(plugins.CommandNames())
+	app.completer.SetAliases(app.aliases())
+	app.completer.SetDirs(dirs)
+	app.updateCwd()
+	app.refreshPrompt(time.Now())
+	return app
 }
 
 // Init initializes the application
 func (a *App) Init() tea.Cmd {
 	// Start the shell
-	if err := a.shell.Start(); err != nil {
+	if err := a.shell.Start(""); err != nil {
 		a.output = append(a.output, "🥺 Oops! Couldn't start shell: "+err.Error())
 	}
 
 	return tea.Batch(
 		a.pet.Init(),
+		a.emit(shell.PluginEvent{Event: shell.EventStart}),
+		a.watchThemeFiles(),
 		tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 			return TickMsg{Time: t}
 		}),
@@ -67,68 +364,317 @@ type TickMsg struct {
 	Time time.Time
 }
 
+// PassthroughDoneMsg is sent when a full-screen program gives the terminal
+// back to the kawaii UI
+type PassthroughDoneMsg struct {
+	Rest []byte
+	Err  error
+}
+
 // Update handles messages and updates the model
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	// components made on the way are in the theme's colors
+	a.styleComponents()
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 		a.ready = true
+		a.resizePanes()
+		a.resizeJobs()
+		if a.recording != nil {
+			if err := a.recording.Resize(msg.Width, msg.Height); err != nil {
+				a.output = append(a.output, "🥺 Oops: "+err.Error())
+			}
+		}
 		if a.startup == nil {
 			a.startup = components.NewStartupSequence(a.width, a.height, "0.1.0")
-			cmds = append(cmds, a.startup.Init())
+			if a.pet.TextOnly() {
+				// there's nothing to read in the animation
+				a.startup.Skip()
+			} else {
+				cmds = append(cmds, a.startup.Init())
+			}
+			a.checkAnniversary(time.Now())
 		}
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		if msg.String() == "ctrl+c" {
+			// the pets remember how they were doing
+			a.pets.Save()
+			a.leaveNeighbors()
 			return a, tea.Quit
+		}
+		if a.danger != nil {
+			cmds = append(cmds, a.handleDangerKey(msg))
+			break
+		}
+		if a.guarded != nil && msg.String() == "esc" {
+			a.answerGuard("")
+			break
+		}
+		if a.adoption != nil {
+			cmds = append(cmds, a.handleAdoptionKey(msg))
+			break
+		}
+		if a.celebration != nil {
+			cmds = append(cmds, a.handleCelebrationKey(msg))
+			break
+		}
+		if a.petScreen != nil {
+			a.handlePetScreenKey(msg)
+			break
+		}
+		if a.gallery != nil {
+			a.handleGalleryKey(msg)
+			break
+		}
+		if a.env != nil {
+			a.handleEnvKey(msg)
+			break
+		}
+		if a.roster != nil {
+			a.handleRosterKey(msg)
+			break
+		}
+		if a.wardrobe != nil {
+			a.handleWardrobeKey(msg)
+			break
+		}
+		if a.themePicker != nil {
+			a.handleThemePickerKey(msg)
+			break
+		}
+		if a.achievements != nil {
+			a.handleAchievementsKey(msg)
+			break
+		}
+		if a.quests != nil {
+			a.handleQuestsKey(msg)
+			break
+		}
+		if a.filter != nil {
+			a.handleFilterKey(msg)
+			break
+		}
+		if a.histSearch != nil {
+			a.handleHistorySearchKey(msg)
+			break
+		}
+		if msg.String() == "ctrl+z" {
+			a.suspend()
+			break
+		}
+		if a.search != nil {
+			a.handleSearchKey(msg)
+			break
+		}
+		if a.copy != nil {
+			cmds = append(cmds, a.handleCopyKey(msg))
+			break
+		}
+		if len(a.completions) > 0 && a.handleCompletionKey(msg) {
+			break
+		}
+		if a.handleScrollKey(msg) || a.handlePaneKey(msg) {
+			break
+		}
+		if a.correction != nil && a.handleCorrectionKey(msg) {
+			break
+		}
+		if a.nextCommand != "" && a.handleNextCommandKey(msg) {
+			break
+		}
+		if msg.String() == "?" && (a.explaining || a.explainKey()) {
+			a.explaining = !a.explaining
+			break
+		}
+		if msg.String() == "esc" && a.explaining {
+			a.explaining = false
+			break
+		}
 
+		switch msg.String() {
 		case "enter":
-			if strings.TrimSpace(a.input) != "" {
-				a.executeCommand(a.input)
-				a.lastCommand = a.input
-				a.input = ""
-				a.cursor = 0
+			if a.foreground != nil {
+				a.sendInput()
+				break
+			}
+			if !shell.IsComplete(a.input.Value()) {
+				// keep editing until the quotes are closed
+				a.input.Insert("\n")
+				break
+			}
+			if strings.TrimSpace(a.input.Value()) != "" {
+				a.submit(a.input.Value())
+				a.input.Reset()
+				a.explaining = false
+				a.scroll = 0
+			}
+
+		case "tab":
+			a.complete()
+
+		case "ctrl+r":
+			a.openHistorySearch()
+
+		case "up":
+			if a.input.MoveLine(-1, false) {
+				break
+			}
+			if entry, ok := a.history.Previous(a.input.Value()); ok {
+				a.input.SetValue(entry)
 			}
 
-		case "backspace":
-			if a.cursor > 0 {
-				a.input = a.input[:a.cursor-1] + a.input[a.cursor:]
-				a.cursor--
+		case "down":
+			if a.input.MoveLine(1, false) {
+				break
+			}
+			if entry, ok := a.history.Next(); ok {
+				a.input.SetValue(entry)
 			}
 
-		case "left":
-			if a.cursor > 0 {
-				a.cursor--
+		case "right", "end", "ctrl+e":
+			// at the end of the input they take the suggestion
+			if !a.acceptSuggestion() {
+				a.input.Update(msg)
 			}
 
-		case "right":
-			if a.cursor < len(a.input) {
-				a.cursor++
+		case "ctrl+right", "alt+f":
+			if !a.acceptSuggestionWord() {
+				a.input.Update(msg)
 			}
 
-		default:
-			if len(msg.String()) == 1 {
-				a.input = a.input[:a.cursor] + msg.String() + a.input[a.cursor:]
-				a.cursor++
+		case "alt+w", "ctrl+x":
+			// copy the selection to the clipboard, ctrl+x cutting it
+			if text := a.input.SelectedText(); text != "" {
+				if msg.String() == "ctrl+x" {
+					a.input.DeleteSelection()
+				}
+				cmds = append(cmds, a.copyToClipboard(text, strings.Count(text, "\n")+1))
 			}
+
+		case "alt+e":
+			a.carePet(pet.CareFeed)
+
+		case "alt+p":
+			a.carePet(pet.CarePlay)
+
+		case "alt+g":
+			a.carePet(pet.CareGroom)
+
+		case "alt+s":
+			a.openPetScreen()
+
+		default:
+			a.input.Update(msg)
 		}
 
+	case tea.MouseMsg:
+		a.handleScrollMouse(msg)
+
 	case TickMsg:
+		lines := len(a.scrollbackLines())
 		var petCmd tea.Cmd
 		a.pet, petCmd = a.pet.Update(msg)
 		if petCmd != nil {
 			cmds = append(cmds, petCmd)
 		}
-		if output := a.shell.GetOutput(); output != "" {
-			a.output = append(a.output, output)
+		cmds = append(cmds, a.pollJobs(msg.Time))
+		a.keepScroll(lines)
+		cmds = append(cmds, a.tickPanes(msg.Time))
+		if cmd := a.refreshGit(a.cwd, msg.Time); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if cmd := a.followThemeSchedule(msg.Time); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		a.petAbility().tick(a, msg.Time)
+		a.refreshPrompt(msg.Time)
+		a.checkAnniversary(msg.Time)
+		a.checkAchievements(msg.Time)
+		a.checkQuests(msg.Time)
+		a.evolvePet()
+		a.cheerLevelUp()
+		a.tellNeeds(msg.Time)
+		a.reloadPetSettings(msg.Time)
+		a.visitNeighbors(msg.Time)
+		a.followClock(msg.Time)
+		a.waitForCommand(msg.Time)
+		a.savePets(msg.Time)
+		if alt, ok := a.screen.TakeAltScreen(); ok {
+			// a full-screen program started, let it have the terminal and
+			// resume ticking once it's done
+			passthrough := a.shell.Passthrough(alt)
+			cmds = append(cmds, tea.Exec(passthrough, func(err error) tea.Msg {
+				return PassthroughDoneMsg{Rest: passthrough.Rest(), Err: err}
+			}))
+			break
 		}
 		cmds = append(cmds, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 			return TickMsg{Time: t}
 		}))
+
+	case GitStatusMsg:
+		a.updateGit(msg)
+
+	case FlagsFoundMsg:
+		a.completeFlags(msg)
+
+	case AppearanceMsg:
+		a.updateAppearance(msg)
+
+	case CopiedMsg:
+		a.showCopied(msg)
+
+	case RecordedGIFMsg:
+		a.showRecordedGIF(msg)
+
+	case ThemeInstalledMsg:
+		a.showInstalledTheme(msg)
+
+	case ThemeFilesChangedMsg:
+		cmds = append(cmds, a.reloadThemeFiles(msg))
+
+	case TerminalColorsMsg:
+		if msg.Err != nil {
+			a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		}
+
+	case tea.FocusMsg:
+		a.focused = true
+
+	case tea.BlurMsg:
+		a.focused = false
+
+	case pet.PetTickMsg, pet.PetAnimateMsg:
+		var petCmd tea.Cmd
+		a.pet, petCmd = a.pet.Update(msg)
+		cmds = append(cmds, petCmd)
+
+	case PluginOutputMsg:
+		a.showPluginOutput(msg)
+
+	case NotifiedMsg:
+		if msg.Err != nil {
+			a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		}
+
+	case SoundPlayedMsg:
+		if msg.Err != nil {
+			a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		}
+
+	case PassthroughDoneMsg:
+		if msg.Err != nil {
+			a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		}
+		a.output = append(a.output, a.screen.Write(msg.Rest)...)
+		cmds = append(cmds, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
+			return TickMsg{Time: t}
+		}))
 	case components.StartupTickMsg:
 		if a.startup != nil && !a.startup.IsComplete() {
 			var cmd tea.Cmd
@@ -147,30 +693,109 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	a.syncTerminalColors()
+	cmds = append(cmds, a.cmds...)
+	a.cmds = nil
 	return a, tea.Batch(cmds...)
 }
 
-// executeCommand processes and executes a command
-func (a *App) executeCommand(command string) {
-	// Get cute command info
-	info := shell.GetCommandInfo(command)
+// submit expands and records the entered command, then executes it
+func (a *App) submit(input string) {
+	a.correction = nil
+	a.nextCommand = ""
+	if a.guarded != nil {
+		a.answerGuard(input)
+		return
+	}
+	command, err := a.history.Expand(input)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if command != input {
+		// echo the expanded command, like bash does
+		a.output = append(a.output, a.theme.Styles.Prompt.Render(a.prompt)+command)
+	}
+	if err := a.history.Add(command); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	a.executeCommand(a.aliases().Expand(command))
+	a.lastCommand = command
+}
+
+// executeCommand processes and executes a command, returning false when it
+// was blocked
+func (a *App) executeCommand(command string) bool {
+	// Get cute command info, the project may describe its own commands
+	if a.remote == "" {
+		if err := a.commands.LoadProject(a.cwd); err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+		}
+	}
+	info := a.commands.Info(command)
 
 	// Show what we're doing
-	cuteLine := a.theme.Styles.CommandInfo.Render(
-		info.Emoji + " " + info.FriendlyName + ": " + info.Description,
-	)
-	a.output = append(a.output, cuteLine)
+	commandLine := len(a.output)
+	a.output = append(a.output, a.theme.Styles.CommandInfo.Render(commandInfoText(info)))
 
-	// Show warning for dangerous commands
-	if info.IsDangerous {
+	rule, dangerous := a.dangerRules.Check(command)
+	if flagged, ok := a.commands.Check(command); ok && (!dangerous || flagged.Severity > rule.Severity) {
+		rule, dangerous = flagged, true
+	}
+	guardRule, guarded := a.guard.Check(command)
+	guarded = guarded && a.guarding
+	if dangerous || guarded {
+		a.playSound(shell.SoundWarning)
+	}
+	switch {
+	case dangerous && rule.Severity == shell.SeverityBlock:
+		a.output = append(a.output, a.theme.Styles.Error.Render("🚫 I won't run this one! "+rule.Reason))
+		a.pet.ReactToCommand(command, true)
+		return false
+	case guarded:
+		// wait for the confirmation phrase
+		a.standGuard(command, info, guardRule, commandLine)
+		return true
+	case dangerous && rule.Severity == shell.SeverityConfirm:
+		// wait for confirmation
 		warning := a.theme.Styles.Warning.Render(
-			"⚠️  This command might be dangerous! Are you sure? (This is just a demo - command will execute)",
+			"⚠️  This command might be dangerous! Please confirm before I run it",
 		)
 		a.output = append(a.output, warning)
+		a.confirmDanger(command, info, rule, commandLine)
+		return true
+	case dangerous && rule.Severity == shell.SeverityWarn:
+		a.output = append(a.output, a.theme.Styles.Warning.Render("⚠️  "+rule.Reason))
 	}
 
+	a.runCommand(command, info, commandLine, dangerous)
+	return true
+}
+
+// commandInfoText describes the command in the output
+func commandInfoText(info shell.CommandInfo) string {
+	return info.Emoji + " " + info.FriendlyName + ": " + info.Description
+}
+
+// runCommand runs the command, either as a special kawaii command or in the
+// shell, where commandLine is its line in the output
+func (a *App) runCommand(command string, info shell.CommandInfo, commandLine int, dangerous bool) {
 	// Update pet reaction
-	a.pet.ReactToCommand(command, info.IsDangerous)
+	a.pet.ReactToCommand(command, dangerous)
+
+	if a.handleJobCommand(command) || a.handleAliasCommand(command) || a.handleScriptCommand(command) || a.handleQueueCommand(command) ||
+		a.handleRecordCommand(command) || a.handleSplitCommand(command) || a.handleDirsCommand(command) ||
+		a.handlePluginCommand(command) || a.handleSandboxCommand(command) || a.handleGuardCommand(command) || a.handleThemeCommand(command) || a.handlePetCommand(command) {
+		return
+	}
+
+	// rm moves files to the trash, unless they should really be deleted or
+	// it runs in the sandbox, where it can't hurt anything
+	if really, ok := shell.StripReally(command); ok {
+		command = really
+	} else if a.remote == "" && !a.sandboxed(command) && a.handleTrashCommand(command) {
+		return
+	}
 
 	// Handle special kawaii commands
 	switch strings.TrimSpace(command) {
@@ -181,30 +806,178 @@ func (a *App) executeCommand(command string) {
 		a.showKawaii()
 		return
 	case "pet":
-		a.showPetStatus()
+		a.openPetScreen()
+		return
+	case "history":
+		a.showHistory()
+		return
+	case "stats":
+		a.showStats()
+		return
+	case "env":
+		a.openEnvPanel()
+		return
+	case "quiet":
+		a.toggleQuiet()
+		return
+	}
+	if query, ok := strings.CutPrefix(strings.TrimSpace(command), "filter"); ok && (query == "" || query[0] == ' ') {
+		a.openFilter(strings.TrimSpace(query))
 		return
 	}
 
 	// Execute the actual command
-	if err := a.shell.ExecuteCommand(command); err != nil {
+	line := command
+	if ssh, ok := a.sshCommand(command); ok {
+		line = ssh
+	} else if a.remote == "" {
+		line = a.sandboxCommand(command)
+	}
+	if line == "" {
+		return
+	}
+	if err := a.shell.ExecuteCommand(line); err != nil {
 		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.pendingCommand = commandLine
+	a.pendingInfo = commandInfoText(info)
+	a.pendingText = command
+	a.pendingStarted = time.Now()
+	a.cmds = append(a.cmds, a.emit(shell.PluginEvent{Event: shell.EventCommand, Command: command}))
+}
+
+// showExitCode adds the exit code indicator next to the command that
+// finished, and lets the pet react to it. Long commands are celebrated and
+// notified about, and errors we know are explained.
+func (a *App) showExitCode(code int, now time.Time) tea.Cmd {
+	// the shell also reports when showing its first prompt
+	if a.pendingCommand < 0 || a.pendingCommand >= len(a.output) {
+		return nil
+	}
+
+	indicator := a.theme.Styles.ExitSuccess.Render("✔")
+	if code != 0 {
+		indicator = a.theme.Styles.ExitFailure.Render(fmt.Sprintf("✘ %d", code))
+	}
+	a.setOutput(a.pendingCommand, a.theme.Styles.CommandInfo.Render(a.pendingInfo+" "+indicator))
+	a.lastOutput = a.lastOutput[:0]
+	for _, entry := range a.output[a.pendingCommand+1:] {
+		a.lastOutput = append(a.lastOutput, strings.Split(entry, "\n")...)
+	}
+	a.lastOutputCommand = a.pendingText
+	a.showErrorHint(code)
+	a.pendingCommand = -1
+	a.lastExitCode, a.hasExitCode = code, true
+	a.gitStale = true
+	a.scriptStepDone(code)
+	a.reactToExitCode(a.pendingText, code)
+	if code == 0 {
+		a.earnTreat()
+	}
+	a.pets.CountCommand(a.pendingText, code, now)
+	a.cheerStreak()
+	if shell.IsNotFound(code) {
+		a.offerCorrection(a.pendingText)
+	}
+	if code == 0 {
+		a.offerNextCommand()
+	}
+	took := now.Sub(a.pendingStarted)
+	a.recordStats(a.pendingText, a.pendingStarted, took, code)
+	a.petAbility().finished(a, a.pendingText, code, took)
+	if a.isLong(took) {
+		a.pet.StopWaiting(code == 0)
+	}
+	return tea.Batch(a.notifyDone(a.pendingText, took, code), a.emitDone(a.pendingText, took, code))
+}
+
+// refreshPrompt renders the prompt segments again, picking up changes to the
+// working directory, the git status and the time
+func (a *App) refreshPrompt(now time.Time) {
+	a.prompt = renderPrompt(a.config.Prompt, promptState{
+		cwd:      a.cwd,
+		remote:   a.remote,
+		sandbox:  a.sandboxTool(),
+		git:      a.git,
+		exitCode: a.lastExitCode,
+		hasExit:  a.hasExitCode,
+		petEmoji: a.pet.GetMoodEmoji(),
+		now:      now,
+	})
+	// the unicorn paints the prompt in colors of its own
+	if painted := a.petAbility().prompt(a.prompt); painted != a.prompt {
+		a.prompt = painted
+		return
 	}
+	a.prompt = a.theme.GradientText(a.prompt, a.theme.Styles.Prompt, now)
 }
 
 // showHelp displays cute help information
 func (a *App) showHelp() {
+	a.output = append(a.output, a.theme.Styles.Help.Render(""), a.titleView("🌸 ✨ Kawaii Shell Commands ✨ 🌸"))
 	help := []string{
-		"",
-		"🌸 ✨ Kawaii Shell Commands ✨ 🌸",
 		"",
 		"🐱 kawaii    - Show kawaii info",
-		"🐱 pet       - Check your pet's status",
+		"🐱 pet       - Spend time with your pet on a screen of their own, or press Alt+S",
+		"🐱 pet status - Check your pet's status",
+		"🐱 pets      - See all your pets and pick your companion",
+		"🐱 pet adopt <type> [name] - Welcome a cat, fox, bunny, dragon, unicorn or robot",
+		"🐱 pet rename <name> - Give your pet a new name, pet names [type] suggests some",
+		"🐱 pet feed / play / groom - Look after your pet, pet feed <treat> gives a treat",
+		"🐱 pet treats - See what's in the treat bag",
+		"🐱 pet stats - Graphs of how your pet has been doing, their moods and favorite commands",
+		"🐱 pet remember <what> - Ask your pet about git, or pet what did we do yesterday",
+		"🐱 pet export [file] - Pack your pet into a signed file, pet import <file> brings them home",
+		"🐱 pet key / pet trust <key> [name] - Share your signing key, trust a friend's",
+		"🐱 pet neighbors - See the pets visiting from other kawaii shells",
+		"🐱 pet gift <treat> to <name> - Bring a visiting pet a treat from the bag",
+		"🐱 pet react <how> [intensity] [message] - Have your pet celebrate, worry, alert or love",
+		"🐱 wardrobe  - Dress your pet up with the accessories you unlocked",
+		"🐱 achievements - See the milestones you reached and the ones to go",
+		"🐱 quests    - See today's quests, finish them all to keep the streak going",
 		"🐱 help      - Show this cute help",
+		"🐱 quiet     - Turn quiet mode on or off, no bells and no pet sounds",
+		"🐱 theme     - Pick a theme, previewing each one as you go, theme gallery compares them all",
+		"🐱 theme auto - Go back to the day and night themes of the schedule",
+		"🐱 theme install <url|name> - Download a theme, by its name from the community registry",
+		"🐱 theme surprise - Try on a theme made up on the spot, theme save <name> keeps it",
+		"🐱 theme export-fang <name> [file] - Write a theme as a fang color scheme, JSON or Go for .go files",
+		"🐱 guard on / off - Have your pet guard force pushes and prod clusters, guard log shows what you let through",
+		"🐱 history   - Show your past commands",
+		"🐱 stats     - See which commands you run the most and the slowest",
+		"🐱 env       - Look through the startup environment and export or unset variables",
+		"🐱 filter    - Show only the lines of the last output matching a pattern",
+		"🐱 record start / stop [--gif] - Record your session to share it",
+		"🐱 mark <name> - Bookmark this directory, unmark forgets it",
+		"🐱 jump <name> - Go to a bookmark, or a directory you visited by a few letters",
+		"🐱 alias     - List your aliases, or add one with alias gs='git status'",
+		"🐱 unalias   - Forget an alias",
+		"🐱 kawaii run <script> - Run the commands of a .kawaii script",
+		"🐱 queue add <command> - Queue commands, queue run runs them one after the other",
+		"🐱 kawaii ssh <host> - Open a remote session, I'll come along",
+		"🐱 sandbox on / off - Try commands out where they can't hurt anything",
+		"🐱 trash     - See what rm moved to the trash",
+		"🐱 undo      - Bring back what the last rm removed",
+		"🐱 restore   - Bring back something from the trash",
+		"🐱 jobs      - See the commands you ran with & at the end",
+		"🐱 fg / bg   - Bring a job to the foreground or let it carry on",
+	}
+	help = append(help, a.pluginHelp()...)
+	help = append(help,
+		"",
+		"⬆️  Up/down browse history, Ctrl+R searches it, !! and !n repeat commands",
+		"⌨️  Shift+arrows select, Ctrl+arrows jump words, Alt+W copies and Ctrl+X cuts",
+		"📜 PgUp/PgDn scroll back, Ctrl+F searches the output",
+		"✂️  Ctrl+O selects output to copy with vi keys",
+		"🪟 split / split -v opens another shell, Alt+arrows move between them",
+		"🔎 Ctrl+G filters the last command's output, like | grep",
+		"🍖 Alt+E feeds your pet, Alt+P plays with them and Alt+G grooms them",
 		"",
 		"✨ All regular commands work too! ✨",
 		"I'll make them cute and friendly! 💕",
 		"",
-	}
+	)
 
 	for _, line := range help {
 		a.output = append(a.output, a.theme.Styles.Help.Render(line))
@@ -235,55 +1008,192 @@ func (a *App) showKawaii() {
 // showPetStatus shows the pet's current status
 func (a *App) showPetStatus() {
 	status := a.pet.GetStatus()
+	if name := a.petAbility().name(); name != "" {
+		status = append(status, "🪄 Ability: "+name)
+	}
 	for _, line := range status {
-		a.output = append(a.output, a.theme.Styles.Pet.Render(line))
+		a.output = append(a.output, a.petLine(line))
+	}
+}
+
+// showHistory lists the recorded commands
+func (a *App) showHistory() {
+	for i, entry := range a.history.Entries() {
+		a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf("%5d  %s", i+1, entry)))
 	}
 }
 
-// View renders the application
+// outputAreaHeight returns the height of the area the panes share
+func (a *App) outputAreaHeight() int {
+	return a.height - petHeight - inputHeight - breadcrumbHeight - 2
+}
+
+// outputBoxHeight returns the height of the active pane's output box,
+// borders included
+func (a *App) outputBoxHeight() int {
+	_, height := a.paneBox(a.activePane(), a.outputAreaHeight())
+	return height
+}
+
+// outputSize returns the number of columns and rows available to programs
+// inside the active pane's output box
+func (a *App) outputSize() (int, int) {
+	return a.paneSize(a.activePane())
+}
+
+// View renders the application in the colors the terminal can show
 func (a *App) View() string {
+	a.styleComponents()
+	return a.downsample(a.view())
+}
+
+// view renders the shell in the theme's colors
+func (a *App) view() string {
 	if !a.ready {
 		return "Loading kawaii shell... ✨"
 	}
 	if a.startup != nil && !a.startup.IsComplete() {
 		return a.startup.Render()
 	}
-	petHeight := 4
-	inputHeight := 3
-	availableHeight := a.height - petHeight - inputHeight - 2
-	outputLines := a.output
-	if len(outputLines) > availableHeight {
-		outputLines = outputLines[len(outputLines)-availableHeight:]
+	if a.petScreen != nil {
+		return a.modalView(a.petScreenView())
+	}
+	if a.gallery != nil {
+		return a.modalView(a.galleryView())
+	}
+	popup := a.completionView()
+	if a.themePicker != nil {
+		popup = a.themePickerView()
+	}
+	availableHeight := a.outputAreaHeight()
+	if popup != "" {
+		availableHeight -= lipgloss.Height(popup)
+	}
+	if lines := a.input.Lines(); lines > 1 {
+		// make room for the continuation lines
+		availableHeight -= (lines - 1) * lipgloss.Height(a.theme.Styles.Prompt.Render(continuationPrompt))
+	}
+	outputBox := a.panesView(availableHeight, func(height int) string {
+		switch {
+		case a.env != nil:
+			return a.envView(height)
+		case a.roster != nil:
+			return a.rosterView(height)
+		case a.wardrobe != nil:
+			return a.wardrobeView(height)
+		case a.achievements != nil:
+			return a.achievementsView(height)
+		case a.quests != nil:
+			return a.questsView(height)
+		case a.filter != nil:
+			return a.filterView(height)
+		case a.histSearch != nil:
+			return a.historySearchView(height)
+		}
+		return a.visibleOutput(height)
+	})
+	input := a.inputView()
+	switch {
+	case a.env != nil:
+		input = a.envInputView()
+	case a.roster != nil:
+		input = a.rosterInputView()
+	case a.wardrobe != nil:
+		input = a.wardrobeInputView()
+	case a.themePicker != nil:
+		input = a.themePickerInputView()
+	case a.achievements != nil:
+		input = a.achievementsInputView()
+	case a.quests != nil:
+		input = a.questsInputView()
+	case a.filter != nil:
+		input = a.filterInputView()
+	case a.histSearch != nil:
+		input = a.historySearchInputView()
+	case a.search != nil:
+		input = a.searchView()
+	case a.copy != nil:
+		input = a.copyView()
 	}
-	output := strings.Join(outputLines, "\n")
-	outputBox := a.theme.Styles.OutputBox.
-		Width(a.width - 2).
-		Height(availableHeight).
-		Render(output)
-	inputText := a.input
-	if a.cursor < len(inputText) {
-		inputText = inputText[:a.cursor] + a.theme.Styles.Cursor.Render(string(inputText[a.cursor])) + inputText[a.cursor+1:]
-	} else {
-		inputText += a.theme.Styles.Cursor.Render(" ")
-	}
-	inputLine := a.theme.Styles.Prompt.Render(a.prompt) + a.theme.Styles.Input.Render(inputText)
 	inputBox := a.theme.Styles.InputBox.
 		Width(a.width - 2).
-		Render(inputLine)
+		Render(input)
 	petView := a.pet.View()
+	if streak := a.streakView(time.Now()); streak != "" {
+		petView += "\n" + streak
+	}
+	party := pet.PartyView(a.pets.Idle())
+	if a.pet.TextOnly() {
+		party = pet.PartyText(a.pets.Idle())
+	}
+	if party != "" {
+		// the other pets hang out in the corner
+		petView += "\n" + lipgloss.PlaceHorizontal(petBoxWidth-2, lipgloss.Right, party)
+	}
+	if visitors := a.visitorsView(); visitors != "" {
+		// and the ones visiting from other shells next to them
+		petView += "\n" + lipgloss.PlaceHorizontal(petBoxWidth-2, lipgloss.Right, visitors)
+	}
 	petBox := a.theme.Styles.PetBox.
-		Width(20).
+		Width(petBoxWidth).
 		Height(petHeight).
 		Render(petView)
-	mainContent := lipgloss.JoinVertical(
-		lipgloss.Left,
-		outputBox,
-		"",
-		inputBox,
-	)
-	return lipgloss.JoinHorizontal(
+	sidebar := petBox
+	if abilityBox := a.abilityView(); abilityBox != "" {
+		sidebar = lipgloss.JoinHorizontal(lipgloss.Top, abilityBox, " ", sidebar)
+	}
+	if projectBox := a.projectView(); projectBox != "" {
+		sidebar = lipgloss.JoinHorizontal(lipgloss.Top, projectBox, " ", sidebar)
+	}
+	if jobsBox := a.jobsView(); jobsBox != "" {
+		sidebar = lipgloss.JoinHorizontal(lipgloss.Top, jobsBox, " ", sidebar)
+	}
+	sections := []string{a.breadcrumbView(), outputBox, cmp.Or(a.correctionView(), a.nextCommandView()), inputBox}
+	if popup != "" {
+		sections = append(sections, popup)
+	}
+	mainContent := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	view := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		mainContent,
 		lipgloss.NewStyle().Width(a.width-len(mainContent)).Render(""),
-	) + "\n" + lipgloss.PlaceHorizontal(a.width, lipgloss.Right, petBox)
+	) + "\n" + lipgloss.PlaceHorizontal(a.width, lipgloss.Right, sidebar)
+	if a.explaining && strings.TrimSpace(a.input.Value()) != "" {
+		panel := a.explainView()
+		view = overlay(view, panel, max(0, a.width-lipgloss.Width(panel)-1), breadcrumbHeight)
+	}
+	if !a.toasts.Empty() {
+		// in the bottom corner of the output, inside its border
+		toasts := a.toasts.Render(toastWidth)
+		view = overlay(view, toasts, max(0, a.width-lipgloss.Width(toasts)-3),
+			max(0, breadcrumbHeight+availableHeight-lipgloss.Height(toasts)-1))
+	}
+	return a.modalView(view)
+}
+
+// modalView draws the modal open, if any, over the view
+func (a *App) modalView(view string) string {
+	var modal string
+	switch {
+	case a.danger != nil:
+		modal = a.danger.modal.Render()
+	case a.adoption != nil:
+		modal = a.adoptionView()
+	case a.celebration != nil:
+		modal = a.celebration.modal.Render()
+	}
+	if modal != "" {
+		x := max(0, (a.width-lipgloss.Width(modal))/2)
+		y := max(0, (a.height-lipgloss.Height(modal))/2)
+		view = overlay(view, modal, x, y)
+	}
+	return view
+}
+
+// overlay draws top over the view at the given position
+func overlay(view, top string, x, y int) string {
+	return lipgloss.NewCanvas(
+		lipgloss.NewLayer(view),
+		lipgloss.NewLayer(top).X(x).Y(y).Z(1),
+	).Render()
 }
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// autosuggestion returns the rest of the suggested command, shown dimmed
+// after the cursor like fish does. History comes first, then the known
+// commands.
+func (a *App) autosuggestion() string {
+	if _, _, selected := a.input.Selection(); selected || !a.input.AtEnd() ||
+		len(a.completions) > 0 || a.history == nil || a.guarded != nil {
+		return ""
+	}
+	input := a.input.Value()
+	suggestion, ok := a.history.Suggest(input)
+	if !ok {
+		suggestion, ok = a.completer.Suggest(input)
+	}
+	rest := strings.TrimPrefix(suggestion, input)
+	if !ok || strings.Contains(rest, "\n") {
+		// multi-line commands would push the input box around
+		return ""
+	}
+	return rest
+}
+
+// acceptSuggestion adds the whole suggestion to the input, reporting whether
+// there was one
+func (a *App) acceptSuggestion() bool {
+	rest := a.autosuggestion()
+	if rest == "" {
+		return false
+	}
+	a.input.Insert(rest)
+	return true
+}
+
+// acceptSuggestionWord adds the next word of the suggestion to the input,
+// reporting whether there was one
+func (a *App) acceptSuggestionWord() bool {
+	rest := a.autosuggestion()
+	if rest == "" {
+		return false
+	}
+	// take the spaces in front of the word along with it
+	word := strings.TrimLeftFunc(rest, unicode.IsSpace)
+	end := len(rest) - len(word)
+	if i := strings.IndexFunc(word, unicode.IsSpace); i >= 0 {
+		end += i
+	} else {
+		end = len(rest)
+	}
+	a.input.Insert(rest[:end])
+	return true
+}
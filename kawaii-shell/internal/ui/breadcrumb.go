@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// breadcrumbHeight is the height of the bar showing the working directory
+const breadcrumbHeight = 1
+
+// breadcrumbSeparator goes between the directories of the breadcrumb
+const breadcrumbSeparator = " › "
+
+// updateCwd picks up the working directory reported by the shell integration,
+// falling back to asking the system for shells without it
+func (a *App) updateCwd() {
+	before := a.cwd
+	if dir, host, ok := a.screen.TakeCwd(); ok {
+		a.cwd = dir
+		a.cwdReported = true
+		a.remote = ""
+		if !shell.IsLocalHost(host) {
+			a.remote = host
+		}
+	} else if !a.cwdReported {
+		a.cwd = a.shell.Cwd()
+	}
+	if a.cwd != before && a.remote == "" {
+		a.visitDir(a.cwd)
+	}
+	if a.cwd != before && before != "" {
+		a.cmds = append(a.cmds, a.emit(shell.PluginEvent{Event: shell.EventCd}))
+	}
+}
+
+// shellCwd returns the working directory of the shell, empty when neither
+// the shell integration nor the system can tell it
+func (a *App) shellCwd() string {
+	if a.cwdReported {
+		return a.cwd
+	}
+	dir, _ := a.shell.ProcessCwd()
+	return dir
+}
+
+// breadcrumbs splits the working directory into the parts of the breadcrumb,
+// starting with the host when it's another computer
+func breadcrumbs(cwd, remote string) []string {
+	path := shortenPath(cwd)
+	var parts []string
+	switch {
+	case remote != "":
+		parts = append(parts, "🌐 "+remote)
+		path = cwd
+	case path == "~" || strings.HasPrefix(path, "~"+string(filepath.Separator)):
+		parts = append(parts, "🏠")
+		path = strings.TrimPrefix(path, "~")
+	case filepath.VolumeName(path) != "":
+		parts = append(parts, "💾 "+filepath.VolumeName(path))
+		path = strings.TrimPrefix(path, filepath.VolumeName(path))
+	default:
+		parts = append(parts, "💻")
+	}
+	for _, dir := range strings.Split(path, string(filepath.Separator)) {
+		if dir != "" {
+			parts = append(parts, dir)
+		}
+	}
+	return parts
+}
+
+// breadcrumbView renders the working directory as a breadcrumb bar, dropping
+// the outermost directories when it doesn't fit
+func (a *App) breadcrumbView() string {
+	if a.cwd == "" {
+		return ""
+	}
+	style := a.theme.Styles.Info
+	parts := breadcrumbs(a.cwd, a.remote)
+	root, dirs := parts[0], parts[1:]
+	crumbs := func() string {
+		crumbs := strings.Join(append([]string{root}, dirs...), breadcrumbSeparator)
+		if len(a.panes) > 1 {
+			crumbs = fmt.Sprintf("🪟 %d/%d  %s", a.activePane()+1, len(a.panes), crumbs)
+		}
+		if a.recording != nil {
+			crumbs = "🔴 REC  " + crumbs
+		}
+		if schedule := a.themeScheduleView(); schedule != "" {
+			crumbs = schedule + "  " + crumbs
+		}
+		return crumbs
+	}
+	for len(dirs) > 1 && lipgloss.Width(crumbs())+style.GetHorizontalFrameSize() > a.width-2 {
+		root, dirs = "…", dirs[1:]
+	}
+	return style.MaxWidth(a.width - 2).Render(crumbs())
+}
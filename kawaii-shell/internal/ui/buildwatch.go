@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+const (
+	// buildRuns is how many builds the dragon keeps watch over
+	buildRuns = 2
+
+	// buildSlower is how much longer than last time a build has to take
+	// for the dragon to mention it, when it takes over buildSlowest
+	buildSlower  = 1.5
+	buildSlowest = 5 * time.Second
+)
+
+// buildCommands are the commands that build, on top of the ones that test
+var buildCommands = []string{
+	"go build", "go install", "cargo build", "npm run build", "yarn build", "pnpm build",
+	"make", "cmake --build", "mvn package", "mvn install", "gradle build", "./gradlew build",
+	"dotnet build", "docker build", "bazel build", "tsc",
+}
+
+// buildRun is how a build or test command went
+type buildRun struct {
+	command string
+	code    int
+	took    time.Duration
+}
+
+// buildWatch is the dragon's ability, watching over the builds and tests,
+// how they went and how long they took
+type buildWatch struct {
+	noAbility
+	runs []buildRun
+}
+
+// isBuildCommand reports whether the command builds or tests
+func isBuildCommand(command string) bool {
+	if pet.IsTestCommand(command) {
+		return true
+	}
+	for _, build := range buildCommands {
+		if command == build || strings.HasPrefix(command, build+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *buildWatch) name() string {
+	return "🐉 Build watch, how the builds and tests went"
+}
+
+func (w *buildWatch) finished(a *App, command string, code int, took time.Duration) {
+	command = strings.Join(strings.Fields(command), " ")
+	if !isBuildCommand(command) {
+		return
+	}
+	for i := len(w.runs) - 1; i >= 0; i-- {
+		last := w.runs[i]
+		if last.command != command {
+			continue
+		}
+		if code == 0 && last.code == 0 && took > buildSlowest && float64(took) > buildSlower*float64(last.took) {
+			a.output = append(a.output, a.petLine(fmt.Sprintf(
+				"🐉 %s noticed %s took %s, it was %s last time", a.pet.Name, command, formatDuration(took), formatDuration(last.took))))
+		}
+		break
+	}
+	w.runs = append(w.runs, buildRun{command: command, code: code, took: took})
+	w.runs = w.runs[max(len(w.runs)-buildRuns, 0):]
+}
+
+func (w *buildWatch) view(a *App) []string {
+	if len(w.runs) == 0 {
+		return nil
+	}
+	lines := []string{"🐉 Build watch"}
+	for i := len(w.runs) - 1; i >= 0; i-- {
+		run := w.runs[i]
+		emoji := "✅"
+		if run.code != 0 {
+			emoji = "🔥"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", emoji, formatDuration(run.took), run.command))
+	}
+	return lines
+}
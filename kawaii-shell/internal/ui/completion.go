@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// maxCompletionRows is the number of candidates shown at once in the popup
+const maxCompletionRows = 8
+
+// FlagsFoundMsg carries the flags found for a command in the background,
+// along with the input they were looked up for
+type FlagsFoundMsg struct {
+	Found  shell.FoundFlags
+	Input  string
+	Cursor int
+}
+
+// complete completes the word under the cursor, opening the popup when
+// there's more than one candidate
+func (a *App) complete() {
+	start, completions, find := a.completer.Complete(a.input.Value(), a.input.Cursor(), a.cwd)
+	if find != nil {
+		// finding flags can mean running the command, which mustn't hold up
+		// typing
+		completer, cwd := a.completer, a.cwd
+		input, cursor := a.input.Value(), a.input.Cursor()
+		a.cmds = append(a.cmds, func() tea.Msg {
+			return FlagsFoundMsg{Found: completer.FindFlags(find, cwd), Input: input, Cursor: cursor}
+		})
+		return
+	}
+	switch len(completions) {
+	case 0:
+		return
+	case 1:
+		a.applyCompletion(start, completions[0])
+		return
+	}
+
+	// extend the word as far as all candidates agree before showing them
+	if prefix := shell.CommonPrefix(completions); len(prefix) > a.input.Cursor()-start {
+		a.replaceWord(start, prefix)
+		return
+	}
+	a.completions = completions
+	a.completionIndex = 0
+	a.completionStart = start
+}
+
+// completeFlags keeps the flags found and completes with them, unless the
+// input changed while they were looked up
+func (a *App) completeFlags(msg FlagsFoundMsg) {
+	a.completer.StoreFlags(msg.Found)
+	if a.input.Value() == msg.Input && a.input.Cursor() == msg.Cursor && len(a.completions) == 0 {
+		a.complete()
+	}
+}
+
+// handleCompletionKey handles keys while the popup is open, returning
+// whether the key was consumed
+func (a *App) handleCompletionKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "tab", "down":
+		a.completionIndex = (a.completionIndex + 1) % len(a.completions)
+	case "shift+tab", "up":
+		a.completionIndex = (a.completionIndex - 1 + len(a.completions)) % len(a.completions)
+	case "enter":
+		a.applyCompletion(a.completionStart, a.completions[a.completionIndex])
+		a.closeCompletions()
+	case "esc":
+		a.closeCompletions()
+	default:
+		a.closeCompletions()
+		return false
+	}
+	return true
+}
+
+// applyCompletion replaces the word under the cursor with the candidate,
+// adding a space after complete words so the next argument can be typed.
+// Flags ending with = wait for their value.
+func (a *App) applyCompletion(start int, completion shell.Completion) {
+	value := completion.Value
+	if !completion.IsDir && !strings.HasSuffix(value, "=") {
+		value += " "
+	}
+	a.replaceWord(start, value)
+}
+
+func (a *App) replaceWord(start int, value string) {
+	a.input.Replace(start, a.input.Cursor(), value)
+}
+
+func (a *App) closeCompletions() {
+	a.completions = nil
+	a.completionIndex = 0
+}
+
+// completionView renders the popup with the completion candidates
+func (a *App) completionView() string {
+	if len(a.completions) == 0 {
+		return ""
+	}
+
+	// keep the selected candidate visible
+	first := max(0, a.completionIndex-maxCompletionRows+1)
+	last := min(len(a.completions), first+maxCompletionRows)
+
+	input := a.theme.Styles.Input
+	itemStyle := lipgloss.NewStyle().
+		Foreground(input.GetForeground()).
+		Background(input.GetBackground()).
+		Padding(0, 1)
+	selectedStyle := a.theme.Styles.Highlight
+	if _, ok := selectedStyle.GetBackground().(lipgloss.NoColor); ok {
+		// not every theme has a highlight style
+		selectedStyle = itemStyle.Reverse(true)
+	}
+
+	rows := make([]string, 0, last-first+1)
+	for i := first; i < last; i++ {
+		if i == a.completionIndex {
+			rows = append(rows, selectedStyle.Render(a.completions[i].Display))
+			continue
+		}
+		rows = append(rows, itemStyle.Render(a.completions[i].Display))
+	}
+	if len(a.completions) > maxCompletionRows {
+		rows = append(rows, itemStyle.Render(strings.Repeat("·", 3)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(a.theme.Roles.Accent).
+		BorderBackground(input.GetBackground()).
+		Background(input.GetBackground()).
+		MarginLeft(lipgloss.Width(a.theme.Styles.Prompt.Render(a.prompt))).
+		Render(strings.Join(rows, "\n"))
+}
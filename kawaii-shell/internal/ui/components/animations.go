@@ -7,9 +7,7 @@ import (
 	"strings"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss/v2"
-	"github.com/charmbracelet/x/exp/charmtone"
 )
 
 type AnimationState int
@@ -0,0 +1,108 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// sparkBars are the bars of a sparkline, from the lowest to the highest
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline is a tiny bar chart, one column per value
+type Sparkline struct {
+	Values []float64
+	Style  lipgloss.Style
+}
+
+// NewSparkline creates a sparkline of the values
+func NewSparkline(values []float64, style lipgloss.Style) *Sparkline {
+	return &Sparkline{Values: values, Style: style}
+}
+
+// Render renders the sparkline, scaled so the highest value gets the full
+// bar. Zero values are left blank.
+func (s *Sparkline) Render() string {
+	highest := 0.0
+	for _, v := range s.Values {
+		highest = max(highest, v)
+	}
+
+	var b strings.Builder
+	for _, v := range s.Values {
+		switch {
+		case v <= 0 || highest == 0:
+			b.WriteRune(' ')
+		default:
+			i := int(v / highest * float64(len(sparkBars)-1))
+			b.WriteRune(sparkBars[i])
+		}
+	}
+	return s.Style.Render(b.String())
+}
+
+// Table lays rows out in aligned columns under a header
+type Table struct {
+	Headers     []string
+	Rows        [][]string
+	HeaderStyle lipgloss.Style
+	CellStyle   lipgloss.Style
+
+	// MaxWidth truncates cells wider than it, zero doesn't
+	MaxWidth int
+}
+
+// NewTable creates a table with the given headers
+func NewTable(headers []string, headerStyle, cellStyle lipgloss.Style) *Table {
+	return &Table{
+		Headers:     headers,
+		HeaderStyle: headerStyle,
+		CellStyle:   cellStyle,
+	}
+}
+
+// AddRow adds a row of cells
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Render renders the table, one line per row
+func (t *Table) Render() []string {
+	widths := make([]int, len(t.Headers))
+	rows := append([][]string{t.Headers}, t.Rows...)
+	for _, row := range rows {
+		for i, cell := range row[:min(len(row), len(widths))] {
+			widths[i] = max(widths[i], t.width(cell))
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for r, row := range rows {
+		style := t.CellStyle
+		if r == 0 {
+			style = t.HeaderStyle
+		}
+		cells := make([]string, len(widths))
+		for i := range widths {
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			if t.MaxWidth > 0 {
+				// wide characters take two columns, so cut by width
+				cell = ansi.Truncate(cell, t.MaxWidth, "…")
+			}
+			cells[i] = cell + strings.Repeat(" ", max(widths[i]-lipgloss.Width(cell), 0))
+		}
+		lines = append(lines, style.Render(strings.TrimRight(strings.Join(cells, "  "), " ")))
+	}
+	return lines
+}
+
+func (t *Table) width(cell string) int {
+	if t.MaxWidth > 0 {
+		return min(lipgloss.Width(cell), t.MaxWidth)
+	}
+	return lipgloss.Width(cell)
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/charmtone"
+)
+
+var (
+	// focusText is how the text of focused components looks
+	focusText = lipgloss.NewStyle().Bold(true)
+
+	// focusRing is the border around focused components
+	focusRing = lipgloss.NewStyle().
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(charmtone.Malibu)
+)
+
+// SetFocusStyles sets how focused buttons, sliders, tabs, dropdowns and
+// modals look: text is the style of their text and ring the border around
+// them. A ring without a border keeps the thick one.
+func SetFocusStyles(text, ring lipgloss.Style) {
+	if ring.GetBorderStyle() == (lipgloss.Border{}) {
+		ring = ring.Border(lipgloss.ThickBorder())
+	}
+	focusText, focusRing = text, ring
+}
+
+// ringed returns the style with the focus ring around it
+func ringed(style lipgloss.Style) lipgloss.Style {
+	return style.
+		Border(focusRing.GetBorderStyle()).
+		BorderForeground(
+			focusRing.GetBorderTopForeground(),
+			focusRing.GetBorderRightForeground(),
+			focusRing.GetBorderBottomForeground(),
+			focusRing.GetBorderLeftForeground())
+}
+
+// focused returns the style with the focus ring around it and the focus
+// look for its text
+func focused(style lipgloss.Style) lipgloss.Style {
+	style = ringed(style)
+	if _, unset := focusText.GetForeground().(lipgloss.NoColor); !unset {
+		style = style.Foreground(focusText.GetForeground())
+	}
+	if _, unset := focusText.GetBackground().(lipgloss.NoColor); !unset {
+		style = style.Background(focusText.GetBackground())
+	}
+	if focusText.GetBold() {
+		style = style.Bold(true)
+	}
+	if focusText.GetUnderline() {
+		style = style.Underline(true)
+	}
+	return style
+}
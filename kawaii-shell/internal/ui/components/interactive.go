@@ -2,13 +2,16 @@ package components
 
 import (
 	"fmt"
+	"image/color"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
 )
 
 // ButtonState represents the current state of a button
@@ -46,22 +49,22 @@ func NewButton(text string, x, y, width int) *Button {
 		Align(lipgloss.Center).
 		Padding(1, 2).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Coral).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#fff8f8")).
 		Foreground(charmtone.Charcoal).
 		Bold(true)
 
 	hoverStyle := baseStyle.Copy().
-		BorderForeground(charmtone.Salmon).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#ffe8e8")).
-		Foreground(charmtone.Coral).
+		Foreground(roles.Accent).
 		Transform(func(s string) string {
 			return fmt.Sprintf("✨ %s ✨", s)
 		})
 
 	pressedStyle := baseStyle.Copy().
-		BorderForeground(charmtone.Cherry).
-		Background(charmtone.Salmon).
+		BorderForeground(roles.Accent).
+		Background(roles.Accent).
 		Foreground(charmtone.Butter).
 		Transform(func(s string) string {
 			return fmt.Sprintf("🌟 %s 🌟", s)
@@ -223,25 +226,15 @@ func (b *Button) Render() string {
 		style = b.PressedStyle
 	case ButtonDisabled:
 		style = b.Style.Copy().
-			Foreground(lipgloss.Color("#999999")).
-			BorderForeground(lipgloss.Color("#cccccc"))
+			Foreground(roles.Muted).
+			BorderForeground(roles.Muted)
 	default:
 		style = b.Style
 	}
 
 	// Add focus indicator
-	if b.Focused {
-		style = style.Copy().
-			Border(lipgloss.ThickBorder()).
-			BorderForeground(charmtone.Malibu)
-	}
-
-	// Add glow effect
-	if b.GlowLevel > 0 {
-		glowIntensity := int(b.GlowLevel * 10)
-		if glowIntensity > 0 {
-			style = style.Copy().Border(lipgloss.ThickBorder())
-		}
+	if b.Focused && b.State != ButtonDisabled {
+		style = focused(style)
 	}
 
 	return style.Render(b.Text)
@@ -268,7 +261,7 @@ func NewSlider(label string, x, y, width int, min, max, value float64) *Slider {
 		Width(width).
 		Padding(1).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Guppy).
+		BorderForeground(roles.Info).
 		Background(lipgloss.Color("#f0f8ff"))
 
 	return &Slider{
@@ -417,9 +410,7 @@ func (s *Slider) Render() string {
 
 	style := s.Style
 	if s.Focused {
-		style = style.Copy().
-			BorderForeground(charmtone.Coral).
-			Background(lipgloss.Color("#fff8f8"))
+		style = focused(style)
 	}
 
 	return style.Render(content)
@@ -436,15 +427,22 @@ type ProgressBar struct {
 	Particles  *ParticleSystem
 	RainbowPos int
 	PulseTime  float64
+
+	// Colors cycle through the filled part, a rainbow by default
+	Colors []string
+
+	// painted is the rainbow of the Colors, made again once they change
+	painted       *themes.Rainbow
+	paintedColors []string
 }
 
 // NewProgressBar creates a stunning progress bar
 func NewProgressBar(label string, x, y, width int, max float64) *ProgressBar {
+	// the style fits the bar, width is the size of the bar itself
 	style := lipgloss.NewStyle().
-		Width(width).
 		Padding(1).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Pony).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#fff0ff"))
 
 	return &ProgressBar{
@@ -462,7 +460,7 @@ func NewProgressBar(label string, x, y, width int, max float64) *ProgressBar {
 func (pb *ProgressBar) Update(msg tea.Msg) (*ProgressBar, tea.Cmd) {
 	var cmds []tea.Cmd
 
-	switch msg := msg.(type) {
+	switch msg.(type) {
 	case ParticleTickMsg:
 		pb.Particles.Update(0.05)
 		pb.updateRainbow()
@@ -514,6 +512,34 @@ func (pb *ProgressBar) updateRainbow() {
 	pb.RainbowPos = (pb.RainbowPos + 1) % 7
 }
 
+// rainbow fills the progress bars without colors of their own and colors
+// the startup's logo
+var rainbow = themes.NewRainbow(lipgloss.NewStyle().Bold(true),
+	lipgloss.Color("#ff0000"), // Red
+	lipgloss.Color("#ff8000"), // Orange
+	lipgloss.Color("#ffff00"), // Yellow
+	lipgloss.Color("#00ff00"), // Green
+	lipgloss.Color("#0080ff"), // Blue
+	lipgloss.Color("#8000ff"), // Purple
+	lipgloss.Color("#ff00ff"), // Magenta
+)
+
+// rainbow returns the rainbow the bar is filled with
+func (pb *ProgressBar) rainbow() *themes.Rainbow {
+	if len(pb.Colors) == 0 {
+		return rainbow
+	}
+	if pb.painted == nil || !slices.Equal(pb.Colors, pb.paintedColors) {
+		colors := make([]color.Color, len(pb.Colors))
+		for i, c := range pb.Colors {
+			colors[i] = lipgloss.Color(c)
+		}
+		pb.painted = themes.NewRainbow(lipgloss.NewStyle().Bold(true), colors...)
+		pb.paintedColors = slices.Clone(pb.Colors)
+	}
+	return pb.painted
+}
+
 // Render renders the stunning progress bar
 func (pb *ProgressBar) Render() string {
 	percent := pb.Progress / pb.Max
@@ -525,28 +551,10 @@ func (pb *ProgressBar) Render() string {
 	emptyWidth := (pb.Width - 4) - filledWidth
 
 	// Create rainbow effect for filled portion
-	colors := []string{
-		"#ff0000", // Red
-		"#ff8000", // Orange
-		"#ffff00", // Yellow
-		"#00ff00", // Green
-		"#0080ff", // Blue
-		"#8000ff", // Purple
-		"#ff00ff", // Magenta
-	}
-
-	var filled string
-	for i := 0; i < filledWidth; i++ {
-		colorIndex := (i + pb.RainbowPos) % len(colors)
-		char := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colors[colorIndex])).
-			Bold(true).
-			Render("█")
-		filled += char
-	}
+	filled := pb.rainbow().Paint(strings.Repeat("█", max(filledWidth, 0)), pb.RainbowPos)
 
 	empty := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#cccccc")).
+		Foreground(roles.Muted).
 		Render(strings.Repeat("░", emptyWidth))
 
 	// Add pulse effect when complete
@@ -35,13 +35,13 @@ type TabGroup struct {
 func NewTabGroup(x, y, width, height int) *TabGroup {
 	baseStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Guppy).
+		BorderForeground(roles.Info).
 		Background(lipgloss.Color("#f0f8ff")).
 		Padding(1)
 
 	activeStyle := lipgloss.NewStyle().
 		Border(lipgloss.ThickBorder()).
-		BorderForeground(charmtone.Coral).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#fff8f8")).
 		Foreground(charmtone.Charcoal).
 		Bold(true).
@@ -176,7 +176,7 @@ func (tg *TabGroup) Render() string {
 
 	// Render tab headers
 	var headers []string
-	for i, tab := range tg.Tabs {
+	for _, tab := range tg.Tabs {
 		style := tg.Style.Copy().
 			Padding(0, 2).
 			Margin(0, 1).
@@ -189,9 +189,7 @@ func (tg *TabGroup) Render() string {
 		}
 
 		if tg.Focused && tab.Active {
-			style = style.Copy().
-				Border(lipgloss.DoubleBorder()).
-				BorderForeground(charmtone.Malibu)
+			style = focused(style)
 		}
 
 		headers = append(headers, style.Render(tab.Title))
@@ -210,7 +208,7 @@ func (tg *TabGroup) Render() string {
 
 		if tg.Tabs[tg.ActiveTab].Active {
 			contentStyle = contentStyle.Copy().
-				BorderForeground(charmtone.Coral).
+				BorderForeground(roles.Accent).
 				Background(lipgloss.Color("#fff8f8"))
 		}
 
@@ -248,7 +246,7 @@ func NewDropdown(label string, x, y, width int) *Dropdown {
 	baseStyle := lipgloss.NewStyle().
 		Width(width).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Guac).
+		BorderForeground(roles.Info).
 		Background(lipgloss.Color("#f5fff5")).
 		Padding(0, 1)
 
@@ -258,7 +256,7 @@ func NewDropdown(label string, x, y, width int) *Dropdown {
 		Background(lipgloss.Color("#ffffff"))
 
 	selectedStyle := optionStyle.Copy().
-		Background(charmtone.Coral).
+		Background(roles.Accent).
 		Foreground(charmtone.Butter).
 		Bold(true).
 		Transform(func(s string) string {
@@ -437,9 +435,7 @@ func (d *Dropdown) Render() string {
 
 	style := d.Style
 	if d.Focused {
-		style = style.Copy().
-			BorderForeground(charmtone.Coral).
-			Background(lipgloss.Color("#fff8f8"))
+		style = focused(style)
 	}
 
 	header := style.Render(headerText)
@@ -460,7 +456,7 @@ func (d *Dropdown) Render() string {
 
 	optionsBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(charmtone.Guac).
+		BorderForeground(roles.Info).
 		Background(lipgloss.Color("#ffffff")).
 		Render(strings.Join(options, "\n"))
 
@@ -488,7 +484,7 @@ func NewModal(title, content string, width, height int) *Modal {
 		Width(width).
 		Height(height).
 		Border(lipgloss.ThickBorder()).
-		BorderForeground(charmtone.Salmon).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#fff8f8")).
 		Padding(2).
 		Align(lipgloss.Center)
@@ -581,7 +577,7 @@ func (m *Modal) Render() string {
 	// Create title
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(charmtone.Coral).
+		Foreground(roles.Accent).
 		Align(lipgloss.Center).
 		Width(m.Width - 4)
 
@@ -614,5 +610,10 @@ func (m *Modal) Render() string {
 		buttonRow,
 	)
 
-	return m.Style.Render(modalContent)
+	style := m.Style
+	if m.Focused {
+		style = ringed(style)
+	}
+
+	return style.Render(modalContent)
 }
@@ -26,6 +26,13 @@ type ParticleSystem struct {
 	width     int
 	height    int
 	active    bool
+
+	// intensity scales how many particles the effects add
+	intensity float64
+
+	// rng rolls the dice for the particles, seeded to show the same effects
+	// every time
+	rng *rand.Rand
 }
 
 // NewParticleSystem creates a new particle system
@@ -35,43 +42,78 @@ func NewParticleSystem(width, height int) *ParticleSystem {
 		width:     width,
 		height:    height,
 		active:    true,
+		intensity: 1,
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+// Seed makes the particles come out the same every time for the seed
+func (ps *ParticleSystem) Seed(seed int64) {
+	ps.rng = rand.New(rand.NewSource(seed))
+}
+
+// pick returns one of the emojis at random
+func (ps *ParticleSystem) pick(emojis []string) string {
+	return emojis[ps.rng.Intn(len(emojis))]
+}
+
+// the emojis of the particles of each kind
+var (
+	sparkleEmojis     = []string{"✨", "⭐", "💫", "🌟", "✦", "✧", "⚡"}
+	heartEmojis       = []string{"💕", "💖", "💗", "💓", "💝", "💘", "💞"}
+	flowerEmojis      = []string{"🌸", "🌺", "🌻", "🌷", "🌹", "🌼", "🌿"}
+	magicEmojis       = []string{"🔮", "🪄", "✨", "🌟", "⭐", "💫", "🎆", "🎇", "🌈", "🦄"}
+	fireworkEmojis    = []string{"🎆", "🎇", "✨", "💥", "🌟", "⚡", "💫"}
+	celebrationEmojis = []string{"🎉", "🎊", "🥳", "🎈", "🎁", "🏆", "👑", "💎"}
+)
+
+// seasonEmojis are the emojis sparkles and petals turn into for the season,
+// none outside of one
+var seasonEmojis []string
+
+// SetSeasonParticles has sparkles and petals turn into the emojis of the
+// season, or back into themselves for none
+func SetSeasonParticles(emojis []string) {
+	seasonEmojis = emojis
+}
+
+// seasonal returns the season's emojis in place of the ones there are
+// when it's in one
+func seasonal(emojis []string) []string {
+	if len(seasonEmojis) > 0 {
+		return seasonEmojis
+	}
+	return emojis
+}
+
 // SparkleEmoji returns random sparkle emojis
 func SparkleEmoji() string {
-	sparkles := []string{"✨", "⭐", "💫", "🌟", "✦", "✧", "⚡"}
-	return sparkles[rand.Intn(len(sparkles))]
+	return sparkleEmojis[rand.Intn(len(sparkleEmojis))]
 }
 
 // HeartEmoji returns random heart emojis
 func HeartEmoji() string {
-	hearts := []string{"💕", "💖", "💗", "💓", "💝", "💘", "💞"}
-	return hearts[rand.Intn(len(hearts))]
+	return heartEmojis[rand.Intn(len(heartEmojis))]
 }
 
 // FlowerEmoji returns random flower emojis
 func FlowerEmoji() string {
-	flowers := []string{"🌸", "🌺", "🌻", "🌷", "🌹", "🌼", "🌿"}
-	return flowers[rand.Intn(len(flowers))]
+	return flowerEmojis[rand.Intn(len(flowerEmojis))]
 }
 
 // MagicEmoji returns random magic emojis
 func MagicEmoji() string {
-	magic := []string{"🔮", "🪄", "✨", "🌟", "⭐", "💫", "🎆", "🎇", "🌈", "🦄"}
-	return magic[rand.Intn(len(magic))]
+	return magicEmojis[rand.Intn(len(magicEmojis))]
 }
 
 // FireworkEmoji returns random firework emojis
 func FireworkEmoji() string {
-	fireworks := []string{"🎆", "🎇", "✨", "💥", "🌟", "⚡", "💫"}
-	return fireworks[rand.Intn(len(fireworks))]
+	return fireworkEmojis[rand.Intn(len(fireworkEmojis))]
 }
 
 // CelebrationEmoji returns random celebration emojis
 func CelebrationEmoji() string {
-	celebration := []string{"🎉", "🎊", "🥳", "🎈", "🎁", "🏆", "👑", "💎"}
-	return celebration[rand.Intn(len(celebration))]
+	return celebrationEmojis[rand.Intn(len(celebrationEmojis))]
 }
 
 // ParticleType represents different types of particle effects
@@ -112,22 +154,23 @@ func (ps *ParticleSystem) AddSparkles(x, y int, count int) {
 	if !ps.active {
 		return
 	}
+	count = ps.scaled(count)
 
 	for i := 0; i < count; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*2 + 0.5
-		life := rand.Float64()*2 + 1
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*2 + 0.5
+		life := ps.rng.Float64()*2 + 1
 
 		particle := Particle{
-			X:        float64(x) + rand.Float64()*4 - 2,
-			Y:        float64(y) + rand.Float64()*4 - 2,
+			X:        float64(x) + ps.rng.Float64()*4 - 2,
+			Y:        float64(y) + ps.rng.Float64()*4 - 2,
 			VX:       math.Cos(angle) * speed,
 			VY:       math.Sin(angle) * speed,
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    SparkleEmoji(),
-			Size:     rand.Float64()*0.5 + 0.5,
-			Rotation: rand.Float64() * 2 * math.Pi,
+			Emoji:    ps.pick(seasonal(sparkleEmojis)),
+			Size:     ps.rng.Float64()*0.5 + 0.5,
+			Rotation: ps.rng.Float64() * 2 * math.Pi,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -139,21 +182,22 @@ func (ps *ParticleSystem) AddHearts(x, y int, count int) {
 	if !ps.active {
 		return
 	}
+	count = ps.scaled(count)
 
 	for i := 0; i < count; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*1.5 + 0.3
-		life := rand.Float64()*3 + 2
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*1.5 + 0.3
+		life := ps.rng.Float64()*3 + 2
 
 		particle := Particle{
-			X:       float64(x) + rand.Float64()*6 - 3,
-			Y:       float64(y) + rand.Float64()*6 - 3,
+			X:       float64(x) + ps.rng.Float64()*6 - 3,
+			Y:       float64(y) + ps.rng.Float64()*6 - 3,
 			VX:      math.Cos(angle) * speed,
 			VY:      math.Sin(angle)*speed - 0.5, // Hearts float up
 			Life:    life,
 			MaxLife: life,
-			Emoji:   HeartEmoji(),
-			Size:    rand.Float64()*0.7 + 0.8,
+			Emoji:   ps.pick(heartEmojis),
+			Size:    ps.rng.Float64()*0.7 + 0.8,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -165,22 +209,23 @@ func (ps *ParticleSystem) AddFlowerPetals(x, y int, count int) {
 	if !ps.active {
 		return
 	}
+	count = ps.scaled(count)
 
 	for i := 0; i < count; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*1 + 0.2
-		life := rand.Float64()*4 + 3
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*1 + 0.2
+		life := ps.rng.Float64()*4 + 3
 
 		particle := Particle{
-			X:        float64(x) + rand.Float64()*8 - 4,
-			Y:        float64(y) + rand.Float64()*8 - 4,
+			X:        float64(x) + ps.rng.Float64()*8 - 4,
+			Y:        float64(y) + ps.rng.Float64()*8 - 4,
 			VX:       math.Cos(angle) * speed,
 			VY:       math.Sin(angle)*speed*0.5 + 0.3, // Petals drift down
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    FlowerEmoji(),
-			Size:     rand.Float64()*0.6 + 0.4,
-			Rotation: rand.Float64() * 2 * math.Pi,
+			Emoji:    ps.pick(seasonal(flowerEmojis)),
+			Size:     ps.rng.Float64()*0.6 + 0.4,
+			Rotation: ps.rng.Float64() * 2 * math.Pi,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -254,6 +299,20 @@ func (ps *ParticleSystem) Clear() {
 	ps.particles = ps.particles[:0]
 }
 
+// SetIntensity scales how many particles the effects add, 0 adding none
+func (ps *ParticleSystem) SetIntensity(intensity float64) {
+	ps.intensity = intensity
+}
+
+// scaled scales the number of particles by the intensity, keeping at least
+// one unless there are none to add
+func (ps *ParticleSystem) scaled(count int) int {
+	if ps.intensity <= 0 || count <= 0 {
+		return 0
+	}
+	return max(int(math.Round(float64(count)*ps.intensity)), 1)
+}
+
 // SetActive enables/disables the particle system
 func (ps *ParticleSystem) SetActive(active bool) {
 	ps.active = active
@@ -279,9 +338,9 @@ func (ps *ParticleSystem) AddMagicBlast(x, y int, intensity int) {
 
 	count := 20 + intensity*5
 	for i := 0; i < count; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*4 + 2
-		life := rand.Float64()*3 + 2
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*4 + 2
+		life := ps.rng.Float64()*3 + 2
 
 		particle := Particle{
 			X:        float64(x),
@@ -290,9 +349,9 @@ func (ps *ParticleSystem) AddMagicBlast(x, y int, intensity int) {
 			VY:       math.Sin(angle) * speed,
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    MagicEmoji(),
-			Size:     rand.Float64()*0.8 + 0.7,
-			Rotation: rand.Float64() * 2 * math.Pi,
+			Emoji:    ps.pick(magicEmojis),
+			Size:     ps.rng.Float64()*0.8 + 0.7,
+			Rotation: ps.rng.Float64() * 2 * math.Pi,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -307,9 +366,9 @@ func (ps *ParticleSystem) AddFireworks(x, y int, colors []string) {
 
 	// Main burst
 	for i := 0; i < 25; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*3 + 1.5
-		life := rand.Float64()*4 + 3
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*3 + 1.5
+		life := ps.rng.Float64()*4 + 3
 
 		particle := Particle{
 			X:       float64(x),
@@ -318,12 +377,12 @@ func (ps *ParticleSystem) AddFireworks(x, y int, colors []string) {
 			VY:      math.Sin(angle) * speed,
 			Life:    life,
 			MaxLife: life,
-			Emoji:   FireworkEmoji(),
-			Size:    rand.Float64()*1.2 + 0.8,
+			Emoji:   ps.pick(fireworkEmojis),
+			Size:    ps.rng.Float64()*1.2 + 0.8,
 		}
 
 		if len(colors) > 0 {
-			particle.Color = colors[rand.Intn(len(colors))]
+			particle.Color = colors[ps.rng.Intn(len(colors))]
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -331,19 +390,19 @@ func (ps *ParticleSystem) AddFireworks(x, y int, colors []string) {
 
 	// Secondary sparkles
 	for i := 0; i < 15; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := rand.Float64()*1.5 + 0.5
-		life := rand.Float64()*2 + 1.5
+		angle := ps.rng.Float64() * 2 * math.Pi
+		speed := ps.rng.Float64()*1.5 + 0.5
+		life := ps.rng.Float64()*2 + 1.5
 
 		particle := Particle{
-			X:       float64(x) + rand.Float64()*10 - 5,
-			Y:       float64(y) + rand.Float64()*10 - 5,
+			X:       float64(x) + ps.rng.Float64()*10 - 5,
+			Y:       float64(y) + ps.rng.Float64()*10 - 5,
 			VX:      math.Cos(angle) * speed,
 			VY:      math.Sin(angle) * speed,
 			Life:    life,
 			MaxLife: life,
-			Emoji:   SparkleEmoji(),
-			Size:    rand.Float64()*0.6 + 0.4,
+			Emoji:   ps.pick(seasonal(sparkleEmojis)),
+			Size:    ps.rng.Float64()*0.6 + 0.4,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -377,17 +436,17 @@ func (ps *ParticleSystem) AddRainbowTrail(startX, startY, endX, endY int) {
 
 		// Add multiple particles at each step
 		for j := 0; j < 3; j++ {
-			life := rand.Float64()*2 + 1
+			life := ps.rng.Float64()*2 + 1
 			particle := Particle{
-				X:       x + rand.Float64()*4 - 2,
-				Y:       y + rand.Float64()*4 - 2,
-				VX:      rand.Float64()*0.5 - 0.25,
-				VY:      rand.Float64()*0.5 - 0.25,
+				X:       x + ps.rng.Float64()*4 - 2,
+				Y:       y + ps.rng.Float64()*4 - 2,
+				VX:      ps.rng.Float64()*0.5 - 0.25,
+				VY:      ps.rng.Float64()*0.5 - 0.25,
 				Life:    life,
 				MaxLife: life,
 				Emoji:   "✨",
 				Color:   colors[i%len(colors)],
-				Size:    rand.Float64()*0.7 + 0.3,
+				Size:    ps.rng.Float64()*0.7 + 0.3,
 			}
 
 			ps.particles = append(ps.particles, particle)
@@ -402,20 +461,20 @@ func (ps *ParticleSystem) AddStardust(x, y, width, height int, density int) {
 	}
 
 	for i := 0; i < density; i++ {
-		px := float64(x) + rand.Float64()*float64(width)
-		py := float64(y) + rand.Float64()*float64(height)
-		life := rand.Float64()*5 + 3
+		px := float64(x) + ps.rng.Float64()*float64(width)
+		py := float64(y) + ps.rng.Float64()*float64(height)
+		life := ps.rng.Float64()*5 + 3
 
 		particle := Particle{
 			X:        px,
 			Y:        py,
-			VX:       rand.Float64()*0.3 - 0.15,
-			VY:       -rand.Float64()*0.5 - 0.2, // Gentle upward drift
+			VX:       ps.rng.Float64()*0.3 - 0.15,
+			VY:       -ps.rng.Float64()*0.5 - 0.2, // Gentle upward drift
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    SparkleEmoji(),
-			Size:     rand.Float64()*0.5 + 0.3,
-			Rotation: rand.Float64() * 2 * math.Pi,
+			Emoji:    ps.pick(seasonal(sparkleEmojis)),
+			Size:     ps.rng.Float64()*0.5 + 0.3,
+			Rotation: ps.rng.Float64() * 2 * math.Pi,
 		}
 
 		ps.particles = append(ps.particles, particle)
@@ -444,18 +503,18 @@ func (ps *ParticleSystem) AddCelebrationBurst(x, y int) {
 		offsetY := int(math.Sin(angle) * 8)
 
 		for j := 0; j < 10; j++ {
-			life := rand.Float64()*3 + 2
-			speed := rand.Float64()*2 + 0.5
+			life := ps.rng.Float64()*3 + 2
+			speed := ps.rng.Float64()*2 + 0.5
 
 			particle := Particle{
 				X:       float64(x + offsetX),
 				Y:       float64(y + offsetY),
-				VX:      math.Cos(angle+rand.Float64()*0.5-0.25) * speed,
-				VY:      math.Sin(angle+rand.Float64()*0.5-0.25) * speed,
+				VX:      math.Cos(angle+ps.rng.Float64()*0.5-0.25) * speed,
+				VY:      math.Sin(angle+ps.rng.Float64()*0.5-0.25) * speed,
 				Life:    life,
 				MaxLife: life,
-				Emoji:   CelebrationEmoji(),
-				Size:    rand.Float64()*1.0 + 0.5,
+				Emoji:   ps.pick(celebrationEmojis),
+				Size:    ps.rng.Float64()*1.0 + 0.5,
 			}
 
 			ps.particles = append(ps.particles, particle)
@@ -484,7 +543,7 @@ func (ps *ParticleSystem) AddSpiralEffect(centerX, centerY int, radius float64,
 		x := float64(centerX) + math.Cos(angle)*currentRadius
 		y := float64(centerY) + math.Sin(angle)*currentRadius
 
-		life := rand.Float64()*2 + 1.5
+		life := ps.rng.Float64()*2 + 1.5
 		particle := Particle{
 			X:        x,
 			Y:        y,
@@ -492,8 +551,8 @@ func (ps *ParticleSystem) AddSpiralEffect(centerX, centerY int, radius float64,
 			VY:       math.Sin(angle+math.Pi/2) * 0.5,
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    MagicEmoji(),
-			Size:     rand.Float64()*0.6 + 0.4,
+			Emoji:    ps.pick(magicEmojis),
+			Size:     ps.rng.Float64()*0.6 + 0.4,
 			Rotation: angle,
 		}
 
@@ -517,7 +576,7 @@ func (ps *ParticleSystem) AddWaveEffect(centerX, centerY, maxRadius int, intensi
 			x := float64(centerX) + math.Cos(angle)*waveRadius
 			y := float64(centerY) + math.Sin(angle)*waveRadius
 
-			life := rand.Float64()*2 + 1 + float64(wave)*0.3
+			life := ps.rng.Float64()*2 + 1 + float64(wave)*0.3
 			particle := Particle{
 				X:       x,
 				Y:       y,
@@ -525,8 +584,8 @@ func (ps *ParticleSystem) AddWaveEffect(centerX, centerY, maxRadius int, intensi
 				VY:      math.Sin(angle) * 0.3,
 				Life:    life,
 				MaxLife: life,
-				Emoji:   SparkleEmoji(),
-				Size:    rand.Float64()*0.5 + 0.3,
+				Emoji:   ps.pick(seasonal(sparkleEmojis)),
+				Size:    ps.rng.Float64()*0.5 + 0.3,
 			}
 
 			ps.particles = append(ps.particles, particle)
@@ -547,7 +606,7 @@ func (ps *ParticleSystem) AddMagicCircle(centerX, centerY, radius int, rotationS
 		x := float64(centerX) + math.Cos(baseAngle)*float64(radius)
 		y := float64(centerY) + math.Sin(baseAngle)*float64(radius)
 
-		life := rand.Float64()*4 + 3
+		life := ps.rng.Float64()*4 + 3
 		particle := Particle{
 			X:        x,
 			Y:        y,
@@ -555,8 +614,8 @@ func (ps *ParticleSystem) AddMagicCircle(centerX, centerY, radius int, rotationS
 			VY:       math.Sin(baseAngle+math.Pi/2) * rotationSpeed,
 			Life:     life,
 			MaxLife:  life,
-			Emoji:    MagicEmoji(),
-			Size:     rand.Float64()*0.8 + 0.6,
+			Emoji:    ps.pick(magicEmojis),
+			Size:     ps.rng.Float64()*0.8 + 0.6,
 			Rotation: baseAngle,
 		}
 
@@ -564,7 +623,7 @@ func (ps *ParticleSystem) AddMagicCircle(centerX, centerY, radius int, rotationS
 	}
 
 	// Add central sparkle
-	life := rand.Float64()*3 + 2
+	life := ps.rng.Float64()*3 + 2
 	centerParticle := Particle{
 		X:       float64(centerX),
 		Y:       float64(centerY),
@@ -584,8 +643,8 @@ func (ps *ParticleSystem) CreateMagicalAura(centerX, centerY, radius int) tea.Cm
 	return tea.Tick(time.Millisecond*200, func(time.Time) tea.Msg {
 		if ps.active {
 			// Add gentle sparkles in a circle
-			angle := rand.Float64() * 2 * math.Pi
-			distance := rand.Float64() * float64(radius)
+			angle := ps.rng.Float64() * 2 * math.Pi
+			distance := ps.rng.Float64() * float64(radius)
 			x := int(float64(centerX) + math.Cos(angle)*distance)
 			y := int(float64(centerY) + math.Sin(angle)*distance)
 
@@ -0,0 +1,23 @@
+package components
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+)
+
+// roles are the colors components are made in by what they mean
+var roles = themes.Roles{
+	Success: charmtone.Guac,
+	Warning: charmtone.Butter,
+	Error:   charmtone.Cherry,
+	Info:    charmtone.Guppy,
+	Accent:  charmtone.Coral,
+	Muted:   lipgloss.Color("#999999"),
+}
+
+// SetRoles sets the colors components are made in by what they mean, the
+// theme's. Components take them when they're made.
+func SetRoles(r themes.Roles) {
+	roles = r
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"image/color"
 	"math"
 	"strings"
 	"time"
@@ -49,6 +50,10 @@ type StartupSequence struct {
 	infoLines         []string
 	cascadeDelay      []float64
 	version           string
+
+	// celebration is what the startup celebrates, like a birthday, if
+	// anything
+	celebration string
 }
 
 // NewStartupSequence creates a stunning startup animation
@@ -98,6 +103,14 @@ func NewStartupSequence(width, height int, version string) *StartupSequence {
 	}
 }
 
+// Celebrate has the startup celebrate the occasion, with its title in place
+// of the welcome and confetti at the end
+func (ss *StartupSequence) Celebrate(title string) {
+	ss.celebration = title
+	ss.infoLines = append(ss.infoLines, "🎂 Baking a cake...")
+	ss.cascadeDelay = append(ss.cascadeDelay, float64(len(ss.cascadeDelay))*0.3)
+}
+
 // StartupTickMsg represents animation tick for startup
 type StartupTickMsg struct {
 	Time time.Time
@@ -124,7 +137,7 @@ func (ss *StartupSequence) Update(msg tea.Msg) (*StartupSequence, tea.Cmd) {
 
 	var cmds []tea.Cmd
 
-	switch msg := msg.(type) {
+	switch msg.(type) {
 	case StartupTickMsg:
 		ss.updateAnimations()
 		ss.updatePhase()
@@ -291,9 +304,19 @@ func (ss *StartupSequence) createPhaseTransitionEffect() {
 		ss.particleSystem.AddSparkles(centerX, centerY, 30)
 		ss.particleSystem.AddHearts(centerX, centerY, 15)
 		ss.particleSystem.AddFlowerPetals(centerX, centerY, 20)
+		if ss.celebration != "" {
+			ss.particleSystem.AddCelebrationBurst(centerX, centerY)
+		}
 	}
 }
 
+// Skip ends the startup right away, for terminals the animation is no use
+// in
+func (ss *StartupSequence) Skip() {
+	ss.phase = PhaseComplete
+	ss.completed = true
+}
+
 // IsComplete returns whether startup is finished
 func (ss *StartupSequence) IsComplete() bool {
 	return ss.completed
@@ -317,7 +340,7 @@ func (ss *StartupSequence) Render() string {
 		Width(ss.width).
 		Height(ss.height).
 		Border(lipgloss.ThickBorder()).
-		BorderForeground(charmtone.Coral).
+		BorderForeground(roles.Accent).
 		Background(lipgloss.Color("#0a0a1a")).
 		Padding(2).
 		Align(lipgloss.Center)
@@ -362,7 +385,7 @@ func (ss *StartupSequence) renderLogoReveal() string {
 
 	// Title with stunning effects
 	titleStyle := lipgloss.NewStyle().
-		Foreground(charmtone.Coral).
+		Foreground(roles.Accent).
 		Bold(true).
 		Align(lipgloss.Center).
 		Transform(func(s string) string {
@@ -387,7 +410,7 @@ func (ss *StartupSequence) renderLogoReveal() string {
 	if ss.logoAlpha > 0.5 {
 		result.WriteString("\n\n")
 		subtitleStyle := lipgloss.NewStyle().
-			Foreground(charmtone.Guppy).
+			Foreground(roles.Info).
 			Italic(true).
 			Align(lipgloss.Center)
 
@@ -453,7 +476,7 @@ func (ss *StartupSequence) renderInfoCascade() string {
 
 	// Render logo
 	logoStyle := lipgloss.NewStyle().
-		Foreground(charmtone.Coral).
+		Foreground(roles.Accent).
 		Bold(true).
 		Align(lipgloss.Center)
 
@@ -516,14 +539,18 @@ func (ss *StartupSequence) renderFinalReveal() string {
 
 	// Final message
 	finalStyle := lipgloss.NewStyle().
-		Foreground(charmtone.Malibu).
+		Foreground(roles.Info).
 		Bold(true).
 		Align(lipgloss.Center).
 		Transform(func(s string) string {
 			return fmt.Sprintf("🎉 %s 🎉", s)
 		})
 
-	result.WriteString(finalStyle.Render("Welcome to your magical terminal!"))
+	welcome := "Welcome to your magical terminal!"
+	if ss.celebration != "" {
+		welcome = ss.celebration
+	}
+	result.WriteString(finalStyle.Render(welcome))
 
 	return result.String()
 }
@@ -538,32 +565,7 @@ func (ss *StartupSequence) renderParticleOverlay() string {
 // Helper functions for stunning effects
 
 func (ss *StartupSequence) applyRainbowEffect(text string) string {
-	colors := []string{
-		"#ff0000", // Red
-		"#ff8000", // Orange
-		"#ffff00", // Yellow
-		"#00ff00", // Green
-		"#0080ff", // Blue
-		"#8000ff", // Purple
-		"#ff00ff", // Magenta
-	}
-
-	var result strings.Builder
-	for i, char := range text {
-		if char == ' ' {
-			result.WriteRune(char)
-			continue
-		}
-
-		colorIndex := (i + ss.rainbowOffset) % len(colors)
-		styledChar := lipgloss.NewStyle().
-			Foreground(lipgloss.Color(colors[colorIndex])).
-			Bold(true).
-			Render(string(char))
-		result.WriteString(styledChar)
-	}
-
-	return result.String()
+	return rainbow.Paint(text, ss.rainbowOffset)
 }
 
 func (ss *StartupSequence) applyMorphingEffect(text string, progress float64) string {
@@ -580,13 +582,13 @@ func (ss *StartupSequence) applyMorphingEffect(text string, progress float64) st
 	return text
 }
 
-func (ss *StartupSequence) getGlowColor() lipgloss.Color {
+func (ss *StartupSequence) getGlowColor() color.Color {
 	intensity := int(ss.glowIntensity * 255)
 	return lipgloss.Color(fmt.Sprintf("#%02xff%02x", intensity, intensity))
 }
 
-func (ss *StartupSequence) getMorphColor(lineIndex int) lipgloss.Color {
-	colors := []lipgloss.Color{
+func (ss *StartupSequence) getMorphColor(lineIndex int) color.Color {
+	colors := []color.Color{
 		charmtone.Coral,
 		charmtone.Salmon,
 		charmtone.Guppy,
@@ -596,7 +598,7 @@ func (ss *StartupSequence) getMorphColor(lineIndex int) lipgloss.Color {
 	return colors[lineIndex%len(colors)]
 }
 
-func (ss *StartupSequence) getExplosionColor(intensity float64) lipgloss.Color {
+func (ss *StartupSequence) getExplosionColor(intensity float64) color.Color {
 	if intensity > 0.8 {
 		return lipgloss.Color("#ffffff")
 	} else if intensity > 0.5 {
@@ -607,8 +609,8 @@ func (ss *StartupSequence) getExplosionColor(intensity float64) lipgloss.Color {
 	return charmtone.Coral
 }
 
-func (ss *StartupSequence) getCascadeColor(index int, progress float64) lipgloss.Color {
-	baseColors := []lipgloss.Color{
+func (ss *StartupSequence) getCascadeColor(index int, progress float64) color.Color {
+	baseColors := []color.Color{
 		charmtone.Coral,
 		charmtone.Salmon,
 		charmtone.Guppy,
@@ -630,9 +632,9 @@ func (ss *StartupSequence) getCascadeColor(index int, progress float64) lipgloss
 	return baseColor
 }
 
-func (ss *StartupSequence) getFinalColor(lineIndex int) lipgloss.Color {
+func (ss *StartupSequence) getFinalColor(lineIndex int) color.Color {
 	// Cycle through gorgeous colors with pulse effect
-	colors := []lipgloss.Color{
+	colors := []color.Color{
 		charmtone.Coral,
 		charmtone.Salmon,
 		charmtone.Guppy,
@@ -0,0 +1,351 @@
+package components
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rivo/uniseg"
+)
+
+// TextInput is text being edited, which can span lines. The cursor moves a
+// whole grapheme at a time, so emoji, accents and wide characters are never
+// cut in two, and text between it and the anchor is selected.
+type TextInput struct {
+	value string
+
+	// cursor and anchor are byte offsets into the value, always between
+	// two graphemes. The anchor is -1 without a selection.
+	cursor int
+	anchor int
+}
+
+// NewTextInput creates an empty text input
+func NewTextInput() *TextInput {
+	return &TextInput{anchor: -1}
+}
+
+// Value returns the text
+func (t *TextInput) Value() string {
+	return t.value
+}
+
+// Cursor returns the byte offset of the cursor in the text
+func (t *TextInput) Cursor() int {
+	return t.cursor
+}
+
+// AtEnd reports whether the cursor is at the end of the text
+func (t *TextInput) AtEnd() bool {
+	return t.cursor == len(t.value)
+}
+
+// SetValue replaces the text, with the cursor at its end
+func (t *TextInput) SetValue(value string) {
+	t.value, t.cursor, t.anchor = value, len(value), -1
+}
+
+// SetCursor moves the cursor to the byte offset, or to the start of the
+// grapheme it's in
+func (t *TextInput) SetCursor(pos int) {
+	t.cursor, t.anchor = t.boundary(pos), -1
+}
+
+// Reset empties the text
+func (t *TextInput) Reset() {
+	t.SetValue("")
+}
+
+// Lines returns the number of lines of the text
+func (t *TextInput) Lines() int {
+	return strings.Count(t.value, "\n") + 1
+}
+
+// Selection returns the byte offsets of the start and end of the selected
+// text, ok being false when nothing is selected
+func (t *TextInput) Selection() (start, end int, ok bool) {
+	if t.anchor < 0 || t.anchor == t.cursor {
+		return 0, 0, false
+	}
+	return min(t.anchor, t.cursor), max(t.anchor, t.cursor), true
+}
+
+// SelectedText returns the selected text, empty when there's none
+func (t *TextInput) SelectedText() string {
+	start, end, _ := t.Selection()
+	return t.value[start:end]
+}
+
+// Insert puts the text at the cursor, in place of the selection if there's
+// one, leaving the cursor after it
+func (t *TextInput) Insert(text string) {
+	start, end, ok := t.Selection()
+	if !ok {
+		start, end = t.cursor, t.cursor
+	}
+	t.Replace(start, end, text)
+}
+
+// Replace puts the text in place of the bytes from start to end, leaving
+// the cursor after it
+func (t *TextInput) Replace(start, end int, text string) {
+	start = t.boundary(start)
+	end = max(t.boundary(end), start)
+	t.value = t.value[:start] + text + t.value[end:]
+	t.cursor, t.anchor = start+len(text), -1
+}
+
+// DeleteSelection removes the selected text, reporting whether there was
+// any
+func (t *TextInput) DeleteSelection() bool {
+	start, end, ok := t.Selection()
+	if ok {
+		t.Replace(start, end, "")
+	}
+	return ok
+}
+
+// MoveLine moves the cursor to the previous or next line, keeping the
+// column it's shown at where the line is long enough, and selecting on the
+// way when selecting is true. It returns false when there's no line to move
+// to.
+func (t *TextInput) MoveLine(delta int, selecting bool) bool {
+	lineStart := t.lineStart(t.cursor)
+	column := uniseg.StringWidth(t.value[lineStart:t.cursor])
+
+	var start int
+	switch {
+	case delta < 0:
+		if lineStart == 0 {
+			return false
+		}
+		start = t.lineStart(lineStart - 1)
+	default:
+		next := strings.IndexByte(t.value[t.cursor:], '\n')
+		if next < 0 {
+			return false
+		}
+		start = t.cursor + next + 1
+	}
+	end := t.lineEnd(start)
+
+	pos, width := start, 0
+	for pos < end {
+		cluster, _, w, _ := uniseg.FirstGraphemeClusterInString(t.value[pos:end], -1)
+		if width+w > column {
+			break
+		}
+		pos += len(cluster)
+		width += w
+	}
+	t.moveTo(pos, selecting)
+	return true
+}
+
+// Update handles the editing keys, reporting whether the key was one of
+// them:
+//
+//   - typed and pasted text goes in at the cursor, in place of the
+//     selection
+//   - left and right move a grapheme, ctrl or alt with them, or alt+b and
+//     alt+f, a word, and home and end, or ctrl+a and ctrl+e, to the ends of
+//     the line. Shift with any of them selects on the way.
+//   - backspace and delete remove a grapheme or the selection, alt with
+//     them a word, ctrl+w back to the last space, and ctrl+u and ctrl+k
+//     the line before and after the cursor
+//   - esc drops the selection
+func (t *TextInput) Update(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "left", "ctrl+b":
+		if start, _, ok := t.Selection(); ok {
+			t.moveTo(start, false)
+			break
+		}
+		t.moveTo(t.prev(t.cursor), false)
+	case "right":
+		if _, end, ok := t.Selection(); ok {
+			t.moveTo(end, false)
+			break
+		}
+		t.moveTo(t.next(t.cursor), false)
+	case "shift+left":
+		t.moveTo(t.prev(t.cursor), true)
+	case "shift+right":
+		t.moveTo(t.next(t.cursor), true)
+	case "ctrl+left", "alt+left", "alt+b":
+		t.moveTo(t.prevWord(t.cursor), false)
+	case "ctrl+right", "alt+right", "alt+f":
+		t.moveTo(t.nextWord(t.cursor), false)
+	case "ctrl+shift+left", "alt+B":
+		t.moveTo(t.prevWord(t.cursor), true)
+	case "ctrl+shift+right", "alt+F":
+		t.moveTo(t.nextWord(t.cursor), true)
+	case "home", "ctrl+a":
+		t.moveTo(t.lineStart(t.cursor), false)
+	case "end", "ctrl+e":
+		t.moveTo(t.lineEnd(t.cursor), false)
+	case "shift+home":
+		t.moveTo(t.lineStart(t.cursor), true)
+	case "shift+end":
+		t.moveTo(t.lineEnd(t.cursor), true)
+	case "backspace":
+		if !t.DeleteSelection() {
+			t.Replace(t.prev(t.cursor), t.cursor, "")
+		}
+	case "delete", "ctrl+d":
+		if !t.DeleteSelection() {
+			t.Replace(t.cursor, t.next(t.cursor), "")
+		}
+	case "alt+backspace":
+		if !t.DeleteSelection() {
+			t.Replace(t.prevWord(t.cursor), t.cursor, "")
+		}
+	case "alt+delete", "alt+d":
+		if !t.DeleteSelection() {
+			t.Replace(t.cursor, t.nextWord(t.cursor), "")
+		}
+	case "ctrl+w":
+		if !t.DeleteSelection() {
+			t.Replace(t.prevField(t.cursor), t.cursor, "")
+		}
+	case "ctrl+u":
+		t.Replace(t.lineStart(t.cursor), t.cursor, "")
+	case "ctrl+k":
+		t.Replace(t.cursor, t.lineEnd(t.cursor), "")
+	case "esc":
+		if _, _, ok := t.Selection(); !ok {
+			return false
+		}
+		t.anchor = -1
+	default:
+		if msg.Alt || (msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace) {
+			return false
+		}
+		if msg.Paste {
+			t.Insert(cleanPaste(string(msg.Runes)))
+			break
+		}
+		t.Insert(string(msg.Runes))
+	}
+	return true
+}
+
+// moveTo moves the cursor to the byte offset, selecting the text it passes
+// over when selecting is true and dropping the selection otherwise
+func (t *TextInput) moveTo(pos int, selecting bool) {
+	switch {
+	case !selecting:
+		t.anchor = -1
+	case t.anchor < 0:
+		t.anchor = t.cursor
+	}
+	t.cursor = pos
+}
+
+// boundary returns the start of the grapheme the byte offset is in
+func (t *TextInput) boundary(pos int) int {
+	pos = min(max(pos, 0), len(t.value))
+	if pos == len(t.value) {
+		return pos
+	}
+	start := 0
+	for state := -1; start < pos; {
+		cluster, _, _, newState := uniseg.FirstGraphemeClusterInString(t.value[start:], state)
+		if start+len(cluster) > pos {
+			break
+		}
+		start += len(cluster)
+		state = newState
+	}
+	return start
+}
+
+// prev returns the byte offset of the grapheme before the one at pos
+func (t *TextInput) prev(pos int) int {
+	if pos == 0 {
+		return 0
+	}
+	return t.boundary(pos - 1)
+}
+
+// next returns the byte offset of the grapheme after the one at pos
+func (t *TextInput) next(pos int) int {
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(t.value[pos:], -1)
+	return pos + len(cluster)
+}
+
+// prevWord returns the byte offset of the start of the word before pos,
+// words being made of letters, digits and _ like readline's
+func (t *TextInput) prevWord(pos int) int {
+	for pos > 0 && !isWord(t.value[t.prev(pos):pos]) {
+		pos = t.prev(pos)
+	}
+	for pos > 0 && isWord(t.value[t.prev(pos):pos]) {
+		pos = t.prev(pos)
+	}
+	return pos
+}
+
+// nextWord returns the byte offset of the end of the word after pos
+func (t *TextInput) nextWord(pos int) int {
+	for pos < len(t.value) && !isWord(t.value[pos:t.next(pos)]) {
+		pos = t.next(pos)
+	}
+	for pos < len(t.value) && isWord(t.value[pos:t.next(pos)]) {
+		pos = t.next(pos)
+	}
+	return pos
+}
+
+// prevField returns the byte offset of the start of the argument before
+// pos, arguments being split by spaces
+func (t *TextInput) prevField(pos int) int {
+	isSpace := func(i int) bool {
+		r, _ := utf8.DecodeLastRuneInString(t.value[:i])
+		return unicode.IsSpace(r)
+	}
+	for pos > 0 && isSpace(pos) {
+		pos = t.prev(pos)
+	}
+	for pos > 0 && !isSpace(pos) {
+		pos = t.prev(pos)
+	}
+	return pos
+}
+
+// lineStart returns the byte offset of the start of the line pos is on
+func (t *TextInput) lineStart(pos int) int {
+	return strings.LastIndexByte(t.value[:pos], '\n') + 1
+}
+
+// lineEnd returns the byte offset of the end of the line pos is on
+func (t *TextInput) lineEnd(pos int) int {
+	if i := strings.IndexByte(t.value[pos:], '\n'); i >= 0 {
+		return pos + i
+	}
+	return len(t.value)
+}
+
+// isWord reports whether the grapheme is part of a word, taking letters in
+// any script and emoji along with digits and _
+func isWord(grapheme string) bool {
+	r, _ := utf8.DecodeRuneInString(grapheme)
+	if r < utf8.RuneSelf {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return !unicode.IsSpace(r) && !unicode.IsPunct(r)
+}
+
+// cleanPaste returns the pasted text with its line endings made \n and
+// control characters other than tabs left out, so a paste can't send keys
+// of its own
+func cleanPaste(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typed returns the key message of typing the text
+func typed(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+func TestTextInputGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		grapheme string
+	}{
+		{"zwj emoji", "👩‍👩‍👧‍👦"},
+		{"skin tone", "👋🏽"},
+		{"flag", "🇯🇵"},
+		{"combining accent", "e\u0301"},
+		{"stacked accents", "a\u0308\u0301"},
+		{"wide character", "語"},
+	}
+	for _, test := range tests {
+		in := NewTextInput()
+		in.SetValue("a" + test.grapheme + "b")
+
+		in.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		in.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		if in.Cursor() != 1 {
+			t.Errorf("%s: cursor at %d after two lefts, want 1", test.name, in.Cursor())
+		}
+		in.Update(tea.KeyMsg{Type: tea.KeyRight})
+		if want := 1 + len(test.grapheme); in.Cursor() != want {
+			t.Errorf("%s: cursor at %d after right, want %d", test.name, in.Cursor(), want)
+		}
+
+		in.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		if in.Value() != "ab" || in.Cursor() != 1 {
+			t.Errorf("%s: backspace left %q with the cursor at %d, want \"ab\" at 1", test.name, in.Value(), in.Cursor())
+		}
+		in.Update(typed(test.grapheme))
+		in.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		in.Update(tea.KeyMsg{Type: tea.KeyDelete})
+		if in.Value() != "ab" {
+			t.Errorf("%s: delete left %q, want \"ab\"", test.name, in.Value())
+		}
+
+		// a cursor set inside the grapheme goes to its start
+		in.SetValue("a" + test.grapheme + "b")
+		in.SetCursor(2)
+		if in.Cursor() != 1 {
+			t.Errorf("%s: SetCursor(2) put the cursor at %d, want 1", test.name, in.Cursor())
+		}
+	}
+}
+
+func TestTextInputSelection(t *testing.T) {
+	in := NewTextInput()
+	in.SetValue("héllo wörld")
+	for range 5 {
+		in.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	}
+	if got := in.SelectedText(); got != "wörld" {
+		t.Fatalf("selected %q, want \"wörld\"", got)
+	}
+
+	in.Update(typed("日本 👩‍👩‍👧‍👦"))
+	if got := in.Value(); got != "héllo 日本 👩‍👩‍👧‍👦" {
+		t.Errorf("typing over the selection gave %q", got)
+	}
+	if _, _, ok := in.Selection(); ok || !in.AtEnd() {
+		t.Errorf("typing over the selection left it selected or the cursor at %d", in.Cursor())
+	}
+
+	in.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	in.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	if got := in.SelectedText(); got != " 👩‍👩‍👧‍👦" {
+		t.Errorf("selected %q, want the space and the emoji", got)
+	}
+	in.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if got := in.Value(); got != "héllo 日本" {
+		t.Errorf("backspace over the selection gave %q", got)
+	}
+
+	// left drops the selection at its start
+	in.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	in.Update(tea.KeyMsg{Type: tea.KeyShiftLeft})
+	in.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if _, _, ok := in.Selection(); ok || in.Cursor() != len("héllo ") {
+		t.Errorf("left left the cursor at %d, want %d without a selection", in.Cursor(), len("héllo "))
+	}
+}
+
+func TestTextInputWords(t *testing.T) {
+	value := "cp ~/über/日本語 café👋🏽"
+	in := NewTextInput()
+	in.SetValue(value)
+
+	altB := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b"), Alt: true}
+	for _, want := range []string{"café👋🏽", "日本語 café👋🏽", "über/日本語 café👋🏽", value} {
+		in.Update(altB)
+		if got := value[in.Cursor():]; got != want {
+			t.Errorf("alt+b stopped before %q, want before %q", got, want)
+		}
+	}
+
+	in.SetCursor(0)
+	altF := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f"), Alt: true}
+	for _, want := range []string{"cp", "cp ~/über", "cp ~/über/日本語", "cp ~/über/日本語 café👋🏽"} {
+		in.Update(altF)
+		if got := value[:in.Cursor()]; got != want {
+			t.Errorf("alt+f stopped after %q, want after %q", got, want)
+		}
+	}
+
+	in.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B"), Alt: true})
+	if got := in.SelectedText(); got != "café👋🏽" {
+		t.Errorf("alt+B selected %q, want \"café👋🏽\"", got)
+	}
+
+	in.SetValue(value)
+	in.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if got := in.Value(); got != "cp ~/über/日本語 " {
+		t.Errorf("ctrl+w left %q", got)
+	}
+	in.Update(tea.KeyMsg{Type: tea.KeyBackspace, Alt: true})
+	if got := in.Value(); got != "cp ~/über/" {
+		t.Errorf("alt+backspace left %q", got)
+	}
+}
+
+func TestTextInputMoveLine(t *testing.T) {
+	in := NewTextInput()
+	in.SetValue("日本\nabcde")
+	in.SetCursor(len("日本\nab"))
+
+	// 日 is two columns wide, so column 2 is after it
+	if !in.MoveLine(-1, false) || in.Cursor() != len("日") {
+		t.Errorf("moving up put the cursor at %d, want %d", in.Cursor(), len("日"))
+	}
+	if in.MoveLine(-1, false) {
+		t.Error("moving up from the first line moved")
+	}
+	if !in.MoveLine(1, true) || in.SelectedText() != "本\nab" {
+		t.Errorf("selecting down selected %q, want \"本\\nab\"", in.SelectedText())
+	}
+}
+
+func TestCleanPaste(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"echo hi\r\nls\r", "echo hi\nls\n"},
+		{"a\tb", "a\tb"},
+		{"rm -rf ~\x1b[201~\r", "rm -rf ~[201~\n"},
+		{"caf\u00e9 e\u0301 👩‍👩‍👧‍👦\x00\x7f", "caf\u00e9 e\u0301 👩‍👩‍👧‍👦"},
+		{"\x03\x04exit", "exit"},
+	}
+	for _, test := range tests {
+		if got := cleanPaste(test.text); got != test.want {
+			t.Errorf("cleanPaste(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+
+	in := NewTextInput()
+	in.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ls\r\n\x1bx"), Paste: true})
+	if got := in.Value(); got != "ls\nx" {
+		t.Errorf("pasting gave %q, want \"ls\\nx\"", got)
+	}
+}
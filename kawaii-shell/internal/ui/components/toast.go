@@ -0,0 +1,76 @@
+package components
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/charmtone"
+)
+
+// Toast is a little notice that shows for a while without getting in the
+// way
+type Toast struct {
+	Message string
+	Until   time.Time
+}
+
+// Toasts are the toasts showing, stacked in a corner with the newest at the
+// bottom. Only so many show at once, the oldest making room for new ones.
+type Toasts struct {
+	Style    lipgloss.Style
+	Duration time.Duration
+	Max      int
+
+	toasts []Toast
+}
+
+// NewToasts creates a stack of toasts showing for the duration, up to limit
+// at once
+func NewToasts(duration time.Duration, limit int) *Toasts {
+	return &Toasts{
+		Style: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(roles.Accent).
+			Foreground(charmtone.Charcoal).
+			Background(lipgloss.Color("#fff8f8")).
+			Padding(0, 1),
+		Duration: duration,
+		Max:      limit,
+	}
+}
+
+// Push shows a new toast
+func (t *Toasts) Push(message string, now time.Time) {
+	t.toasts = append(t.toasts, Toast{Message: message, Until: now.Add(t.Duration)})
+	if len(t.toasts) > t.Max {
+		t.toasts = t.toasts[len(t.toasts)-t.Max:]
+	}
+}
+
+// Update takes the toasts that showed long enough away
+func (t *Toasts) Update(now time.Time) {
+	showing := t.toasts[:0]
+	for _, toast := range t.toasts {
+		if now.Before(toast.Until) {
+			showing = append(showing, toast)
+		}
+	}
+	t.toasts = showing
+}
+
+// Empty reports whether no toast is showing
+func (t *Toasts) Empty() bool {
+	return len(t.toasts) == 0
+}
+
+// Render renders the toasts showing, right aligned and no wider than width
+func (t *Toasts) Render(width int) string {
+	var toasts []string
+	frame := t.Style.GetHorizontalFrameSize()
+	for _, toast := range t.toasts {
+		message := ansi.Truncate(toast.Message, max(width-frame, 1), "…")
+		toasts = append(toasts, t.Style.Render(message))
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, toasts...)
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// copyMode moves a cursor around the scrollback to select and copy output.
+// The mouse is taken by the UI, so this is how text gets out of it.
+type copyMode struct {
+	// line and col are the cursor, col is in runes of the line without its
+	// colors and may be past the end of shorter lines
+	line, col int
+
+	// anchorLine and anchorCol are where the selection started
+	anchorLine, anchorCol int
+	selecting             bool
+	linewise              bool
+}
+
+// CopiedMsg is sent once the selection has been sent to the clipboard
+type CopiedMsg struct {
+	Lines int
+	Err   error
+}
+
+// startCopyMode puts the cursor at the start of the bottom line on screen
+func (a *App) startCopyMode() {
+	lines := a.scrollbackLines()
+	a.copy = &copyMode{line: max(len(lines)-1-a.scroll, 0)}
+	if a.scroll > 0 {
+		// the indicator covers the bottom line
+		a.copy.line = max(a.copy.line-1, 0)
+	}
+}
+
+// handleCopyKey handles the vi-style keys of copy mode, nothing else gets
+// them until it's left
+func (a *App) handleCopyKey(msg tea.KeyMsg) tea.Cmd {
+	c := a.copy
+	lines := a.scrollbackLines()
+	if len(lines) == 0 {
+		a.copy = nil
+		return nil
+	}
+	half := max(a.outputRows(a.outputBoxHeight())/2, 1)
+	lineLen := func() int { return len([]rune(ansi.Strip(lines[c.line]))) }
+
+	switch msg.String() {
+	case "esc":
+		if c.selecting {
+			c.selecting = false
+			break
+		}
+		a.leaveCopyMode()
+		return nil
+	case "q", "ctrl+o":
+		a.leaveCopyMode()
+		return nil
+	case "h", "left":
+		c.col = max(min(c.col, lineLen()-1)-1, 0)
+	case "l", "right":
+		c.col = min(c.col+1, max(lineLen()-1, 0))
+	case "k", "up":
+		c.line--
+	case "j", "down":
+		c.line++
+	case "ctrl+u", "pgup":
+		c.line -= half
+	case "ctrl+d", "pgdown":
+		c.line += half
+	case "0", "home":
+		c.col = 0
+	case "$", "end":
+		c.col = max(lineLen()-1, 0)
+	case "g":
+		c.line = 0
+	case "G":
+		c.line = len(lines) - 1
+	case "w":
+		c.col = nextWord([]rune(ansi.Strip(lines[c.line])), c.col)
+	case "b":
+		c.col = previousWord([]rune(ansi.Strip(lines[c.line])), c.col)
+	case "v", "V":
+		linewise := msg.String() == "V"
+		if c.selecting && c.linewise == linewise {
+			c.selecting = false
+			break
+		}
+		if !c.selecting {
+			c.anchorLine, c.anchorCol = c.line, c.col
+		}
+		c.selecting = true
+		c.linewise = linewise
+	case "y", "enter":
+		text, count := c.selection(lines)
+		a.leaveCopyMode()
+		return a.copyToClipboard(text, count)
+	case "a":
+		text, _ := c.selection(lines)
+		a.leaveCopyMode()
+		a.queueCommands(strings.Split(text, "\n"))
+		return nil
+	}
+	c.line = min(max(c.line, 0), len(lines)-1)
+	a.scrollToLine(c.line, len(lines))
+	return nil
+}
+
+// leaveCopyMode goes back to following the output
+func (a *App) leaveCopyMode() {
+	a.copy = nil
+	a.scroll = 0
+}
+
+// scrollToLine scrolls as little as possible to show the given line above
+// the scroll indicator
+func (a *App) scrollToLine(line, total int) {
+	rows := a.outputRows(a.outputBoxHeight())
+	end := total - a.scroll
+	start := end - rows
+	switch {
+	case line < start:
+		a.scrollBy(start - line)
+	case line >= end-1 && a.scroll > 0:
+		// the bottom line is covered by the indicator while scrolled back
+		a.scrollBy(-(line - end + 2))
+	}
+}
+
+// copyToClipboard sends the text to the clipboard of the terminal
+func (a *App) copyToClipboard(text string, lines int) tea.Cmd {
+	terminal := a.terminal()
+	return func() tea.Msg {
+		return CopiedMsg{Lines: lines, Err: shell.CopyToClipboard(terminal, text)}
+	}
+}
+
+// showCopied tells how the copy went
+func (a *App) showCopied(msg CopiedMsg) {
+	if msg.Err != nil {
+		a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		return
+	}
+	noun := "lines"
+	if msg.Lines == 1 {
+		noun = "line"
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(
+		fmt.Sprintf("📋 Copied %d %s to your clipboard!", msg.Lines, noun)))
+}
+
+// bounds returns the start and end of the selection in order, the end
+// included. Without a selection it's the line under the cursor.
+func (c *copyMode) bounds() (startLine, startCol, endLine, endCol int, linewise bool) {
+	if !c.selecting {
+		return c.line, 0, c.line, 0, true
+	}
+	startLine, startCol, endLine, endCol = c.anchorLine, c.anchorCol, c.line, c.col
+	if endLine < startLine || (endLine == startLine && endCol < startCol) {
+		startLine, startCol, endLine, endCol = endLine, endCol, startLine, startCol
+	}
+	return startLine, startCol, endLine, endCol, c.linewise
+}
+
+// selected returns the columns of the line that are selected, from start to
+// end excluded
+func (c *copyMode) selected(line, length int) (int, int, bool) {
+	startLine, startCol, endLine, endCol, linewise := c.bounds()
+	if !c.selecting || line < startLine || line > endLine {
+		return 0, 0, false
+	}
+	start, end := 0, length
+	if !linewise {
+		if line == startLine {
+			start = min(startCol, length)
+		}
+		if line == endLine {
+			end = min(endCol+1, length)
+		}
+	}
+	return start, end, start < end
+}
+
+// selection returns the selected text without colors and how many lines it
+// spans
+func (c *copyMode) selection(lines []string) (string, int) {
+	startLine, _, endLine, _, _ := c.bounds()
+	var selected []string
+	for line := startLine; line <= endLine; line++ {
+		text := []rune(ansi.Strip(lines[line]))
+		start, end, _ := c.selected(line, len(text))
+		if !c.selecting {
+			start, end = 0, len(text)
+		}
+		selected = append(selected, strings.TrimRight(string(text[start:end]), " "))
+	}
+	return strings.Join(selected, "\n"), len(selected)
+}
+
+// copyModeLine renders a scrollback line with the selection and the cursor
+func (a *App) copyModeLine(index int, line string) string {
+	c := a.copy
+	text := []rune(ansi.Strip(line))
+	start, end, selected := c.selected(index, len(text))
+	if !selected && index != c.line {
+		return line
+	}
+	if index == c.line && len(text) == 0 {
+		return a.theme.Styles.Cursor.Render(" ")
+	}
+
+	cursor := -1
+	if index == c.line {
+		cursor = min(c.col, len(text)-1)
+	}
+	selection := a.theme.Styles.Highlight.Reverse(true)
+	var b strings.Builder
+	for i, r := range text {
+		switch {
+		case i == cursor:
+			b.WriteString(a.theme.Styles.Cursor.Render(string(r)))
+		case selected && i >= start && i < end:
+			b.WriteString(selection.Render(string(r)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// copyView renders the help shown in place of the input in copy mode
+func (a *App) copyView() string {
+	help := "hjkl move • v select • V select lines • y copy • a queue • esc leave"
+	if a.copy.selecting {
+		help = "hjkl move • y copy • a queue • esc stop selecting"
+	}
+	return a.theme.Styles.Prompt.Render("✂️  copy mode") +
+		lipgloss.NewStyle().Faint(true).Render("  "+help)
+}
+
+// nextWord returns the start of the word after the column
+func nextWord(text []rune, col int) int {
+	i := min(col, len(text))
+	for i < len(text) && !unicode.IsSpace(text[i]) {
+		i++
+	}
+	for i < len(text) && unicode.IsSpace(text[i]) {
+		i++
+	}
+	if i >= len(text) {
+		return max(len(text)-1, 0)
+	}
+	return i
+}
+
+// previousWord returns the start of the word before the column
+func previousWord(text []rune, col int) int {
+	i := min(col, len(text)) - 1
+	for i > 0 && unicode.IsSpace(text[i]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(text[i-1]) {
+		i--
+	}
+	return max(i, 0)
+}
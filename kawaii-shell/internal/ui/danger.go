@@ -0,0 +1,132 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+const (
+	dangerModalWidth  = 56
+	dangerModalHeight = 14
+)
+
+// dangerConfirmation is a dangerous command waiting for the user to confirm
+// it before it's sent to the shell
+type dangerConfirmation struct {
+	modal   *components.Modal
+	focus   int
+	command string
+	info    shell.CommandInfo
+
+	// sandboxed offers to run the command in the sandbox instead
+	sandboxed bool
+
+	// line is the output line of the command info
+	line int
+}
+
+// confirmDanger opens the modal asking whether to run a dangerous command.
+// Cancel has the focus, so pressing enter without looking is safe.
+func (a *App) confirmDanger(command string, info shell.CommandInfo, rule shell.DangerRule, line int) {
+	modal := components.NewModal(
+		"⚠️  Are you sure?",
+		info.Emoji+" "+command+"\n\n"+rule.Reason,
+		dangerModalWidth,
+		dangerModalHeight,
+	)
+	cancel := components.NewButton("🛡️ Cancel", 0, 0, 14)
+	cancel.OnClick = func() { a.resolveDanger(false) }
+	modal.AddButton(cancel)
+	_, err := a.findSandbox()
+	sandboxed := err == nil && a.sandbox == nil && a.remote == ""
+	if sandboxed {
+		// the pet would rather see it tried out somewhere safe first
+		sandbox := components.NewButton("🏖️ Sandbox", 0, 0, 14)
+		sandbox.OnClick = a.sandboxDanger
+		modal.AddButton(sandbox)
+	}
+	confirm := components.NewButton("💥 Run it", 0, 0, 14)
+	confirm.OnClick = func() { a.resolveDanger(true) }
+	modal.AddButton(confirm)
+	cancel.Focus()
+	modal.Focus()
+	modal.Show()
+
+	a.danger = &dangerConfirmation{
+		modal:     modal,
+		command:   command,
+		info:      info,
+		line:      line,
+		sandboxed: sandboxed,
+	}
+}
+
+// handleDangerKey handles keys while the confirmation modal is open, nothing
+// else gets them until the user decides
+func (a *App) handleDangerKey(msg tea.KeyMsg) tea.Cmd {
+	danger := a.danger
+	switch msg.String() {
+	case "tab", "right":
+		danger.moveFocus(1)
+	case "shift+tab", "left":
+		danger.moveFocus(-1)
+	case "y":
+		a.resolveDanger(true)
+	case "n":
+		a.resolveDanger(false)
+	case "s":
+		if danger.sandboxed {
+			a.sandboxDanger()
+		}
+	case "enter", "esc":
+		_, cmd := danger.modal.Update(msg)
+		if a.danger == danger && !danger.modal.Visible {
+			// closed with esc
+			a.resolveDanger(false)
+		}
+		return cmd
+	}
+	return nil
+}
+
+// moveFocus focuses the button delta places after the focused one, going
+// round at either end
+func (d *dangerConfirmation) moveFocus(delta int) {
+	buttons := d.modal.Buttons
+	buttons[d.focus].Blur()
+	d.focus = (d.focus + delta + len(buttons)) % len(buttons)
+	buttons[d.focus].Focus()
+}
+
+// resolveDanger closes the modal, running the command if it was confirmed
+func (a *App) resolveDanger(run bool) {
+	danger := a.danger
+	if danger == nil {
+		return
+	}
+	danger.modal.Hide()
+	a.danger = nil
+	a.pets.SurviveDanger()
+
+	if !run {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🛡️ Phew! I didn't run "+danger.command))
+		a.stopScript("you didn't want to run " + danger.command)
+		return
+	}
+	a.runCommand(danger.command, danger.info, danger.line, true)
+	a.advanceScript()
+}
+
+// sandboxDanger closes the modal and runs the command in the sandbox
+func (a *App) sandboxDanger() {
+	danger := a.danger
+	if danger == nil {
+		return
+	}
+	danger.modal.Hide()
+	a.danger = nil
+	a.pets.SurviveDanger()
+	a.runCommand("sandbox "+danger.command, danger.info, danger.line, true)
+	a.advanceScript()
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// maxJumpList is the number of directories jump lists without a query
+const maxJumpList = 10
+
+// visitDir remembers that the shell went to the directory, for jump
+func (a *App) visitDir(dir string) {
+	if a.dirs == nil || dir == "" || a.remote != "" {
+		return
+	}
+	if err := a.dirs.Visit(dir, time.Now()); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+}
+
+// handleDirsCommand runs the mark, unmark and jump kawaii commands,
+// returning whether the command was one of them
+func (a *App) handleDirsCommand(command string) bool {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) == 0 || a.dirs == nil {
+		return false
+	}
+	args := make([]string, 0, len(words)-1)
+	for _, word := range words[1:] {
+		args = append(args, word.Value)
+	}
+
+	switch words[0].Value {
+	case "mark":
+		switch len(args) {
+		case 0:
+			a.showMarks()
+		case 1:
+			if a.remoteOnly("mark") {
+				break
+			}
+			if err := a.dirs.Mark(args[0], a.cwd); err != nil {
+				a.output = append(a.output, "🥺 Oops: "+err.Error())
+				break
+			}
+			a.output = append(a.output, a.theme.Styles.Success.Render(
+				"🔖 "+args[0]+" now takes you to "+shortenPath(a.cwd)+", jump "+args[0]+" goes there"))
+		default:
+			a.output = append(a.output, "🥺 Oops: try mark <name> to bookmark this directory")
+		}
+
+	case "unmark":
+		for _, name := range args {
+			found, err := a.dirs.Unmark(name)
+			switch {
+			case err != nil:
+				a.output = append(a.output, "🥺 Oops: "+err.Error())
+			case !found:
+				a.output = append(a.output, "🥺 Oops: there's no bookmark called "+name)
+			default:
+				a.output = append(a.output, a.theme.Styles.Info.Render("👋 Bye bye "+name))
+			}
+		}
+
+	case "jump":
+		if len(args) == 0 {
+			a.showJumpTargets()
+			break
+		}
+		if a.remoteOnly("jump") {
+			break
+		}
+		a.jump(args)
+
+	default:
+		return false
+	}
+	return true
+}
+
+// jump takes the shell to the bookmark or the visited directory matching
+// the query, or to the directory itself when it's given, as completion does
+func (a *App) jump(query []string) {
+	if a.pendingCommand >= 0 {
+		a.output = append(a.output, fmt.Sprintf("🥺 Oops: wait for %s to finish first", a.pendingText))
+		return
+	}
+
+	dir, ok := a.dirs.Find(query, a.cwd, time.Now())
+	// completion puts the whole path of the directory in place of the last
+	// word, a lone word may also be a directory right here
+	last := query[len(query)-1]
+	if len(query) == 1 && !filepath.IsAbs(last) {
+		last = filepath.Join(a.cwd, last)
+	}
+	if info, err := os.Stat(last); err == nil && info.IsDir() && filepath.IsAbs(last) {
+		dir, ok = last, true
+	}
+	if !ok {
+		a.output = append(a.output, "🥺 Oops: I don't know where that is yet, cd there once and I'll remember")
+		return
+	}
+
+	if err := a.shell.Cd(dir); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("🦘 Hop! Off to "+shortenPath(dir)))
+}
+
+// showMarks lists the bookmarks
+func (a *App) showMarks() {
+	names := a.dirs.MarkNames()
+	if len(names) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🔖 No bookmarks yet! Try mark work in your favorite directory"))
+		return
+	}
+	for _, name := range names {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🔖 "+name+" → "+shortenPath(a.dirs.Marks[name])))
+	}
+}
+
+// showJumpTargets lists the bookmarks and the directories visited the most
+func (a *App) showJumpTargets() {
+	a.showMarks()
+	dirs := a.dirs.Matches(nil, time.Now())
+	for _, dir := range dirs[:min(len(dirs), maxJumpList)] {
+		a.output = append(a.output, a.theme.Styles.Info.Render("📂 "+shortenPath(dir)))
+	}
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// envPanel lists the environment kawaii started with in place of the
+// output, along with the changes made in it, to look through it and change
+// what the next commands run with
+type envPanel struct {
+	query string
+
+	// selected is the selected variable among the matching ones, and offset
+	// the first one on screen
+	selected, offset int
+
+	// editing is the variable whose value is being edited, empty when none
+	editing string
+	value   string
+
+	// status tells how the last change went
+	status string
+}
+
+// openEnvPanel shows the environment panel
+func (a *App) openEnvPanel() {
+	a.env = &envPanel{}
+}
+
+// matches returns the variables whose name or value contain the query
+func (p *envPanel) matches() []shell.EnvVar {
+	vars := shell.Environ()
+	if p.query == "" {
+		return vars
+	}
+	query := strings.ToLower(p.query)
+	matches := vars[:0]
+	for _, v := range vars {
+		if strings.Contains(strings.ToLower(v.Name), query) || strings.Contains(strings.ToLower(v.Value), query) {
+			matches = append(matches, v)
+		}
+	}
+	return matches
+}
+
+// handleEnvKey handles the keys of the environment panel, nothing else gets
+// them until it's closed
+func (a *App) handleEnvKey(msg tea.KeyMsg) {
+	p := a.env
+	if p.editing != "" {
+		switch msg.String() {
+		case "esc":
+			p.editing = ""
+		case "enter":
+			a.setEnv(p.editing, p.value)
+			p.editing = ""
+		case "backspace":
+			_, size := utf8.DecodeLastRuneInString(p.value)
+			p.value = p.value[:len(p.value)-size]
+		case "ctrl+u":
+			p.value = ""
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				p.value += string(msg.Runes)
+			}
+		}
+		return
+	}
+
+	matches := p.matches()
+	page := max(a.outputRows(a.outputBoxHeight())-1, 1)
+	switch msg.String() {
+	case "esc":
+		if p.query != "" {
+			p.query, p.selected = "", 0
+			break
+		}
+		a.env = nil
+		return
+	case "up", "ctrl+p":
+		p.selected--
+	case "down", "ctrl+n":
+		p.selected++
+	case "pgup":
+		p.selected -= page
+	case "pgdown":
+		p.selected += page
+	case "enter":
+		if name, value, ok := strings.Cut(p.query, "="); ok {
+			// NAME=value exports a new variable
+			if a.setEnv(name, value) {
+				p.query = ""
+			}
+			break
+		}
+		if p.selected < len(matches) {
+			p.editing, p.value = matches[p.selected].Name, matches[p.selected].Value
+		}
+	case "ctrl+d", "delete":
+		if p.selected < len(matches) {
+			a.unsetEnv(matches[p.selected].Name)
+		}
+	case "backspace":
+		_, size := utf8.DecodeLastRuneInString(p.query)
+		p.query = p.query[:len(p.query)-size]
+		p.selected = 0
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			p.query += string(msg.Runes)
+			p.selected = 0
+		}
+	}
+	p.selected = min(max(p.selected, 0), max(len(p.matches())-1, 0))
+}
+
+// setEnv exports the variable in the shell session, reporting whether it
+// worked
+func (a *App) setEnv(name, value string) bool {
+	if err := a.envReady(); err != nil {
+		a.env.status = "🥺 " + err.Error()
+		return false
+	}
+	if err := a.shell.Setenv(name, value); err != nil {
+		a.env.status = "🥺 " + err.Error()
+		return false
+	}
+	a.env.status = fmt.Sprintf("🌿 Exported %s for the next commands", name)
+	a.output = append(a.output, a.theme.Styles.Success.Render(a.env.status))
+	return true
+}
+
+// unsetEnv removes the variable from the shell session
+func (a *App) unsetEnv(name string) {
+	if err := a.envReady(); err != nil {
+		a.env.status = "🥺 " + err.Error()
+		return
+	}
+	if err := a.shell.Unsetenv(name); err != nil {
+		a.env.status = "🥺 " + err.Error()
+		return
+	}
+	a.env.status = fmt.Sprintf("🍂 Unset %s for the next commands", name)
+	a.output = append(a.output, a.theme.Styles.Success.Render(a.env.status))
+}
+
+// envReady returns an error while a command is running in the shell, as it
+// would get the export as its input
+func (a *App) envReady() error {
+	if a.pendingCommand >= 0 {
+		return fmt.Errorf("wait for %s to finish first", a.pendingText)
+	}
+	return nil
+}
+
+// envView renders the variables matching the query, in place of the output
+func (a *App) envView(height int) string {
+	p := a.env
+	matches := p.matches()
+	rows := a.outputRows(height) - 1
+	cols, _ := a.outputSize()
+
+	// keep the selected variable on screen
+	if p.selected < p.offset {
+		p.offset = p.selected
+	} else if p.selected >= p.offset+rows {
+		p.offset = p.selected - rows + 1
+	}
+	p.offset = min(p.offset, max(len(matches)-rows, 0))
+
+	// the shell doesn't tell what it exported, so it's what commands start
+	// from rather than what they run with
+	title := fmt.Sprintf("🌿 Startup environment and changes made here • %d variables", len(matches))
+	if p.query != "" {
+		title += fmt.Sprintf(" matching %q", p.query)
+	}
+	lines := []string{a.theme.Styles.Help.Render(title)}
+	name := lipgloss.NewStyle().Foreground(a.theme.Roles.Accent).Bold(true)
+	for i := p.offset; i < min(p.offset+rows, len(matches)); i++ {
+		v := matches[i]
+		if i == p.selected {
+			line := ansi.Truncate(v.Name+"="+v.Value, cols-2, "…")
+			lines = append(lines, a.theme.Styles.Highlight.Render(line))
+			continue
+		}
+		lines = append(lines, ansi.Truncate(name.Render(v.Name)+"="+v.Value, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// envInputView renders the filter, or the value being edited, in place of
+// the input
+func (a *App) envInputView() string {
+	p := a.env
+	faint := lipgloss.NewStyle().Faint(true)
+	status := ""
+	if p.status != "" {
+		status = "  " + p.status
+	}
+	if p.editing != "" {
+		return a.theme.Styles.Prompt.Render("✏️  "+p.editing+"=") +
+			a.theme.Styles.Input.Render(p.value) +
+			a.theme.Styles.Cursor.Render(" ") +
+			faint.Render("  enter to export • esc to cancel")
+	}
+	return a.theme.Styles.Prompt.Render("🌿 env ") +
+		a.theme.Styles.Input.Render(p.query) +
+		a.theme.Styles.Cursor.Render(" ") +
+		faint.Render("  enter edit • ctrl+d unset • NAME=value enter exports • esc close") +
+		status
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// errorCardWidth is the widest the error explanation card gets
+const errorCardWidth = 72
+
+// showErrorHint adds a card explaining the error below the output of the
+// command that failed, when it's one we recognize
+func (a *App) showErrorHint(code int) {
+	if code == 0 || shell.IsNotFound(code) || a.errorHints == nil {
+		// commands that weren't found get a correction instead
+		return
+	}
+	hint, ok := a.errorHints.Match(ansi.Strip(strings.Join(a.lastOutput, "\n")))
+	if !ok {
+		return
+	}
+
+	title := lipgloss.NewStyle().Foreground(a.theme.Roles.Accent).Bold(true)
+	lines := []string{title.Render("💡 " + hint.Title)}
+	if hint.Explanation != "" {
+		lines = append(lines, hint.Explanation)
+	}
+	if len(hint.Steps) > 0 {
+		lines = append(lines, "", "Things to try:")
+		for _, step := range hint.Steps {
+			lines = append(lines, "  🌸 "+step)
+		}
+	}
+	cols, _ := a.outputSize()
+	card := a.theme.Styles.FloatingBox.
+		Align(lipgloss.Left).
+		Width(min(cols, errorCardWidth)).
+		Render(strings.Join(lines, "\n"))
+	a.output = append(a.output, card)
+}
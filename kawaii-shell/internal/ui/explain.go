@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/tldr"
+)
+
+const (
+	// maxExplainWidth is the widest the explain panel gets
+	maxExplainWidth = 56
+
+	// maxExplainExamples is the number of examples shown from the page
+	maxExplainExamples = 4
+)
+
+// explainKey reports whether ? should open the explain panel rather than be
+// typed. That's when it follows a space at the end of the command, so globs
+// like file?.txt can still be typed.
+func (a *App) explainKey() bool {
+	return strings.TrimSpace(a.input.Value()) != "" &&
+		a.input.AtEnd() &&
+		strings.HasSuffix(a.input.Value(), " ")
+}
+
+// explainView renders the side panel breaking down the typed command
+func (a *App) explainView() string {
+	input := a.theme.Styles.Input
+	width := min(maxExplainWidth, a.width/2)
+	text := lipgloss.NewStyle().Foreground(input.GetForeground())
+	title := text.Bold(true)
+	command := lipgloss.NewStyle().Foreground(a.theme.Roles.Accent).Bold(true)
+
+	var lines []string
+	explanation, ok := tldr.Explain(a.input.Value())
+	if !ok {
+		lines = append(lines,
+			title.Render("🤔 Hmm..."),
+			"",
+			text.Render("I don't have a page for "+strings.Fields(a.input.Value())[0]+" yet!"),
+		)
+	} else {
+		page := explanation.Page
+		lines = append(lines, title.Render("📖 "+page.Name), text.Render(page.Description), "")
+		if len(explanation.Flags) > 0 || len(explanation.Unknown) > 0 {
+			lines = append(lines, title.Render("Your flags"))
+			for _, flag := range explanation.Flags {
+				lines = append(lines, command.Render(flag.Flag)+" "+text.Render(flag.Description))
+			}
+			for _, flag := range explanation.Unknown {
+				lines = append(lines, command.Render(flag)+" "+text.Render("🤔 not sure about this one"))
+			}
+			lines = append(lines, "")
+		}
+		lines = append(lines, title.Render("Examples"))
+		for _, example := range page.Examples[:min(len(page.Examples), maxExplainExamples)] {
+			lines = append(lines, text.Render("• "+example.Description), command.Render("  "+example.Command))
+		}
+	}
+	lines = append(lines, "", text.Faint(true).Render("enter to run • esc to close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(a.theme.Roles.Accent).
+		BorderBackground(input.GetBackground()).
+		Background(input.GetBackground()).
+		Padding(0, 1).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// outputFilter shows only the lines of the last command's output matching a
+// pattern, like piping it through grep without running it again
+type outputFilter struct {
+	query string
+
+	// scroll is how many matching lines the view is scrolled back
+	scroll int
+}
+
+// openFilter starts filtering the output of the last command
+func (a *App) openFilter(query string) {
+	if len(a.lastOutput) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🔎 There's no command output to filter yet"))
+		return
+	}
+	a.filter = &outputFilter{query: query}
+}
+
+// pattern returns the query as a case insensitive regular expression, taken
+// literally while it isn't a valid one, such as halfway through typing it
+func (f *outputFilter) pattern() *regexp.Regexp {
+	re, err := regexp.Compile("(?i)" + f.query)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(f.query))
+	}
+	return re
+}
+
+// filterMatches returns the lines of the last command's output matching the
+// query, with the matches highlighted
+func (a *App) filterMatches() []string {
+	if a.filter.query == "" {
+		return a.lastOutput
+	}
+	re := a.filter.pattern()
+	var matches []string
+	for _, line := range a.lastOutput {
+		plain := ansi.Strip(line)
+		if loc := re.FindAllStringIndex(plain, -1); len(loc) > 0 {
+			// no padding, the kept lines should read like the output
+			matches = append(matches, highlightRanges(plain, loc, a.theme.Styles.Highlight.Padding(0)))
+		}
+	}
+	return matches
+}
+
+// highlightRanges renders the ranges of the line with the style
+func highlightRanges(line string, ranges [][]int, style lipgloss.Style) string {
+	var b strings.Builder
+	pos := 0
+	for _, r := range ranges {
+		if r[0] == r[1] {
+			// empty matches have nothing to highlight
+			continue
+		}
+		b.WriteString(line[pos:r[0]])
+		b.WriteString(style.Render(line[r[0]:r[1]]))
+		pos = r[1]
+	}
+	b.WriteString(line[pos:])
+	return b.String()
+}
+
+// handleFilterKey handles the keys of the filter, nothing else gets them
+// until it's closed
+func (a *App) handleFilterKey(msg tea.KeyMsg) {
+	f := a.filter
+	page := max(a.outputRows(a.outputBoxHeight())-1, 1)
+	switch msg.String() {
+	case "esc", "ctrl+g":
+		a.filter = nil
+		return
+	case "enter":
+		// keep the matching lines in the output
+		matches := a.filterMatches()
+		a.filter = nil
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			fmt.Sprintf("🔎 %d lines of the last output matching %q", len(matches), f.query)))
+		a.output = append(a.output, matches...)
+		return
+	case "up", "ctrl+p":
+		f.scroll++
+	case "down", "ctrl+n":
+		f.scroll--
+	case "pgup":
+		f.scroll += page
+	case "pgdown":
+		f.scroll -= page
+	case "backspace":
+		_, size := utf8.DecodeLastRuneInString(f.query)
+		f.query = f.query[:len(f.query)-size]
+		f.scroll = 0
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			f.query += string(msg.Runes)
+			f.scroll = 0
+		}
+	}
+	f.scroll = min(max(f.scroll, 0), max(len(a.filterMatches())-page, 0))
+}
+
+// filterView renders the matching lines in place of the output, the latest
+// ones at the bottom like the output
+func (a *App) filterView(height int) string {
+	matches := a.filterMatches()
+	rows := a.outputRows(height) - 1
+	end := max(len(matches)-a.filter.scroll, 0)
+	start := max(end-rows, 0)
+
+	title := fmt.Sprintf("🔎 %d of %d lines of %s", len(matches), len(a.lastOutput), a.lastOutputCommand)
+	if a.filter.scroll > 0 {
+		title += fmt.Sprintf(" • %d lines back", a.filter.scroll)
+	}
+	lines := append([]string{a.theme.Styles.Help.Render(title)}, matches[start:end]...)
+	return strings.Join(lines, "\n")
+}
+
+// filterInputView renders the pattern being typed in place of the input
+func (a *App) filterInputView() string {
+	status := "enter keeps the lines • esc to close"
+	if a.filter.query == "" {
+		status = "type a pattern • " + status
+	}
+	return a.theme.Styles.Prompt.Render("🔎 grep ") +
+		a.theme.Styles.Input.Render(a.filter.query) +
+		a.theme.Styles.Cursor.Render(" ") +
+		lipgloss.NewStyle().Faint(true).Render("  "+status)
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+)
+
+const (
+	// galleryCardWidth is how wide the sample of each theme is
+	galleryCardWidth = 34
+
+	// galleryGap is the room between the samples
+	galleryGap = 2
+)
+
+// themeGallery shows a sample of every theme side by side on the whole
+// screen, to compare them before picking one
+type themeGallery struct {
+	themes   []*themes.KawaiiTheme
+	selected int
+}
+
+// openThemeGallery opens the gallery on the current theme
+func (a *App) openThemeGallery() {
+	gallery := &themeGallery{themes: themes.GetThemes()}
+	for i, theme := range gallery.themes {
+		if theme.ID() == a.theme.ID() {
+			gallery.selected = i
+		}
+	}
+	a.gallery = gallery
+}
+
+// handleGalleryKey handles the keys of the gallery, nothing else gets them
+// until it's closed
+func (a *App) handleGalleryKey(msg tea.KeyMsg) {
+	g := a.gallery
+	switch msg.String() {
+	case "esc", "q":
+		a.gallery = nil
+		return
+	case "left", "h", "shift+tab":
+		g.selected--
+	case "right", "l", "tab":
+		g.selected++
+	case "enter", " ":
+		a.gallery = nil
+		a.pickTheme(g.themes[g.selected])
+		return
+	}
+	g.selected = (g.selected + len(g.themes)) % len(g.themes)
+}
+
+// galleryView renders the samples of as many themes as fit next to each
+// other, scrolled to the selected one
+func (a *App) galleryView() string {
+	g := a.gallery
+	shown := max(a.width/(galleryCardWidth+galleryGap), 1)
+	first := max(g.selected-shown+1, 0)
+	var cards []string
+	for i, theme := range g.themes[first:min(first+shown, len(g.themes))] {
+		if len(cards) > 0 {
+			cards = append(cards, strings.Repeat(" ", galleryGap))
+		}
+		cards = append(cards, a.galleryCard(theme, first+i == g.selected))
+	}
+
+	title := a.titleView("🎨 Theme gallery")
+	help := lipgloss.NewStyle().Faint(true).Render("←→ pick • enter to switch to it • esc back")
+	view := lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, title, "", lipgloss.JoinHorizontal(lipgloss.Top, cards...), "", help))
+	// the bottom doesn't fit on small screens
+	if lines := strings.Split(view, "\n"); len(lines) > a.height {
+		view = strings.Join(lines[:a.height], "\n")
+	}
+	return view
+}
+
+// galleryCard renders the sample of the theme: the prompt, some output, a
+// warning, the pet box and buttons. The boxes keep their borders and
+// colors, with less room above and below to fit on the screen.
+func (a *App) galleryCard(theme *themes.KawaiiTheme, selected bool) string {
+	s := theme.Styles
+	name := lipgloss.NewStyle().Width(galleryCardWidth).Render("  " + theme.Name)
+	if selected {
+		name = a.theme.Styles.Highlight.Width(galleryCardWidth).Render("▶ " + theme.Name)
+	}
+	box := func(style lipgloss.Style) lipgloss.Style {
+		return style.UnsetMargins().PaddingTop(0).PaddingBottom(0).Width(galleryCardWidth)
+	}
+
+	input := box(s.InputBox).Render(s.Prompt.Render("🌸>") + s.Input.Render("ls ~/treats"))
+	output := box(s.OutputBox).Render(strings.Join([]string{
+		s.Info.Render("✨ cookies.txt  mochi.png"),
+		s.Help.Render("💡 try help"),
+		s.Pet.Render(a.pet.Icon() + " " + a.pet.Name + " is happy!"),
+		s.ExitSuccess.Render("✔") + " " + s.ExitFailure.Render("✘ 1"),
+	}, "\n"))
+	warning := box(s.Warning).Render("⚠️  Careful with that one!")
+	petBox := box(s.PetBox).Width(petBoxWidth).Render(a.pet.Icon() + " " + a.pet.Name + "\n⚡80 💖100")
+	button := func(style lipgloss.Style, text string) string {
+		return style.Border(lipgloss.RoundedBorder()).
+			BorderForeground(s.PetBox.GetBorderTopForeground()).
+			Padding(0, 1).
+			Render(text)
+	}
+	buttons := lipgloss.JoinHorizontal(lipgloss.Top, button(s.Highlight, "🍖 Feed"), " ", button(s.Input, "🎾 Play"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, name, "", input, output, warning, petBox, buttons)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// gitRefreshInterval is how often the git status is refreshed while idle,
+// picking up changes made outside the shell
+const gitRefreshInterval = 5 * time.Second
+
+// GitStatusMsg carries the result of a git status refresh, along with the
+// project the directory is in
+type GitStatusMsg struct {
+	Dir    string
+	Status shell.GitStatus
+	OK     bool
+
+	Project *shell.Project
+}
+
+// refreshGit starts a git status refresh in the background when the working
+// directory changed, a command finished or the last one is getting old.
+// Only one runs at a time, so typing never waits on git.
+func (a *App) refreshGit(cwd string, now time.Time) tea.Cmd {
+	if a.gitPending {
+		return nil
+	}
+	if a.remote != "" {
+		// git would look at this computer
+		a.git, a.project = nil, nil
+		return nil
+	}
+	if cwd == a.gitDir && !a.gitStale && now.Sub(a.gitCheckedAt) < gitRefreshInterval {
+		return nil
+	}
+	a.gitPending = true
+	a.gitStale = false
+	a.gitCheckedAt = now
+	return func() tea.Msg {
+		status, ok := shell.ReadGitStatus(cwd)
+		msg := GitStatusMsg{Dir: cwd, Status: status, OK: ok}
+		if project, found := shell.DetectProject(cwd); found {
+			msg.Project = &project
+		}
+		return msg
+	}
+}
+
+// updateGit stores the refreshed git status
+func (a *App) updateGit(msg GitStatusMsg) {
+	a.gitPending = false
+	a.gitDir = msg.Dir
+	a.project = msg.Project
+	if !msg.OK {
+		a.git = nil
+		return
+	}
+	a.git = &msg.Status
+}
+
+// gitLines renders the git status for the project widget, the branch with
+// how far it is from upstream and the uncommitted changes
+func (a *App) gitLines() []string {
+	if a.git == nil {
+		return nil
+	}
+
+	branch := "🌿 " + a.git.Branch
+	if a.git.Ahead > 0 {
+		branch += fmt.Sprintf(" ↑%d", a.git.Ahead)
+	}
+	if a.git.Behind > 0 {
+		branch += fmt.Sprintf(" ↓%d", a.git.Behind)
+	}
+	if a.git.Upstream != "" && a.git.Ahead == 0 && a.git.Behind == 0 {
+		branch += " 💕"
+	}
+	lines := []string{branch}
+
+	if !a.git.Dirty() {
+		lines = append(lines, "✨ clean")
+	} else {
+		changes := []string{fmt.Sprintf("✏️ %d", a.git.Changed())}
+		if a.git.Staged > 0 {
+			changes = append(changes, fmt.Sprintf("+%d", a.git.Staged))
+		}
+		if a.git.Modified > 0 {
+			changes = append(changes, fmt.Sprintf("~%d", a.git.Modified))
+		}
+		if a.git.Untracked > 0 {
+			changes = append(changes, fmt.Sprintf("?%d", a.git.Untracked))
+		}
+		if a.git.Conflicts > 0 {
+			changes = append(changes, fmt.Sprintf("!%d", a.git.Conflicts))
+		}
+		lines = append(lines, strings.Join(changes, " "))
+	}
+	return lines
+}
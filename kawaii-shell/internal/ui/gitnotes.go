@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// gitNotes is the cat's ability, summing up the git status in a few words
+// next to the pet
+type gitNotes struct {
+	noAbility
+}
+
+func (gitNotes) name() string {
+	return "🐱 Git notes, the git status in a few words"
+}
+
+func (gitNotes) view(a *App) []string {
+	if a.git == nil {
+		return nil
+	}
+	git := a.git
+	lines := []string{"🐾 " + a.pet.Name + "'s notes"}
+	switch {
+	case git.Conflicts > 0:
+		lines = append(lines, fmt.Sprintf("%s to untangle!", plural(git.Conflicts, "conflict")))
+	case !git.Dirty():
+		lines = append(lines, "All tidy on "+git.Branch)
+	default:
+		var changes []string
+		if git.Staged > 0 {
+			changes = append(changes, fmt.Sprintf("%d ready", git.Staged))
+		}
+		if git.Modified > 0 {
+			changes = append(changes, fmt.Sprintf("%d changed", git.Modified))
+		}
+		if git.Untracked > 0 {
+			changes = append(changes, fmt.Sprintf("%d new", git.Untracked))
+		}
+		lines = append(lines, strings.Join(changes, ", "))
+	}
+
+	switch {
+	case git.Ahead > 0 && git.Behind > 0:
+		lines = append(lines, "Went separate ways with "+git.Upstream)
+	case git.Ahead > 0:
+		lines = append(lines, plural(git.Ahead, "commit")+" to push")
+	case git.Behind > 0:
+		lines = append(lines, plural(git.Behind, "commit")+" to pull")
+	case git.Upstream == "":
+		lines = append(lines, "Not pushed anywhere yet")
+	case git.Staged > 0:
+		lines = append(lines, "Time to commit?")
+	}
+	return lines
+}
+
+// plural counts the things, like 1 commit or 2 commits
+func plural(n int, thing string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, thing)
+	}
+	return fmt.Sprintf("%d %ss", n, thing)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+const (
+	// guardPrompt replaces the prompt while a protected command waits for
+	// the confirmation phrase
+	guardPrompt = "🛡️ say the words > "
+
+	// guardLogLines is how many approvals guard log shows
+	guardLogLines = 10
+)
+
+// guardedCommand is a protected command waiting for the confirmation phrase
+type guardedCommand struct {
+	command string
+	info    shell.CommandInfo
+
+	// line is the output line of the command info
+	line int
+}
+
+// handleGuardCommand runs guard, guard on, guard off and guard log,
+// returning false for other commands
+func (a *App) handleGuardCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != "guard" || len(fields) > 2 {
+		return false
+	}
+	name := a.pet.Name
+	switch strings.Join(fields[1:], " ") {
+	case "":
+		if !a.guarding {
+			a.output = append(a.output, a.theme.Styles.Info.Render(
+				"🛡️ Guard mode is off, guard on has "+name+" stand in the way of force pushes and prod clusters"))
+			break
+		}
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			"🛡️ "+name+" is on guard! Protected commands only run after you type \""+a.guard.Phrase+"\""))
+	case "on":
+		a.guarding = true
+		a.pet.StandGuard()
+		a.output = append(a.output, a.theme.Styles.Success.Render(
+			"🛡️ "+name+" is on guard! Protected commands only run after you type \""+a.guard.Phrase+"\""))
+	case "off":
+		a.guarding = false
+		a.output = append(a.output, a.theme.Styles.Info.Render("🛡️ Guard mode is off, "+name+" is taking a break"))
+	case "log":
+		a.showGuardLog()
+	default:
+		a.output = append(a.output, a.theme.Styles.Help.Render("🛡️ Try guard, guard on, guard off or guard log"))
+	}
+	return true
+}
+
+// showGuardLog lists the last protected commands that were run anyway
+func (a *App) showGuardLog() {
+	approvals, err := a.guard.Approvals(guardLogLines)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if len(approvals) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🛡️ Nothing got past "+a.pet.Name+" yet"))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Highlight.Render("🛡️ What you let through"))
+	for _, approval := range approvals {
+		a.output = append(a.output, a.theme.Styles.Help.Render(
+			approval.Time.Local().Format("2006-01-02 15:04")+"  "+shortenPath(approval.Dir)+"  ")+approval.Command)
+	}
+}
+
+// standGuard has the pet stand in the way of a protected command, which
+// waits for the confirmation phrase to be typed
+func (a *App) standGuard(command string, info shell.CommandInfo, rule shell.DangerRule, line int) {
+	a.pet.StandGuard()
+	a.output = append(a.output,
+		a.theme.Styles.Warning.Render("🛡️ "+a.pet.Name+" stands in the way! "+rule.Reason),
+		a.theme.Styles.Help.Render("Type \""+a.guard.Phrase+"\" to run it anyway, anything else or esc lets it go"),
+	)
+	a.guarded = &guardedCommand{command: command, info: info, line: line}
+}
+
+// answerGuard runs the protected command when the input is the confirmation
+// phrase, writing it down in the guard log, and lets it go otherwise
+func (a *App) answerGuard(input string) {
+	guarded := a.guarded
+	a.guarded = nil
+	if !a.guard.Confirms(input) {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🛡️ Phew! I didn't run "+guarded.command))
+		a.stopScript("you didn't want to run " + guarded.command)
+		return
+	}
+	if err := a.guard.Approve(guarded.command, a.cwd, time.Now()); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("🛡️ Okay, I trust you! I wrote it down in the guard log"))
+	a.runCommand(guarded.command, guarded.info, guarded.line, true)
+	a.advanceScript()
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/rivo/uniseg"
+)
+
+// syntaxStyles returns the style of each kind of token, on the background of
+// the input so they fit any theme
+func (a *App) syntaxStyles() map[shell.TokenKind]lipgloss.Style {
+	input := a.theme.Styles.Input
+	base := lipgloss.NewStyle().
+		Foreground(input.GetForeground()).
+		Background(input.GetBackground())
+	c := lipgloss.LightDark(isDark(input.GetBackground()))
+	return map[shell.TokenKind]lipgloss.Style{
+		shell.TokenSpace:    base,
+		shell.TokenArgument: base,
+		shell.TokenCommand:  base.Foreground(c(charmtone.Pony, charmtone.Cheeky)).Bold(true),
+		shell.TokenFlag:     base.Foreground(c(lipgloss.Color("#0CB37F"), charmtone.Guac)),
+		shell.TokenPath:     base.Foreground(c(charmtone.Malibu, charmtone.Guppy)).Underline(true),
+		shell.TokenQuoted:   base.Foreground(c(charmtone.Coral, charmtone.Salmon)),
+		shell.TokenVariable: base.Foreground(c(charmtone.Grape, charmtone.Lilac)),
+		shell.TokenOperator: base.Foreground(c(charmtone.Squid, charmtone.Oyster)),
+		shell.TokenComment:  base.Foreground(c(charmtone.Squid, lipgloss.Color("#747282"))).Italic(true),
+	}
+}
+
+// highlightInput renders the input with its syntax highlighted, the
+// selection highlighted over it and the cursor on top
+func (a *App) highlightInput() string {
+	styles := a.syntaxStyles()
+	cursor := a.theme.Styles.Cursor
+	selected := a.theme.Styles.Highlight.Padding(0)
+	at := a.input.Cursor()
+	start, end, _ := a.input.Selection()
+
+	var b strings.Builder
+	pos := 0
+	for _, token := range shell.Tokenize(a.input.Value()) {
+		style := styles[token.Kind]
+		for text := token.Text; text != ""; {
+			if pos == at {
+				grapheme, _, _, _ := uniseg.FirstGraphemeClusterInString(text, -1)
+				if grapheme == "\n" {
+					// the cursor is at the end of a line
+					b.WriteString(cursor.Render(" ") + "\n")
+				} else {
+					b.WriteString(cursor.Render(grapheme))
+				}
+				pos += len(grapheme)
+				text = text[len(grapheme):]
+				continue
+			}
+
+			// the token is split where the cursor and the selection are
+			n := len(text)
+			for _, cut := range []int{at, start, end} {
+				if cut > pos && cut < pos+n {
+					n = cut - pos
+				}
+			}
+			if pos >= start && pos < end {
+				b.WriteString(renderLines(selected, text[:n]))
+			} else {
+				b.WriteString(renderLines(style, text[:n]))
+			}
+			pos += n
+			text = text[n:]
+		}
+	}
+	if a.input.AtEnd() {
+		// the cursor sits on the suggestion, if there's one
+		rest := a.autosuggestion()
+		grapheme, rest, _, _ := uniseg.FirstGraphemeClusterInString(rest, -1)
+		if grapheme == "" {
+			grapheme = " "
+		}
+		b.WriteString(cursor.Render(grapheme))
+		b.WriteString(renderLines(styles[shell.TokenSpace].Faint(true), rest))
+	}
+	return b.String()
+}
+
+// renderLines renders each line of the text on its own, so lipgloss doesn't
+// pad the lines to the same width
+func renderLines(style lipgloss.Style, text string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = style.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isDark reports whether the color is dark, treating no color as dark like
+// most terminals
+func isDark(c color.Color) bool {
+	if c == nil {
+		return true
+	}
+	if _, ok := c.(lipgloss.NoColor); ok {
+		return true
+	}
+	r, g, b, _ := c.RGBA()
+	luminance := 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+	return luminance < 0.5*0xffff
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// maxPreviewLines is how many lines of the selected command the history
+// search previews
+const maxPreviewLines = 3
+
+// historySearch fuzzy searches the history as the query is typed, in place
+// of the output, the best match at the bottom next to the input
+type historySearch struct {
+	query string
+
+	// selected is the selected match, the best one being 0
+	selected int
+
+	// draft is the input from before the search, put back when it's left
+	draft       string
+	draftCursor int
+}
+
+// openHistorySearch starts searching the history for what was typed
+func (a *App) openHistorySearch() {
+	a.closeCompletions()
+	a.histSearch = &historySearch{query: a.input.Value(), draft: a.input.Value(), draftCursor: a.input.Cursor()}
+}
+
+// historyMatches returns the past commands matching the query
+func (a *App) historyMatches() []shell.HistoryMatch {
+	if a.history == nil {
+		return nil
+	}
+	return a.history.Search(a.histSearch.query)
+}
+
+// handleHistorySearchKey handles the keys of the history search, nothing
+// else gets them until it's left
+func (a *App) handleHistorySearchKey(msg tea.KeyMsg) {
+	s := a.histSearch
+	page := max(a.outputRows(a.outputBoxHeight())-maxPreviewLines-3, 1)
+	switch msg.String() {
+	case "esc", "ctrl+g":
+		a.input.SetValue(s.draft)
+		a.input.SetCursor(s.draftCursor)
+		a.histSearch = nil
+		return
+	case "enter", "tab":
+		// the command goes to the input to look over before running it
+		if matches := a.historyMatches(); s.selected < len(matches) {
+			a.input.SetValue(matches[s.selected].Command)
+		}
+		a.histSearch = nil
+		return
+	case "up", "ctrl+p", "ctrl+r":
+		s.selected++
+	case "down", "ctrl+n", "ctrl+s":
+		s.selected--
+	case "pgup":
+		s.selected += page
+	case "pgdown":
+		s.selected -= page
+	case "ctrl+u":
+		s.query, s.selected = "", 0
+	case "backspace":
+		_, size := utf8.DecodeLastRuneInString(s.query)
+		s.query = s.query[:len(s.query)-size]
+		s.selected = 0
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			s.query += string(msg.Runes)
+			s.selected = 0
+		}
+	}
+	s.selected = min(max(s.selected, 0), max(len(a.historyMatches())-1, 0))
+}
+
+// historySearchView renders the matches with the letters matching the
+// query highlighted, below a preview of the selected command
+func (a *App) historySearchView(height int) string {
+	s := a.histSearch
+	matches := a.historyMatches()
+	cols, _ := a.outputSize()
+
+	title := fmt.Sprintf("🔍 History • %d commands", len(matches))
+	if s.query != "" {
+		title += fmt.Sprintf(" matching %q", s.query)
+	}
+	lines := []string{a.theme.Styles.Help.Render(title)}
+	if len(matches) == 0 {
+		return strings.Join(append(lines, a.theme.Styles.Info.Render("🥺 Nothing like that in your history")), "\n")
+	}
+	lines = append(lines, a.historyPreview(matches[s.selected].Command, cols)...)
+	lines = append(lines, lipgloss.NewStyle().Faint(true).Render(strings.Repeat("─", max(cols, 1))))
+
+	// the selected match stays on screen, the best ones at the bottom
+	rows := max(a.outputRows(height)-len(lines), 1)
+	start := max(s.selected-rows+1, 0)
+	end := min(start+rows, len(matches))
+	mark := a.theme.Styles.Highlight.Padding(0)
+	for i := end - 1; i >= start; i-- {
+		match := matches[i]
+		first, _, multiline := strings.Cut(match.Command, "\n")
+		if i == s.selected {
+			if multiline {
+				first += " ↵"
+			}
+			lines = append(lines, a.theme.Styles.Highlight.Render(ansi.Truncate("▶ "+first, cols-2, "…")))
+			continue
+		}
+		var ranges [][]int
+		for _, pos := range match.Positions {
+			if pos < len(first) {
+				_, size := utf8.DecodeRuneInString(first[pos:])
+				ranges = append(ranges, []int{pos, pos + size})
+			}
+		}
+		line := "  " + highlightRanges(first, ranges, mark)
+		if multiline {
+			line += lipgloss.NewStyle().Faint(true).Render(" ↵")
+		}
+		lines = append(lines, ansi.Truncate(line, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// historyPreview renders the whole selected command highlighted like the
+// input, and how it went the last time it ran
+func (a *App) historyPreview(command string, cols int) []string {
+	styles := a.syntaxStyles()
+	var b strings.Builder
+	for _, token := range shell.Tokenize(command) {
+		b.WriteString(renderLines(styles[token.Kind].UnsetBackground(), token.Text))
+	}
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) > maxPreviewLines {
+		lines = append(lines[:maxPreviewLines-1], lipgloss.NewStyle().Faint(true).Render(
+			fmt.Sprintf("… %d more lines", len(lines)-maxPreviewLines+1)))
+	}
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, cols, "…")
+	}
+
+	if a.stats != nil {
+		if count, last := a.stats.Runs(command); count > 0 {
+			indicator := a.theme.Styles.ExitSuccess.Render("✔")
+			if last.ExitCode != 0 {
+				indicator = a.theme.Styles.ExitFailure.Render(fmt.Sprintf("✘ %d", last.ExitCode))
+			}
+			return append(lines, a.theme.Styles.Info.Render(fmt.Sprintf("🔁 Ran %d times • last %s, took %s ",
+				count, formatAgo(time.Since(last.Started)), formatDuration(last.Duration)))+indicator)
+		}
+	}
+	return append(lines, a.theme.Styles.Info.Render("🔁 Not timed yet"))
+}
+
+// historySearchInputView renders the query being typed in place of the
+// input
+func (a *App) historySearchInputView() string {
+	return a.theme.Styles.Prompt.Render("🔍 history ") +
+		a.theme.Styles.Input.Render(a.histSearch.query) +
+		a.theme.Styles.Cursor.Render(" ") +
+		lipgloss.NewStyle().Faint(true).Render("  ↑↓ pick • enter to edit it • esc to cancel")
+}
+
+// formatAgo tells how long ago something happened, roughly
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,24 @@
+package ui
+
+import "strings"
+
+// continuationPrompt is shown in front of the lines after the first one of
+// a multi-line command
+const continuationPrompt = "…> "
+
+// inputView renders the input with the prompt in front of its first line and
+// the continuation prompt in front of the others
+func (a *App) inputView() string {
+	lines := strings.Split(a.highlightInput(), "\n")
+	for i, line := range lines {
+		prompt := a.prompt
+		if a.guarded != nil {
+			prompt = guardPrompt
+		}
+		if i > 0 {
+			prompt = continuationPrompt
+		}
+		lines[i] = a.theme.Styles.Prompt.Render(prompt) + a.theme.Styles.Input.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+const (
+	// jobLinger is how long finished jobs stay in the sidebar
+	jobLinger = time.Minute
+
+	// jobsWidth is the width of the jobs sidebar
+	jobsWidth = 22
+
+	// maxSidebarJobs is the number of jobs listed in the sidebar
+	maxSidebarJobs = 2
+)
+
+// handleJobCommand starts commands ending with & as jobs and runs the jobs,
+// fg and bg kawaii commands, returning whether the command was handled. The
+// last three are left to the shell's own job control while there are no
+// kawaii jobs.
+func (a *App) handleJobCommand(command string) bool {
+	// jobs run on this computer, the remote shell has its own job control
+	if background, ok := shell.ParseBackground(command); ok && a.remote == "" {
+		// rm moves files to the trash in the background too, unless they
+		// should really be deleted
+		if really, ok := shell.StripReally(background); ok {
+			background = really
+		} else if !a.sandboxed(background) && a.handleTrashCommand(background) {
+			return true
+		}
+		a.startJob(background)
+		return true
+	}
+
+	fields := strings.Fields(command)
+	if len(fields) == 0 || len(a.jobs) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "jobs":
+		a.showJobs()
+	case "fg", "bg":
+		job, err := a.findJob(fields[1:])
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			return true
+		}
+		if fields[0] == "fg" {
+			a.attachJob(job)
+		} else {
+			a.resumeJob(job)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// startJob runs the command in the background in its own terminal
+func (a *App) startJob(command string) {
+	a.nextJobID++
+	cols, rows := a.outputSize()
+	run := a.sandboxCommand(command)
+	if run == "" {
+		return
+	}
+	job, err := shell.StartJob(a.nextJobID, run, a.cwd, cols, rows)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	job.Command = command
+	a.jobs = append(a.jobs, job)
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		fmt.Sprintf("🚀 Job [%d] is running in the background: %s", job.ID, command)))
+}
+
+// findJob returns the job with the ID in the arguments, like 2 or %2, or the
+// most recent unfinished one without arguments
+func (a *App) findJob(args []string) (*shell.Job, error) {
+	if len(args) == 0 {
+		for i := len(a.jobs) - 1; i >= 0; i-- {
+			if a.jobs[i].State != shell.JobDone {
+				return a.jobs[i], nil
+			}
+		}
+		return nil, fmt.Errorf("all the jobs are done")
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(args[0], "%"))
+	if err != nil {
+		return nil, fmt.Errorf("%s isn't a job number", args[0])
+	}
+	for _, job := range a.jobs {
+		if job.ID == id {
+			if job.State == shell.JobDone {
+				return nil, fmt.Errorf("job [%d] is already done", id)
+			}
+			return job, nil
+		}
+	}
+	return nil, fmt.Errorf("there's no job [%d]", id)
+}
+
+// attachJob brings the job to the foreground, showing what it wrote in the
+// meantime and sending it what's typed until it finishes or Ctrl+Z stops it
+func (a *App) attachJob(job *shell.Job) {
+	if err := job.Resume(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.foreground = job
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		fmt.Sprintf("🎮 Job [%d] is in the foreground, Ctrl+Z puts it back: %s", job.ID, job.Command)))
+	a.output = append(a.output, job.TakeLines()...)
+}
+
+// resumeJob lets a stopped job carry on in the background
+func (a *App) resumeJob(job *shell.Job) {
+	if err := job.Resume(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		fmt.Sprintf("▶️  Job [%d] carries on in the background: %s", job.ID, job.Command)))
+}
+
+// sendInput sends the typed line to the job in the foreground, its terminal
+// echoes it back
+func (a *App) sendInput() {
+	if err := a.foreground.Send(a.input.Value() + "\n"); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	a.input.Reset()
+}
+
+// suspend stops the job in the foreground, or sends Ctrl+Z to the shell so
+// its own job control stops the command it's running
+func (a *App) suspend() {
+	job := a.foreground
+	if job == nil {
+		if a.pendingCommand >= 0 {
+			if err := a.shell.Write("\x1a"); err != nil {
+				a.output = append(a.output, "🥺 Oops: "+err.Error())
+			}
+		}
+		return
+	}
+	if err := job.Suspend(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.foreground = nil
+	if current := job.Current(); current != "" {
+		a.output = append(a.output, current)
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		fmt.Sprintf("⏸️  Job [%d] is stopped, fg or bg gets it going again", job.ID)))
+}
+
+// pollJobs collects the output of the jobs and tells when they finish
+func (a *App) pollJobs(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, job := range a.jobs {
+		finished := job.Poll()
+		if job == a.foreground {
+			a.output = append(a.output, job.TakeLines()...)
+		}
+		if !finished {
+			continue
+		}
+
+		indicator := a.theme.Styles.ExitSuccess.Render("✔")
+		if job.ExitCode != 0 {
+			indicator = a.theme.Styles.ExitFailure.Render(fmt.Sprintf("✘ %d", job.ExitCode))
+		}
+		if job == a.foreground {
+			a.foreground = nil
+			if current := job.Current(); current != "" {
+				a.output = append(a.output, current)
+			}
+		}
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			fmt.Sprintf("%s Job [%d] finished after %s: %s ", jobEmoji(job), job.ID, job.Finished.Sub(job.Started).Round(time.Second), job.Command))+indicator)
+		a.recordStats(job.Command, job.Started, job.Finished.Sub(job.Started), job.ExitCode)
+		if job.ExitCode == 0 {
+			a.pet.Celebrate()
+		} else {
+			a.reactToExitCode(job.Command, job.ExitCode)
+		}
+		cmds = append(cmds, a.notifyDone(job.Command, job.Finished.Sub(job.Started), job.ExitCode))
+	}
+
+	// forget about jobs a while after they're done
+	jobs := a.jobs[:0]
+	for _, job := range a.jobs {
+		if job.State == shell.JobDone && now.Sub(job.Finished) > jobLinger {
+			job.Close()
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	a.jobs = jobs
+	return tea.Batch(cmds...)
+}
+
+// showJobs lists the jobs like the shell's jobs builtin
+func (a *App) showJobs() {
+	for _, job := range a.jobs {
+		state := job.State.String()
+		if job.State == shell.JobDone {
+			state = fmt.Sprintf("done (%d)", job.ExitCode)
+		}
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			fmt.Sprintf("%s [%d] %-10s %s", jobEmoji(job), job.ID, state, job.Command)))
+	}
+}
+
+// resizeJobs sets the size of the jobs' terminals
+func (a *App) resizeJobs() {
+	cols, rows := a.outputSize()
+	for _, job := range a.jobs {
+		job.Resize(cols, rows)
+	}
+}
+
+// jobEmoji shows the state of the job
+func jobEmoji(job *shell.Job) string {
+	switch {
+	case job.State == shell.JobStopped:
+		return "⏸️"
+	case job.State == shell.JobDone && job.ExitCode == 0:
+		return "✅"
+	case job.State == shell.JobDone:
+		return "❌"
+	default:
+		return "⏳"
+	}
+}
+
+// jobsView renders the sidebar widget listing the jobs, empty when there
+// are none
+func (a *App) jobsView() string {
+	if len(a.jobs) == 0 {
+		return ""
+	}
+
+	width := jobsWidth - a.theme.Styles.PetBox.GetHorizontalFrameSize() - a.theme.Styles.Info.GetHorizontalFrameSize()
+	lines := []string{"⚙️ Jobs"}
+	// the most recent ones, as many as fit next to the pet
+	jobs := a.jobs[max(len(a.jobs)-maxSidebarJobs, 0):]
+	for _, job := range jobs {
+		line := fmt.Sprintf("%s %d %s", jobEmoji(job), job.ID, job.Command)
+		if job == a.foreground {
+			line = "▶ " + line
+		}
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+	return a.theme.Styles.PetBox.
+		Width(jobsWidth).
+		Height(petHeight).
+		Render(a.theme.Styles.Info.Render(strings.Join(lines, "\n")))
+}
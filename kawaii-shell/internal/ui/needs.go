@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// toastDuration is how long a toast shows
+	toastDuration = 8 * time.Second
+
+	// maxToasts is how many toasts show at once
+	maxToasts = 3
+
+	// toastWidth is how wide toasts get at most
+	toastWidth = 48
+)
+
+// tellNeeds shows a toast for each need the companion started to feel, so
+// it doesn't go hungry without anyone noticing
+func (a *App) tellNeeds(now time.Time) {
+	for _, need := range a.pet.NewNeeds(now) {
+		a.toasts.Push(fmt.Sprintf("%s %s is %s! Try %s", need.Emoji, a.pet.Name, need.Name, need.Command), now)
+	}
+	a.toasts.Update(now)
+}
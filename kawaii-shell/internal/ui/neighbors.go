@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// neighborAnnounce is how often the companion says hello to the other
+// shells
+const neighborAnnounce = 5 * time.Second
+
+// followNeighbors moves in or out of the neighborhood, as the pet settings
+// say
+func (a *App) followNeighbors() {
+	on := a.petSettings.Settings.Neighbors
+	switch {
+	case on && a.neighbors == nil:
+		neighbors, err := shell.OpenNeighbors(shell.DefaultNeighborsDir())
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			return
+		}
+		a.neighbors, a.neighborsAnnouncedAt = neighbors, time.Time{}
+	case !on && a.neighbors != nil:
+		a.leaveNeighbors()
+	}
+}
+
+// leaveNeighbors moves out of the neighborhood, the companion saying
+// goodbye to the visitors
+func (a *App) leaveNeighbors() {
+	if a.neighbors == nil {
+		return
+	}
+	a.neighbors.Close(a.visitor())
+	a.neighbors = nil
+}
+
+// visitor is the companion as it visits the other shells
+func (a *App) visitor() shell.Visitor {
+	return shell.Visitor{Name: a.pet.Name, Icon: a.pet.Icon(), Level: a.pet.Level}
+}
+
+// visitNeighbors has the companion say hello to the other shells every so
+// often, and tells who came to visit, who went home and what they brought.
+// The companion reacts to the news integrations sent.
+func (a *App) visitNeighbors(now time.Time) {
+	if a.neighbors == nil || a.adoption != nil {
+		// the pet isn't home yet
+		return
+	}
+	if now.Sub(a.neighborsAnnouncedAt) >= neighborAnnounce {
+		a.neighborsAnnouncedAt = now
+		a.neighbors.Announce(a.visitor())
+	}
+
+	news := a.neighbors.Poll(now)
+	if a.petSettings.Settings.Verbosity != pet.VerbosityQuiet {
+		for _, visitor := range news.Arrived {
+			a.output = append(a.output, a.petLine(fmt.Sprintf(
+				"👋 %s %s came over from another shell to visit %s!", visitor.Icon, visitor.Name, a.pet.Name)))
+		}
+		for _, visitor := range news.Left {
+			a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+				"🏡 %s %s went home, see you soon!", visitor.Icon, visitor.Name)))
+		}
+	}
+	for _, gift := range news.Gifts {
+		treat, err := a.pets.PutTreat(gift.Treat)
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			continue
+		}
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"🎁 %s %s brought %s a %s %s! It's in the treat bag", gift.From.Icon, gift.From.Name, a.pet.Name, treat.Emoji, treat.Name)))
+	}
+	for _, reaction := range news.Reactions {
+		kind, err := pet.ParseReaction(reaction.Kind)
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			continue
+		}
+		a.triggerReaction(kind, reaction.Intensity, reaction.Message)
+	}
+}
+
+// giftNeighbor has the companion bring a treat from the bag over to a pet
+// visiting from another shell, the one named or the only one there is
+func (a *App) giftNeighbor(name, to string) {
+	if a.neighbors == nil {
+		a.output = append(a.output, "🥺 Oops: visiting is off, neighbors: true in pet.yaml turns it on")
+		return
+	}
+	visitors := a.neighbors.Visitors()
+	i := slices.IndexFunc(visitors, func(v shell.Visitor) bool { return strings.EqualFold(v.Name, to) })
+	switch {
+	case len(visitors) == 0:
+		a.output = append(a.output, a.theme.Styles.Info.Render("🏡 Nobody's visiting, pets in other kawaii shells come over on their own"))
+		return
+	case to == "" && len(visitors) > 1:
+		a.output = append(a.output, "🥺 Oops: who gets it? Try pet gift "+name+" to "+visitors[0].Name)
+		return
+	case to == "":
+		i = 0
+	case i < 0:
+		a.output = append(a.output, "🥺 Oops: "+to+" isn't visiting, pet neighbors lists who is")
+		return
+	}
+
+	treat, err := a.pets.TakeTreat(name)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error()+", pet treats shows what's in the bag")
+		return
+	}
+	if err := a.neighbors.Give(visitors[i], a.visitor(), treat.Name); err != nil {
+		// the treat goes back in the bag
+		a.pets.PutTreat(treat.Name)
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if err := a.pets.Save(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"🎁 %s trots over to %s %s with a %s %s!", a.pet.Name, visitors[i].Icon, visitors[i].Name, treat.Emoji, treat.Name)))
+}
+
+// showNeighbors lists the pets visiting from the other shells
+func (a *App) showNeighbors() {
+	if a.neighbors == nil {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🏡 Visiting is off, neighbors: true in pet.yaml turns it on"))
+		return
+	}
+	visitors := a.neighbors.Visitors()
+	if len(visitors) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🏡 Nobody's visiting, pets in other kawaii shells come over on their own"))
+		return
+	}
+	names := make([]string, len(visitors))
+	for i, visitor := range visitors {
+		names[i] = fmt.Sprintf("%s %s (level %d)", visitor.Icon, visitor.Name, visitor.Level)
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		"🏡 Visiting: "+strings.Join(names, ", ")+" • pet gift <treat> to <name> brings them a treat"))
+}
+
+// visitorsView renders the pets visiting from the other shells, waving
+// from a corner of the pet box
+func (a *App) visitorsView() string {
+	if a.neighbors == nil {
+		return ""
+	}
+	visitors := a.neighbors.Visitors()
+	if len(visitors) == 0 {
+		return ""
+	}
+	icons := make([]string, len(visitors))
+	names := make([]string, len(visitors))
+	for i, visitor := range visitors {
+		icons[i], names[i] = visitor.Icon, visitor.Name
+	}
+	if a.pet.TextOnly() {
+		return "Visiting " + strings.Join(names, ", ")
+	}
+	return "👋" + strings.Join(icons, "")
+}
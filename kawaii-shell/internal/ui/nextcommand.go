@@ -0,0 +1,46 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// offerNextCommand shows the banner with the command the companion thinks
+// comes next, from the ones it saw run one after the other before
+func (a *App) offerNextCommand() {
+	if next, ok := a.pet.NextCommand(); ok {
+		a.nextCommand = next
+	}
+}
+
+// handleNextCommandKey puts the command the companion suggests in the input
+// with → and dismisses it with esc, while nothing has been typed. It reports
+// whether the key was used.
+func (a *App) handleNextCommandKey(msg tea.KeyMsg) bool {
+	if a.input.Value() != "" {
+		return false
+	}
+	switch msg.String() {
+	case "right":
+		a.input.SetValue(a.nextCommand)
+		a.nextCommand = ""
+	case "esc":
+		a.nextCommand = ""
+	default:
+		return false
+	}
+	return true
+}
+
+// nextCommandView renders the banner suggesting the next command, in the
+// place of the blank line above the input like corrections
+func (a *App) nextCommandView() string {
+	if a.nextCommand == "" {
+		return ""
+	}
+	accent := lipgloss.NewStyle().Foreground(a.theme.Roles.Accent).Bold(true)
+	return lipgloss.NewStyle().MaxWidth(a.width).Render(
+		"💡 " + a.pet.Name + " thinks " + accent.Render(a.nextCommand) + " comes next " +
+			lipgloss.NewStyle().Faint(true).Render("→ to take it • esc to dismiss"),
+	)
+}
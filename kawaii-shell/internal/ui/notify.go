@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// NotifiedMsg is sent once a notification about a finished command went out
+type NotifiedMsg struct {
+	Err error
+}
+
+// isLong reports whether a command running for the given time is worth
+// telling about when it finishes
+func (a *App) isLong(took time.Duration) bool {
+	return a.config.Notify.After > 0 && took >= a.config.Notify.After
+}
+
+// notifyDone tells that a long command finished while the terminal isn't
+// focused, with a desktop notification and the bell, as configured
+func (a *App) notifyDone(command string, took time.Duration, code int) tea.Cmd {
+	notify := a.config.Notify
+	notify.Bell = notify.Bell && !a.quiet
+	if !a.isLong(took) || a.focused || (!notify.Desktop && !notify.Bell) {
+		return nil
+	}
+
+	status := "✔ done"
+	if code != 0 {
+		status = fmt.Sprintf("✘ failed with %d", code)
+	}
+	body := fmt.Sprintf("%s %s after %s", command, status, took.Round(time.Second))
+	terminal := a.terminal()
+	return func() tea.Msg {
+		var errs []error
+		if notify.Bell {
+			errs = append(errs, shell.Bell(terminal))
+		}
+		if notify.Desktop {
+			errs = append(errs, shell.Notify(terminal, "🌸 Kawaii Shell", body))
+		}
+		return NotifiedMsg{Err: errors.Join(errs...)}
+	}
+}
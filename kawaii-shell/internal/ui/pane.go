@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// maxPanes is the most panes the output area is split into
+const maxPanes = 4
+
+// splitDirection is how the panes share the output area
+type splitDirection int
+
+const (
+	// splitStacked puts the panes one above the other
+	splitStacked splitDirection = iota
+	// splitSideBySide puts the panes next to each other
+	splitSideBySide
+)
+
+// pane is a shell session with its own output, the output area can be split
+// between several of them
+type pane struct {
+	shell       *shell.Shell
+	screen      *shell.Screen
+	output      []string
+	cwd         string
+	cwdReported bool
+
+	// lines is the output split into lines as far as it was split, and
+	// lineStarts the line each of those output entries starts at
+	lines      []string
+	lineStarts []int
+
+	// remote is the host the shell is on when it's not this computer, like
+	// in a kawaii ssh session
+	remote string
+
+	// pendingCommand is the output line of the command running in the
+	// shell, which gets its exit code once it finishes
+	pendingCommand int
+	pendingInfo    string
+	pendingText    string
+	pendingStarted time.Time
+
+	// lastExitCode is the exit code of the last command, shown in the
+	// prompt once there is one
+	lastExitCode int
+	hasExitCode  bool
+
+	// scroll is how many lines the output is scrolled back, 0 follows the
+	// output as it comes in
+	scroll int
+
+	// correction fixes the command that wasn't found, if there's one
+	correction *shell.Correction
+
+	// nextCommand is the command the pet thinks comes next, if it has an
+	// idea
+	nextCommand string
+
+	// lastOutput is what the last command in the shell wrote, which the
+	// filter goes through
+	lastOutput        []string
+	lastOutputCommand string
+}
+
+// newPane creates a pane for the shell
+func newPane(sh *shell.Shell) *pane {
+	return &pane{
+		shell:          sh,
+		screen:         shell.NewScreen(),
+		pendingCommand: -1,
+	}
+}
+
+// handleSplitCommand runs split, split -v and split close, returning whether
+// the command was one of them
+func (a *App) handleSplitCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != "split" {
+		return false
+	}
+	switch strings.Join(fields[1:], " ") {
+	case "":
+		a.splitPane(splitStacked)
+	case "-v":
+		a.splitPane(splitSideBySide)
+	case "close":
+		a.closePane()
+	default:
+		a.output = append(a.output, "🥺 Oops: try split, split -v or split close")
+	}
+	return true
+}
+
+// splitPane opens a new shell in a pane next to the others, in the same
+// directory as the active one. All the panes follow the latest direction.
+func (a *App) splitPane(direction splitDirection) {
+	if len(a.panes) >= maxPanes {
+		a.output = append(a.output, fmt.Sprintf("🥺 Oops: %d panes is as cozy as it gets", maxPanes))
+		return
+	}
+	dir := a.cwd
+	if a.remote != "" {
+		// the new shell is on this computer
+		dir = ""
+	}
+	sh, err := shell.NewShell()
+	if err == nil {
+		err = sh.Start(dir)
+	}
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops! Couldn't start shell: "+err.Error())
+		return
+	}
+
+	p := newPane(sh)
+	p.cwd = dir
+	a.split = direction
+	a.panes = append(a.panes, p)
+	a.resizePanes()
+	a.focusPane(len(a.panes) - 1)
+}
+
+// closePane closes the active pane and its shell, stopping the script
+// running there
+func (a *App) closePane() {
+	if len(a.panes) == 1 {
+		a.output = append(a.output, "🥺 Oops: there's only one pane, exit or Ctrl+C to leave")
+		return
+	}
+	i := a.activePane()
+	closed := a.pane
+	a.shell.Close()
+	a.panes = append(a.panes[:i], a.panes[i+1:]...)
+	a.resizePanes()
+	a.focusPane(min(i, len(a.panes)-1))
+	// its steps would never finish
+	if a.script != nil && a.script.pane == closed {
+		a.stopScript("its pane was closed")
+	}
+}
+
+// handlePaneKey moves between the panes with alt and the arrows or hjkl,
+// reporting whether the key was used
+func (a *App) handlePaneKey(msg tea.KeyMsg) bool {
+	if len(a.panes) < 2 {
+		return false
+	}
+	i := a.activePane()
+	switch msg.String() {
+	case "alt+left", "alt+up", "alt+h", "alt+k":
+		i = (i + len(a.panes) - 1) % len(a.panes)
+	case "alt+right", "alt+down", "alt+l", "alt+j":
+		i = (i + 1) % len(a.panes)
+	default:
+		return false
+	}
+	a.focusPane(i)
+	return true
+}
+
+// focusPane makes the pane the one getting the input, and the pet has a
+// look at it
+func (a *App) focusPane(i int) {
+	a.pane = a.panes[i]
+	a.completions = nil
+	a.gitStale = true
+
+	comment := "all quiet here"
+	switch {
+	case a.pendingCommand >= 0:
+		comment = "busy with " + a.pendingText
+	case a.hasExitCode && a.lastExitCode != 0:
+		comment = "the last command failed here, we'll fix it"
+	case a.hasExitCode:
+		comment = "the last command went great"
+	}
+	a.output = append(a.output, a.petLine(fmt.Sprintf(
+		"%s %s: Pane %d in %s, %s!", a.pet.GetMoodEmoji(), a.pet.Name, i+1, shortenPath(a.cwd), comment)))
+}
+
+// activePane returns the index of the active pane
+func (a *App) activePane() int {
+	for i, p := range a.panes {
+		if p == a.pane {
+			return i
+		}
+	}
+	return 0
+}
+
+// paneBox returns the size of the pane's box, borders included, when the
+// panes share an area of the given height
+func (a *App) paneBox(i, height int) (int, int) {
+	width := a.width - 2
+	share := func(total int) int {
+		size := total / len(a.panes)
+		if i < total%len(a.panes) {
+			size++
+		}
+		return size
+	}
+	if a.split == splitSideBySide {
+		return share(width), height
+	}
+	return width, share(height)
+}
+
+// paneSize returns the number of columns and rows available to programs
+// inside the pane
+func (a *App) paneSize(i int) (int, int) {
+	box := a.theme.Styles.OutputBox
+	width, height := a.paneBox(i, a.outputAreaHeight())
+	cols := width - box.GetHorizontalBorderSize() - box.GetHorizontalPadding()
+	rows := height - box.GetVerticalBorderSize() - box.GetVerticalPadding()
+	return max(cols, 1), max(rows, 1)
+}
+
+// resizePanes sets the size of every pane's shell
+func (a *App) resizePanes() {
+	for i, p := range a.panes {
+		if err := p.shell.Resize(a.paneSize(i)); err != nil {
+			a.output = append(a.output, "🥺 Oops! Couldn't resize shell: "+err.Error())
+		}
+	}
+}
+
+// tickPanes takes in what the shells of all the panes wrote, the active one
+// last so it's active again afterwards
+func (a *App) tickPanes(now time.Time) tea.Cmd {
+	active := a.pane
+	var cmds []tea.Cmd
+	for _, p := range a.panes {
+		if p != active {
+			a.pane = p
+			cmds = append(cmds, a.tickPane(now))
+		}
+	}
+	a.pane = active
+	cmds = append(cmds, a.tickPane(now))
+	return tea.Batch(cmds...)
+}
+
+// tickPane takes in what the shell of the active pane wrote
+func (a *App) tickPane(now time.Time) tea.Cmd {
+	var cmds []tea.Cmd
+	a.trimScrollback()
+	lines := len(a.scrollbackLines())
+	if data := a.shell.ReadOutput(); len(data) > 0 {
+		a.output = append(a.output, a.decorate(a.screen.Write(data))...)
+	}
+	for _, code := range a.screen.TakeExitCodes() {
+		cmds = append(cmds, a.showExitCode(code, now))
+	}
+	a.keepScroll(lines)
+	a.updateCwd()
+	return tea.Batch(cmds...)
+}
+
+// panesView renders the panes in the output area, the active one with the
+// given content
+func (a *App) panesView(height int, content func(height int) string) string {
+	active := a.pane
+	defer func() { a.pane = active }()
+
+	boxes := make([]string, len(a.panes))
+	for i, p := range a.panes {
+		width, boxHeight := a.paneBox(i, height)
+		box := a.theme.Styles.OutputBox.Width(width).Height(boxHeight)
+		if len(a.panes) == 1 {
+			boxes[i] = box.Render(content(boxHeight))
+			continue
+		}
+
+		a.pane = p
+		text := a.visibleOutput(boxHeight)
+		if p == active {
+			text = content(boxHeight)
+		} else {
+			// the active pane stands out with the theme's border
+			box = box.BorderStyle(lipgloss.NormalBorder())
+		}
+		// lines written before the split are too wide
+		cols := width - box.GetHorizontalBorderSize() - box.GetHorizontalPadding()
+		lines := strings.Split(text, "\n")
+		for j, line := range lines {
+			lines[j] = ansi.Truncate(line, cols, "…")
+		}
+		boxes[i] = box.Render(strings.Join(lines, "\n"))
+	}
+	if a.split == splitSideBySide {
+		return lipgloss.JoinHorizontal(lipgloss.Top, boxes...)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, boxes...)
+}
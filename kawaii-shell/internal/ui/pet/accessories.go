@@ -0,0 +1,95 @@
+package pet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Slot is where an accessory is worn, one accessory at a time each
+type Slot int
+
+const (
+	SlotHead Slot = iota
+	SlotNeck
+)
+
+// Accessory is something pets can wear once the achievement rewarding it
+// is earned
+type Accessory struct {
+	ID    string
+	Name  string
+	Emoji string
+	Slot  Slot
+}
+
+// Accessories are all the accessories there are
+var Accessories = []Accessory{
+	{"bow", "Bow", "🎀", SlotHead},
+	{"scarf", "Cozy scarf", "🧣", SlotNeck},
+	{"flower-crown", "Flower crown", "🌸", SlotHead},
+	{"top-hat", "Top hat", "🎩", SlotHead},
+	{"collar", "Sparkly collar", "💎", SlotNeck},
+	{"crown", "Crown", "👑", SlotHead},
+	{"party-hat", "Party hat", "🥳", SlotHead},
+}
+
+// EarnedBy returns the achievement rewarding the accessory
+func (a Accessory) EarnedBy() Achievement {
+	for _, achievement := range Achievements {
+		if achievement.Reward == a.ID {
+			return achievement
+		}
+	}
+	return Achievement{}
+}
+
+// FindAccessory returns the accessory with the ID or name, ignoring the case
+func FindAccessory(name string) (Accessory, bool) {
+	for _, accessory := range Accessories {
+		if strings.EqualFold(accessory.ID, name) || strings.EqualFold(accessory.Name, name) {
+			return accessory, true
+		}
+	}
+	return Accessory{}, false
+}
+
+// Wears reports whether the pet wears the accessory
+func (p *Pet) Wears(accessory Accessory) bool {
+	return slices.Contains(p.Wearing, accessory.ID)
+}
+
+// worn returns the emoji of the accessory the pet wears in the slot, empty
+// when it wears none there
+func (p *Pet) worn(slot Slot) string {
+	for _, id := range p.Wearing {
+		if accessory, ok := FindAccessory(id); ok && accessory.Slot == slot {
+			return accessory.Emoji
+		}
+	}
+	return ""
+}
+
+// Unlocked reports whether the accessory was earned
+func (r *Roster) Unlocked(accessory Accessory) bool {
+	return slices.Contains(r.Wardrobe, accessory.ID)
+}
+
+// ToggleAccessory puts the accessory on the active pet, in place of the one
+// worn in the same slot, or takes it off when it's already worn
+func (r *Roster) ToggleAccessory(accessory Accessory) (bool, error) {
+	if !r.Unlocked(accessory) {
+		return false, fmt.Errorf("the %s is still locked, %s earns it", strings.ToLower(accessory.Name), accessory.EarnedBy().Name)
+	}
+	p := r.Pet()
+	if p.Wears(accessory) {
+		p.Wearing = slices.DeleteFunc(p.Wearing, func(id string) bool { return id == accessory.ID })
+		return false, r.Save()
+	}
+	p.Wearing = slices.DeleteFunc(p.Wearing, func(id string) bool {
+		worn, ok := FindAccessory(id)
+		return !ok || worn.Slot == accessory.Slot
+	})
+	p.Wearing = append(p.Wearing, accessory.ID)
+	return true, r.Save()
+}
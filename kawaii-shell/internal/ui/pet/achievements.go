@@ -0,0 +1,139 @@
+package pet
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Milestones counts the shell usage achievements are earned for
+type Milestones struct {
+	Commands        int `json:"commands"`
+	GitPushes       int `json:"git_pushes"`
+	DangersSurvived int `json:"dangers_survived"`
+	Birthdays       int `json:"birthdays"`
+
+	// DayStreak is how many days in a row commands were run, up to LastDay,
+	// RestDay the last day off that didn't break it
+	DayStreak  int    `json:"day_streak"`
+	LastDay    string `json:"last_day"`
+	RestDay    string `json:"rest_day"`
+	BestStreak int    `json:"best_streak"`
+}
+
+// Achievement is a milestone worth celebrating, earning the companion
+// experience and sometimes an accessory
+type Achievement struct {
+	ID          string
+	Name        string
+	Emoji       string
+	Description string
+	XP          int
+
+	// Reward is the ID of the accessory the achievement unlocks, if any
+	Reward string
+
+	// progress returns how far along the roster is, and how far it needs
+	// to get
+	progress func(r *Roster) (int, int)
+}
+
+// Achievements are all the achievements there are
+var Achievements = []Achievement{
+	{"first-command", "Hello, shell!", "🐣", "Run your first command", 10, "", commands(1)},
+	{"level-2", "Growing up", "🌱", "Reach level 2", 20, "bow", levelReached(2)},
+	{"commands-100", "Centurion", "💯", "Run 100 commands", 50, "scarf", commands(100)},
+	{"git-push", "Shipped it!", "🚀", "Push with git for the first time", 30, "top-hat", func(r *Roster) (int, int) {
+		return r.Milestones.GitPushes, 1
+	}},
+	{"close-call", "Close call", "😅", "Survive a dangerous command warning", 20, "", func(r *Roster) (int, int) {
+		return r.Milestones.DangersSurvived, 1
+	}},
+	{"party", "Party time", "🎉", "Adopt a second pet", 20, "flower-crown", func(r *Roster) (int, int) {
+		return len(r.Pets), 2
+	}},
+	{"streak-7", "Week of love", "📅", "Use the shell 7 days in a row", 100, "crown", func(r *Roster) (int, int) {
+		return r.Milestones.DayStreak, 7
+	}},
+	{"birthday", "Happy birthday!", "🎂", "Celebrate a pet's first birthday", 100, "party-hat", func(r *Roster) (int, int) {
+		return r.Milestones.Birthdays, 1
+	}},
+	{"commands-1000", "Terminal wizard", "🧙", "Run 1000 commands", 200, "collar", commands(1000)},
+	{"level-10", "Top of the class", "🎓", "Reach level 10", 100, "", levelReached(10)},
+}
+
+// commands is the progress towards running the number of commands
+func commands(count int) func(r *Roster) (int, int) {
+	return func(r *Roster) (int, int) {
+		return r.Milestones.Commands, count
+	}
+}
+
+// levelReached is the progress of the pet closest to the level
+func levelReached(level int) func(r *Roster) (int, int) {
+	return func(r *Roster) (int, int) {
+		highest := 0
+		for _, p := range r.Pets {
+			highest = max(highest, p.Level)
+		}
+		return highest, level
+	}
+}
+
+// Progress returns how far along the roster is towards the achievement,
+// and how far it needs to get
+func (a Achievement) Progress(r *Roster) (int, int) {
+	done, total := a.progress(r)
+	return min(done, total), total
+}
+
+// Achieved reports whether the achievement was earned, and when
+func (r *Roster) Achieved(achievement Achievement) (time.Time, bool) {
+	earned, ok := r.Achievements[achievement.ID]
+	return earned, ok
+}
+
+// CountCommand counts a command that finished towards the achievements,
+// the streak and the quests of the day
+func (r *Roster) CountCommand(command string, exitCode int, now time.Time) {
+	m := &r.Milestones
+	m.Commands++
+	if fields := strings.Fields(command); exitCode == 0 && len(fields) > 1 && fields[0] == "git" && slices.Contains(fields[1:], "push") {
+		m.GitPushes++
+	}
+
+	r.countDay(now)
+	r.advanceQuests(questStep{
+		command:  command,
+		exitCode: exitCode,
+		tried:    r.tried(command),
+		cleaned:  removed(command, exitCode),
+	}, now)
+}
+
+// SurviveDanger counts a dangerous command warning the user got through
+func (r *Roster) SurviveDanger() {
+	r.Milestones.DangersSurvived++
+}
+
+// CheckAchievements awards the achievements earned since the last time,
+// returning them. The companion gets the experience, and the rewards go to
+// the wardrobe.
+func (r *Roster) CheckAchievements(now time.Time) []Achievement {
+	var earned []Achievement
+	for _, achievement := range Achievements {
+		if _, ok := r.Achieved(achievement); ok {
+			continue
+		}
+		if done, total := achievement.Progress(r); done < total {
+			continue
+		}
+		r.Achievements[achievement.ID] = now
+		if achievement.Reward != "" && !slices.Contains(r.Wardrobe, achievement.Reward) {
+			r.Wardrobe = append(r.Wardrobe, achievement.Reward)
+		}
+		r.Pet().GainExperience(achievement.XP)
+		earned = append(earned, achievement)
+	}
+	return earned
+}
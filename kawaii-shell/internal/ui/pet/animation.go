@@ -0,0 +1,101 @@
+package pet
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+// animateEvery is how often the pet's idle motion moves along
+const animateEvery = 100 * time.Millisecond
+
+// PetAnimateMsg moves the pet's idle motion along
+type PetAnimateMsg struct{}
+
+// animate ticks the idle motion
+func animate() tea.Cmd {
+	return tea.Tick(animateEvery, func(time.Time) tea.Msg {
+		return PetAnimateMsg{}
+	})
+}
+
+// initAnimations sets up the elements the idle motion is made of, the
+// motion of the pet and its glow, in the animation manager
+func (p *Pet) initAnimations() {
+	p.animationManager = components.NewAnimationManager()
+	p.motion = components.NewAnimatedElement("", 0, 0)
+	p.glow = components.NewAnimatedElement("", 0, 0)
+	p.animationManager.AddElement(p.motion)
+	p.animationManager.AddElement(p.glow)
+}
+
+// nextMotion starts the next idle motion once the last one finished, each
+// one showing the next animation frame. Excited pets hop, happy ones sway
+// to and fro, calm ones sway slower and sleepy ones just breathe.
+func (p *Pet) nextMotion() {
+	if p.glow.State == components.AnimIdle && (p.Mood == MoodExcited || p.Mood == MoodLove) {
+		p.glow.Glow(0.4, 1.5)
+	}
+	if p.motion.State != components.AnimIdle {
+		return
+	}
+	p.Animation = (p.Animation + 1) % 8
+
+	// a hop ends a line up, the pet lands back for the next one
+	p.motion.Y, p.motion.TargetY = 0, 0
+	switch {
+	case p.Activity == ActivityCelebrating || p.Mood == MoodExcited:
+		p.hop()
+	case p.Mood == MoodSleepy:
+		p.motion.Pulse(3)
+	case p.Mood == MoodHappy || p.Mood == MoodLove:
+		p.sway(1.2)
+	default:
+		p.sway(2.4)
+	}
+}
+
+// hop has the pet drop from a line up, landing with a bounce
+func (p *Pet) hop() {
+	p.motion.Y, p.motion.TargetY = -1, -1
+	p.motion.Bounce(-1, 0.8)
+}
+
+// sway moves the pet over to the other side, taking the seconds
+func (p *Pet) sway(seconds float64) {
+	target := 1.0
+	if p.motion.TargetX > 0 {
+		target = -1
+	}
+	p.motion.MoveTo(target, 0, seconds)
+}
+
+// swayOffset returns how many columns the pet swayed off the middle
+func (p *Pet) swayOffset() int {
+	return int(math.Round(max(-1, min(1, p.motion.X))))
+}
+
+// lifted reports whether the pet is up in the air, hopping
+func (p *Pet) lifted() bool {
+	return p.motion.State == components.AnimBounce && p.motion.Y <= -0.5
+}
+
+// glowing reports whether the pet's glow is at its brightest
+func (p *Pet) glowing() bool {
+	return p.glow.State == components.AnimGlow && p.glow.Alpha < 0.9
+}
+
+// swayLine moves the line of the pet's sprite by the sway, padding the
+// other side so it stays centered in the pet box
+func (p *Pet) swayLine(line string) string {
+	switch p.swayOffset() {
+	case 1:
+		return "  " + line
+	case -1:
+		return line + "  "
+	}
+	return line
+}
@@ -0,0 +1,102 @@
+package pet
+
+import (
+	"fmt"
+	"time"
+)
+
+// Span is how long it has been for an anniversary
+type Span int
+
+const (
+	SpanWeek Span = iota
+	SpanMonth
+	SpanYear
+)
+
+// spanNames are the names of the spans, one of them
+var spanNames = map[Span]string{
+	SpanWeek:  "week",
+	SpanMonth: "month",
+	SpanYear:  "year",
+}
+
+// anniversaryXP is the experience gift for each span of an anniversary
+var anniversaryXP = map[Span]int{
+	SpanWeek:  10,
+	SpanMonth: 30,
+	SpanYear:  100,
+}
+
+// Anniversary is a number of weeks, months or years since a pet's birthday
+type Anniversary struct {
+	Span  Span
+	Count int
+}
+
+// ID names the anniversary, like year-1
+func (a Anniversary) ID() string {
+	return fmt.Sprintf("%s-%d", spanNames[a.Span], a.Count)
+}
+
+// Title tells whose anniversary it is and how long it has been
+func (a Anniversary) Title(name string) string {
+	if a.Span == SpanYear {
+		return fmt.Sprintf("Happy birthday, %s! %s old today", name, plural(a.Count, "year"))
+	}
+	return fmt.Sprintf("%s together with %s!", plural(a.Count, spanNames[a.Span]), name)
+}
+
+// XP returns the experience the pet gets as a gift for the anniversary
+func (a Anniversary) XP() int {
+	return anniversaryXP[a.Span] * a.Count
+}
+
+// AnniversaryOn returns the pet's anniversary on the day of now, if there's
+// one. A birthday beats a month, which beats a week. Pets born on the 31st
+// have their monthly anniversary on the last day of shorter months.
+func (p *Pet) AnniversaryOn(now time.Time) (Anniversary, bool) {
+	born := p.Birthday.In(now.Location())
+	year, month, day := now.Date()
+	bornYear, bornMonth, bornDay := born.Date()
+
+	months := (year-bornYear)*12 + int(month-bornMonth)
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, now.Location()).Day()
+	if months > 0 && day == min(bornDay, lastDay) {
+		if months%12 == 0 {
+			return Anniversary{SpanYear, months / 12}, true
+		}
+		return Anniversary{SpanMonth, months}, true
+	}
+
+	// rounded, days with a clock change are an hour short or long
+	days := int(dayStart(now).Sub(dayStart(born)).Round(24*time.Hour) / (24 * time.Hour))
+	if days > 0 && days%7 == 0 {
+		return Anniversary{SpanWeek, days / 7}, true
+	}
+	return Anniversary{}, false
+}
+
+// CelebrateAnniversary returns the companion's anniversary on the day of
+// now, once. The companion gets the experience as a gift and keeps a memory
+// of it, and birthdays count towards the achievements.
+func (r *Roster) CelebrateAnniversary(now time.Time) (Anniversary, bool) {
+	p := r.Pet()
+	anniversary, ok := p.AnniversaryOn(now)
+	if !ok || p.Celebrated == anniversary.ID() {
+		return Anniversary{}, false
+	}
+	p.Celebrated = anniversary.ID()
+	if anniversary.Span == SpanYear {
+		r.Milestones.Birthdays++
+	}
+
+	p.Memories = append(p.Memories, fmt.Sprintf("%s: 🎂 %s", now.Format("15:04"), anniversary.Title(p.Name)))
+	if len(p.Memories) > 20 {
+		p.Memories = p.Memories[len(p.Memories)-20:]
+	}
+	p.Happiness += 10
+	p.Celebrate()
+	p.GainExperience(anniversary.XP())
+	return anniversary, true
+}
@@ -0,0 +1,69 @@
+package pet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnniversaryOn(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	p.Birthday = time.Date(2024, time.January, 31, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		day  string
+		want string
+	}{
+		{"2024-01-31", ""},
+		{"2024-02-06", ""},
+		{"2024-02-07", "week-1"},
+		// February is short, the month comes on its last day
+		{"2024-02-29", "month-1"},
+		{"2024-03-31", "month-2"},
+		{"2024-04-30", "month-3"},
+		{"2025-01-31", "year-1"},
+		{"2026-01-31", "year-2"},
+		{"2026-02-01", ""},
+	}
+	for _, test := range tests {
+		now, err := time.Parse(time.DateOnly, test.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(9 * time.Hour)
+		anniversary, ok := p.AnniversaryOn(now)
+		got := ""
+		if ok {
+			got = anniversary.ID()
+		}
+		if got != test.want {
+			t.Errorf("AnniversaryOn(%s) = %q, want %q", test.day, got, test.want)
+		}
+	}
+}
+
+func TestCelebrateAnniversary(t *testing.T) {
+	r, err := LoadRoster("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := r.Pet()
+	p.Birthday = time.Date(2024, time.March, 14, 12, 0, 0, 0, time.UTC)
+	birthday := time.Date(2025, time.March, 14, 8, 0, 0, 0, time.UTC)
+
+	anniversary, ok := r.CelebrateAnniversary(birthday)
+	if !ok || anniversary.ID() != "year-1" {
+		t.Fatalf("CelebrateAnniversary() = %q, %v, want year-1", anniversary.ID(), ok)
+	}
+	if p.Level < 2 {
+		t.Errorf("level %d after %d XP for the birthday, want a level up", p.Level, anniversary.XP())
+	}
+	if memories := p.RecentMemories(1); len(memories) == 0 || memories[0] != "08:00: 🎂 Happy birthday, Neko! 1 year old today" {
+		t.Errorf("the last memory is %q, want the birthday", memories)
+	}
+	if _, ok := r.CelebrateAnniversary(birthday.Add(time.Hour)); ok {
+		t.Error("the birthday was celebrated twice")
+	}
+	if earned := r.CheckAchievements(birthday); len(earned) == 0 || !r.Unlocked(Accessory{ID: "party-hat"}) {
+		t.Error("the first birthday didn't unlock the party hat")
+	}
+}
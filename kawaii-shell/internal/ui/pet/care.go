@@ -0,0 +1,156 @@
+package pet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Care is a way of looking after the pet
+type Care int
+
+const (
+	CareFeed Care = iota
+	CarePlay
+	CareGroom
+)
+
+// careCooldowns are how long the pet needs between two of each care
+var careCooldowns = map[Care]time.Duration{
+	CareFeed:  5 * time.Minute,
+	CarePlay:  2 * time.Minute,
+	CareGroom: 10 * time.Minute,
+}
+
+// playEnergy is the energy the pet needs to play
+const playEnergy = 20
+
+// Treat is a snack from the treat bag, given on top of the meals
+type Treat struct {
+	Name  string
+	Emoji string
+}
+
+// Treats are all the treats there are, cookies being what every pet likes
+var Treats = []Treat{
+	{"cookie", "🍪"},
+	{"fish", "🐟"},
+	{"berry", "🫐"},
+	{"carrot", "🥕"},
+	{"pepper", "🌶️"},
+	{"cupcake", "🧁"},
+	{"battery", "🔋"},
+}
+
+// FindTreat returns the treat with the name, ignoring the case
+func FindTreat(name string) (Treat, bool) {
+	for _, treat := range Treats {
+		if strings.EqualFold(treat.Name, name) {
+			return treat, true
+		}
+	}
+	return Treat{}, false
+}
+
+// FavoriteTreat returns the treat the pet loves the most, cookies for the
+// types without a favorite
+func (p *Pet) FavoriteTreat() Treat {
+	if treat, ok := FindTreat(packOf(p.Type).FavoriteTreat); ok {
+		return treat
+	}
+	return Treats[0]
+}
+
+// Cooldown returns how long until the pet is up for the care again, 0 when
+// it is now
+func (p *Pet) Cooldown(care Care) time.Duration {
+	last := p.LastFed
+	switch care {
+	case CarePlay:
+		last = p.LastPlayed
+	case CareGroom:
+		last = p.LastGroomed
+	}
+	return max(careCooldowns[care]-time.Since(last), 0)
+}
+
+// Care looks after the pet, unless it had enough of that for now
+func (p *Pet) Care(care Care) error {
+	if wait := p.Cooldown(care); wait > 0 {
+		wait = wait.Round(time.Second)
+		switch care {
+		case CarePlay:
+			return fmt.Errorf("%s is still catching their breath, try again in %s", p.Name, wait)
+		case CareGroom:
+			return fmt.Errorf("%s is still fluffy from last time, try again in %s", p.Name, wait)
+		}
+		return fmt.Errorf("%s isn't hungry yet, try again in %s", p.Name, wait)
+	}
+
+	switch care {
+	case CareFeed:
+		p.Feed()
+	case CarePlay:
+		if p.Energy < playEnergy {
+			return fmt.Errorf("%s is too tired to play, feed them first", p.Name)
+		}
+		p.Play()
+	case CareGroom:
+		p.Groom()
+	}
+	return nil
+}
+
+// Play plays with the pet, which chases boredom and loneliness away but
+// tires it out
+func (p *Pet) Play() {
+	p.LastPlayed = p.now()
+	p.lastReactionTime = p.LastPlayed
+	p.State.Boredom -= 0.5
+	p.State.Loneliness -= 0.3
+	p.State.Exhaustion += 0.15
+	p.Energy -= 10
+	p.Happiness += 10 + int(p.Personality.Playfulness*10)
+	p.Mood = MoodPlayful
+	p.Activity = ActivityPlaying
+	p.SpecialState = "playtime"
+	p.Experience += 2
+
+	p.particleSystem.AddSparkles(25, 10, 6)
+	p.particleSystem.AddFlowerPetals(25, 10, 4)
+	p.checkLevelUp()
+	p.capStateValues()
+}
+
+// Groom brushes the pet, which calms it down
+func (p *Pet) Groom() {
+	p.LastGroomed = p.now()
+	p.lastReactionTime = p.LastGroomed
+	p.State.Stress -= 0.4
+	p.State.Loneliness -= 0.2
+	p.Happiness += 8
+	p.Mood = MoodLove
+	p.SpecialState = "groomed"
+
+	p.particleSystem.AddHearts(25, 10, 6)
+	p.capStateValues()
+}
+
+// EatTreat gives the pet a treat, its favorite one making its day
+func (p *Pet) EatTreat(treat Treat) {
+	p.lastReactionTime = p.now()
+	p.State.Hunger -= 0.2
+	p.Happiness += 10
+	p.Mood = MoodHappy
+	p.SpecialState = "treat"
+	if treat == p.FavoriteTreat() {
+		p.Happiness += 15
+		p.Mood = MoodExcited
+		p.SpecialState = "favorite-treat"
+		p.particleSystem.AddHearts(25, 10, 8)
+	}
+	p.Activity = ActivityEating
+
+	p.particleSystem.AddSparkles(25, 10, 4)
+	p.capStateValues()
+}
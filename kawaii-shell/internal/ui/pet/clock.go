@@ -0,0 +1,36 @@
+package pet
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clock tells the pet the time
+type Clock interface {
+	Now() time.Time
+}
+
+// Deterministic makes the pet go by the clock and roll its dice, and the
+// ones of its particles, from the seed, so it behaves the same every time.
+// That's for tests, pets go by the real time otherwise.
+func (p *Pet) Deterministic(clock Clock, seed int64) {
+	p.clock = clock
+	p.rng = rand.New(rand.NewSource(seed))
+	p.particleSystem.Seed(seed)
+}
+
+// now returns the time by the pet's clock
+func (p *Pet) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+	return p.clock.Now()
+}
+
+// random returns the dice the pet rolls
+func (p *Pet) random() *rand.Rand {
+	if p.rng == nil {
+		p.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return p.rng
+}
@@ -0,0 +1,121 @@
+package pet
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	time time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.time
+}
+
+// newTestPet returns a pet that behaves the same every time, with a
+// personality of its own and the clock at noon
+func newTestPet(personality Personality) (*Pet, *fakeClock) {
+	clock := &fakeClock{time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC)}
+	p := NewPet("Neko", TypeCat)
+	p.Deterministic(clock, 1)
+	p.Personality = personality
+	p.LastFed, p.LastPlayed, p.LastGroomed, p.Birthday = clock.time, clock.time, clock.time, clock.time
+	return p, clock
+}
+
+// golden compares what came out with the golden file, updating it with
+// -update
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file, run with -update to create it: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s doesn't match the golden file:\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+// status describes how the pet is doing, one line for each tick
+func status(tick int, p *Pet) string {
+	return fmt.Sprintf("%3d %-11s %-11s %s hunger=%.2f boredom=%.2f lonely=%.2f stress=%.2f energy=%d | %s",
+		tick, moodNames[p.Mood], activityNames[p.Activity], p.GetMoodEmoji(),
+		p.State.Hunger, p.State.Boredom, p.State.Loneliness, p.State.Stress, p.Energy, p.GetPetMessage())
+}
+
+func TestMoodTransitions(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	personalities := map[string]Personality{
+		"balanced": {Curiosity: 0.5, Playfulness: 0.5, Loyalty: 0.5, Intelligence: 0.5, Energy: 0.5},
+		"playful":  {Curiosity: 0.6, Playfulness: 0.95, Loyalty: 0.7, Intelligence: 0.5, Energy: 0.9},
+	}
+	for name, personality := range personalities {
+		t.Run(name, func(t *testing.T) {
+			p, clock := newTestPet(personality)
+			var lines []string
+			// the pet ticks every 5 seconds, this is a little over an hour
+			for tick := 0; tick < 800; tick++ {
+				clock.time = clock.time.Add(5 * time.Second)
+				Model{p}.Update(PetTickMsg{})
+				if tick%20 == 0 {
+					lines = append(lines, status(tick, p))
+				}
+			}
+			golden(t, "mood-"+name, strings.Join(lines, "\n")+"\n")
+		})
+	}
+}
+
+func TestMoodTransitionsRepeat(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	personality := Personality{Curiosity: 0.5, Playfulness: 0.5, Loyalty: 0.5, Intelligence: 0.5, Energy: 0.5}
+	run := func() string {
+		p, clock := newTestPet(personality)
+		// happy enough for the dice to pick the mood
+		p.Happiness = 100
+		var b strings.Builder
+		for tick := range 50 {
+			clock.time = clock.time.Add(5 * time.Second)
+			p.updateMood()
+			p.updateActivity()
+			p.Happiness = 100
+			b.WriteString(status(tick, p) + "\n")
+		}
+		return b.String()
+	}
+	if first, second := run(), run(); first != second {
+		t.Errorf("the same seed gave different moods:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestView(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	p, clock := newTestPet(Personality{Curiosity: 0.5, Playfulness: 0.5, Loyalty: 0.5, Intelligence: 0.5, Energy: 0.5})
+	var views []string
+	for _, command := range []string{"ls", "git status", "rm -rf build"} {
+		clock.time = clock.time.Add(time.Minute)
+		p.ReactToCommand(command, command == "rm -rf build")
+		views = append(views, "$ "+command+"\n"+ansi.Strip(p.View()))
+	}
+	golden(t, "view", strings.Join(views, "\n\n")+"\n")
+}
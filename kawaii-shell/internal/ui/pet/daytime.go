@@ -0,0 +1,107 @@
+package pet
+
+import (
+	"fmt"
+	"time"
+)
+
+// dayPart is the part of the day pets live by
+type dayPart int
+
+const (
+	dayUnknown dayPart = iota
+	dayMorning
+	dayAfternoon
+	dayEvening
+	dayNight
+)
+
+const (
+	// napAfter is how long the pet stays up at night after something
+	// woke it
+	napAfter = 2 * time.Minute
+
+	// focusReactEvery is how many commands it takes for the pet to react
+	// during focus hours
+	focusReactEvery = 4
+)
+
+// partOfDay returns the part of the day the time is in, the night going
+// from 22:00 to 06:00
+func partOfDay(t time.Time) dayPart {
+	switch hour := t.Hour(); {
+	case hour >= 6 && hour < 12:
+		return dayMorning
+	case hour >= 12 && hour < 18:
+		return dayAfternoon
+	case hour >= 18 && hour < 22:
+		return dayEvening
+	default:
+		return dayNight
+	}
+}
+
+// FollowClock makes the pet live by the clock, dozing off at night and
+// waking up full of energy in the morning. It returns a greeting when a new
+// part of the day starts, and keeps the pet quiet during focus hours.
+func (p *Pet) FollowClock(now time.Time, focus bool) string {
+	p.focus = focus
+	part := partOfDay(now)
+	if part == dayNight && p.Activity != ActivitySleeping && p.SpecialState == "" && !p.Waiting() &&
+		now.Sub(p.lastReactionTime) > napAfter {
+		p.fallAsleep()
+	}
+	if part == p.dayPart {
+		return ""
+	}
+	p.dayPart = part
+	greeting := p.greet(part)
+	if p.settings.Verbosity == VerbosityQuiet {
+		return ""
+	}
+	return greeting
+}
+
+// greet has the pet start the part of the day, returning what it says
+func (p *Pet) greet(part dayPart) string {
+	switch part {
+	case dayMorning:
+		p.Energy += 30
+		p.State.Exhaustion = 0
+		p.Mood = MoodExcited
+		p.Activity = ActivityIdle
+		p.particleSystem.AddSparkles(25, 10, 5)
+		p.capStateValues()
+		return fmt.Sprintf("☀️ Good morning! %s stretches and is full of energy", p.Name)
+	case dayAfternoon:
+		return fmt.Sprintf("🌤️ Good afternoon! %s is keeping you company", p.Name)
+	case dayEvening:
+		return fmt.Sprintf("🌆 Good evening! %s is winding down", p.Name)
+	default:
+		if !p.Waiting() {
+			p.fallAsleep()
+		}
+		return fmt.Sprintf("🌙 It's getting late, %s is getting sleepy... 💤", p.Name)
+	}
+}
+
+// fallAsleep makes the pet doze off
+func (p *Pet) fallAsleep() {
+	p.Mood = MoodSleepy
+	p.Activity = ActivitySleeping
+}
+
+// reacts reports whether the pet reacts to the command, which it may only
+// do now and then, and even less during focus hours. Dangerous commands
+// always get a reaction.
+func (p *Pet) reacts(isDangerous bool) bool {
+	every := p.settings.ReactEvery
+	if p.focus {
+		every = max(every, focusReactEvery)
+	}
+	if every <= 1 || isDangerous {
+		return true
+	}
+	p.unreacted++
+	return p.unreacted%every == 0
+}
@@ -0,0 +1,112 @@
+package pet
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stage is how far the pet evolved
+type Stage int
+
+const (
+	StageBaby Stage = iota
+	StageGrown
+	StageMythic
+)
+
+const (
+	// evolutionFrame is how long each frame of the evolution sequence shows
+	evolutionFrame = 200 * time.Millisecond
+
+	// evolutionFrames is how many frames the sequence has, the last few
+	// showing the new look
+	evolutionFrames = 16
+	settledFrames   = 4
+)
+
+// evolutionSparkles go around the pet while it evolves
+var evolutionSparkles = []string{"✨", "✨ 💫", "💫 ✨ 💫", "🌟 💫 🌟", "✨ 🌟 ✨ 🌟"}
+
+// StageName returns what the pet is called at its stage, like Kitten
+func (p *Pet) StageName() string {
+	if name := packOf(p.Type).stage(p.Stage).Name; name != "" {
+		return name
+	}
+	return p.Type.String()
+}
+
+// stageSprites returns the emojis of the pet at the stage, nil when it
+// looks like it always does, like grown up pets
+func (p *Pet) stageSprites(stage Stage) []string {
+	return packOf(p.Type).stage(stage).Emojis
+}
+
+// Evolve evolves the pet once it reached the level of the next stage,
+// starting the evolution sequence. It reports whether it evolved.
+func (p *Pet) Evolve(levels []int) bool {
+	stage := stageAt(p.Level, levels)
+	if stage <= p.Stage {
+		return false
+	}
+
+	p.evolvedFrom, p.Stage = p.Stage, stage
+	p.evolvedAt = p.now()
+	p.lastReactionTime = p.evolvedAt.Add(evolutionFrames * evolutionFrame)
+	p.SpecialState = "evolved"
+	p.Mood = MoodProud
+	p.Happiness += 20
+	p.particleSystem.AddSparkles(25, 10, 20)
+	p.particleSystem.AddFlowerPetals(25, 10, 10)
+	p.capStateValues()
+	return true
+}
+
+// stageAt returns the stage pets are at by the level, given the levels they
+// grow up and turn mythic at
+func stageAt(level int, levels []int) Stage {
+	stage := StageBaby
+	for i, at := range levels[:min(len(levels), int(StageMythic))] {
+		if level >= at {
+			stage = Stage(i + 1)
+		}
+	}
+	return stage
+}
+
+// Evolving reports whether the evolution sequence is playing
+func (p *Pet) Evolving() bool {
+	return !p.evolvedAt.IsZero() && time.Since(p.evolvedAt) < evolutionFrames*evolutionFrame
+}
+
+// evolutionView renders the frame of the evolution sequence, flickering
+// between the old look and the new one faster and faster until it settles
+func (p *Pet) evolutionView() string {
+	frame := int(time.Since(p.evolvedAt) / evolutionFrame)
+	sprite := p.spriteAt(p.Stage)
+	if frame < evolutionFrames-settledFrames && flickersOld(frame) {
+		sprite = p.spriteAt(p.evolvedFrom)
+	}
+	sparkles := evolutionSparkles[min(frame*len(evolutionSparkles)/evolutionFrames, len(evolutionSparkles)-1)]
+	return fmt.Sprintf("%s\n  %s %s\n\n🌟 Evolving! 🌟", sparkles, sprite, p.Name)
+}
+
+// flickersOld reports whether the frame shows the old look, which shows
+// less and less as the sequence goes on
+func flickersOld(frame int) bool {
+	switch {
+	case frame < 4:
+		return frame%4 < 3
+	case frame < 8:
+		return frame%2 == 0
+	default:
+		return frame%3 == 0
+	}
+}
+
+// spriteAt returns the main emoji of the pet at the stage
+func (p *Pet) spriteAt(stage Stage) string {
+	if sprites := p.stageSprites(stage); len(sprites) > 0 {
+		return sprites[0]
+	}
+	return p.Type.Icon()
+}
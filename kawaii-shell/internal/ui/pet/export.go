@@ -0,0 +1,293 @@
+package pet
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// exportVersion is the version of the exported pets written
+const exportVersion = 1
+
+// trustedKeysFile is the file next to the pets listing the keys of the
+// people whose pets are trusted, one per line with who they are after it
+const trustedKeysFile = "pet.trusted"
+
+// Passport is what goes along with a pet moving to another machine: who it
+// is, how it's doing and what it wears, but not the commands it remembers
+type Passport struct {
+	Name        string      `json:"name"`
+	Type        PetType     `json:"type"`
+	Personality Personality `json:"personality"`
+	Stage       Stage       `json:"stage"`
+	Level       int         `json:"level"`
+	Experience  int         `json:"experience"`
+	Energy      int         `json:"energy"`
+	Happiness   int         `json:"happiness"`
+	Birthday    time.Time   `json:"birthday"`
+	Wearing     []string    `json:"wearing"`
+	Exported    time.Time   `json:"exported"`
+}
+
+// exportedPet is the passport signed by the roster it was exported from.
+// The signature only vouches for the pet when the key is one the importing
+// roster trusts, anyone can sign a passport with a key of their own.
+type exportedPet struct {
+	Version   int             `json:"version"`
+	Pet       json.RawMessage `json:"pet"`
+	Key       []byte          `json:"key"`
+	Signature []byte          `json:"signature"`
+}
+
+// Export returns the active pet as signed JSON, to be imported elsewhere
+func (r *Roster) Export(now time.Time) ([]byte, error) {
+	key, err := r.signingKey()
+	if err != nil {
+		return nil, err
+	}
+	p := r.Pet()
+	passport, err := json.Marshal(Passport{
+		Name:        p.Name,
+		Type:        p.Type,
+		Personality: p.Personality,
+		Stage:       p.Stage,
+		Level:       p.Level,
+		Experience:  p.Experience,
+		Energy:      p.Energy,
+		Happiness:   p.Happiness,
+		Birthday:    p.Birthday,
+		Wearing:     p.Wearing,
+		Exported:    now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", p.Name, err)
+	}
+	data, err := json.MarshalIndent(exportedPet{
+		Version:   exportVersion,
+		Pet:       passport,
+		Key:       key.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(key, passport),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", p.Name, err)
+	}
+	return data, nil
+}
+
+// Signer is who signed an exported pet
+type Signer struct {
+	// Key is the public key the pet was signed with and Fingerprint a short
+	// form of it to compare by eye
+	Key, Fingerprint string
+
+	// Name is who the key was trusted as, Home whether it's the roster's own
+	// key and Trusted whether it's that or one trusted with Trust. Pets of
+	// other signers could have been made up by anyone.
+	Name          string
+	Home, Trusted bool
+}
+
+// Import adds the pet exported elsewhere to the roster, telling who signed
+// it. Its level, stage and experience have to go together, the stage one
+// reached by the level with the levels pets grow up and turn mythic at.
+func (r *Roster) Import(data []byte, levels []int) (*Pet, Signer, error) {
+	var exported exportedPet
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, Signer{}, fmt.Errorf("invalid exported pet: %w", err)
+	}
+	if exported.Version != exportVersion {
+		return nil, Signer{}, fmt.Errorf("exported pets of version %d aren't supported", exported.Version)
+	}
+	// the passport was signed before it was indented
+	var signed bytes.Buffer
+	if err := json.Compact(&signed, exported.Pet); err != nil {
+		return nil, Signer{}, fmt.Errorf("invalid exported pet: %w", err)
+	}
+	if len(exported.Key) != ed25519.PublicKeySize || !ed25519.Verify(exported.Key, signed.Bytes(), exported.Signature) {
+		return nil, Signer{}, fmt.Errorf("the exported pet's signature doesn't match, it was damaged on the way")
+	}
+	var passport Passport
+	if err := json.Unmarshal(signed.Bytes(), &passport); err != nil {
+		return nil, Signer{}, fmt.Errorf("invalid exported pet: %w", err)
+	}
+	if err := passport.check(levels); err != nil {
+		return nil, Signer{}, fmt.Errorf("invalid exported pet: %w", err)
+	}
+
+	petType, err := ParseType(string(passport.Type))
+	if err != nil {
+		return nil, Signer{}, fmt.Errorf("can't welcome %s: %w", passport.Name, err)
+	}
+	signer, err := r.signer(exported.Key)
+	if err != nil {
+		return nil, Signer{}, err
+	}
+
+	p, err := r.Adopt(passport.Name, petType)
+	if err != nil {
+		return nil, Signer{}, err
+	}
+	p.Personality = passport.Personality
+	p.Stage = passport.Stage
+	p.Level = passport.Level
+	p.Experience = max(passport.Experience, 0)
+	p.Energy = min(max(passport.Energy, 0), 100)
+	p.Happiness = min(max(passport.Happiness, 0), 100)
+	p.Birthday = passport.Birthday
+	p.Wearing = slices.DeleteFunc(passport.Wearing, func(id string) bool {
+		_, ok := FindAccessory(id)
+		return !ok
+	})
+	return p, signer, r.Save()
+}
+
+// check reports what doesn't go together in the passport: experience is
+// what's gained towards the next level, which takes 100 per level, and the
+// stage is one the level reaches
+func (passport Passport) check(levels []int) error {
+	switch {
+	case passport.Level < 1:
+		return fmt.Errorf("%s can't be level %d", passport.Name, passport.Level)
+	case passport.Experience < 0 || passport.Experience >= passport.Level*100:
+		return fmt.Errorf("%s can't have %d experience at level %d", passport.Name, passport.Experience, passport.Level)
+	case passport.Stage < StageBaby || passport.Stage > stageAt(passport.Level, levels):
+		return fmt.Errorf("%s can't be at stage %d at level %d", passport.Name, passport.Stage, passport.Level)
+	}
+	return nil
+}
+
+// PublicKey returns the key the roster signs exported pets with, for
+// friends to trust them, and its fingerprint
+func (r *Roster) PublicKey() (key, fingerprint string, err error) {
+	private, err := r.signingKey()
+	if err != nil {
+		return "", "", err
+	}
+	public := private.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(public), keyFingerprint(public), nil
+}
+
+// Trust trusts the pets signed with the key, of whoever the name says,
+// returning the signer it makes
+func (r *Roster) Trust(key, name string) (Signer, error) {
+	public, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(public) != ed25519.PublicKeySize {
+		return Signer{}, fmt.Errorf("%s isn't a pet key, pet key shows what they look like", key)
+	}
+	signer, err := r.signer(public)
+	switch {
+	case err != nil:
+		return Signer{}, err
+	case signer.Home:
+		return Signer{}, errors.New("that's your own key, it's trusted already")
+	case signer.Trusted:
+		return Signer{}, fmt.Errorf("the key of %s is trusted already", cmp.Or(signer.Name, signer.Fingerprint))
+	case r.path == "":
+		return Signer{}, errors.New("can't trust keys without a place to keep them")
+	}
+	path := filepath.Join(filepath.Dir(r.path), trustedKeysFile)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return Signer{}, fmt.Errorf("failed to trust the key: %w", err)
+	}
+	line := strings.TrimSpace(key + " " + strings.Join(strings.Fields(name), " "))
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		f.Close()
+		return Signer{}, fmt.Errorf("failed to trust the key: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return Signer{}, fmt.Errorf("failed to trust the key: %w", err)
+	}
+	signer.Name, signer.Trusted = name, true
+	return signer, nil
+}
+
+// signer returns who the key is to the roster: its own, one it trusts or
+// someone unknown
+func (r *Roster) signer(public ed25519.PublicKey) (Signer, error) {
+	signer := Signer{Key: base64.StdEncoding.EncodeToString(public), Fingerprint: keyFingerprint(public)}
+	private, err := r.signingKey()
+	if err != nil {
+		return Signer{}, err
+	}
+	if private.Public().(ed25519.PublicKey).Equal(public) {
+		signer.Home, signer.Trusted = true, true
+		return signer, nil
+	}
+	if r.path == "" {
+		return signer, nil
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(r.path), trustedKeysFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return signer, nil
+	}
+	if err != nil {
+		return Signer{}, fmt.Errorf("failed to read the trusted keys: %w", err)
+	}
+	for line := range strings.Lines(string(data)) {
+		key, name, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if key == signer.Key {
+			signer.Name, signer.Trusted = strings.TrimSpace(name), true
+			break
+		}
+	}
+	return signer, nil
+}
+
+// keyFingerprint returns the start of the SHA-256 of the key, in groups of
+// four hex digits
+func keyFingerprint(public ed25519.PublicKey) string {
+	hash := sha256.Sum256(public)
+	sum := hex.EncodeToString(hash[:8])
+	groups := make([]string, 0, len(sum)/4)
+	for i := 0; i < len(sum); i += 4 {
+		groups = append(groups, sum[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
+
+// signingKey returns the key exported pets are signed with, next to the
+// pets, made up the first time. Rosters kept in memory get a new one every
+// time.
+func (r *Roster) signingKey() (ed25519.PrivateKey, error) {
+	if r.path == "" {
+		_, key, err := ed25519.GenerateKey(nil)
+		return key, err
+	}
+	path := filepath.Join(filepath.Dir(r.path), "pet.key")
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read the signing key: %w", err)
+	default:
+		seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid signing key %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make a signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to save the signing key: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key.Seed())+"\n"), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save the signing key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,135 @@
+package pet
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestImportSigner(t *testing.T) {
+	home, err := LoadRoster(filepath.Join(t.TempDir(), "pets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	friend, err := LoadRoster(filepath.Join(t.TempDir(), "pets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mochi := export(t, friend, "Mochi")
+	boba := export(t, friend, "Boba")
+
+	_, signer, err := home.Import(mochi, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Trusted || signer.Home {
+		t.Errorf("Import() signer = %+v before trusting the key, want an unknown one", signer)
+	}
+
+	key, _, err := friend.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := home.Trust(key, "Sam"); err != nil {
+		t.Fatal(err)
+	}
+	_, signer, err = home.Import(boba, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signer.Trusted || signer.Name != "Sam" {
+		t.Errorf("Import() signer = %+v after trusting the key, want Sam's", signer)
+	}
+
+	// a passport edited and signed again with a key of its own
+	forged := resign(t, boba, func(p *Passport) { p.Name, p.Level = "Comet", 99 })
+	_, signer, err = home.Import(forged, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Trusted {
+		t.Errorf("Import() signer = %+v for a passport signed again, want an unknown one", signer)
+	}
+}
+
+func TestImportInconsistent(t *testing.T) {
+	r, err := LoadRoster("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := export(t, r, "Mochi")
+
+	tests := []struct {
+		name string
+		edit func(*Passport)
+	}{
+		{"level 0", func(p *Passport) { p.Level = 0 }},
+		{"negative experience", func(p *Passport) { p.Experience = -1 }},
+		{"experience past the level", func(p *Passport) { p.Level, p.Experience = 2, 200 }},
+		{"mythic too early", func(p *Passport) { p.Level, p.Stage = 3, StageMythic }},
+	}
+	for _, test := range tests {
+		// named anew so it's the stats that are turned away
+		edit := func(p *Passport) { p.Name = "Boba"; test.edit(p) }
+		if _, _, err := r.Import(resign(t, data, edit), []int{5, 15}); err == nil {
+			t.Errorf("%s: Import() succeeded, want an error", test.name)
+		}
+	}
+
+	ok := resign(t, data, func(p *Passport) { p.Name, p.Level, p.Experience, p.Stage = "Comet", 15, 1499, StageMythic })
+	if _, _, err := r.Import(ok, []int{5, 15}); err != nil {
+		t.Errorf("Import() = %v for a mythic pet at level 15, want no error", err)
+	}
+}
+
+// export adopts a cat by the name and exports it
+func export(t *testing.T, r *Roster, name string) []byte {
+	t.Helper()
+	if _, err := r.Adopt(name, TypeCat); err != nil {
+		t.Fatal(err)
+	}
+	i, _ := r.Find(name)
+	if _, err := r.Switch(i); err != nil {
+		t.Fatal(err)
+	}
+	data, err := r.Export(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// resign returns the exported pet edited, signed again with a new key
+func resign(t *testing.T, data []byte, edit func(*Passport)) []byte {
+	t.Helper()
+	var exported exportedPet
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatal(err)
+	}
+	var passport Passport
+	if err := json.Unmarshal(exported.Pet, &passport); err != nil {
+		t.Fatal(err)
+	}
+	edit(&passport)
+	signed, err := json.Marshal(passport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, signed); err != nil {
+		t.Fatal(err)
+	}
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported.Pet, exported.Key, exported.Signature = compact.Bytes(), public, ed25519.Sign(private, compact.Bytes())
+	data, err = json.Marshal(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
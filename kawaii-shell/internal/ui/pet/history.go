@@ -0,0 +1,82 @@
+package pet
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// sampleInterval is how often how the pet is doing gets recorded
+	sampleInterval = 10 * time.Minute
+
+	// maxSamples is how many samples are kept, three days' worth
+	maxSamples = 3 * 24 * int(time.Hour/sampleInterval)
+)
+
+// Sample is how the pet was doing at a time
+type Sample struct {
+	Time      time.Time `json:"time"`
+	Happiness int       `json:"happiness"`
+	Energy    int       `json:"energy"`
+	Stress    float64   `json:"stress"`
+	Mood      Mood      `json:"mood"`
+}
+
+// CommandCount is how many times a command was run with the pet
+type CommandCount struct {
+	Name  string
+	Count int
+}
+
+// RecordSample records how the pet is doing, once per sample interval
+func (p *Pet) RecordSample(now time.Time) {
+	if n := len(p.History); n > 0 && now.Sub(p.History[n-1].Time) < sampleInterval {
+		return
+	}
+	p.History = append(p.History, Sample{
+		Time:      now,
+		Happiness: p.Happiness,
+		Energy:    p.Energy,
+		Stress:    p.State.Stress,
+		Mood:      p.Mood,
+	})
+	if len(p.History) > maxSamples {
+		p.History = slices.Delete(p.History, 0, len(p.History)-maxSamples)
+	}
+}
+
+// MoodCounts returns how many samples caught the pet in each mood
+func (p *Pet) MoodCounts() map[Mood]int {
+	counts := make(map[Mood]int)
+	for _, sample := range p.History {
+		counts[sample.Mood]++
+	}
+	return counts
+}
+
+// countCommand counts the command towards the favorite ones, by its name
+func (p *Pet) countCommand(command string) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return
+	}
+	if p.Commands == nil {
+		p.Commands = make(map[string]int)
+	}
+	p.Commands[fields[0]]++
+}
+
+// FavoriteCommands returns up to n of the commands run the most with the
+// pet, the most run first
+func (p *Pet) FavoriteCommands(n int) []CommandCount {
+	favorites := make([]CommandCount, 0, len(p.Commands))
+	for name, count := range p.Commands {
+		favorites = append(favorites, CommandCount{Name: name, Count: count})
+	}
+	slices.SortFunc(favorites, func(a, b CommandCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return favorites[:min(n, len(favorites))]
+}
@@ -0,0 +1,145 @@
+package pet
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// maxJournal is how many commands the pet remembers
+	maxJournal = 1000
+
+	// recallLines is how many memories the pet brings up at most
+	recallLines = 8
+)
+
+// Memory is a command the pet remembers running together
+type Memory struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+}
+
+// recallFillers are the words of a question that don't say what it's about
+var recallFillers = []string{"what", "did", "we", "do", "on", "the", "this", "last", "together", "about", "when", "run", "ran"}
+
+// journal writes the command down in the memories the pet keeps
+func (p *Pet) journal(command string, now time.Time) {
+	p.Journal = append(p.Journal, Memory{Time: now, Command: command})
+	if len(p.Journal) > maxJournal {
+		p.Journal = slices.Delete(p.Journal, 0, len(p.Journal)-maxJournal)
+	}
+}
+
+// Recall answers a question about what was done together, like "git" or
+// "what did we do yesterday", the way the pet remembers it
+func (p *Pet) Recall(question string, now time.Time) []string {
+	var words []string
+	from, to, day := time.Time{}, now, ""
+	for _, word := range strings.Fields(strings.ToLower(strings.TrimRight(question, "?"))) {
+		if start, end, label, ok := dayOf(word, now); ok {
+			from, to, day = start, end, label
+			continue
+		}
+		if !slices.Contains(recallFillers, word) {
+			words = append(words, word)
+		}
+	}
+	about := strings.Join(words, " ")
+	if about == "" && day == "" {
+		from, day = dayStart(now), "today"
+	}
+
+	var memories []Memory
+	for _, memory := range p.Journal {
+		if !memory.Time.Before(from) && !memory.Time.After(to) && strings.Contains(memory.Command, about) && !isRecall(memory.Command) {
+			memories = append(memories, memory)
+		}
+	}
+	if len(memories) == 0 {
+		switch {
+		case about == "":
+			return []string{fmt.Sprintf("💭 %s thinks really hard... nothing from %s, we must have been resting! 😴", p.Name, day)}
+		case day != "":
+			return []string{fmt.Sprintf("💭 %s doesn't remember any %s from %s... 🤔", p.Name, about, day)}
+		default:
+			return []string{fmt.Sprintf("💭 %s doesn't remember any %s together... let's make some memories! 🥺", p.Name, about)}
+		}
+	}
+
+	last := memories[len(memories)-1]
+	var lines []string
+	switch {
+	case about == "":
+		lines = append(lines, fmt.Sprintf("💭 %s remembers %s! We ran %s together:", p.Name, day, plural(len(memories), "command")))
+	default:
+		lines = append(lines, fmt.Sprintf("💭 Oh, %s! %s remembers we did that %s, the last time %s at %s:",
+			about, p.Name, plural(len(memories), "time"), whenDay(last.Time, now), last.Time.Format("15:04")))
+	}
+	for _, memory := range memories[max(len(memories)-recallLines, 0):] {
+		lines = append(lines, fmt.Sprintf("  %-9s %s  %s", whenDay(memory.Time, now), memory.Time.Format("15:04"), memory.Command))
+	}
+	return lines
+}
+
+// isRecall reports whether the command asked the pet to recall something,
+// which isn't worth remembering itself
+func isRecall(command string) bool {
+	fields := strings.Fields(command)
+	return len(fields) > 1 && fields[0] == "pet" && (fields[1] == "remember" || fields[1] == "what")
+}
+
+// dayOf returns when the day the word names starts and ends and what to
+// call it, for today, yesterday, the week or a day of the week, which is the
+// last one there was
+func dayOf(word string, now time.Time) (time.Time, time.Time, string, bool) {
+	today := dayStart(now)
+	switch word {
+	case "today":
+		return today, now, "today", true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today.Add(-time.Nanosecond), "yesterday", true
+	case "week":
+		return today.AddDate(0, 0, -6), now, "this week", true
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if word == strings.ToLower(weekday.String()) {
+			start := today.AddDate(0, 0, -((int(now.Weekday()) - int(weekday) + 7) % 7))
+			end := start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			if end.After(now) {
+				end = now
+			}
+			return start, end, weekday.String(), true
+		}
+	}
+	return time.Time{}, time.Time{}, "", false
+}
+
+// dayStart returns the midnight the day of the time started at
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// whenDay tells the day of the time the way people say it, like yesterday
+func whenDay(t, now time.Time) string {
+	switch days := int(math.Round(dayStart(now).Sub(dayStart(t)).Hours() / 24)); {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "yesterday"
+	case days < 7:
+		return t.Weekday().String()
+	default:
+		return t.Format("Jan 2")
+	}
+}
+
+// plural counts the things, like 1 time or 3 times
+func plural(n int, thing string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, thing)
+	}
+	return fmt.Sprintf("%d %ss", n, thing)
+}
@@ -0,0 +1,43 @@
+package pet
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+// Model is a pet as a tea.Model, for composing it with other models or
+// running it in a program of its own. The App holds the pet itself, its
+// Update keeping the *Pet type.
+type Model struct {
+	*Pet
+}
+
+// Update updates the pet (implements tea.Model interface)
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := m.Pet.Update(msg)
+	return m, cmd
+}
+
+// Component is a pet as a component of a ComponentManager. It gets the
+// messages like any other, there's just nothing to focus.
+type Component struct {
+	*Pet
+}
+
+// Update updates the pet (implements components.Component interface)
+func (c Component) Update(msg tea.Msg) (components.Component, tea.Cmd) {
+	_, cmd := c.Pet.Update(msg)
+	return c, cmd
+}
+
+// Render renders the pet like View
+func (c Component) Render() string {
+	return c.View()
+}
+
+// Focus does nothing, the pet takes no input of its own
+func (c Component) Focus() {}
+
+// Blur does nothing, the pet takes no input of its own
+func (c Component) Blur() {}
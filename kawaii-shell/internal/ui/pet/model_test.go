@@ -0,0 +1,109 @@
+package pet
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+func TestModelInit(t *testing.T) {
+	m := Model{NewPet("Neko", TypeCat)}
+	if m.Init() == nil {
+		t.Fatal("Init returned no command, the pet would never tick")
+	}
+}
+
+func TestModelUpdate(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	hunger := p.State.Hunger
+
+	model, cmd := Model{p}.Update(PetTickMsg{})
+	m, ok := model.(Model)
+	if !ok {
+		t.Fatalf("Update returned a %T, want a Model", model)
+	}
+	if m.Pet != p {
+		t.Error("Update returned another pet")
+	}
+	if cmd == nil {
+		t.Error("Update on a tick returned no command, the pet would stop ticking")
+	}
+	if p.State.Hunger <= hunger {
+		t.Errorf("hunger is %v after a tick, want more than %v", p.State.Hunger, hunger)
+	}
+}
+
+func TestModelUpdateKey(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	animation := p.Animation
+
+	Model{p}.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if p.Animation == animation {
+		t.Error("the pet didn't react to the key")
+	}
+}
+
+func TestModelUpdateOtherMsg(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	before := *p
+
+	_, cmd := Model{p}.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if cmd != nil {
+		t.Error("Update returned a command for a message the pet doesn't handle")
+	}
+	if p.State != before.State || p.Mood != before.Mood || p.Animation != before.Animation {
+		t.Error("Update changed the pet for a message it doesn't handle")
+	}
+}
+
+func TestModelView(t *testing.T) {
+	m := Model{NewPet("Neko", TypeCat)}
+	view := m.View()
+	for _, want := range []string{"Neko", "Lv.1"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View is missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestComponent(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	hunger := p.State.Hunger
+
+	manager := components.NewComponentManager()
+	manager.AddComponent(Component{p})
+	manager.Update(PetTickMsg{})
+	if p.State.Hunger <= hunger {
+		t.Errorf("hunger is %v after a tick through the manager, want more than %v", p.State.Hunger, hunger)
+	}
+
+	updated, _ := Component{p}.Update(PetTickMsg{})
+	if c, ok := updated.(Component); !ok || c.Pet != p {
+		t.Errorf("Update returned %#v, want the same pet as a Component", updated)
+	}
+	if render := (Component{p}).Render(); !strings.Contains(render, "Neko") {
+		t.Errorf("Render is missing the pet's name:\n%s", render)
+	}
+}
+
+func TestModelUpdateKeyHops(t *testing.T) {
+	p := NewPet("Neko", TypeCat)
+	if view := p.View(); !strings.HasPrefix(view, "\n") {
+		t.Errorf("View starts with a sprite line before the pet hopped:\n%s", view)
+	}
+
+	Model{p}.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if view := p.View(); strings.HasPrefix(view, "\n") {
+		t.Errorf("View starts with an empty line while the pet hops:\n%s", view)
+	}
+
+	// the hop is over, the pet landed
+	p.motion.Update(1)
+	p.nextMotion()
+	if view := p.View(); !strings.HasPrefix(view, "\n") {
+		t.Errorf("View starts with a sprite line after the pet landed:\n%s", view)
+	}
+}
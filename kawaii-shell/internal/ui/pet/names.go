@@ -0,0 +1,66 @@
+package pet
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxNameLength is how many characters a pet's name can have
+const MaxNameLength = 16
+
+// cuteNames are the names the name generator suggests for any pet
+var cuteNames = []string{
+	"Boba", "Pudding", "Sprinkle", "Biscuit", "Peaches", "Tofu", "Dumpling",
+	"Pickle", "Nugget", "Waffles", "Bean", "Noodle", "Sushi", "Marshmallow",
+}
+
+// SuggestNames returns up to n names that suit a pet of the type, none of
+// them taken by the pets of the roster. The type's own names come first.
+func (r *Roster) SuggestNames(petType PetType, n int) []string {
+	own := slices.Clone(packOf(petType).Names)
+	others := slices.Clone(cuteNames)
+	rand.Shuffle(len(own), func(i, j int) { own[i], own[j] = own[j], own[i] })
+	rand.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+
+	var names []string
+	for _, name := range append(own, others...) {
+		if len(names) == n {
+			break
+		}
+		if _, taken := r.Find(name); !taken && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// checkName checks the name is fine for the pet at the index, -1 for a new
+// pet, returning it without the spaces around
+func (r *Roster) checkName(name string, index int) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", fmt.Errorf("your pet needs a name")
+	case utf8.RuneCountInString(name) > MaxNameLength:
+		return "", fmt.Errorf("%s is a mouthful, names have up to %d characters", name, MaxNameLength)
+	}
+	if i, ok := r.Find(name); ok && i != index {
+		return "", fmt.Errorf("there's already a pet called %s", r.Pets[i].Name)
+	}
+	return name, nil
+}
+
+// Rename gives the active pet a new name, returning the old one
+func (r *Roster) Rename(name string) (string, error) {
+	name, err := r.checkName(name, r.Active)
+	if err != nil {
+		return "", err
+	}
+	p := r.Pet()
+	old := p.Name
+	p.Name = name
+	return old, r.Save()
+}
@@ -0,0 +1,59 @@
+package pet
+
+import "time"
+
+const (
+	// needThreshold is how strong a need gets before the pet tells about
+	// it
+	needThreshold = 0.7
+
+	// needCalm is how far a need has to go down for the pet to tell about
+	// it again
+	needCalm = 0.5
+
+	// needCooldown is how long the pet waits before telling about the same
+	// need again, however it's looked after
+	needCooldown = 15 * time.Minute
+)
+
+// Need is something the pet needs looking after for
+type Need struct {
+	Name  string
+	Emoji string
+	Care  Care
+
+	// Command is the command that looks after the need
+	Command string
+}
+
+// needs are the needs pets tell about, and how strong each is
+var needs = []struct {
+	Need
+	level func(p *Pet) float64
+}{
+	{Need{"hungry", "🍖", CareFeed, "pet feed"}, func(p *Pet) float64 { return p.State.Hunger }},
+	{Need{"bored", "🧶", CarePlay, "pet play"}, func(p *Pet) float64 { return p.State.Boredom }},
+	{Need{"stressed", "😣", CareGroom, "pet groom"}, func(p *Pet) float64 { return p.State.Stress }},
+}
+
+// NewNeeds returns the needs the pet started to feel since the last time.
+// Each is told once until the pet is looked after, and not more often than
+// every needCooldown. The pet keeps them to itself during focus hours.
+func (p *Pet) NewNeeds(now time.Time) []Need {
+	if p.needing == nil {
+		p.needing, p.neededAt = make(map[string]bool), make(map[string]time.Time)
+	}
+	var felt []Need
+	for _, need := range needs {
+		level := need.level(p)
+		switch {
+		case level < needCalm:
+			p.needing[need.Name] = false
+		case level > needThreshold && !p.needing[need.Name] && !p.focus && now.Sub(p.neededAt[need.Name]) >= needCooldown:
+			p.needing[need.Name] = true
+			p.neededAt[need.Name] = now
+			felt = append(felt, need.Need)
+		}
+	}
+	return felt
+}
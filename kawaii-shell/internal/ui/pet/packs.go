@@ -0,0 +1,159 @@
+package pet
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed packs/*.yaml
+var packFiles embed.FS
+
+// Pack is a pet type: what it's called, how it looks in each mood, special
+// state and stage, and what it likes. The built-in ones are bundled in, and
+// more can be added as YAML files in a directory, named after the type.
+type Pack struct {
+	Name          string `yaml:"name"`
+	SuggestedName string `yaml:"suggested_name"`
+	FavoriteTreat string `yaml:"favorite_treat"`
+
+	// Names are the names the name generator suggests for the type, on top
+	// of the ones that suit any pet
+	Names []string `yaml:"names"`
+
+	// Emojis are how the pet looks, the first one standing for the type
+	Emojis []string `yaml:"emojis"`
+
+	// Moods and SpecialStates are the looks of the pet in the moods and
+	// special states, by name, in place of the ones every pet has
+	Moods         map[string][]string `yaml:"moods"`
+	SpecialStates map[string][]string `yaml:"special_states"`
+
+	// Stages are how the pet is called and looks as a baby, grown up and
+	// mythic. Grown up pets look like their emojis unless told otherwise.
+	Stages []PackStage `yaml:"stages"`
+
+	// Sprites are the ASCII sprites of the pet, written like the sprite
+	// files
+	Sprites string `yaml:"sprites"`
+
+	sheet spriteSheet
+}
+
+// PackStage is how a pet is called and looks at a stage
+type PackStage struct {
+	Name   string   `yaml:"name"`
+	Emojis []string `yaml:"emojis"`
+}
+
+// builtinTypes are the pet types bundled in, in the order they're offered
+var builtinTypes = []PetType{TypeCat, TypeFox, TypeBunny, TypeDragon, TypeUnicorn, TypeRobot}
+
+// packs are the packs of the pet types there are, and packTypes the order
+// they're offered in
+var packs, packTypes = loadBuiltinPacks()
+
+// loadBuiltinPacks loads the packs bundled in the binary, with their sprites
+func loadBuiltinPacks() (map[PetType]*Pack, []PetType) {
+	loaded := make(map[PetType]*Pack)
+	for _, t := range builtinTypes {
+		data, err := packFiles.ReadFile("packs/" + string(t) + ".yaml")
+		if err != nil {
+			panic(err)
+		}
+		pack, err := parsePack(data)
+		if err != nil {
+			panic(fmt.Errorf("invalid pet pack %s: %w", t, err))
+		}
+		if sprites, err := spriteFiles.ReadFile("sprites/" + string(t) + ".txt"); err == nil {
+			pack.sheet = parseSprites(string(sprites))
+		}
+		loaded[t] = pack
+	}
+	return loaded, slices.Clone(builtinTypes)
+}
+
+// LoadPacks adds the pet types described by the YAML files in dir, which
+// may not exist. Packs named like a built-in type replace it.
+func LoadPacks(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to read pet packs: %w", err)
+	}
+	var errs []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read pet pack: %w", err))
+			continue
+		}
+		pack, err := parsePack(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid pet pack %s: %w", file, err))
+			continue
+		}
+		t := PetType(strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".yaml")))
+		if _, ok := packs[t]; !ok {
+			packTypes = append(packTypes, t)
+		}
+		packs[t] = pack
+	}
+	return errors.Join(errs...)
+}
+
+// parsePack parses and checks a pack
+func parsePack(data []byte) (*Pack, error) {
+	var pack Pack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, err
+	}
+	switch {
+	case pack.Name == "":
+		return nil, fmt.Errorf("the pet type needs a name")
+	case len(pack.Emojis) == 0:
+		return nil, fmt.Errorf("%s needs emojis", pack.Name)
+	case len(pack.Stages) > int(StageMythic)+1:
+		return nil, fmt.Errorf("%s has %d stages, pets only grow up and turn mythic", pack.Name, len(pack.Stages))
+	}
+	if _, ok := FindTreat(pack.FavoriteTreat); pack.FavoriteTreat != "" && !ok {
+		return nil, fmt.Errorf("there's no %s treat", pack.FavoriteTreat)
+	}
+	for mood := range pack.Moods {
+		if !slices.Contains(slices.Collect(maps.Values(moodNames)), mood) {
+			return nil, fmt.Errorf("there's no %s mood", mood)
+		}
+	}
+	if pack.SuggestedName == "" {
+		pack.SuggestedName = pack.Name
+	}
+	pack.sheet = parseSprites(pack.Sprites)
+	return &pack, nil
+}
+
+// packOf returns the pack of the pet type, a plain one for types no pack
+// describes anymore
+func packOf(t PetType) *Pack {
+	if pack, ok := packs[t]; ok {
+		return pack
+	}
+	name := string(t)
+	if name != "" {
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	return &Pack{Name: name, SuggestedName: name, Emojis: []string{"🐾"}}
+}
+
+// stage returns how the pet type is called and looks at the stage
+func (pack *Pack) stage(stage Stage) PackStage {
+	if int(stage) < len(pack.Stages) {
+		return pack.Stages[stage]
+	}
+	return PackStage{}
+}
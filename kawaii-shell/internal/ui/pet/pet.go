@@ -2,26 +2,26 @@ package pet
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/pcstyle/kawaii-shell/internal/ui/components"
 )
 
-// PetType represents different types of pets
-type PetType int
+// PetType is the kind of pet, named after the pack describing it
+type PetType string
 
 const (
-	TypeCat PetType = iota
-	TypeFox
-	TypeBunny
-	TypeDragon
-	TypeUnicorn
-	TypeRobot
+	TypeCat     PetType = "cat"
+	TypeFox     PetType = "fox"
+	TypeBunny   PetType = "bunny"
+	TypeDragon  PetType = "dragon"
+	TypeUnicorn PetType = "unicorn"
+	TypeRobot   PetType = "robot"
 )
 
 // Mood represents the pet's current emotional state
@@ -42,21 +42,21 @@ const (
 
 // Personality traits
 type Personality struct {
-	Curiosity    float64 // 0.0 to 1.0
-	Playfulness  float64
-	Loyalty      float64
-	Intelligence float64
-	Energy       float64
+	Curiosity    float64 `json:"curiosity"` // 0.0 to 1.0
+	Playfulness  float64 `json:"playfulness"`
+	Loyalty      float64 `json:"loyalty"`
+	Intelligence float64 `json:"intelligence"`
+	Energy       float64 `json:"energy"`
 }
 
 // PetState represents complex pet state
 type PetState struct {
-	Hunger     float64
-	Thirst     float64
-	Boredom    float64
-	Loneliness float64
-	Stress     float64
-	Exhaustion float64
+	Hunger     float64 `json:"hunger"`
+	Thirst     float64 `json:"thirst"`
+	Boredom    float64 `json:"boredom"`
+	Loneliness float64 `json:"loneliness"`
+	Stress     float64 `json:"stress"`
+	Exhaustion float64 `json:"exhaustion"`
 }
 
 // Activity represents what the pet is currently doing
@@ -72,55 +72,91 @@ const (
 	ActivityCelebrating
 	ActivityWorrying
 	ActivityExploring
+	ActivityWaiting
 )
 
 // Pet represents a hyper-advanced virtual companion
 type Pet struct {
-	Name         string
-	Type         PetType
-	Mood         Mood
-	Personality  Personality
-	State        PetState
-	Activity     Activity
-	Energy       int
-	Happiness    int
-	Level        int
-	Experience   int
-	LastFed      time.Time
-	LastPlayed   time.Time
-	Animation    int
-	LastCmd      string
-	Memories     []string // Remember recent interactions
-	Birthday     time.Time
-	FavoriteCmd  string
-	SpecialState string // For special animations/states
-
-	// Animation and visual state
+	Name         string      `json:"name"`
+	Type         PetType     `json:"type"`
+	Mood         Mood        `json:"mood"`
+	Personality  Personality `json:"personality"`
+	State        PetState    `json:"state"`
+	Activity     Activity    `json:"activity"`
+	Energy       int         `json:"energy"`
+	Happiness    int         `json:"happiness"`
+	Level        int         `json:"level"`
+	Experience   int         `json:"experience"`
+	LastFed      time.Time   `json:"last_fed"`
+	LastPlayed   time.Time   `json:"last_played"`
+	LastGroomed  time.Time   `json:"last_groomed"`
+	Animation    int         `json:"-"`
+	LastCmd      string      `json:"last_cmd"`
+	Memories     []string    `json:"memories"` // Remember recent interactions
+	Birthday     time.Time   `json:"birthday"`
+	Celebrated   string      `json:"celebrated"` // The last anniversary celebrated
+	FavoriteCmd  string      `json:"favorite_cmd"`
+	Wearing      []string    `json:"wearing"`
+	Stage        Stage       `json:"stage"`
+	SpecialState string      `json:"-"` // For special animations/states
+
+	// Commands counts the commands run with the pet by name, History is
+	// how it was doing over time and Journal the commands it remembers
+	Commands map[string]int `json:"commands"`
+	History  []Sample       `json:"history"`
+	Journal  []Memory       `json:"journal"`
+
+	// Animation and visual state, the idle motion and glow driven by the
+	// animation manager
 	animationManager *components.AnimationManager
+	motion           *components.AnimatedElement
+	glow             *components.AnimatedElement
 	particleSystem   *components.ParticleSystem
-	glowIntensity    float64
-	bounceHeight     float64
-	floatOffset      float64
-	sparkleCount     int
 	lastReactionTime time.Time
+
+	// evolvedAt is when the pet last evolved, from the stage before
+	evolvedAt   time.Time
+	evolvedFrom Stage
+
+	// leveledUp is set once the pet levels up, until LeveledUp tells
+	leveledUp bool
+
+	// dayPart is the part of the day the pet was last greeted for, and
+	// focus whether it's focus hours. unreacted counts the commands towards
+	// the next reaction, quiet telling the last command didn't get one.
+	dayPart   dayPart
+	focus     bool
+	unreacted int
+	quiet     bool
+
+	// failedCmd is the last command that failed, failStreak times in a row
+	failedCmd  string
+	failStreak int
+
+	// waitingSince is when the long command the pet waits for started
+	waitingSince time.Time
+
+	// needing are the needs the pet told about and wasn't looked after
+	// for yet, and neededAt when it last told about each
+	needing  map[string]bool
+	neededAt map[string]time.Time
+
+	// settings are what the pet behaves by
+	settings Settings
+
+	// clock tells the pet the time and rng rolls its dice, see
+	// Deterministic
+	clock Clock
+	rng   *rand.Rand
 }
 
 // NewPet creates a new hyper-cute pet companion with personality
 func NewPet(name string, petType PetType) *Pet {
-	// Generate random personality
-	personality := Personality{
-		Curiosity:    rand.Float64()*0.5 + 0.5,
-		Playfulness:  rand.Float64()*0.4 + 0.6,
-		Loyalty:      rand.Float64()*0.3 + 0.7,
-		Intelligence: rand.Float64()*0.6 + 0.4,
-		Energy:       rand.Float64()*0.4 + 0.6,
-	}
-
-	return &Pet{
+	p := &Pet{
 		Name:        name,
 		Type:        petType,
 		Mood:        MoodHappy,
-		Personality: personality,
+		Personality: NewPersonality(TraitBalanced),
 		State: PetState{
 			Hunger:     0.2,
 			Thirst:     0.1,
@@ -129,29 +165,28 @@ func NewPet(name string, petType PetType) *Pet {
 			Stress:     0.0,
 			Exhaustion: 0.2,
 		},
-		Activity:         ActivityIdle,
-		Energy:           80,
-		Happiness:        100,
-		Level:            1,
-		Experience:       0,
-		LastFed:          time.Now(),
-		LastPlayed:       time.Now().Add(-time.Hour),
-		Animation:        0,
-		Memories:         make([]string, 0),
-		Birthday:         time.Now(),
-		animationManager: components.NewAnimationManager(),
-		particleSystem:   components.NewParticleSystem(50, 20),
-		glowIntensity:    0.0,
-		bounceHeight:     0.0,
-		floatOffset:      0.0,
-		sparkleCount:     0,
+		Activity:       ActivityIdle,
+		Energy:         80,
+		Happiness:      100,
+		Level:          1,
+		Experience:     0,
+		LastFed:        time.Now(),
+		LastPlayed:     time.Now().Add(-time.Hour),
+		LastGroomed:    time.Now().Add(-time.Hour),
+		Animation:      0,
+		Memories:       make([]string, 0),
+		Birthday:       time.Now(),
+		particleSystem: components.NewParticleSystem(50, 20),
+		settings:       DefaultSettings(),
 	}
+	p.initAnimations()
+	return p
 }
 
 // Init initializes the pet (implements tea.Model interface)
 func (p *Pet) Init() tea.Cmd {
 	return tea.Batch(
-		components.ParticleUpdateCmd(),
+		animate(),
 		tea.Tick(time.Second, func(time.Time) tea.Msg {
 			return PetTickMsg{}
 		}),
@@ -166,11 +201,11 @@ func (p *Pet) Update(msg tea.Msg) (*Pet, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
-	case components.ParticleTickMsg:
+	case PetAnimateMsg:
 		// Update animations and particles
 		p.animationManager.Update()
 		p.updateVisualEffects()
-		cmds = append(cmds, components.ParticleUpdateCmd())
+		cmds = append(cmds, animate())
 
 	case PetTickMsg:
 		// Update pet state over time
@@ -192,7 +227,7 @@ func (p *Pet) Update(msg tea.Msg) (*Pet, tea.Cmd) {
 
 // updateState updates internal pet state over time
 func (p *Pet) updateState() {
-	now := time.Now()
+	now := p.now()
 	timeSinceLastFed := now.Sub(p.LastFed)
 	timeSinceLastPlayed := now.Sub(p.LastPlayed)
 
@@ -235,7 +270,7 @@ func (p *Pet) updateMood() {
 	} else if p.State.Loneliness > 0.6 {
 		p.Mood = MoodCurious
 	} else if p.Happiness > 90 {
-		if rand.Float64() > 0.7 {
+		if p.random().Float64() > 0.7 {
 			p.Mood = MoodExcited
 		} else {
 			p.Mood = MoodLove
@@ -268,7 +303,7 @@ func (p *Pet) updateActivity() {
 	case MoodCurious:
 		p.Activity = ActivityExploring
 	default:
-		if rand.Float64() > 0.8 {
+		if p.random().Float64() > 0.8 {
 			p.Activity = ActivityThinking
 		} else {
 			p.Activity = ActivityWatching
@@ -280,16 +315,24 @@ func (p *Pet) updateActivity() {
 func (p *Pet) ReactToCommand(command string, isDangerous bool) {
 	p.LastCmd = command
 	p.Experience++
-	p.lastReactionTime = time.Now()
 
 	// Add to memory
 	p.addToMemory(command)
+	p.countCommand(command)
 
 	// Update favorite command
 	if p.countCommandInMemory(command) > p.countCommandInMemory(p.FavoriteCmd) {
 		p.FavoriteCmd = command
 	}
 
+	p.quiet = !p.reacts(isDangerous)
+	if p.quiet {
+		p.checkLevelUp()
+		p.capStateValues()
+		return
+	}
+	p.lastReactionTime = p.now()
+
 	// Intelligent reaction based on personality and command
 	if isDangerous {
 		p.reactToDanger(command)
@@ -305,6 +348,124 @@ func (p *Pet) ReactToCommand(command string, isDangerous bool) {
 
 	// Create visual reaction
 	p.createCommandReactionEffect(command, isDangerous)
+	p.capStateValues()
+}
+
+// ReactToExitCode makes the pet react to how the command went, cheering
+// for passing tests and comforting more and more when the same command
+// keeps failing. It returns what the pet says about it, if anything, which
+// is more or less depending on the verbosity.
+func (p *Pet) ReactToExitCode(command string, code int) string {
+	line := p.reactToExitCode(command, code)
+	switch {
+	case p.settings.Verbosity == VerbosityQuiet:
+		return ""
+	case line == "" && p.settings.Verbosity == VerbosityChatty && !p.quiet && code != 130:
+		return fmt.Sprintf("%s %s: %s", p.GetMoodEmoji(), p.Name, p.GetPetMessage())
+	}
+	return line
+}
+
+// reactToExitCode reacts to how the command went, returning what the pet
+// has to say about it
+func (p *Pet) reactToExitCode(command string, code int) string {
+	command = strings.Join(strings.Fields(command), " ")
+	tries := p.failStreak + 1
+	fixed := code == 0 && command == p.failedCmd && p.failStreak > 1
+	switch {
+	case code == 0:
+		p.failedCmd, p.failStreak = "", 0
+	case code == 130:
+		// interrupted with Ctrl+C, nothing to worry about
+		return ""
+	case command == p.failedCmd:
+		p.failStreak++
+	default:
+		p.failedCmd, p.failStreak = command, 1
+	}
+	if p.quiet {
+		return ""
+	}
+	p.lastReactionTime = p.now()
+	defer p.capStateValues()
+
+	switch {
+	case fixed:
+		p.Mood = MoodExcited
+		p.Activity = ActivityCelebrating
+		p.SpecialState = "fixed-it"
+		p.Happiness += 10
+		p.State.Stress -= 0.3
+		p.particleSystem.AddSparkles(25, 10, 12)
+		p.particleSystem.AddHearts(25, 10, 5)
+		return fmt.Sprintf("🎉 You fixed it after %d tries! %s is so proud of you!", tries, p.Name)
+	case code == 0 && IsTestCommand(command):
+		p.Mood = MoodProud
+		p.Activity = ActivityCelebrating
+		p.SpecialState = "tests-passed"
+		p.Happiness += 5
+		p.State.Stress -= 0.1
+		p.particleSystem.AddSparkles(25, 10, 8)
+		return fmt.Sprintf("✅ All green! %s does a happy dance 💃", p.Name)
+	case code == 0:
+		p.Happiness += 2
+		p.State.Stress -= 0.05
+		p.particleSystem.AddSparkles(25, 10, 2)
+		return ""
+	}
+
+	// the more it fails, the more it's worth comforting
+	p.State.Stress += 0.1 * float64(min(p.failStreak, 3))
+	p.Happiness -= 2
+	p.SpecialState = ""
+	// loyal pets try to cheer you up instead of worrying
+	if p.Personality.Loyalty > 0.7 || p.failStreak >= 3 {
+		p.Mood = MoodLove
+	} else {
+		p.Mood = MoodWorried
+	}
+	switch {
+	case p.failStreak >= 3:
+		p.SpecialState = "comforting"
+		p.particleSystem.AddHearts(25, 10, 6)
+		return fmt.Sprintf("💕 %s curls up next to you. %d tries... let's take a deep breath and try again together", p.Name, p.failStreak)
+	case p.failStreak == 2:
+		p.particleSystem.AddHearts(25, 10, 2)
+		return fmt.Sprintf("🫂 That's okay, %s believes in you! You've got this 💪", p.Name)
+	case IsTestCommand(command):
+		return fmt.Sprintf("🧪 Some tests failed... %s is sure you'll fix them!", p.Name)
+	}
+	return ""
+}
+
+// testCommands are the commands that run tests
+var testCommands = []string{
+	"go test", "cargo test", "npm test", "npm run test", "yarn test", "pnpm test",
+	"make test", "pytest", "python -m pytest", "jest", "vitest", "rspec",
+	"bundle exec rspec", "mvn test", "gradle test", "./gradlew test", "dotnet test", "mix test",
+}
+
+// IsTestCommand reports whether the command runs tests
+func IsTestCommand(command string) bool {
+	for _, test := range testCommands {
+		if command == test || strings.HasPrefix(command, test+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// Celebrate makes the pet cheer for something that went well in the
+// background, like a finished job
+func (p *Pet) Celebrate() {
+	p.lastReactionTime = p.now()
+	p.Activity = ActivityCelebrating
+	p.Mood = MoodExcited
+	p.Happiness += 5
+	p.Experience++
+	p.particleSystem.AddSparkles(25, 10, 5)
+	p.checkLevelUp()
+	p.capStateValues()
 }
 
 // reactToDanger handles dangerous commands
@@ -321,6 +482,14 @@ func (p *Pet) reactToDanger(command string) {
 	}
 }
 
+// StandGuard has the pet stand in the way of a protected command until it's
+// approved
+func (p *Pet) StandGuard() {
+	p.Mood = MoodWorried
+	p.SpecialState = "protective"
+	p.lastReactionTime = p.now()
+}
+
 // reactToSafeCommand handles safe commands
 func (p *Pet) reactToSafeCommand(command string) {
 	curiosityBonus := p.Personality.Curiosity * 5
@@ -337,13 +506,17 @@ func (p *Pet) reactToSafeCommand(command string) {
 
 // handleSpecialCommands creates special reactions
 func (p *Pet) handleSpecialCommands(command string) {
-	switch {
-	case strings.Contains(command, "git"):
+	category := commandCategory(command)
+	if !p.settings.Triggers(category) {
+		return
+	}
+	switch category {
+	case "git":
 		p.Mood = MoodProud // Smart pet loves version control!
 		p.SpecialState = "git-genius"
 		p.Happiness += 10
 
-	case strings.Contains(command, "rm"):
+	case "rm":
 		if p.Personality.Intelligence > 0.7 {
 			p.Mood = MoodWorried
 			p.SpecialState = "protective"
@@ -351,30 +524,30 @@ func (p *Pet) handleSpecialCommands(command string) {
 			p.Mood = MoodCurious
 		}
 
-	case strings.Contains(command, "ls"), strings.Contains(command, "dir"):
+	case "files":
 		p.Mood = MoodCurious
 		p.Activity = ActivityExploring
 		p.SpecialState = "explorer"
 
-	case strings.Contains(command, "help"):
+	case "help":
 		p.Mood = MoodHappy
 		p.SpecialState = "helpful"
 		p.Happiness += 5
 
-	case strings.Contains(command, "cat"), strings.Contains(command, "type"):
+	case "cat":
 		if p.Type == TypeCat {
 			p.Mood = MoodMischievous
 			p.SpecialState = "cat-joke"
 			p.Happiness += 8
 		}
 
-	case strings.Contains(command, "python"):
+	case "python":
 		if p.Type == TypeDragon {
 			p.Mood = MoodExcited
 			p.SpecialState = "python-dragon"
 		}
 
-	case strings.Contains(command, "npm"), strings.Contains(command, "node"):
+	case "node":
 		if p.Personality.Intelligence > 0.6 {
 			p.Mood = MoodProud
 			p.SpecialState = "dev-mode"
@@ -382,6 +555,28 @@ func (p *Pet) handleSpecialCommands(command string) {
 	}
 }
 
+// commandCategory returns the category of command pets have a special
+// reaction to, empty for the others
+func commandCategory(command string) string {
+	switch {
+	case strings.Contains(command, "git"):
+		return "git"
+	case strings.Contains(command, "rm"):
+		return "rm"
+	case strings.Contains(command, "ls"), strings.Contains(command, "dir"):
+		return "files"
+	case strings.Contains(command, "help"):
+		return "help"
+	case strings.Contains(command, "cat"), strings.Contains(command, "type"):
+		return "cat"
+	case strings.Contains(command, "python"):
+		return "python"
+	case strings.Contains(command, "npm"), strings.Contains(command, "node"):
+		return "node"
+	}
+	return ""
+}
+
 // Advanced emoji system with personality
 func (p *Pet) GetPetEmoji() string {
 	baseEmojis := p.getBaseEmojis()
@@ -393,6 +588,11 @@ func (p *Pet) GetPetEmoji() string {
 		return specialEmojis[p.Animation%len(specialEmojis)]
 	}
 
+	// Babies and mythic pets have a look of their own
+	if sprites := p.stageSprites(p.Stage); len(sprites) > 0 {
+		return sprites[p.Animation%len(sprites)]
+	}
+
 	// Use mood variations if available
 	if len(moodVariations) > 0 {
 		return moodVariations[p.Animation%len(moodVariations)]
@@ -402,57 +602,24 @@ func (p *Pet) GetPetEmoji() string {
 	return baseEmojis[p.Animation%len(baseEmojis)]
 }
 
+// Icon returns the emoji standing for the pet, as evolved as it is
+func (p *Pet) Icon() string {
+	return p.spriteAt(p.Stage)
+}
+
 // getBaseEmojis returns base emojis for the pet type
 func (p *Pet) getBaseEmojis() []string {
-	switch p.Type {
-	case TypeCat:
-		return []string{"🐱", "😺", "😸", "😻", "😽", "🙀", "😿", "😾"}
-	case TypeFox:
-		return []string{"🦊", "🦊", "🐺", "🦊"}
-	case TypeBunny:
-		return []string{"🐰", "🐇", "🐰", "🐇", "🥕"}
-	case TypeDragon:
-		return []string{"🐉", "🐲", "🔥", "🐉"}
-	case TypeUnicorn:
-		return []string{"🦄", "✨", "🌈", "⭐"}
-	case TypeRobot:
-		return []string{"🤖", "⚡", "🔋", "💻"}
-	default:
-		return []string{"🐱"}
-	}
+	return packOf(p.Type).Emojis
 }
 
-// getMoodVariations returns mood-specific emoji variations
+// getMoodVariations returns mood-specific emoji variations, the pet type's
+// own ones first
 func (p *Pet) getMoodVariations() []string {
-	switch p.Mood {
-	case MoodHappy:
-		switch p.Type {
-		case TypeCat:
-			return []string{"😸", "😺", "😻", "😽"}
-		case TypeDragon:
-			return []string{"🐲✨", "🐉💖", "🔥😊"}
-		case TypeUnicorn:
-			return []string{"🦄✨", "🌈💕", "⭐😊"}
-		}
-
-	case MoodExcited:
-		switch p.Type {
-		case TypeCat:
-			return []string{"😻", "🤩", "😸", "🎉"}
-		case TypeDragon:
-			return []string{"🐲🎉", "🔥⭐", "🐉✨"}
-		case TypeRobot:
-			return []string{"🤖⚡", "💻✨", "🔋🎉"}
-		}
-
-	case MoodWorried:
-		switch p.Type {
-		case TypeCat:
-			return []string{"🙀", "😿", "😾"}
-		case TypeBunny:
-			return []string{"😰🐰", "😨🐇"}
-		}
+	if variations := packOf(p.Type).Moods[moodNames[p.Mood]]; len(variations) > 0 {
+		return variations
+	}
 
+	switch p.Mood {
 	case MoodSleepy:
 		return []string{"😴", "💤", "😪"}
 
@@ -475,8 +642,13 @@ func (p *Pet) getMoodVariations() []string {
 	return nil
 }
 
-// getSpecialStateEmojis returns special state emoji sequences
+// getSpecialStateEmojis returns special state emoji sequences, the pet
+// type's own ones first
 func (p *Pet) getSpecialStateEmojis() []string {
+	if sequence := packOf(p.Type).SpecialStates[p.SpecialState]; len(sequence) > 0 {
+		return sequence
+	}
+
 	switch p.SpecialState {
 	case "git-genius":
 		return []string{"🤓", "📚", "🧠", "💻"}
@@ -492,6 +664,24 @@ func (p *Pet) getSpecialStateEmojis() []string {
 		return []string{"🐍", "🐲", "🔥", "⚡"}
 	case "dev-mode":
 		return []string{"👨‍💻", "⚡", "🖥️", "🚀"}
+	case "well-fed", "treat":
+		return []string{"😋", "🍖", "😋", "🍪"}
+	case "favorite-treat":
+		return []string{"🤤", "😍", "🤤", "💖"}
+	case "playtime":
+		return []string{"🎾", "🧶", "🪀", "🎾"}
+	case "groomed":
+		return []string{"🪮", "✨", "🛁", "💅"}
+	case "evolved":
+		return []string{p.spriteAt(p.Stage), "🌟", p.spriteAt(p.Stage), "✨"}
+	case "tests-passed":
+		return []string{"✅", "💃", "🥳", "✅"}
+	case "fixed-it":
+		return []string{"🎉", "🥳", "🏆", "🎉"}
+	case "comforting":
+		return []string{"🫂", "💕", "🥺", "💕"}
+	case "worth-the-wait":
+		return []string{"🥳", "🎊", "⏰", "🎉"}
 	}
 
 	return nil
@@ -575,7 +765,7 @@ func (p *Pet) GetStatus() []string {
 	if len(p.Memories) > 0 {
 		status = append(status, "")
 		status = append(status, "🧠 Recent Memories:")
-		for i, memory := range p.getRecentMemories(3) {
+		for i, memory := range p.RecentMemories(3) {
 			status = append(status, fmt.Sprintf("  %d. %s", i+1, memory))
 		}
 	}
@@ -584,23 +774,23 @@ func (p *Pet) GetStatus() []string {
 	status = append(status, p.GetPetMessage())
 	status = append(status, "")
 
+	if p.TextOnly() {
+		for i, line := range status {
+			status[i] = Plain(line)
+		}
+	}
 	return status
 }
 
 // Helper functions
 func (p *Pet) getTypeName() string {
-	names := map[PetType]string{
-		TypeCat:     "Cat",
-		TypeFox:     "Fox",
-		TypeBunny:   "Bunny",
-		TypeDragon:  "Dragon",
-		TypeUnicorn: "Unicorn",
-		TypeRobot:   "Robot",
-	}
-	return names[p.Type]
+	return p.StageName()
 }
 
 func (p *Pet) getPersonalityBar(value float64) string {
+	if p.TextOnly() {
+		return fmt.Sprintf("%.0f%%", value*100)
+	}
 	bars := int(value * 10)
 	full := strings.Repeat("█", bars)
 	empty := strings.Repeat("░", 10-bars)
@@ -618,11 +808,17 @@ func (p *Pet) getActivityString() string {
 		ActivityCelebrating: "Celebrating success 🎉",
 		ActivityWorrying:    "Feeling concerned 😰",
 		ActivityExploring:   "Exploring files 🔍",
+		ActivityWaiting:     "Waiting with you ⏳",
 	}
 	return activities[p.Activity]
 }
 
 func (p *Pet) GetMoodString() string {
+	return p.Mood.String()
+}
+
+// String returns the name of the mood, like Happy
+func (m Mood) String() string {
 	moods := map[Mood]string{
 		MoodHappy:       "Happy",
 		MoodCurious:     "Curious",
@@ -635,12 +831,18 @@ func (p *Pet) GetMoodString() string {
 		MoodProud:       "Proud",
 		MoodMischievous: "Mischievous",
 	}
-	return moods[p.Mood]
+	return moods[m]
+}
+
+// Emoji returns the emoji standing for the mood
+func (m Mood) Emoji() string {
+	return (&Pet{Mood: m}).getMoodBaseEmoji()
 }
 
 // Memory and learning functions
 func (p *Pet) addToMemory(command string) {
-	p.Memories = append(p.Memories, fmt.Sprintf("%s: %s", time.Now().Format("15:04"), command))
+	p.journal(command, p.now())
+	p.Memories = append(p.Memories, fmt.Sprintf("%s: %s", p.now().Format("15:04"), command))
 	if len(p.Memories) > 20 {
 		p.Memories = p.Memories[len(p.Memories)-20:]
 	}
@@ -656,13 +858,25 @@ func (p *Pet) countCommandInMemory(command string) int {
 	return count
 }
 
-func (p *Pet) getRecentMemories(count int) []string {
+// RecentMemories returns the last count things the pet remembers, the
+// latest last
+func (p *Pet) RecentMemories(count int) []string {
 	if len(p.Memories) <= count {
 		return p.Memories
 	}
 	return p.Memories[len(p.Memories)-count:]
 }
 
+// GainExperience gives the pet experience, levelling it up as many times
+// as it's enough for
+func (p *Pet) GainExperience(xp int) {
+	p.Experience += xp
+	for p.Experience >= p.Level*100 {
+		p.checkLevelUp()
+	}
+	p.capStateValues()
+}
+
 func (p *Pet) checkLevelUp() {
 	requiredXP := p.Level * 100
 	if p.Experience >= requiredXP {
@@ -670,11 +884,19 @@ func (p *Pet) checkLevelUp() {
 		p.Experience -= requiredXP
 		p.Happiness += 20
 		p.SpecialState = "level-up"
+		p.leveledUp = true
 		// Trigger celebration effect
 		p.createLevelUpEffect()
 	}
 }
 
+// LeveledUp reports whether the pet leveled up since the last time
+func (p *Pet) LeveledUp() bool {
+	leveledUp := p.leveledUp
+	p.leveledUp = false
+	return leveledUp
+}
+
 func (p *Pet) capStateValues() {
 	if p.State.Hunger > 1.0 {
 		p.State.Hunger = 1.0
@@ -694,6 +916,10 @@ func (p *Pet) capStateValues() {
 	if p.State.Exhaustion > 1.0 {
 		p.State.Exhaustion = 1.0
 	}
+	// care can't take the needs below nothing
+	for _, need := range []*float64{&p.State.Hunger, &p.State.Thirst, &p.State.Boredom, &p.State.Loneliness, &p.State.Stress, &p.State.Exhaustion} {
+		*need = max(*need, 0)
+	}
 
 	if p.Energy < 0 {
 		p.Energy = 0
@@ -712,10 +938,13 @@ func (p *Pet) capStateValues() {
 // Advanced message system with personality and context
 func (p *Pet) GetPetMessage() string {
 	messages := p.getContextualMessages()
+	if len(messages) == 0 {
+		messages = p.voiceFallback()
+	}
 	if len(messages) > 0 {
 		return messages[p.Animation%len(messages)]
 	}
-	return "Just here to help! 💕"
+	return ""
 }
 
 func (p *Pet) getContextualMessages() []string {
@@ -727,162 +956,44 @@ func (p *Pet) getContextualMessages() []string {
 	}
 
 	// Activity-based messages
-	switch p.Activity {
-	case ActivityPlaying:
-		return []string{
-			"Let's have some fun! 🏾",
-			"Play time is the best time! 🎪",
-			"Wanna play a game? 🎮",
-		}
-	case ActivitySleeping:
-		return []string{
-			"Zzz... sweet dreams... 💤",
-			"*snoring softly* 😴",
-			"Just resting my eyes... 😪",
-		}
-	case ActivityExploring:
-		return []string{
-			"So many interesting files! 🔍",
-			"What's in this directory? 📂",
-			"Let's see what we can find! 🗺️",
-		}
+	if messages := p.voiceLines(func(v *Voice) map[string][]string { return v.Activities }, activityNames[p.Activity]); len(messages) > 0 {
+		return messages
 	}
 
-	// Mood-based messages with personality influence
-	return p.getMoodMessages()
+	// Mood-based messages with personality influence, and the season's
+	return append(p.getMoodMessages(), p.getSeasonMessages()...)
 }
 
 func (p *Pet) getSpecialStateMessages() []string {
-	switch p.SpecialState {
-	case "git-genius":
-		return []string{
-			"Git is such an elegant tool! 🤓",
-			"Version control makes me happy! 📚",
-			"I love tracking changes! 💻",
-		}
-	case "protective":
-		return []string{
-			"Wait! That command looks risky! ⚠️",
-			"Are you sure about deleting that? 🛡️",
-			"Let me protect you from mistakes! 👮‍♀️",
-		}
-	case "cat-joke":
-		return []string{
-			"Did you just 'cat' me? How funny! 😹",
-			"Meow! I see what you did there! 🤣",
-			"That's purr-fectly hilarious! 😸",
-		}
-	case "level-up":
-		return []string{
-			"LEVEL UP! I'm getting smarter! 🎉",
-			"Wow! I feel more experienced! ⭐",
-			"Thanks for helping me grow! 🚀",
-		}
+	return p.voiceLines(func(v *Voice) map[string][]string { return v.SpecialStates }, p.SpecialState)
+}
+
+// getSeasonMessages returns the lines for the season the shell is dressed
+// up for, none outside of one
+func (p *Pet) getSeasonMessages() []string {
+	if season == "" {
+		return nil
 	}
-	return nil
+	return p.voiceLines(func(v *Voice) map[string][]string { return v.Seasons }, season)
 }
 
+// getMoodMessages returns the lines for the mood, the ones for the pet's
+// strong traits first, like happy/playful
 func (p *Pet) getMoodMessages() []string {
-	switch p.Mood {
-	case MoodHappy:
-		if p.Personality.Playfulness > 0.7 {
-			return []string{
-				"Life is wonderful! Let's code! 🌟",
-				"Every command is an adventure! 🎪",
-				"I'm so excited to help! ✨",
-			}
-		} else {
-			return []string{
-				"Everything looks great today! 🌸",
-				"I love helping with commands! ✨",
-				"Ready for more adventures! 🎉",
-			}
+	mood := moodNames[p.Mood]
+	var keys []string
+	for _, trait := range Traits() {
+		if value := p.Personality.trait(trait); value != nil && *value > voiceTraits[trait] {
+			keys = append(keys, mood+"/"+strings.ToLower(trait.String()))
 		}
-
-	case MoodCurious:
-		if p.Personality.Intelligence > 0.7 {
-			return []string{
-				"Fascinating! Tell me more! 🤓",
-				"This is intellectually stimulating! 🧠",
-				"I'm learning so much! 📚",
-			}
-		} else {
-			return []string{
-				"Ooh, what are we doing now? 🤔",
-				"That command looks interesting! 👀",
-				"I wonder what will happen next! ✨",
-			}
-		}
-
-	case MoodWorried:
-		if p.Personality.Loyalty > 0.8 {
-			return []string{
-				"I care about you! Please be careful! 🥺",
-				"Your safety is important to me! 💖",
-				"Let me help you avoid mistakes! 🤝",
-			}
-		} else {
-			return []string{
-				"Be careful with that command! 😰",
-				"Are you sure about this? 🥺",
-				"Maybe double-check that? 💭",
-			}
-		}
-
-	case MoodExcited:
-		return []string{
-			"This is SO COOL! 🤩",
-			"WOW! That was amazing! ⭐",
-			"I'm bursting with excitement! ⚡",
-		}
-
-	case MoodLove:
-		return []string{
-			"I love working with you! 💕",
-			"You're the best human ever! 🥰",
-			"My heart is full of joy! 💖",
-		}
-
-	case MoodPlayful:
-		return []string{
-			"Let's make this fun! 😜",
-			"Time to get creative! 🎨",
-			"I'm feeling mischievous! 😋",
-		}
-
-	case MoodProud:
-		return []string{
-			"Look how smart we are! 😎",
-			"We make a great team! 🏆",
-			"I'm proud of our progress! ⭐",
-		}
-
-	default:
-		return []string{"Just here to help! 💕"}
 	}
+	return p.voiceLines(func(v *Voice) map[string][]string { return v.Moods }, append(keys, mood)...)
 }
 
 // Visual effects functions
 func (p *Pet) updateVisualEffects() {
-	// Update animation counter
-	p.Animation = (p.Animation + 1) % 8
-
-	// Update floating effect based on mood
-	switch p.Mood {
-	case MoodHappy, MoodExcited, MoodLove:
-		p.floatOffset = math.Sin(float64(p.Animation)*0.5) * 2
-	case MoodSleepy:
-		p.floatOffset = 0
-	default:
-		p.floatOffset = math.Sin(float64(p.Animation)*0.3) * 1
-	}
-
-	// Update glow intensity
-	if p.Mood == MoodExcited || p.Mood == MoodLove {
-		p.glowIntensity = 0.5 + math.Sin(float64(p.Animation)*0.8)*0.3
-	} else {
-		p.glowIntensity = 0.1
-	}
+	// Move on to the next frame once the motion finished
+	p.nextMotion()
 
 	// Clear special state after some time
 	if p.SpecialState != "" && time.Since(p.lastReactionTime) > time.Second*10 {
@@ -940,20 +1051,55 @@ func (p *Pet) reactToInput(input string) {
 		p.particleSystem.AddSparkles(25, 10, 1)
 	}
 	p.Animation = (p.Animation + 1) % 8
+	p.hop()
 }
 
 // View renders the stunning pet display
 func (p *Pet) View() string {
+	if p.TextOnly() {
+		return p.textView(p.now())
+	}
+	if p.Evolving() {
+		return p.evolutionView()
+	}
 	petEmoji := p.GetPetEmoji()
 	name := p.Name
 	mood := p.GetMoodEmoji()
 
 	// Create base pet display with enhanced visuals
-	lines := []string{
-		fmt.Sprintf("  %s %s", petEmoji, name),
-		fmt.Sprintf("  %s Lv.%d", mood, p.Level),
-		"",
-		fmt.Sprintf("⚡%d 💖%d", p.Energy, p.Happiness),
+	header := fmt.Sprintf("  %s %s", petEmoji, name)
+	lines := []string{header}
+	row := accessoryRow
+	sprite := p.sprite(p.now())
+	if sprite != nil {
+		lines, row = slices.Clone(sprite), centeredRow
+	}
+	// accessories go above and below the pet, lined up with it
+	if hat := p.worn(SlotHead); hat != "" {
+		lines = slices.Insert(lines, 0, row(hat, lines[0]))
+	}
+	if neck := p.worn(SlotNeck); neck != "" {
+		lines = append(lines, row(neck, lines[0]))
+	}
+	if sprite != nil {
+		for i, line := range lines {
+			lines[i] = p.swayLine(line)
+		}
+		// the emoji stays by the name, showing how evolved the pet is
+		lines = append(lines, header)
+	}
+	level := fmt.Sprintf("  %s Lv.%d", mood, p.Level)
+	if p.glowing() {
+		level += " ✨"
+	}
+	lines = append(lines, level)
+
+	// the pet sits on its stats, a hop lifts it a line up
+	stats := fmt.Sprintf("⚡%d 💖%d", p.Energy, p.Happiness)
+	if p.lifted() {
+		lines = append(lines, "", stats)
+	} else {
+		lines = append([]string{""}, append(lines, stats)...)
 	}
 
 	// Add special indicators
@@ -962,13 +1108,31 @@ func (p *Pet) View() string {
 	}
 
 	// Add activity indicator
-	if p.Activity != ActivityIdle {
+	switch {
+	case p.Waiting():
+		lines = append(lines, p.waitingView(p.now()))
+	case p.Activity != ActivityIdle:
 		lines = append(lines, p.getActivityEmoji())
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// accessoryRow renders the accessory as wide as the header, so it sits right
+// above or below the pet once centered
+func accessoryRow(emoji, header string) string {
+	row := "  " + emoji
+	return row + strings.Repeat(" ", max(ansi.StringWidth(header)-ansi.StringWidth(row), 0))
+}
+
+// centeredRow renders the accessory in the middle of a row as wide as the
+// sprite line
+func centeredRow(emoji, line string) string {
+	width := ansi.StringWidth(line)
+	left := max(width-ansi.StringWidth(emoji), 0) / 2
+	return strings.Repeat(" ", left) + emoji + strings.Repeat(" ", max(width-left-ansi.StringWidth(emoji), 0))
+}
+
 func (p *Pet) getActivityEmoji() string {
 	switch p.Activity {
 	case ActivityPlaying:
@@ -987,6 +1151,8 @@ func (p *Pet) getActivityEmoji() string {
 		return "😰"
 	case ActivityExploring:
 		return "🔍"
+	case ActivityWaiting:
+		return "⏳"
 	default:
 		return ""
 	}
@@ -994,7 +1160,8 @@ func (p *Pet) getActivityEmoji() string {
 
 // Feed feeds the pet and triggers happiness effects
 func (p *Pet) Feed() {
-	p.LastFed = time.Now()
+	p.LastFed = p.now()
+	p.lastReactionTime = p.LastFed
 	p.State.Hunger = 0
 	p.State.Thirst = 0
 	p.Energy = 100
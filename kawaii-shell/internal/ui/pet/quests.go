@@ -0,0 +1,210 @@
+package pet
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// questsPerDay is how many quests there are each day
+	questsPerDay = 3
+
+	// questStreakXP is the bonus experience for finishing all of the day's
+	// quests, for each day of the streak up to a week
+	questStreakXP = 10
+)
+
+// QuestLog is the quests of the day and how far along they are
+type QuestLog struct {
+	Day       string         `json:"day"`
+	IDs       []string       `json:"ids"`
+	Progress  map[string]int `json:"progress"`
+	Completed []string       `json:"completed"`
+
+	// Streak is how many days in a row all the quests were finished, up to
+	// LastDay
+	Streak  int    `json:"streak"`
+	LastDay string `json:"last_day"`
+}
+
+// Quest is a little goal for the day, earning the companion experience
+type Quest struct {
+	ID          string
+	Name        string
+	Emoji       string
+	Description string
+	Goal        int
+	XP          int
+
+	// counts returns how much the step counts towards the quest
+	counts func(step questStep) int
+}
+
+// questStep is something done that may count towards the quests
+type questStep struct {
+	command  string
+	exitCode int
+
+	// tried is set for commands never run before
+	tried bool
+
+	// cleaned is how many files were cleaned up
+	cleaned int
+}
+
+// Quests are all the quests the day's ones are picked from
+var Quests = []Quest{
+	{"git", "Version keeper", "🌿", "Run 3 git commands", 3, 30, commandsOf("git")},
+	{"tidy", "Spring cleaning", "🧹", "Clean up 5 files", 5, 40, func(step questStep) int {
+		return step.cleaned
+	}},
+	{"new", "Adventurer", "🧭", "Try a command you've never used", 1, 50, func(step questStep) int {
+		return boolCount(step.tried && step.exitCode == 0)
+	}},
+	{"tests", "Test pilot", "🧪", "Run tests that pass 2 times", 2, 40, func(step questStep) int {
+		return boolCount(step.exitCode == 0 && IsTestCommand(step.command))
+	}},
+	{"busy", "Busy bee", "🐝", "Run 20 commands", 20, 30, func(step questStep) int {
+		return boolCount(step.command != "")
+	}},
+	{"explore", "Explorer", "🗺️", "Visit 5 directories", 5, 20, commandsOf("cd")},
+	{"clean-run", "Smooth sailing", "⛵", "Run 10 commands that go well", 10, 30, func(step questStep) int {
+		return boolCount(step.command != "" && step.exitCode == 0)
+	}},
+}
+
+// commandsOf counts the commands run with the program that went well
+func commandsOf(program string) func(step questStep) int {
+	return func(step questStep) int {
+		fields := strings.Fields(step.command)
+		return boolCount(step.exitCode == 0 && len(fields) > 0 && fields[0] == program)
+	}
+}
+
+// boolCount counts 1 when it's so
+func boolCount(ok bool) int {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// FindQuest returns the quest with the ID
+func FindQuest(id string) (Quest, bool) {
+	i := slices.IndexFunc(Quests, func(q Quest) bool { return q.ID == id })
+	if i < 0 {
+		return Quest{}, false
+	}
+	return Quests[i], true
+}
+
+// TodaysQuests returns the quests of the day, picking new ones once the
+// day changed. Everyone gets the same ones on the same day.
+func (r *Roster) TodaysQuests(now time.Time) []Quest {
+	log := &r.Quests
+	if today := now.Format(time.DateOnly); log.Day != today {
+		day := fnv.New64a()
+		day.Write([]byte(today))
+		picked := rand.New(rand.NewSource(int64(day.Sum64()))).Perm(len(Quests))[:questsPerDay]
+		log.Day, log.IDs, log.Progress, log.Completed = today, nil, map[string]int{}, nil
+		for _, i := range picked {
+			log.IDs = append(log.IDs, Quests[i].ID)
+		}
+	}
+	if log.Progress == nil {
+		log.Progress = map[string]int{}
+	}
+	var quests []Quest
+	for _, id := range log.IDs {
+		if quest, ok := FindQuest(id); ok {
+			quests = append(quests, quest)
+		}
+	}
+	return quests
+}
+
+// QuestProgress returns how far along the quest of the day is, and how far
+// it needs to get
+func (r *Roster) QuestProgress(quest Quest) (int, int) {
+	return min(r.Quests.Progress[quest.ID], quest.Goal), quest.Goal
+}
+
+// QuestStreak returns how many days in a row all the quests were finished,
+// as long as the streak goes on
+func (r *Roster) QuestStreak(now time.Time) int {
+	switch r.Quests.LastDay {
+	case now.Format(time.DateOnly), now.AddDate(0, 0, -1).Format(time.DateOnly):
+		return r.Quests.Streak
+	}
+	return 0
+}
+
+// CountCleanup counts the files cleaned up towards the quests
+func (r *Roster) CountCleanup(files int, now time.Time) {
+	r.advanceQuests(questStep{cleaned: files}, now)
+}
+
+// removed returns how many files the rm command removed, when it went well
+func removed(command string, exitCode int) int {
+	fields := strings.Fields(command)
+	if exitCode != 0 || len(fields) == 0 || fields[0] != "rm" {
+		return 0
+	}
+	files := 0
+	for _, field := range fields[1:] {
+		if !strings.HasPrefix(field, "-") {
+			files++
+		}
+	}
+	return files
+}
+
+// advanceQuests counts the step towards the quests of the day
+func (r *Roster) advanceQuests(step questStep, now time.Time) {
+	for _, quest := range r.TodaysQuests(now) {
+		r.Quests.Progress[quest.ID] += quest.counts(step)
+	}
+}
+
+// tried reports whether the command was never run with any of the pets
+// before, counting the time it's just been run
+func (r *Roster) tried(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	runs := 0
+	for _, p := range r.Pets {
+		runs += p.Commands[fields[0]]
+	}
+	return runs <= 1
+}
+
+// CheckQuests awards the quests finished since the last time, returning
+// them, and the bonus experience when that finished all of the day's
+// quests. The companion gets the experience.
+func (r *Roster) CheckQuests(now time.Time) ([]Quest, int) {
+	log := &r.Quests
+	quests := r.TodaysQuests(now)
+	var finished []Quest
+	for _, quest := range quests {
+		if done, goal := r.QuestProgress(quest); done < goal || slices.Contains(log.Completed, quest.ID) {
+			continue
+		}
+		log.Completed = append(log.Completed, quest.ID)
+		r.Pet().GainExperience(quest.XP)
+		finished = append(finished, quest)
+	}
+	if len(finished) == 0 || len(log.Completed) < len(quests) {
+		return finished, 0
+	}
+
+	log.Streak = r.QuestStreak(now) + 1
+	log.LastDay = log.Day
+	bonus := questStreakXP * min(log.Streak, 7)
+	r.Pet().GainExperience(bonus)
+	return finished, bonus
+}
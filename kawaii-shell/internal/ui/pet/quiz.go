@@ -0,0 +1,71 @@
+package pet
+
+const (
+	// quizBase is how strong each part of the personality starts out in
+	// the quiz
+	quizBase = 0.4
+
+	// quizNudge is how much stronger each answer makes the traits it
+	// brings out
+	quizNudge = 0.15
+)
+
+// QuizQuestion is a question of the personality quiz, each answer bringing
+// out some traits
+type QuizQuestion struct {
+	Question string
+	Answers  []QuizAnswer
+}
+
+// QuizAnswer is an answer to a quiz question
+type QuizAnswer struct {
+	Emoji  string
+	Text   string
+	Traits []Trait
+}
+
+// Quiz is the personality quiz taken when adopting a pet, instead of
+// leaving their personality to chance
+var Quiz = []QuizQuestion{
+	{"A rainy afternoon. They're...", []QuizAnswer{
+		{"📦", "Exploring every box around", []Trait{TraitCurious}},
+		{"🌧️", "Chasing raindrops", []Trait{TraitPlayful, TraitEnergetic}},
+		{"🛋️", "Curled up next to you", []Trait{TraitLoyal}},
+		{"🧩", "Working out the door latch", []Trait{TraitClever}},
+	}},
+	{"You come home. They...", []QuizAnswer{
+		{"🏃", "Zoom around in circles", []Trait{TraitEnergetic}},
+		{"💝", "Wait at the door for you", []Trait{TraitLoyal}},
+		{"🎾", "Bring you their best toy", []Trait{TraitPlayful}},
+		{"👀", "Sniff your bag for clues", []Trait{TraitCurious, TraitClever}},
+	}},
+	{"Your command fails. They...", []QuizAnswer{
+		{"🧠", "Read the error with you", []Trait{TraitClever}},
+		{"🤗", "Snuggle up until it's fixed", []Trait{TraitLoyal}},
+		{"🔍", "Ask what every line means", []Trait{TraitCurious}},
+		{"😹", "Pounce on the cursor", []Trait{TraitPlayful}},
+	}},
+	{"Their perfect weekend?", []QuizAnswer{
+		{"⛰️", "Hiking the tallest hill", []Trait{TraitEnergetic, TraitCurious}},
+		{"🎲", "Board games, and winning", []Trait{TraitClever, TraitPlayful}},
+		{"🏡", "A lazy day home with you", []Trait{TraitLoyal}},
+		{"⚡", "Everything, all at once", []Trait{TraitEnergetic}},
+	}},
+}
+
+// QuizPersonality returns the personality the answers to the quiz add up
+// to, one answer for each question in order
+func QuizPersonality(answers []int) Personality {
+	personality := Personality{quizBase, quizBase, quizBase, quizBase, quizBase}
+	for i, answer := range answers {
+		if i >= len(Quiz) || answer < 0 || answer >= len(Quiz[i].Answers) {
+			continue
+		}
+		for _, trait := range Quiz[i].Answers[answer].Traits {
+			if value := personality.trait(trait); value != nil {
+				*value = min(*value+quizNudge, 1)
+			}
+		}
+	}
+	return personality
+}
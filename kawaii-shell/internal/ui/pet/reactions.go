@@ -0,0 +1,116 @@
+package pet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reaction is a kind of news from outside the shell pets react to, told by
+// plugins and integrations like CI webhooks or build watchers
+type Reaction string
+
+const (
+	// ReactionCelebrate is for good news, like a build that passed
+	ReactionCelebrate Reaction = "celebrate"
+	// ReactionWorry is for bad news, like a deploy that failed
+	ReactionWorry Reaction = "worry"
+	// ReactionAlert is for something that needs you, like a review
+	ReactionAlert Reaction = "alert"
+	// ReactionLove is for some love, like a star on your project
+	ReactionLove Reaction = "love"
+)
+
+// DefaultIntensity is how strongly pets react when nobody says
+const DefaultIntensity = 0.5
+
+// reactionEffect is how a pet takes a kind of news, at full intensity
+type reactionEffect struct {
+	mood      Mood
+	activity  Activity
+	state     string
+	happiness int
+	stress    float64
+
+	// particles shows the particles of the reaction, as many as asked for
+	particles func(p *Pet, count int)
+}
+
+// reactionEffects are how pets take each kind of news
+var reactionEffects = map[Reaction]reactionEffect{
+	ReactionCelebrate: {MoodExcited, ActivityCelebrating, "good-news", 10, 0, func(p *Pet, count int) {
+		p.particleSystem.AddSparkles(25, 10, count)
+	}},
+	ReactionWorry: {MoodWorried, ActivityWorrying, "bad-news", -5, 0.3, nil},
+	ReactionAlert: {MoodCurious, ActivityWatching, "heads-up", 0, 0, nil},
+	ReactionLove: {MoodLove, ActivityIdle, "loved", 10, 0, func(p *Pet, count int) {
+		p.particleSystem.AddHearts(25, 10, count)
+	}},
+}
+
+// Reactions returns the kinds of news pets react to
+func Reactions() []Reaction {
+	return []Reaction{ReactionCelebrate, ReactionWorry, ReactionAlert, ReactionLove}
+}
+
+// ParseReaction returns the kind of reaction with the name, ignoring the
+// case
+func ParseReaction(name string) (Reaction, error) {
+	for _, reaction := range Reactions() {
+		if strings.EqualFold(name, string(reaction)) {
+			return reaction, nil
+		}
+	}
+	return "", fmt.Errorf("pets don't know how to %s, try celebrate, worry, alert or love", name)
+}
+
+// ParseReactionArgs reads a reaction written as its kind, the intensity
+// from 0 to 1 if it's given, and the message, like celebrate 0.8 Build
+// passed!
+func ParseReactionArgs(args []string) (Reaction, float64, string, error) {
+	if len(args) == 0 {
+		return "", 0, "", fmt.Errorf("how should your pet react? Try celebrate, worry, alert or love")
+	}
+	kind, err := ParseReaction(args[0])
+	if err != nil {
+		return "", 0, "", err
+	}
+	intensity := DefaultIntensity
+	if len(args) > 1 {
+		if value, err := strconv.ParseFloat(args[1], 64); err == nil {
+			intensity, args = value, args[1:]
+		}
+	}
+	return kind, intensity, strings.Join(args[1:], " "), nil
+}
+
+// TriggerReaction has the pet react to news from outside the shell, as
+// strongly as the intensity from 0 to 1 says, returning the message for it
+// as the pet tells it. Without a message the pet has its own say, unless
+// it's quiet.
+func (p *Pet) TriggerReaction(kind Reaction, intensity float64, message string) string {
+	effect, ok := reactionEffects[kind]
+	if !ok {
+		return ""
+	}
+	intensity = min(max(intensity, 0), 1)
+
+	p.lastReactionTime = p.now()
+	p.Mood = effect.mood
+	p.Activity = effect.activity
+	p.SpecialState = effect.state
+	p.Happiness += int(float64(effect.happiness) * intensity)
+	p.State.Stress += effect.stress * intensity
+	if effect.particles != nil {
+		effect.particles(p, 3+int(12*intensity))
+	}
+	p.capStateValues()
+
+	if message == "" {
+		if p.settings.Verbosity == VerbosityQuiet {
+			return ""
+		}
+		message = p.GetPetMessage()
+	}
+	return fmt.Sprintf("%s %s: %s", p.GetMoodEmoji(), p.Name, message)
+}
@@ -0,0 +1,251 @@
+package pet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+// MaxPets is how many pets fit in the roster
+const MaxPets = 6
+
+// treatEvery is how many commands that went well earn a treat
+const treatEvery = 20
+
+// Roster is the pets the user owns, one of them being the active companion
+// that reacts to the commands and earns the experience
+type Roster struct {
+	Pets   []*Pet `json:"pets"`
+	Active int    `json:"active"`
+
+	// Treats is the treat bag, how many of each treat by name
+	Treats map[string]int `json:"treats"`
+
+	// Streak counts the commands that went well towards the next treat
+	Streak int `json:"streak"`
+
+	// Wardrobe is the IDs of the accessories unlocked, in order
+	Wardrobe []string `json:"wardrobe"`
+
+	// Achievements are when each achievement earned was, by ID
+	Achievements map[string]time.Time `json:"achievements"`
+	Milestones   Milestones           `json:"milestones"`
+
+	// Quests are the quests of the day
+	Quests QuestLog `json:"quests"`
+
+	path string
+
+	// settings are what the pets behave by
+	settings Settings
+
+	// streakDay is how the streak went on the first command of the day,
+	// until StreakNews tells
+	streakDay *StreakDay
+
+	// firstRun is set until the first pet is adopted, Neko the cat keeps
+	// the seat warm meanwhile
+	firstRun bool
+}
+
+// LoadRoster reads the pets saved in the file, starting with Neko the cat
+// when there are none yet. An empty path keeps them in memory only.
+func LoadRoster(path string) (*Roster, error) {
+	r := &Roster{path: path, settings: DefaultSettings()}
+	var err error
+	if path != "" {
+		err = r.load()
+	}
+	if len(r.Pets) == 0 {
+		r.Pets, r.Active = []*Pet{NewPet("Neko", TypeCat)}, 0
+		r.Treats = map[string]int{"cookie": 3}
+	}
+	if r.Treats == nil {
+		r.Treats = map[string]int{}
+	}
+	if r.Achievements == nil {
+		r.Achievements = map[string]time.Time{}
+	}
+	r.Active = min(max(r.Active, 0), len(r.Pets)-1)
+	return r, err
+}
+
+// load reads the pets from the file, which may not exist yet
+func (r *Roster) load() error {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		r.firstRun = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read pets: %w", err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		r.Pets = nil
+		return fmt.Errorf("invalid pets %s: %w", r.path, err)
+	}
+	pets := r.Pets[:0]
+	for _, p := range r.Pets {
+		if p != nil {
+			p.initAnimations()
+			p.particleSystem = components.NewParticleSystem(50, 20)
+			p.settings = DefaultSettings()
+			pets = append(pets, p)
+		}
+	}
+	r.Pets = pets
+	return nil
+}
+
+// FirstRun reports whether no pet was adopted yet
+func (r *Roster) FirstRun() bool {
+	return r.firstRun
+}
+
+// Welcome makes the pet the first one of the roster, in place of Neko who
+// was only keeping the seat warm
+func (r *Roster) Welcome(p *Pet) error {
+	p.Configure(r.settings)
+	r.Pets, r.Active, r.firstRun = []*Pet{p}, 0, false
+	return r.Save()
+}
+
+// Pet returns the active pet
+func (r *Roster) Pet() *Pet {
+	return r.Pets[r.Active]
+}
+
+// Idle returns the pets hanging out while another one is active
+func (r *Roster) Idle() []*Pet {
+	var idle []*Pet
+	for i, p := range r.Pets {
+		if i != r.Active {
+			idle = append(idle, p)
+		}
+	}
+	return idle
+}
+
+// Find returns the index of the pet with the name, ignoring the case
+func (r *Roster) Find(name string) (int, bool) {
+	for i, p := range r.Pets {
+		if strings.EqualFold(p.Name, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Adopt adds a new pet to the roster, the active one stays active
+func (r *Roster) Adopt(name string, petType PetType) (*Pet, error) {
+	name, err := r.checkName(name, -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Pets) >= MaxPets {
+		return nil, fmt.Errorf("%d pets is a full house already", MaxPets)
+	}
+	p := NewPet(name, petType)
+	p.Configure(r.settings)
+	r.Pets = append(r.Pets, p)
+	return p, r.Save()
+}
+
+// Switch makes the pet at the index the active one
+func (r *Roster) Switch(index int) (*Pet, error) {
+	if index < 0 || index >= len(r.Pets) {
+		return nil, fmt.Errorf("there's no pet %d", index+1)
+	}
+	r.Active = index
+	return r.Pet(), r.Save()
+}
+
+// GiveTreat gives the active pet a treat from the bag
+func (r *Roster) GiveTreat(name string) (Treat, error) {
+	treat, err := r.TakeTreat(name)
+	if err != nil {
+		return Treat{}, err
+	}
+	r.Pet().EatTreat(treat)
+	return treat, r.Save()
+}
+
+// TakeTreat takes a treat out of the bag
+func (r *Roster) TakeTreat(name string) (Treat, error) {
+	treat, ok := FindTreat(name)
+	if !ok {
+		return Treat{}, fmt.Errorf("there's no %s treat", name)
+	}
+	if r.Treats[treat.Name] == 0 {
+		return Treat{}, fmt.Errorf("the treat bag has no %s left %s", treat.Name, treat.Emoji)
+	}
+	r.Treats[treat.Name]--
+	if r.Treats[treat.Name] == 0 {
+		delete(r.Treats, treat.Name)
+	}
+	return treat, nil
+}
+
+// PutTreat puts a treat in the bag, like one brought by a visitor
+func (r *Roster) PutTreat(name string) (Treat, error) {
+	treat, ok := FindTreat(name)
+	if !ok {
+		return Treat{}, fmt.Errorf("there's no %s treat", name)
+	}
+	r.Treats[treat.Name]++
+	return treat, r.Save()
+}
+
+// Earn counts a command that went well, returning the treat it earned
+// every so often
+func (r *Roster) Earn() (Treat, bool) {
+	r.Streak++
+	if r.Streak < treatEvery {
+		return Treat{}, false
+	}
+	r.Streak = 0
+	// the companion's favorite comes up more often than the others
+	treat := Treats[rand.Intn(len(Treats))]
+	if rand.Float64() < 0.5 {
+		treat = r.Pet().FavoriteTreat()
+	}
+	r.Treats[treat.Name]++
+	return treat, true
+}
+
+// Save writes the pets to the file, once the first one was adopted
+func (r *Roster) Save() error {
+	if r.path == "" || r.firstRun {
+		return nil
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to save pets: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save pets: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save pets: %w", err)
+	}
+	return nil
+}
+
+// PartyView renders the idle pets hanging out in a corner of the pet box
+func PartyView(pets []*Pet) string {
+	if len(pets) == 0 {
+		return ""
+	}
+	icons := make([]string, len(pets))
+	for i, p := range pets {
+		icons[i] = p.Icon()
+	}
+	return "🎉" + strings.Join(icons, "")
+}
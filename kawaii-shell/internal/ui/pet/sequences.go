@@ -0,0 +1,128 @@
+package pet
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// sequenceMinimum is how many times a command has to have come next for
+	// the pet to suggest it
+	sequenceMinimum = 3
+
+	// sequenceShare is how much of the time a command has to have come next
+	// for the pet to suggest it
+	sequenceShare = 0.5
+
+	// sequenceGap is how far apart commands can be and still go together
+	sequenceGap = 10 * time.Minute
+)
+
+// subcommand matches the words that say what a program does rather than
+// what it does it to, like commit in git commit
+var subcommand = regexp.MustCompile(`^[a-z][a-z-]*$`)
+
+// commandKey returns what the command is without the details that change
+// every time, like git commit for git commit -m "fix"
+func commandKey(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) > 1 && subcommand.MatchString(fields[1]) {
+		return fields[0] + " " + fields[1]
+	}
+	if len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// NextCommand returns the command the pet learned tends to come after the
+// last ones run, from the commands it remembers. Two commands in a row say
+// more than one, so git add then git commit is looked for before git
+// commit alone. Commands that are different every time, like commit
+// messages, are suggested without the details.
+func (p *Pet) NextCommand() (string, bool) {
+	if p.settings.Verbosity == VerbosityQuiet {
+		return "", false
+	}
+	var journal []Memory
+	for _, memory := range p.Journal {
+		if !isRecall(memory.Command) {
+			journal = append(journal, memory)
+		}
+	}
+	for context := 2; context > 0; context-- {
+		if next, ok := nextAfter(journal, context); ok {
+			return next, true
+		}
+	}
+	return "", false
+}
+
+// nextAfter returns the command that came the most after the last context
+// commands of the journal, when it did often enough
+func nextAfter(journal []Memory, context int) (string, bool) {
+	if len(journal) <= context {
+		return "", false
+	}
+	last := journal[len(journal)-context:]
+	if !together(last) {
+		return "", false
+	}
+
+	// the commands that came next by key, and how they were written
+	counts := make(map[string]int)
+	written := make(map[string]map[string]int)
+	total := 0
+	for i := context; i < len(journal); i++ {
+		if !together(journal[i-context:i+1]) || !sameKeys(journal[i-context:i], last) {
+			continue
+		}
+		key, command := commandKey(journal[i].Command), strings.Join(strings.Fields(journal[i].Command), " ")
+		counts[key]++
+		if written[key] == nil {
+			written[key] = make(map[string]int)
+		}
+		written[key][command]++
+		total++
+	}
+
+	best := ""
+	for key, count := range counts {
+		if count > counts[best] || count == counts[best] && key < best {
+			best = key
+		}
+	}
+	if best == "" || counts[best] < sequenceMinimum || float64(counts[best]) < sequenceShare*float64(total) ||
+		best == commandKey(last[len(last)-1].Command) {
+		return "", false
+	}
+	// the way it's always written, or just the key when that changes
+	for command, count := range written[best] {
+		if count*2 > counts[best] {
+			return command, true
+		}
+	}
+	return best, true
+}
+
+// together reports whether the memories were run one soon after the other
+func together(memories []Memory) bool {
+	for i := 1; i < len(memories); i++ {
+		if memories[i].Time.Sub(memories[i-1].Time) > sequenceGap {
+			return false
+		}
+	}
+	return true
+}
+
+// sameKeys reports whether the memories are the same commands, details
+// aside
+func sameKeys(memories, others []Memory) bool {
+	for i := range memories {
+		if commandKey(memories[i].Command) != commandKey(others[i].Command) {
+			return false
+		}
+	}
+	return true
+}
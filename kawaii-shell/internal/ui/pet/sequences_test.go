@@ -0,0 +1,65 @@
+package pet
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// remember has the pet remember the commands, a minute apart
+func remember(p *Pet, now time.Time, commands ...string) time.Time {
+	for _, command := range commands {
+		now = now.Add(time.Minute)
+		p.journal(command, now)
+	}
+	return now
+}
+
+func TestNextCommand(t *testing.T) {
+	p, clock := newTestPet(Personality{})
+	now := clock.time
+	for i := range 4 {
+		now = remember(p, now, "git add .", fmt.Sprintf("git commit -m 'fix %d'", i), "git push", "ls")
+		now = now.Add(time.Hour)
+	}
+
+	tests := []struct {
+		run  string
+		want string
+	}{
+		{"git add .", "git commit"},
+		{"git commit -m 'wip'", "git push"},
+		{"git push", "ls"},
+		// ls comes before git add only after an hour's break
+		{"ls", ""},
+	}
+	for _, test := range tests {
+		now = remember(p, now, test.run)
+		got, ok := p.NextCommand()
+		if got != test.want || ok != (test.want != "") {
+			t.Errorf("after %s: NextCommand() = %q, %v, want %q", test.run, got, ok, test.want)
+		}
+	}
+}
+
+func TestNextCommandNotSureYet(t *testing.T) {
+	p, clock := newTestPet(Personality{})
+	now := remember(p, clock.time, "make", "make test", "make", "make test", "make")
+	if got, ok := p.NextCommand(); ok {
+		t.Errorf("NextCommand() = %q after seeing it twice, want nothing yet", got)
+	}
+
+	remember(p, now, "make test", "make")
+	if got, _ := p.NextCommand(); got != "make test" {
+		t.Errorf("NextCommand() = %q, want make test", got)
+	}
+}
+
+func TestNextCommandQuiet(t *testing.T) {
+	p, clock := newTestPet(Personality{})
+	remember(p, clock.time, "a", "b", "a", "b", "a", "b", "a")
+	p.settings.Verbosity = VerbosityQuiet
+	if got, ok := p.NextCommand(); ok {
+		t.Errorf("NextCommand() = %q for a quiet pet, want nothing", got)
+	}
+}
@@ -0,0 +1,147 @@
+package pet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Verbosity is how much pets have to say
+type Verbosity int
+
+const (
+	VerbosityNormal Verbosity = iota
+	VerbosityQuiet
+	VerbosityChatty
+)
+
+// verbosityNames are how verbosities are written in the settings file
+var verbosityNames = map[Verbosity]string{
+	VerbosityNormal: "normal",
+	VerbosityQuiet:  "quiet",
+	VerbosityChatty: "chatty",
+}
+
+// UnmarshalText decodes the verbosity from its name
+func (v *Verbosity) UnmarshalText(text []byte) error {
+	for verbosity, name := range verbosityNames {
+		if string(text) == name {
+			*v = verbosity
+			return nil
+		}
+	}
+	return fmt.Errorf("there's no %s verbosity, try quiet, normal or chatty", text)
+}
+
+// Settings tune how pets behave
+type Settings struct {
+	// ReactEvery is how many commands it takes for pets to react to one,
+	// 1 reacts to all of them
+	ReactEvery int `yaml:"react_every"`
+
+	// Particles scales how many particles effects make, 0 turns them off
+	Particles float64 `yaml:"particles"`
+
+	// Verbosity is how much pets have to say about what happens
+	Verbosity Verbosity `yaml:"verbosity"`
+
+	// SpecialStates turns the special reactions to categories of commands
+	// on and off, like git: false. They're all on by default.
+	SpecialStates map[string]bool `yaml:"special_states"`
+
+	// Neighbors has pets visit the other shells running on the machine
+	Neighbors bool `yaml:"neighbors"`
+
+	// Language is the language pets speak, like de, the one of the locale
+	// when it's empty
+	Language string `yaml:"language"`
+
+	// TextOnly has pets do without emoji, sprites and particles, showing
+	// plain lines instead for screen readers and minimal terminals
+	TextOnly bool `yaml:"text_only"`
+}
+
+// DefaultSettings returns the settings used without a settings file
+func DefaultSettings() Settings {
+	return Settings{ReactEvery: 1, Particles: 1, Neighbors: true}
+}
+
+// Triggers reports whether the category of command gets a special
+// reaction
+func (s Settings) Triggers(category string) bool {
+	on, ok := s.SpecialStates[category]
+	return category != "" && (!ok || on)
+}
+
+// SettingsFile is the file settings are read from, read again whenever it
+// changes
+type SettingsFile struct {
+	Settings Settings
+
+	path    string
+	modTime time.Time
+}
+
+// NewSettingsFile creates the settings file at the path, with the default
+// settings until it's loaded
+func NewSettingsFile(path string) *SettingsFile {
+	return &SettingsFile{Settings: DefaultSettings(), path: path}
+}
+
+// Load reads the settings when the file changed since the last time,
+// reporting whether they did. Without a file the defaults are used, and
+// the settings stay as they were when it's invalid.
+func (f *SettingsFile) Load() (bool, error) {
+	info, err := os.Stat(f.path)
+	var modTime time.Time
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return false, fmt.Errorf("failed to read pet settings: %w", err)
+	default:
+		modTime = info.ModTime()
+	}
+	if modTime.Equal(f.modTime) {
+		return false, nil
+	}
+	f.modTime = modTime
+	if modTime.IsZero() {
+		f.Settings = DefaultSettings()
+		return true, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read pet settings: %w", err)
+	}
+	settings := DefaultSettings()
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return false, fmt.Errorf("invalid pet settings %s: %w", f.path, err)
+	}
+	settings.ReactEvery = max(settings.ReactEvery, 1)
+	settings.Particles = max(settings.Particles, 0)
+	f.Settings = settings
+	return true, nil
+}
+
+// Configure has the pets behave by the settings, the ones adopted later
+// too
+func (r *Roster) Configure(settings Settings) {
+	r.settings = settings
+	for _, p := range r.Pets {
+		p.Configure(settings)
+	}
+}
+
+// Configure has the pet behave by the settings
+func (p *Pet) Configure(settings Settings) {
+	p.settings = settings
+	particles := settings.Particles
+	if settings.TextOnly {
+		particles = 0
+	}
+	p.particleSystem.SetIntensity(particles)
+}
@@ -0,0 +1,146 @@
+package pet
+
+import (
+	"embed"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+//go:embed sprites/*.txt
+var spriteFiles embed.FS
+
+// spriteFrame is how long each frame of a sprite shows
+const spriteFrame = 500 * time.Millisecond
+
+// moodNames are what sprite files call the moods
+var moodNames = map[Mood]string{
+	MoodHappy:       "happy",
+	MoodCurious:     "curious",
+	MoodWorried:     "worried",
+	MoodSleepy:      "sleepy",
+	MoodExcited:     "excited",
+	MoodLove:        "love",
+	MoodAngry:       "angry",
+	MoodPlayful:     "playful",
+	MoodProud:       "proud",
+	MoodMischievous: "mischievous",
+}
+
+// activityNames are what sprite files call the activities
+var activityNames = map[Activity]string{
+	ActivityIdle:        "idle",
+	ActivityPlaying:     "playing",
+	ActivitySleeping:    "sleeping",
+	ActivityEating:      "eating",
+	ActivityWatching:    "watching",
+	ActivityThinking:    "thinking",
+	ActivityCelebrating: "celebrating",
+	ActivityWorrying:    "worrying",
+	ActivityExploring:   "exploring",
+	ActivityWaiting:     "waiting",
+}
+
+// spriteSection matches the line starting a section of a sprite file, like
+// [sleepy sleeping]
+var spriteSection = regexp.MustCompile(`^\[([a-z/ ]+)\]$`)
+
+// spriteSheet holds the frames of a pet type, by the mood or activity they're
+// drawn for, or both like celebrating/proud
+type spriteSheet map[string][][]string
+
+// parseSprites parses a sprite file. Each section starts with the moods and
+// activities it's drawn for in brackets, and has frames separated by ---
+// lines.
+func parseSprites(data string) spriteSheet {
+	sheet := make(spriteSheet)
+	var names, frame []string
+	flush := func() {
+		for len(frame) > 0 && strings.TrimSpace(frame[len(frame)-1]) == "" {
+			frame = frame[:len(frame)-1]
+		}
+		for len(frame) > 0 && strings.TrimSpace(frame[0]) == "" {
+			frame = frame[1:]
+		}
+		if len(frame) > 0 {
+			for _, name := range names {
+				sheet[name] = append(sheet[name], frame)
+			}
+		}
+		frame = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		switch m := spriteSection.FindStringSubmatch(line); {
+		case m != nil:
+			flush()
+			names = strings.Fields(m[1])
+		case line == "---":
+			flush()
+		default:
+			frame = append(frame, line)
+		}
+	}
+	flush()
+
+	for name, frames := range sheet {
+		sheet[name] = evenFrames(frames)
+	}
+	return sheet
+}
+
+// evenFrames pads the frames to the same size, so the pet doesn't jump
+// around when centered
+func evenFrames(frames [][]string) [][]string {
+	width, height := 0, 0
+	for _, frame := range frames {
+		height = max(height, len(frame))
+		for _, line := range frame {
+			width = max(width, ansi.StringWidth(line))
+		}
+	}
+	even := make([][]string, len(frames))
+	for i, frame := range frames {
+		lines := make([]string, height-len(frame), height)
+		lines = append(lines, frame...)
+		for j, line := range lines {
+			lines[j] = line + strings.Repeat(" ", width-ansi.StringWidth(line))
+		}
+		even[i] = lines
+	}
+	return even
+}
+
+// sprite returns the frame of the pet's sprite showing at the time, nil when
+// its type has no sprites. The frames drawn for what the pet is doing win
+// over the ones for how it feels.
+func (p *Pet) sprite(now time.Time) []string {
+	sheet := packOf(p.Type).sheet
+	mood, activity := moodNames[p.Mood], activityNames[p.Activity]
+	for _, name := range []string{activity + "/" + mood, activity, mood, "idle"} {
+		if frames := sheet[name]; len(frames) > 0 {
+			return frames[int(now.UnixMilli()/spriteFrame.Milliseconds())%len(frames)]
+		}
+	}
+	return nil
+}
+
+// Portrait returns the frame of the pet's sprite showing at the time twice
+// as big, for showing the pet off. Pets without sprites show their emoji.
+func (p *Pet) Portrait(now time.Time) []string {
+	sprite := p.sprite(now)
+	if sprite == nil {
+		return []string{p.GetPetEmoji()}
+	}
+	portrait := make([]string, 0, 2*len(sprite))
+	for _, line := range sprite {
+		var big strings.Builder
+		for _, r := range line {
+			big.WriteRune(r)
+			big.WriteRune(r)
+		}
+		portrait = append(portrait, big.String(), big.String())
+	}
+	return portrait
+}
@@ -0,0 +1,108 @@
+package pet
+
+import "time"
+
+const (
+	// streakXP is the experience for each day of the streak, up to
+	// maxStreakXP a day
+	streakXP    = 10
+	maxStreakXP = 100
+
+	// streakHappiness is the happiness for each day of the streak, up to
+	// maxStreakHappiness a day
+	streakHappiness    = 2
+	maxStreakHappiness = 20
+
+	// restEvery is how many days go by between the days off that don't
+	// break the streak
+	restEvery = 7
+)
+
+// StreakDay is how the streak went on the first command of a day
+type StreakDay struct {
+	Streak    int
+	XP        int
+	Happiness int
+
+	// Rested is set when the day before was a day off, which didn't break
+	// the streak
+	Rested bool
+
+	// Broken is how long the streak that ended was, if one did
+	Broken int
+}
+
+// countDay counts the day of the command towards the streak. Every day of
+// the streak after the first earns the companion more experience and
+// happiness, and a day off now and then is forgiven.
+func (r *Roster) countDay(now time.Time) {
+	m := &r.Milestones
+	today := now.Format(time.DateOnly)
+	if m.LastDay == today {
+		return
+	}
+
+	var day StreakDay
+	switch m.LastDay {
+	case now.AddDate(0, 0, -1).Format(time.DateOnly):
+		m.DayStreak++
+	case now.AddDate(0, 0, -2).Format(time.DateOnly):
+		if r.canRest(now) {
+			m.DayStreak++
+			m.RestDay = now.AddDate(0, 0, -1).Format(time.DateOnly)
+			day.Rested = true
+			break
+		}
+		fallthrough
+	default:
+		if m.DayStreak > 1 {
+			day.Broken = m.DayStreak
+		}
+		m.DayStreak = 1
+	}
+	m.LastDay = today
+	m.BestStreak = max(m.BestStreak, m.DayStreak)
+
+	day.Streak = m.DayStreak
+	if day.Streak > 1 {
+		day.XP = min(streakXP*day.Streak, maxStreakXP)
+		day.Happiness = min(streakHappiness*day.Streak, maxStreakHappiness)
+		p := r.Pet()
+		p.Happiness += day.Happiness
+		p.GainExperience(day.XP)
+	}
+	r.streakDay = &day
+}
+
+// canRest reports whether yesterday can be a day off without breaking the
+// streak, it's been long enough since the last one
+func (r *Roster) canRest(now time.Time) bool {
+	rested, err := time.ParseInLocation(time.DateOnly, r.Milestones.RestDay, now.Location())
+	return err != nil || now.Sub(rested) > restEvery*24*time.Hour
+}
+
+// DayStreak returns how many days in a row the shell was used, as long as
+// the streak goes on
+func (r *Roster) DayStreak(now time.Time) int {
+	m := r.Milestones
+	switch m.LastDay {
+	case now.Format(time.DateOnly), now.AddDate(0, 0, -1).Format(time.DateOnly):
+		return m.DayStreak
+	case now.AddDate(0, 0, -2).Format(time.DateOnly):
+		if r.canRest(now) {
+			return m.DayStreak
+		}
+	}
+	return 0
+}
+
+// StreakNews returns how the streak went on the first command of the day,
+// once
+func (r *Roster) StreakNews() (StreakDay, bool) {
+	day := r.streakDay
+	r.streakDay = nil
+	if day == nil {
+		return StreakDay{}, false
+	}
+	return *day, true
+}
@@ -0,0 +1,68 @@
+package pet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreak(t *testing.T) {
+	r, err := LoadRoster("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		day    int
+		want   StreakDay
+		streak int
+	}{
+		{0, StreakDay{Streak: 1}, 1},
+		{1, StreakDay{Streak: 2, XP: 20, Happiness: 4}, 2},
+		{2, StreakDay{Streak: 3, XP: 30, Happiness: 6}, 3},
+		// a day off is forgiven
+		{4, StreakDay{Streak: 4, XP: 40, Happiness: 8, Rested: true}, 4},
+		// but not another one within the week
+		{6, StreakDay{Streak: 1, Broken: 4}, 1},
+		{7, StreakDay{Streak: 2, XP: 20, Happiness: 4}, 2},
+	}
+	for _, test := range tests {
+		now := start.AddDate(0, 0, test.day)
+		r.CountCommand("ls", 0, now)
+		got, ok := r.StreakNews()
+		if !ok || got != test.want {
+			t.Errorf("day %d: StreakNews() = %+v, %v, want %+v", test.day, got, ok, test.want)
+		}
+		if streak := r.DayStreak(now); streak != test.streak {
+			t.Errorf("day %d: DayStreak() = %d, want %d", test.day, streak, test.streak)
+		}
+
+		// the next commands of the day don't count again
+		r.CountCommand("ls", 0, now.Add(time.Hour))
+		if got, ok := r.StreakNews(); ok {
+			t.Errorf("day %d: StreakNews() = %+v on the second command, want nothing", test.day, got)
+		}
+	}
+	if r.Milestones.BestStreak != 4 {
+		t.Errorf("BestStreak = %d, want 4", r.Milestones.BestStreak)
+	}
+	if streak := r.DayStreak(start.AddDate(0, 0, 20)); streak != 0 {
+		t.Errorf("DayStreak() = %d two weeks later, want 0", streak)
+	}
+}
+
+func TestStreakXPCapped(t *testing.T) {
+	r, err := LoadRoster("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	var day StreakDay
+	for i := range 30 {
+		r.CountCommand("ls", 0, start.AddDate(0, 0, i))
+		day, _ = r.StreakNews()
+	}
+	if day.Streak != 30 || day.XP != maxStreakXP || day.Happiness != maxStreakHappiness {
+		t.Errorf("day 30 of the streak = %+v, want the bonuses capped", day)
+	}
+}
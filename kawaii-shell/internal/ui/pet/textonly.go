@@ -0,0 +1,67 @@
+package pet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// zeroWidthJoiner glues emoji together into one, like a family
+const zeroWidthJoiner = '\u200d'
+
+// TextOnly reports whether the pet does without emoji, sprites and
+// particles, with plain lines for screen readers and minimal terminals
+func (p *Pet) TextOnly() bool {
+	return p.settings.TextOnly
+}
+
+// Plain returns the text without its emoji, for pets that are text only.
+// The indentation stays, the spaces left around the emoji are collapsed.
+func Plain(s string) string {
+	text := strings.TrimLeft(s, " ")
+	indent := s[:len(s)-len(text)]
+	text = strings.Map(func(r rune) rune {
+		if r == zeroWidthJoiner || r > unicode.MaxASCII && unicode.In(r, unicode.So, unicode.Sk, unicode.Variation_Selector) {
+			return -1
+		}
+		return r
+	}, text)
+	return indent + strings.Join(strings.Fields(text), " ")
+}
+
+// textView renders the pet as plain lines, one thing on each
+func (p *Pet) textView(now time.Time) string {
+	if p.Evolving() {
+		return fmt.Sprintf("%s\nis evolving!", p.Name)
+	}
+	lines := []string{
+		p.Name,
+		fmt.Sprintf("Lv.%d %s", p.Level, p.GetMoodString()),
+		fmt.Sprintf("Energy %d", p.Energy),
+		fmt.Sprintf("Happiness %d", p.Happiness),
+	}
+	if p.SpecialState != "" {
+		lines = append(lines, p.SpecialState)
+	}
+	switch {
+	case p.Waiting():
+		lines = append(lines, "Waiting "+now.Sub(p.waitingSince).Round(time.Second).String())
+	case p.Activity != ActivityIdle:
+		lines = append(lines, Plain(p.getActivityString()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PartyText lists the other pets hanging out by name, for pets that are
+// text only
+func PartyText(pets []*Pet) string {
+	if len(pets) == 0 {
+		return ""
+	}
+	names := make([]string, len(pets))
+	for i, p := range pets {
+		names[i] = p.Name
+	}
+	return "With " + strings.Join(names, ", ")
+}
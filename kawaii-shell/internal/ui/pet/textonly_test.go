@@ -0,0 +1,44 @@
+package pet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlain(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"🐱 Neko the cat (Level 2)", "Neko the cat (Level 2)"},
+		{"  ⚡ Energy: 80/100", "  Energy: 80/100"},
+		{"Enjoying a snack 🍽️", "Enjoying a snack"},
+		{"👨‍👩‍👧 family time", "family time"},
+		{"no emoji at all", "no emoji at all"},
+	}
+	for _, test := range tests {
+		if got := Plain(test.in); got != test.want {
+			t.Errorf("Plain(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestTextOnly(t *testing.T) {
+	p, _ := newTestPet(Personality{})
+	p.Configure(Settings{ReactEvery: 1, Particles: 1, TextOnly: true})
+
+	want := "Neko\nLv.1 Happy\nEnergy 80\nHappiness 100"
+	if view := p.View(); view != want {
+		t.Errorf("View() = %q, want %q", view, want)
+	}
+	for _, line := range p.GetStatus() {
+		if line != Plain(line) {
+			t.Errorf("GetStatus() has emoji in %q", line)
+		}
+	}
+
+	// the commands and moods work just the same
+	p.ReactToCommand("git status", false)
+	if view := p.View(); !strings.Contains(view, "git-genius") {
+		t.Errorf("View() = %q after git status, want the special state", view)
+	}
+}
@@ -0,0 +1,101 @@
+package pet
+
+import "math/rand"
+
+// Trait is what a pet's personality leans towards, picked when adopting it
+type Trait int
+
+const (
+	TraitBalanced Trait = iota
+	TraitCurious
+	TraitPlayful
+	TraitLoyal
+	TraitClever
+	TraitEnergetic
+)
+
+// traitNames are the names of the traits, as shown
+var traitNames = map[Trait]string{
+	TraitBalanced:  "Balanced",
+	TraitCurious:   "Curious",
+	TraitPlayful:   "Playful",
+	TraitLoyal:     "Loyal",
+	TraitClever:    "Clever",
+	TraitEnergetic: "Energetic",
+}
+
+// traitEmojis are the emojis of the traits, like in the pet's status
+var traitEmojis = map[Trait]string{
+	TraitBalanced:  "⚖️",
+	TraitCurious:   "🔍",
+	TraitPlayful:   "🎪",
+	TraitLoyal:     "💝",
+	TraitClever:    "🧠",
+	TraitEnergetic: "⚡",
+}
+
+// Traits returns all the traits, in order
+func Traits() []Trait {
+	return []Trait{TraitBalanced, TraitCurious, TraitPlayful, TraitLoyal, TraitClever, TraitEnergetic}
+}
+
+// String returns the name of the trait, like Curious
+func (t Trait) String() string {
+	return traitNames[t]
+}
+
+// Emoji returns the emoji standing for the trait
+func (t Trait) Emoji() string {
+	return traitEmojis[t]
+}
+
+// NewPersonality returns a random personality leaning towards the trait,
+// which comes out strong whatever the dice say
+func NewPersonality(trait Trait) Personality {
+	personality := Personality{
+		Curiosity:    rand.Float64()*0.5 + 0.5,
+		Playfulness:  rand.Float64()*0.4 + 0.6,
+		Loyalty:      rand.Float64()*0.3 + 0.7,
+		Intelligence: rand.Float64()*0.6 + 0.4,
+		Energy:       rand.Float64()*0.4 + 0.6,
+	}
+	if value := personality.trait(trait); value != nil {
+		*value = rand.Float64()*0.1 + 0.9
+	}
+	return personality
+}
+
+// trait returns the part of the personality the trait is about, nil for
+// balanced
+func (p *Personality) trait(trait Trait) *float64 {
+	switch trait {
+	case TraitCurious:
+		return &p.Curiosity
+	case TraitPlayful:
+		return &p.Playfulness
+	case TraitLoyal:
+		return &p.Loyalty
+	case TraitClever:
+		return &p.Intelligence
+	case TraitEnergetic:
+		return &p.Energy
+	}
+	return nil
+}
+
+// Strongest returns the trait the personality leans towards the most,
+// balanced when none stands out
+func (p Personality) Strongest() Trait {
+	strongest, most := TraitBalanced, 0.0
+	for _, trait := range Traits() {
+		value := p.trait(trait)
+		switch {
+		case value == nil:
+		case *value > most:
+			strongest, most = trait, *value
+		case *value == most:
+			strongest = TraitBalanced
+		}
+	}
+	return strongest
+}
@@ -0,0 +1,41 @@
+package pet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Types returns all the pet types, the built-in ones first and then the
+// ones added by packs
+func Types() []PetType {
+	return slices.Clone(packTypes)
+}
+
+// String returns the name of the pet type, like Cat
+func (t PetType) String() string {
+	return packOf(t).Name
+}
+
+// Icon returns the emoji standing for the pet type
+func (t PetType) Icon() string {
+	return packOf(t).Emojis[0]
+}
+
+// SuggestedName returns a name that suits a pet of the type
+func (t PetType) SuggestedName() string {
+	return packOf(t).SuggestedName
+}
+
+// ParseType returns the pet type with the name, ignoring the case
+func ParseType(name string) (PetType, error) {
+	var names []string
+	for _, t := range packTypes {
+		if strings.EqualFold(name, string(t)) || strings.EqualFold(name, t.String()) {
+			return t, nil
+		}
+		names = append(names, string(t))
+	}
+	return TypeCat, fmt.Errorf("there's no %s pet, try %s or %s", name,
+		strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+}
@@ -0,0 +1,194 @@
+package pet
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed voices/*.yaml
+var voiceFiles embed.FS
+
+// defaultLanguage is the language pets speak when they don't know yours
+const defaultLanguage = "en"
+
+// Voice is what pets say in a language: lines for the activities, special
+// states and moods by name, and the ones for when there's nothing else
+type Voice struct {
+	Fallback      []string            `yaml:"fallback"`
+	Activities    map[string][]string `yaml:"activities"`
+	SpecialStates map[string][]string `yaml:"special_states"`
+
+	// Moods can have lines for pets with a strong trait, like
+	// happy/playful, said in place of the mood's own
+	Moods map[string][]string `yaml:"moods"`
+
+	// Seasons are lines for the seasons the shell dresses up for, like
+	// halloween, said along with the mood's
+	Seasons map[string][]string `yaml:"seasons"`
+}
+
+// voiceTraits are how strong each trait has to be for the pet to sound
+// like it
+var voiceTraits = map[Trait]float64{
+	TraitCurious:   0.7,
+	TraitPlayful:   0.7,
+	TraitLoyal:     0.8,
+	TraitClever:    0.7,
+	TraitEnergetic: 0.7,
+}
+
+// voices are the voices of the languages pets speak, by language
+var voices = loadBuiltinVoices()
+
+// season is the season the shell is dressed up for, like halloween, empty
+// outside of one
+var season string
+
+// SetSeason has pets say the lines of the season along with the others, or
+// stop when it's empty
+func SetSeason(id string) {
+	season = id
+}
+
+// loadBuiltinVoices loads the voices bundled in the binary
+func loadBuiltinVoices() map[string]*Voice {
+	files, err := voiceFiles.ReadDir("voices")
+	if err != nil {
+		panic(err)
+	}
+	loaded := make(map[string]*Voice)
+	for _, file := range files {
+		data, err := voiceFiles.ReadFile("voices/" + file.Name())
+		if err != nil {
+			panic(err)
+		}
+		var voice Voice
+		if err := yaml.Unmarshal(data, &voice); err != nil {
+			panic(fmt.Errorf("invalid pet voice %s: %w", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), ".yaml")] = &voice
+	}
+	return loaded
+}
+
+// LoadVoices adds the voices in the YAML files in dir, which may not exist,
+// named after their language like de.yaml or pt-br.yaml. Lines for a
+// language pets already speak replace the ones they had.
+func LoadVoices(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to read pet voices: %w", err)
+	}
+	var errs []error
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read pet voice: %w", err))
+			continue
+		}
+		var voice Voice
+		if err := yaml.Unmarshal(data, &voice); err != nil {
+			errs = append(errs, fmt.Errorf("invalid pet voice %s: %w", file, err))
+			continue
+		}
+		language := strings.ToLower(strings.TrimSuffix(filepath.Base(file), ".yaml"))
+		voices[language] = voices[language].merge(&voice)
+	}
+	return errors.Join(errs...)
+}
+
+// merge returns the voice with the lines of the other one in place of its
+// own
+func (v *Voice) merge(other *Voice) *Voice {
+	if v == nil {
+		return other
+	}
+	merged := &Voice{
+		Fallback:      v.Fallback,
+		Activities:    mergeLines(v.Activities, other.Activities),
+		SpecialStates: mergeLines(v.SpecialStates, other.SpecialStates),
+		Moods:         mergeLines(v.Moods, other.Moods),
+		Seasons:       mergeLines(v.Seasons, other.Seasons),
+	}
+	if len(other.Fallback) > 0 {
+		merged.Fallback = other.Fallback
+	}
+	return merged
+}
+
+// mergeLines returns the lines with the other ones in place of theirs
+func mergeLines(lines, other map[string][]string) map[string][]string {
+	merged := make(map[string][]string, len(lines)+len(other))
+	maps.Copy(merged, lines)
+	maps.Copy(merged, other)
+	return merged
+}
+
+// languages returns the languages the pet speaks, the one it's set to or
+// the one of the locale first, then without the region, then English
+func (p *Pet) languages() []string {
+	language := p.settings.Language
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if language != "" {
+			break
+		}
+		language = os.Getenv(env)
+	}
+	// en_US.UTF-8 is en-us
+	language, _, _ = strings.Cut(language, ".")
+	language = strings.ToLower(strings.ReplaceAll(language, "_", "-"))
+
+	var languages []string
+	if language != "" && language != "c" && language != "posix" {
+		languages = append(languages, language)
+		if base, _, ok := strings.Cut(language, "-"); ok {
+			languages = append(languages, base)
+		}
+	}
+	return slices.Compact(append(languages, defaultLanguage))
+}
+
+// voiceLines returns the lines of the first key the pet has lines for in the
+// section of its voice, in the first language that has any
+func (p *Pet) voiceLines(section func(v *Voice) map[string][]string, keys ...string) []string {
+	for _, language := range p.languages() {
+		voice, ok := voices[language]
+		if !ok {
+			continue
+		}
+		for _, key := range keys {
+			if lines := section(voice)[key]; len(lines) > 0 {
+				return p.say(lines)
+			}
+		}
+	}
+	return nil
+}
+
+// voiceFallback returns the lines the pet says when there's nothing else
+func (p *Pet) voiceFallback() []string {
+	for _, language := range p.languages() {
+		if voice, ok := voices[language]; ok && len(voice.Fallback) > 0 {
+			return p.say(voice.Fallback)
+		}
+	}
+	return nil
+}
+
+// say fills the pet's name and stage into the lines
+func (p *Pet) say(lines []string) []string {
+	replacer := strings.NewReplacer("{name}", p.Name, "{stage}", p.StageName())
+	said := make([]string, len(lines))
+	for i, line := range lines {
+		said[i] = replacer.Replace(line)
+	}
+	return said
+}
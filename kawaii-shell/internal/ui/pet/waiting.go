@@ -0,0 +1,50 @@
+package pet
+
+import (
+	"strings"
+	"time"
+)
+
+// waitingCells is how wide the bar bouncing while the pet waits is
+const waitingCells = 5
+
+// Wait has the pet wait along with a long command that started at the
+// time, cheering it on
+func (p *Pet) Wait(since time.Time) {
+	p.waitingSince = since
+	p.Activity = ActivityWaiting
+}
+
+// Waiting reports whether the pet waits for a long command
+func (p *Pet) Waiting() bool {
+	return !p.waitingSince.IsZero()
+}
+
+// StopWaiting stops waiting for the long command, bursting into a
+// celebration when it's worth it
+func (p *Pet) StopWaiting(celebrate bool) {
+	p.waitingSince = time.Time{}
+	if p.Activity == ActivityWaiting {
+		p.Activity = ActivityIdle
+	}
+	if !celebrate {
+		return
+	}
+	p.Celebrate()
+	p.SpecialState = "worth-the-wait"
+	p.particleSystem.AddSparkles(25, 10, 15)
+	p.particleSystem.AddHearts(25, 10, 5)
+	p.particleSystem.AddFlowerPetals(25, 10, 8)
+}
+
+// waitingView renders a little bar bouncing back and forth, with how long
+// the command has been running
+func (p *Pet) waitingView(now time.Time) string {
+	frame := int(now.UnixMilli() / spriteFrame.Milliseconds())
+	pos := frame % (2*waitingCells - 2)
+	if pos >= waitingCells {
+		pos = 2*waitingCells - 2 - pos
+	}
+	bar := strings.Repeat("▱", pos) + "▰" + strings.Repeat("▱", waitingCells-pos-1)
+	return bar + " " + now.Sub(p.waitingSince).Round(time.Second).String()
+}
@@ -0,0 +1,485 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// petSaveInterval is how often the pets are saved while the shell runs
+const petSaveInterval = time.Minute
+
+// petSettingsCheck is how often the pet settings file is checked for changes
+const petSettingsCheck = time.Second
+
+// petNameSuggestions is how many names the name generator suggests at once
+const petNameSuggestions = 5
+
+// rosterPanel lists the pets in place of the output, to pick the active one
+type rosterPanel struct {
+	selected int
+}
+
+// handlePetCommand runs pets and the pet kawaii commands that take
+// arguments, returning whether the command was one of them. pet on its own
+// shows the status of the active pet.
+func (a *App) handlePetCommand(command string) bool {
+	fields := strings.Fields(command)
+	switch {
+	case len(fields) == 1 && fields[0] == "pets":
+		a.roster = &rosterPanel{selected: a.pets.Active}
+		return true
+	case len(fields) == 1 && fields[0] == "wardrobe":
+		a.wardrobe = &wardrobePanel{}
+		return true
+	case len(fields) == 1 && fields[0] == "achievements":
+		a.achievements = &achievementsPanel{}
+		return true
+	case len(fields) == 1 && fields[0] == "quests":
+		a.quests = &questsPanel{}
+		return true
+	case len(fields) < 2 || fields[0] != "pet":
+		return false
+	}
+
+	switch fields[1] {
+	case "adopt":
+		if len(fields) < 3 {
+			a.output = append(a.output, "🥺 Oops: who are we adopting? Try pet adopt fox Kit")
+			break
+		}
+		petType, err := pet.ParseType(fields[2])
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			break
+		}
+		name := strings.Join(fields[3:], " ")
+		if name == "" {
+			// pet adopt fox gets a name from the generator
+			if names := a.pets.SuggestNames(petType, 1); len(names) > 0 {
+				name = names[0]
+			}
+		}
+		p, err := a.pets.Adopt(name, petType)
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			break
+		}
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"%s Welcome home, %s the %s! pet switch %s makes them your companion", p.Icon(), p.Name, p.StageName(), p.Name)))
+	case "switch":
+		if len(fields) < 3 {
+			a.output = append(a.output, "🥺 Oops: which pet? Try pet switch Neko, or pets to pick one")
+			break
+		}
+		index, ok := a.pets.Find(strings.Join(fields[2:], " "))
+		if !ok {
+			a.output = append(a.output, "🥺 Oops: there's no pet called "+strings.Join(fields[2:], " ")+", pets lists them")
+			break
+		}
+		a.switchPet(index)
+	case "rename":
+		if len(fields) < 3 {
+			a.suggestNames(a.pet.Type)
+			break
+		}
+		a.renamePet(strings.Join(fields[2:], " "))
+	case "names":
+		petType := a.pet.Type
+		if len(fields) > 2 {
+			var err error
+			if petType, err = pet.ParseType(fields[2]); err != nil {
+				a.output = append(a.output, "🥺 Oops: "+err.Error())
+				break
+			}
+		}
+		a.suggestNames(petType)
+	case "status":
+		a.showPetStatus()
+	case "feed":
+		if len(fields) > 2 {
+			a.giveTreat(fields[2])
+			break
+		}
+		a.carePet(pet.CareFeed)
+	case "play":
+		a.carePet(pet.CarePlay)
+	case "groom":
+		a.carePet(pet.CareGroom)
+	case "treats":
+		a.showTreats()
+	case "stats":
+		a.showPetStats(time.Now())
+	case "remember":
+		a.recall(strings.Join(fields[2:], " "))
+	case "what":
+		// pet what did we do yesterday
+		a.recall(strings.Join(fields[1:], " "))
+	case "export":
+		a.exportPet(strings.Join(fields[2:], " "))
+	case "import":
+		if len(fields) < 3 {
+			a.output = append(a.output, "🥺 Oops: which file? Try pet import neko.pet.json")
+			break
+		}
+		a.importPet(strings.Join(fields[2:], " "))
+	case "key":
+		a.showPetKey()
+	case "trust":
+		if len(fields) < 3 {
+			a.output = append(a.output, "🥺 Oops: whose key? Try pet trust <key> Sam, with the key pet key shows them")
+			break
+		}
+		a.trustPetKey(fields[2], strings.Join(fields[3:], " "))
+	case "neighbors":
+		a.showNeighbors()
+	case "gift":
+		if len(fields) < 3 {
+			a.output = append(a.output, "🥺 Oops: which treat? Try pet gift cookie, or pet gift cookie to Mochi")
+			break
+		}
+		var to string
+		if len(fields) > 4 && fields[3] == "to" {
+			to = strings.Join(fields[4:], " ")
+		}
+		a.giftNeighbor(fields[2], to)
+	case "react":
+		kind, intensity, message, err := pet.ParseReactionArgs(fields[2:])
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			break
+		}
+		a.triggerReaction(kind, intensity, message)
+	case "wear":
+		accessory, ok := pet.FindAccessory(strings.Join(fields[2:], " "))
+		if !ok {
+			a.output = append(a.output, "🥺 Oops: there's no such accessory, wardrobe lists them")
+			break
+		}
+		a.toggleAccessory(accessory)
+	default:
+		a.output = append(a.output, "🥺 Oops: try pet, pets, pet status, pet feed, pet play, pet groom, pet treats, pet stats, pet remember <what>, pet export, pet import <file>, pet neighbors, pet gift <treat>, pet wear <accessory>, pet adopt <type> [name], pet switch <name>, pet rename <name>, pet names [type] or pet react <how> [message]")
+	}
+	return true
+}
+
+// carePet looks after the companion, from the pet commands and their
+// hotkeys
+func (a *App) carePet(care pet.Care) {
+	p := a.pet
+	if err := p.Care(care); err != nil {
+		a.output = append(a.output, a.theme.Styles.Info.Render("⏳ "+err.Error()))
+		return
+	}
+	var line string
+	switch care {
+	case pet.CareFeed:
+		line = fmt.Sprintf("🍖 %s gobbles up their meal! ⚡%d 💖%d", p.Name, p.Energy, p.Happiness)
+	case pet.CarePlay:
+		line = fmt.Sprintf("🎾 %s zooms around after the ball! 💖%d", p.Name, p.Happiness)
+	case pet.CareGroom:
+		line = fmt.Sprintf("🪮 %s is all soft and shiny now! 💖%d", p.Name, p.Happiness)
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(line))
+}
+
+// giveTreat gives the companion a treat from the bag
+func (a *App) giveTreat(name string) {
+	treat, err := a.pets.GiveTreat(name)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error()+", pet treats shows what's in the bag")
+		return
+	}
+	line := fmt.Sprintf("%s %s munches on the %s! 💖%d", treat.Emoji, a.pet.Name, treat.Name, a.pet.Happiness)
+	if treat == a.pet.FavoriteTreat() {
+		line = fmt.Sprintf("%s %s's favorite! %s is over the moon! 💖%d", treat.Emoji, treat.Name, a.pet.Name, a.pet.Happiness)
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(line))
+}
+
+// showTreats lists what's in the treat bag
+func (a *App) showTreats() {
+	var treats []string
+	for _, treat := range pet.Treats {
+		if count := a.pets.Treats[treat.Name]; count > 0 {
+			treats = append(treats, fmt.Sprintf("%s %s ×%d", treat.Emoji, treat.Name, count))
+		}
+	}
+	favorite := a.pet.FavoriteTreat()
+	if len(treats) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+			"🎒 The treat bag is empty, commands that go well earn more! %s loves %s %s", a.pet.Name, favorite.Emoji, favorite.Name)))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+		"🎒 Treat bag: %s • %s loves %s %s, pet feed <treat> gives one", strings.Join(treats, ", "), a.pet.Name, favorite.Emoji, favorite.Name)))
+}
+
+// earnTreat counts a command that went well towards the next treat
+func (a *App) earnTreat() {
+	if treat, ok := a.pets.Earn(); ok {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"🎁 %s found a %s %s for the treat bag!", a.pet.Name, treat.Emoji, treat.Name)))
+	}
+}
+
+// renamePet gives the companion a new name
+func (a *App) renamePet(name string) {
+	old, err := a.pets.Rename(name)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	// the neighbors hear about the new name right away
+	a.neighborsAnnouncedAt = time.Time{}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"%s %s is now called %s! They love their new name 💕", a.pet.Icon(), old, a.pet.Name)))
+}
+
+// suggestNames shows a few names that would suit a pet of the type
+func (a *App) suggestNames(petType pet.PetType) {
+	names := a.pets.SuggestNames(petType, petNameSuggestions)
+	if len(names) == 0 {
+		a.output = append(a.output, "🥺 Oops: all the names are taken, you'll have to make one up!")
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+		"🎲 Names for a %s: %s • pet rename <name> picks one", strings.ToLower(petType.String()), strings.Join(names, ", "))))
+}
+
+// switchPet makes the pet at the index the active one, the others join the
+// party
+func (a *App) switchPet(index int) {
+	if index == a.pets.Active {
+		a.output = append(a.output, a.theme.Styles.Info.Render(a.pet.Icon()+" "+a.pet.Name+" is already by your side"))
+		return
+	}
+	previous := a.pet
+	p, err := a.pets.Switch(index)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.pet = p
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"%s %s is your companion now! %s joins the party 🎉", p.Icon(), p.Name, previous.Name)))
+}
+
+// recall has the companion tell what it remembers about the question
+func (a *App) recall(question string) {
+	for _, line := range a.pet.Recall(question, time.Now()) {
+		a.output = append(a.output, a.petLine(line))
+	}
+}
+
+// exportPet saves the companion to a signed file, named after it unless
+// given, to be imported on another machine
+func (a *App) exportPet(path string) {
+	if path == "" {
+		path = strings.ToLower(a.pet.Name) + ".pet.json"
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.cwd, path)
+	}
+	data, err := a.pets.Export(time.Now())
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		a.output = append(a.output, "🥺 Oops: failed to export "+a.pet.Name+": "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"🧳 %s packed their bags into %s, pet import brings them home anywhere", a.pet.Name, path)))
+}
+
+// importPet adds the pet exported to the file to the roster
+func (a *App) importPet(path string) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.cwd, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: failed to import a pet: "+err.Error())
+		return
+	}
+	p, signer, err := a.pets.Import(data, a.config.Pet.Evolution)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	var line string
+	switch {
+	case signer.Home:
+		line = fmt.Sprintf("🏡 %s %s is back home, level %d! pet switch %s makes them your companion", p.Icon(), p.Name, p.Level, p.Name)
+	case signer.Trusted:
+		line = fmt.Sprintf("🧳 %s %s the %s moved in from %s, level %d! pet switch %s makes them your companion",
+			p.Icon(), p.Name, p.StageName(), cmp.Or(signer.Name, signer.Fingerprint), p.Level, p.Name)
+	default:
+		line = fmt.Sprintf("🧳 %s %s the %s moved in, level %d! pet switch %s makes them your companion", p.Icon(), p.Name, p.StageName(), p.Level, p.Name)
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(line))
+	if !signer.Trusted {
+		a.output = append(a.output, a.theme.Styles.Warning.Render(fmt.Sprintf(
+			"⚠️  %s was signed by a key you don't trust, %s, so anyone could have made up their stats. "+
+				"If it's a friend's, check it with them and pet trust %s <name>", p.Name, signer.Fingerprint, signer.Key)))
+	}
+}
+
+// showPetKey shows the key exported pets are signed with, for friends to
+// trust them
+func (a *App) showPetKey() {
+	key, fingerprint, err := a.pets.PublicKey()
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output,
+		a.theme.Styles.Info.Render("🔑 Your pets are signed with "+key+" ("+fingerprint+")"),
+		a.theme.Styles.Help.Render("Friends who pet trust it welcome your pets as yours"))
+}
+
+// trustPetKey trusts the pets signed with the key, of whoever the name says
+func (a *App) trustPetKey(key, name string) {
+	signer, err := a.pets.Trust(key, name)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"🔑 Pets signed with %s (%s) are welcome as %s's now", key, signer.Fingerprint, cmp.Or(name, "a friend"))))
+}
+
+// reactToExitCode lets the companion react to how the command went,
+// showing what it has to say about it
+func (a *App) reactToExitCode(command string, code int) {
+	if line := a.pet.ReactToExitCode(command, code); line != "" {
+		a.output = append(a.output, a.petLine(line))
+	}
+}
+
+// waitForCommand has the companion wait along with the command running in
+// the shell once it's running for long
+func (a *App) waitForCommand(now time.Time) {
+	switch {
+	case a.pendingCommand >= 0 && a.isLong(now.Sub(a.pendingStarted)):
+		a.pet.Wait(a.pendingStarted)
+	case a.pet.Waiting():
+		// the command is in another pane now
+		a.pet.StopWaiting(false)
+	}
+}
+
+// followClock lets the companion live by the clock, greeting when a new
+// part of the day starts
+func (a *App) followClock(now time.Time) {
+	if a.adoption != nil {
+		// the pet isn't home yet
+		return
+	}
+	focus := slices.ContainsFunc(a.config.Pet.FocusHours, func(h config.Hours) bool { return h.Contains(now) })
+	if greeting := a.pet.FollowClock(now, focus); greeting != "" {
+		a.output = append(a.output, a.theme.Styles.Info.Render(greeting))
+		a.playSound(shell.SoundGreeting)
+	}
+}
+
+// reloadPetSettings has the pets follow the settings file again when it
+// changed, checking at most every petSettingsCheck
+func (a *App) reloadPetSettings(now time.Time) {
+	if now.Sub(a.petSettingsCheckedAt) < petSettingsCheck {
+		return
+	}
+	a.petSettingsCheckedAt = now
+	changed, err := a.petSettings.Load()
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if changed {
+		a.pets.Configure(a.petSettings.Settings)
+		a.followNeighbors()
+		a.output = append(a.output, a.theme.Styles.Info.Render("🐾 Pet settings reloaded"))
+	}
+}
+
+// evolvePet evolves the companion once it reached the level for it
+func (a *App) evolvePet() {
+	from := a.pet.StageName()
+	if a.pet.Evolve(a.config.Pet.Evolution) {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"🌟 What? %s is evolving! %s → %s %s", a.pet.Name, from, a.pet.Icon(), a.pet.StageName())))
+	}
+}
+
+// savePets saves the pets once in a while, so they remember how they're
+// doing next time
+func (a *App) savePets(now time.Time) {
+	if now.Sub(a.petsSavedAt) < petSaveInterval {
+		return
+	}
+	a.petsSavedAt = now
+	a.pet.RecordSample(now)
+	if err := a.pets.Save(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+}
+
+// handleRosterKey handles the keys of the roster, nothing else gets them
+// until it's closed
+func (a *App) handleRosterKey(msg tea.KeyMsg) {
+	r := a.roster
+	switch msg.String() {
+	case "esc", "q":
+		a.roster = nil
+		return
+	case "up", "k", "ctrl+p":
+		r.selected--
+	case "down", "j", "ctrl+n":
+		r.selected++
+	case "enter":
+		a.switchPet(r.selected)
+		a.roster = nil
+		return
+	}
+	r.selected = min(max(r.selected, 0), len(a.pets.Pets)-1)
+}
+
+// rosterView renders the pets, the active one first among equals
+func (a *App) rosterView(height int) string {
+	cols, _ := a.outputSize()
+	lines := []string{a.theme.Styles.Help.Render(fmt.Sprintf("🐾 Your pets • %d of %d", len(a.pets.Pets), pet.MaxPets))}
+	for i, p := range a.pets.Pets[:min(len(a.pets.Pets), max(a.outputRows(height)-1, 1))] {
+		line := fmt.Sprintf("%s %s the %s · Lv.%d · %s %s · ⚡%d 💖%d",
+			p.Icon(), p.Name, p.StageName(), p.Level, p.GetMoodEmoji(), p.GetMoodString(), p.Energy, p.Happiness)
+		if i == a.pets.Active {
+			line += " · 💕 companion"
+		} else {
+			line += " · 🎉 partying"
+		}
+		if i == a.roster.selected {
+			lines = append(lines, a.theme.Styles.Highlight.Render(ansi.Truncate("▶ "+line, cols-2, "…")))
+			continue
+		}
+		lines = append(lines, ansi.Truncate("  "+line, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rosterInputView renders the help of the roster in place of the input
+func (a *App) rosterInputView() string {
+	return a.theme.Styles.Prompt.Render("🐾 pets") +
+		lipgloss.NewStyle().Faint(true).Render("  ↑↓ pick • enter to make them your companion • esc close")
+}
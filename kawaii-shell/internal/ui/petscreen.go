@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+const (
+	petScreenButtonWidth = 18
+	petScreenBarWidth    = 14
+
+	// petScreenBarColumns is how many bars go next to each other
+	petScreenBarColumns = 4
+
+	// petScreenMemories is how many of the things the pet remembers show
+	petScreenMemories = 5
+)
+
+// petScreen shows the companion on the whole screen, with how it's doing,
+// what it remembers and buttons to look after it
+type petScreen struct {
+	buttons []*components.Button
+	focus   int
+
+	// bars are how the companion is doing, along petScreenBars
+	bars []*components.ProgressBar
+
+	// notice is what the last button pressed had to say
+	notice string
+}
+
+// petScreenBars are the bars of the pet screen, and how full each is
+var petScreenBars = []struct {
+	label string
+	max   float64
+	value func(p *pet.Pet) float64
+}{
+	{"⚡ Energy", 100, func(p *pet.Pet) float64 { return float64(p.Energy) }},
+	{"💖 Happiness", 100, func(p *pet.Pet) float64 { return float64(p.Happiness) }},
+	{"🍖 Hunger", 1, func(p *pet.Pet) float64 { return p.State.Hunger }},
+	{"💧 Thirst", 1, func(p *pet.Pet) float64 { return p.State.Thirst }},
+	{"🧶 Boredom", 1, func(p *pet.Pet) float64 { return p.State.Boredom }},
+	{"🫂 Loneliness", 1, func(p *pet.Pet) float64 { return p.State.Loneliness }},
+	{"😣 Stress", 1, func(p *pet.Pet) float64 { return p.State.Stress }},
+	{"😴 Exhaustion", 1, func(p *pet.Pet) float64 { return p.State.Exhaustion }},
+}
+
+// openPetScreen opens the pet screen, with the feed button focused
+func (a *App) openPetScreen() {
+	screen := &petScreen{}
+	for _, b := range []struct {
+		text  string
+		press func()
+	}{
+		{"🍖 Feed", func() { a.carePetOnScreen(pet.CareFeed) }},
+		{"🎾 Play", func() { a.carePetOnScreen(pet.CarePlay) }},
+		{"🪮 Groom", func() { a.carePetOnScreen(pet.CareGroom) }},
+		{"📜 Quests", a.openQuestsFromScreen},
+	} {
+		button := components.NewButton(a.petText(b.text), 0, 0, petScreenButtonWidth)
+		button.OnClick = b.press
+		// slim, to leave the room to the pet
+		button.Style = button.Style.Padding(0, 1)
+		button.HoverStyle = button.HoverStyle.Padding(0, 1)
+		button.PressedStyle = button.PressedStyle.Padding(0, 1)
+		screen.buttons = append(screen.buttons, button)
+	}
+	screen.buttons[0].Focus()
+	for _, b := range petScreenBars {
+		bar := components.NewProgressBar(b.label, 0, 0, petScreenBarWidth, b.max)
+		bar.Style = lipgloss.NewStyle()
+		screen.bars = append(screen.bars, bar)
+	}
+	a.petScreen = screen
+}
+
+// handlePetScreenKey handles the keys of the pet screen, nothing else gets
+// them until it's closed
+func (a *App) handlePetScreenKey(msg tea.KeyMsg) {
+	screen := a.petScreen
+	switch msg.String() {
+	case "esc", "q", "alt+s":
+		a.petScreen = nil
+	case "tab", "right", "l":
+		screen.moveFocus(1)
+	case "shift+tab", "left", "h":
+		screen.moveFocus(-1)
+	case "enter", " ":
+		screen.buttons[screen.focus].Press()
+	case "f":
+		a.carePetOnScreen(pet.CareFeed)
+	case "p":
+		a.carePetOnScreen(pet.CarePlay)
+	case "g":
+		a.carePetOnScreen(pet.CareGroom)
+	}
+}
+
+// moveFocus focuses the button by the offset from the focused one
+func (s *petScreen) moveFocus(offset int) {
+	s.buttons[s.focus].Blur()
+	s.focus = (s.focus + offset + len(s.buttons)) % len(s.buttons)
+	s.buttons[s.focus].Focus()
+}
+
+// carePetOnScreen looks after the companion, telling how it went on the
+// pet screen as well as in the output
+func (a *App) carePetOnScreen(care pet.Care) {
+	lines := len(a.output)
+	a.carePet(care)
+	if a.petScreen != nil && len(a.output) > lines {
+		a.petScreen.notice = a.output[len(a.output)-1]
+	}
+}
+
+// openQuestsFromScreen closes the pet screen for the quests of the day
+func (a *App) openQuestsFromScreen() {
+	a.petScreen = nil
+	a.quests = &questsPanel{}
+}
+
+// petScreenView renders the pet screen: the companion big and animated
+// next to what it remembers, how it's doing below and the buttons at the
+// bottom
+func (a *App) petScreenView() string {
+	screen, p := a.petScreen, a.pet
+
+	now := time.Now()
+	header := a.titleView(a.petText(fmt.Sprintf("%s %s the %s · Lv.%d · %s %s",
+		p.Icon(), p.Name, p.StageName(), p.Level, p.GetMoodEmoji(), p.GetMoodString())))
+	picture := strings.Join(p.Portrait(now), "\n")
+	if p.TextOnly() {
+		picture = p.View()
+	}
+	portrait := lipgloss.JoinVertical(lipgloss.Center,
+		picture,
+		"",
+		a.petLine(p.GetPetMessage()),
+	)
+
+	memories := []string{a.theme.Styles.Help.Render(a.petText("🧠 Recent memories"))}
+	for _, memory := range p.RecentMemories(petScreenMemories) {
+		memories = append(memories, ansi.Truncate("  "+memory, a.width/3, "…"))
+	}
+	if len(memories) == 1 {
+		memories = append(memories, "  Nothing yet, let's run some commands!")
+	}
+
+	// the bars line up in columns, as wide as a full one
+	column := lipgloss.NewStyle().Width(petScreenBarWidth + 5)
+	var rows, row []string
+	for i, b := range petScreenBars {
+		screen.bars[i].SetProgress(b.value(p))
+		a.paintProgress(screen.bars[i], now)
+		bar := screen.bars[i].Render()
+		if p.TextOnly() {
+			// a plain percentage reads better than a bar
+			bar = fmt.Sprintf("%s %.0f%%", pet.Plain(b.label), b.value(p)/b.max*100)
+		}
+		row = append(row, column.Render(bar))
+		if len(row) == petScreenBarColumns || i == len(petScreenBars)-1 {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+	}
+
+	var buttons []string
+	for _, button := range screen.buttons {
+		buttons = append(buttons, button.Render())
+	}
+	sections := []string{
+		header,
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center, portrait, "      ", lipgloss.JoinVertical(lipgloss.Left, memories...)),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Top, buttons...),
+	}
+	if screen.notice != "" {
+		sections = append(sections, screen.notice)
+	}
+	sections = append(sections, lipgloss.NewStyle().Faint(true).Render(
+		"←→ pick • enter press • f feed • p play • g groom • esc back"))
+
+	view := lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Center, sections...))
+	// the bottom doesn't fit on small screens
+	if lines := strings.Split(view, "\n"); len(lines) > a.height {
+		view = strings.Join(lines[:a.height], "\n")
+	}
+	return view
+}
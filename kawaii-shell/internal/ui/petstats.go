@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+const (
+	// petStatsRows is the number of favorite commands shown
+	petStatsRows = 5
+
+	// petStatsLabelWidth is how wide the labels in front of the graphs are
+	petStatsLabelWidth = 16
+)
+
+// showPetStats renders the dashboard of how the companion has been doing:
+// graphs of its happiness, energy and stress, the moods it was in and the
+// commands it likes the most
+func (a *App) showPetStats(now time.Time) {
+	p := a.pet
+	p.RecordSample(now)
+	help := a.theme.Styles.Help
+	info := a.theme.Styles.Info
+	header := info.Bold(true)
+
+	// the graphs leave room for the value now after them
+	cols, _ := a.outputSize()
+	samples := p.History[max(len(p.History)-max(cols-petStatsLabelWidth-6, 10), 0):]
+	happiness := make([]float64, len(samples))
+	energy := make([]float64, len(samples))
+	stress := make([]float64, len(samples))
+	for i, sample := range samples {
+		happiness[i] = float64(sample.Happiness)
+		energy[i] = float64(sample.Energy)
+		stress[i] = sample.Stress
+	}
+	graph := func(label string, values []float64, now string) string {
+		return info.Render(label + strings.Repeat(" ", max(petStatsLabelWidth-lipgloss.Width(label), 1)) +
+			components.NewSparkline(values, lipgloss.NewStyle()).Render() + " " + now)
+	}
+	a.output = append(a.output,
+		"",
+		help.Render(fmt.Sprintf("🌸 ✨ %s's Stats ✨ 🌸", p.Name)),
+		"",
+		help.Render("📈 Since "+samples[0].Time.Local().Format("Jan 2 15:04")),
+		graph("💖 Happiness", happiness, fmt.Sprint(p.Happiness)),
+		graph("⚡ Energy", energy, fmt.Sprint(p.Energy)),
+		graph("😰 Stress", stress, fmt.Sprintf("%.0f%%", p.State.Stress*100)),
+		"",
+		help.Render("🎭 Moods"),
+	)
+	counts := p.MoodCounts()
+	moods := slices.SortedFunc(maps.Keys(counts), func(a, b pet.Mood) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+	moodTable := components.NewTable([]string{"Mood", "Share", ""}, header, info)
+	for _, mood := range moods {
+		moodTable.AddRow(mood.Emoji()+" "+mood.String(),
+			fmt.Sprintf("%d%%", counts[mood]*100/len(p.History)),
+			strings.Repeat("█", max(counts[mood]*20/len(p.History), 1)))
+	}
+	a.output = append(a.output, moodTable.Render()...)
+
+	a.output = append(a.output, "", help.Render("💕 Favorite commands"))
+	favorites := p.FavoriteCommands(petStatsRows)
+	if len(favorites) == 0 {
+		a.output = append(a.output, info.Render("None yet, run a few together and come back!"), "")
+		return
+	}
+	favoriteTable := components.NewTable([]string{"#", "Command", "Runs", ""}, header, info)
+	for i, command := range favorites {
+		bar := strings.Repeat("█", max(command.Count*20/favorites[0].Count, 1))
+		favoriteTable.AddRow(fmt.Sprint(i+1), command.Name, fmt.Sprint(command.Count), bar)
+	}
+	a.output = append(a.output, favoriteTable.Render()...)
+	a.output = append(a.output, "")
+}
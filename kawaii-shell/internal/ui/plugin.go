@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// PluginOutputMsg is sent when a plugin command finishes or a plugin is done
+// with an event, with what it printed
+type PluginOutputMsg struct {
+	Plugin string
+	// Command is empty for events
+	Command  string
+	Lines    []string
+	ExitCode int
+	Err      error
+
+	// pane is the one it was run from
+	pane *pane
+}
+
+// handlePluginCommand runs the command in its plugin when a plugin adds it,
+// returning whether it did
+func (a *App) handlePluginCommand(command string) bool {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) == 0 {
+		return false
+	}
+	plugin, pluginCommand, ok := a.plugins.Command(words[0].Value)
+	if !ok {
+		return false
+	}
+	if a.sandbox != nil {
+		// plugins run on their own, the sandbox can't hold them
+		a.output = append(a.output, a.theme.Styles.Warning.Render(
+			"🏖️ "+pluginCommand.Name+" is a plugin command and would run for real, sandbox off lets it"))
+		return true
+	}
+	args := make([]string, 0, len(words)-1)
+	for _, word := range words[1:] {
+		args = append(args, word.Value)
+	}
+
+	p, dir := a.pane, a.localCwd()
+	a.cmds = append(a.cmds, func() tea.Msg {
+		lines, code, err := plugin.Run(pluginCommand.Name, args, dir)
+		return PluginOutputMsg{Plugin: plugin.Name, Command: pluginCommand.Name, Lines: lines, ExitCode: code, Err: err, pane: p}
+	})
+	return true
+}
+
+// emit tells the plugins subscribed to the event about it
+func (a *App) emit(event shell.PluginEvent) tea.Cmd {
+	event.Cwd = a.localCwd()
+	var cmds []tea.Cmd
+	p := a.pane
+	for _, plugin := range a.plugins {
+		if !plugin.Subscribed(event.Event) {
+			continue
+		}
+		cmds = append(cmds, func() tea.Msg {
+			lines, err := plugin.Send(event)
+			return PluginOutputMsg{Plugin: plugin.Name, Lines: lines, Err: err, pane: p}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// emitDone tells the plugins a command finished
+func (a *App) emitDone(command string, took time.Duration, code int) tea.Cmd {
+	return a.emit(shell.PluginEvent{Event: shell.EventDone, Command: command, ExitCode: &code, Duration: took.Seconds()})
+}
+
+// showPluginOutput shows what the plugin printed in the pane it was run
+// from, if it's still open
+func (a *App) showPluginOutput(msg PluginOutputMsg) {
+	active := a.pane
+	defer func() { a.pane = active }()
+	if slices.Contains(a.panes, msg.pane) {
+		a.pane = msg.pane
+	}
+
+	lines := len(a.scrollbackLines())
+	a.showPluginLines(msg.Lines)
+	switch {
+	case msg.Err != nil:
+		a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+	case msg.Command != "" && msg.ExitCode != 0:
+		a.output = append(a.output, a.theme.Styles.ExitFailure.Render(
+			fmt.Sprintf("✘ %s from %s failed with exit code %d", msg.Command, msg.Plugin, msg.ExitCode)))
+		a.reactToExitCode(msg.Command, msg.ExitCode)
+	case msg.Command != "":
+		a.reactToExitCode(msg.Command, 0)
+	}
+	a.keepScroll(lines)
+}
+
+// decorate applies the plugins' decorators to the output lines of the shell
+func (a *App) decorate(lines []string) []string {
+	if len(a.plugins) == 0 {
+		return lines
+	}
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		decorator, ok := a.plugins.Decorator(plain)
+		if !ok {
+			continue
+		}
+		if decorator.Color != "" || decorator.Bold {
+			style := lipgloss.NewStyle().Bold(decorator.Bold)
+			if decorator.Color != "" {
+				style = style.Foreground(lipgloss.Color(decorator.Color))
+			}
+			line = style.Render(plain)
+		}
+		lines[i] = decorator.Prefix + line + decorator.Suffix
+	}
+	return lines
+}
+
+// pluginHelp lists the commands the plugins add, for help
+func (a *App) pluginHelp() []string {
+	var help []string
+	for _, plugin := range a.plugins {
+		for _, command := range plugin.Commands {
+			help = append(help, fmt.Sprintf("🧩 %-9s - %s (%s)", command.Name, command.Description, plugin.Name))
+		}
+	}
+	return help
+}
+
+// localCwd is the working directory of the shell when it's on this
+// computer, plugins run here
+func (a *App) localCwd() string {
+	if a.remote != "" {
+		return ""
+	}
+	return a.cwd
+}
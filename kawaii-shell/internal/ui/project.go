@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// projectWidth is the widest the project widget gets
+const projectWidth = 24
+
+// projectView renders the sidebar widget with the project the shell is in
+// and its git status, empty outside of projects
+func (a *App) projectView() string {
+	var lines []string
+	if a.project != nil {
+		name := a.project.Emoji + " " + a.project.Name
+		if a.project.Language != "" {
+			name += " · " + a.project.Language
+		}
+		lines = append(lines, name)
+	}
+	lines = append(lines, a.gitLines()...)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, projectWidth, "…")
+	}
+	return a.theme.Styles.PetBox.
+		Height(petHeight).
+		Render(a.theme.Styles.Info.Render(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// promptState is what the prompt segments are rendered from
+type promptState struct {
+	cwd      string
+	remote   string
+	sandbox  string
+	git      *shell.GitStatus
+	exitCode int
+	hasExit  bool
+	petEmoji string
+	now      time.Time
+}
+
+// segment renders a part of the prompt, returning an empty string to hide it
+type segment func(cfg config.PromptConfig, state promptState) string
+
+// segments are the prompt segments that can be used in the config file
+var segments = map[string]segment{
+	"cwd":    cwdSegment,
+	"git":    gitSegment,
+	"time":   timeSegment,
+	"status": statusSegment,
+	"pet":    petSegment,
+	"symbol": symbolSegment,
+}
+
+// renderPrompt joins the configured segments, skipping unknown and empty ones
+func renderPrompt(cfg config.PromptConfig, state promptState) string {
+	parts := make([]string, 0, len(cfg.Segments))
+	for _, name := range cfg.Segments {
+		render, ok := segments[name]
+		if !ok {
+			continue
+		}
+		if part := render(cfg, state); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if state.sandbox != "" {
+		// always shown, so it's clear commands don't run for real
+		parts = append([]string{"🏖️ " + state.sandbox}, parts...)
+	}
+	return strings.Join(parts, cfg.Separator) + " "
+}
+
+func cwdSegment(_ config.PromptConfig, state promptState) string {
+	switch {
+	case state.cwd == "":
+		return ""
+	case state.remote != "":
+		return "🌐 " + state.remote + ":" + state.cwd
+	}
+	return "📂 " + shortenPath(state.cwd)
+}
+
+// shortenPath replaces the home directory with ~
+func shortenPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return filepath.Join("~", rest)
+	}
+	return path
+}
+
+func gitSegment(_ config.PromptConfig, state promptState) string {
+	if state.git == nil {
+		return ""
+	}
+	git := "🌿 " + state.git.Branch
+	if state.git.Dirty() {
+		git += "*"
+	}
+	if state.git.Ahead > 0 {
+		git += fmt.Sprintf(" ↑%d", state.git.Ahead)
+	}
+	if state.git.Behind > 0 {
+		git += fmt.Sprintf(" ↓%d", state.git.Behind)
+	}
+	return git
+}
+
+func timeSegment(cfg config.PromptConfig, state promptState) string {
+	return "🕐 " + state.now.Format(cfg.TimeFormat)
+}
+
+func statusSegment(_ config.PromptConfig, state promptState) string {
+	switch {
+	case !state.hasExit:
+		return ""
+	case state.exitCode == 0:
+		return "💖"
+	default:
+		return fmt.Sprintf("💔 %d", state.exitCode)
+	}
+}
+
+func petSegment(_ config.PromptConfig, state promptState) string {
+	return state.petEmoji
+}
+
+func symbolSegment(cfg config.PromptConfig, _ promptState) string {
+	return cfg.Symbol
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// questBarWidth is how wide the progress bars of the quests are
+const questBarWidth = 10
+
+// questsPanel lists the quests of the day in place of the output
+type questsPanel struct{}
+
+// checkQuests cheers for the quests finished since the last tick
+func (a *App) checkQuests(now time.Time) {
+	finished, bonus := a.pets.CheckQuests(now)
+	for _, quest := range finished {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"📜 Quest complete: %s %s! +%d XP for %s", quest.Emoji, quest.Name, quest.XP, a.pet.Name)))
+	}
+	if bonus > 0 {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"🔥 All of today's quests done, %d day streak! +%d XP bonus", a.pets.QuestStreak(now), bonus)))
+	}
+}
+
+// handleQuestsKey handles the keys of the quests panel, nothing else gets
+// them until it's closed
+func (a *App) handleQuestsKey(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc", "q", "enter":
+		a.quests = nil
+	}
+}
+
+// questsView renders the quests of the day with how far along they are
+func (a *App) questsView(height int) string {
+	now := time.Now()
+	cols, _ := a.outputSize()
+	quests := a.pets.TodaysQuests(now)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	lines := []string{a.theme.Styles.Help.Render(fmt.Sprintf("📜 Today's quests • 🔥 %d day streak • new ones in %s",
+		a.pets.QuestStreak(now), strings.TrimSuffix(tomorrow.Sub(now).Round(time.Minute).String(), "0s")))}
+
+	for _, quest := range quests[:min(len(quests), max(a.outputRows(height)-1, 0))] {
+		done, goal := a.pets.QuestProgress(quest)
+		filled := done * questBarWidth / goal
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", questBarWidth-filled)
+		line := fmt.Sprintf("%s %s · %s · %s %d/%d · +%d XP", quest.Emoji, quest.Name, quest.Description, bar, done, goal, quest.XP)
+		if done >= goal {
+			lines = append(lines, a.theme.Styles.ExitSuccess.Render(ansi.Truncate("✔ "+line, cols, "…")))
+			continue
+		}
+		lines = append(lines, ansi.Truncate("  "+line, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// questsInputView renders the help of the quests panel in place of the
+// input
+func (a *App) questsInputView() string {
+	return a.theme.Styles.Prompt.Render("📜 quests") +
+		lipgloss.NewStyle().Faint(true).Render("  finish them all for a streak bonus • esc close")
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// queueName is the name the command queue runs under, like a script
+const queueName = "queue"
+
+// handleQueueCommand runs the queue kawaii commands, returning whether the
+// command was one of them
+func (a *App) handleQueueCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != "queue" {
+		return false
+	}
+	if len(fields) == 1 {
+		a.showQueue()
+		return true
+	}
+
+	switch fields[1] {
+	case "add":
+		// everything after add is the command, quotes and all
+		_, rest, _ := strings.Cut(strings.TrimSpace(command), "add")
+		a.queueCommands([]string{rest})
+	case "run":
+		keepGoing := false
+		for _, flag := range fields[2:] {
+			if flag != "--keep-going" && flag != "-k" {
+				a.output = append(a.output, "🥺 Oops: queue run only knows --keep-going")
+				return true
+			}
+			keepGoing = true
+		}
+		a.runQueue(keepGoing)
+	case "clear":
+		a.queue = nil
+		a.output = append(a.output, a.theme.Styles.Info.Render("🧹 The queue is empty"))
+	case "stop":
+		if a.script == nil {
+			a.output = append(a.output, "🥺 Oops: nothing is running")
+			break
+		}
+		a.stopScript("you stopped it, the command running finishes")
+	default:
+		a.output = append(a.output, "🥺 Oops: try queue add <command>, queue run, queue stop or queue clear")
+	}
+	return true
+}
+
+// queueCommands adds the commands to the queue, or to the end of the queue
+// running
+func (a *App) queueCommands(commands []string) {
+	added := 0
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+		added++
+		if script := a.script; script != nil && script.name == queueName {
+			script.steps = append(script.steps, shell.ScriptStep{Command: command, Line: len(script.steps) + 1})
+			script.progress.Max = float64(len(script.steps))
+			script.progress.Label = fmt.Sprintf("%s %d/%d", script.name, script.next-1, len(script.steps))
+			a.setOutput(script.progressLine, script.progress.Render())
+			a.output = append(a.output, a.theme.Styles.Success.Render(
+				fmt.Sprintf("📋 Queued #%d: %s", len(script.steps), command)))
+			continue
+		}
+		a.queue = append(a.queue, command)
+		a.output = append(a.output, a.theme.Styles.Success.Render(
+			fmt.Sprintf("📋 Queued #%d: %s", len(a.queue), command)))
+	}
+	if added == 0 {
+		a.output = append(a.output, "🥺 Oops: what should I queue? Try queue add make test")
+		return
+	}
+	if a.script == nil && len(a.queue) == added {
+		// a hint the first time around
+		a.output = append(a.output, a.theme.Styles.Help.Render("▶️  queue run runs them one after the other"))
+	}
+}
+
+// showQueue lists the commands waiting in the queue
+func (a *App) showQueue() {
+	if len(a.queue) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			"📋 The queue is empty, queue add <command> adds one or select some in copy mode and press a"))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("📋 Queued commands:"))
+	for i, command := range a.queue {
+		a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf("  %d. %s", i+1, command)))
+	}
+}
+
+// runQueue runs the queued commands one after the other, stopping at the
+// first that fails unless it keeps going
+func (a *App) runQueue(keepGoing bool) {
+	if len(a.queue) == 0 {
+		a.output = append(a.output, "🥺 Oops: the queue is empty, queue add <command> adds one")
+		return
+	}
+	if a.script != nil {
+		a.output = append(a.output, "🥺 Oops: "+a.script.name+" is still running")
+		return
+	}
+
+	steps := make([]shell.ScriptStep, len(a.queue))
+	for i, command := range a.queue {
+		steps[i] = shell.ScriptStep{Command: command, Line: i + 1}
+	}
+	a.queue = nil
+	a.startScript(queueName, "command", steps, keepGoing)
+}
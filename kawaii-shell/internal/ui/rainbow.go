@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+)
+
+// promptRainbow is the rainbow the unicorn paints the prompt in
+var promptRainbow = themes.NewRainbow(lipgloss.NewStyle(),
+	charmtone.Cherry, charmtone.Coral, charmtone.Butter, charmtone.Guac,
+	charmtone.Malibu, charmtone.Grape, charmtone.Pony,
+)
+
+// rainbowPrompt is the unicorn's ability, painting the prompt in the colors
+// of the rainbow
+type rainbowPrompt struct {
+	noAbility
+}
+
+func (rainbowPrompt) name() string {
+	return "🦄 Rainbow prompt, a touch of magic in every command"
+}
+
+func (rainbowPrompt) prompt(prompt string) string {
+	return promptRainbow.Paint(prompt, 0)
+}
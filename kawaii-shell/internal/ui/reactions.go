@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// reactionDirective starts the lines plugins print to have the companion
+// react, like ::pet celebrate 0.8 Build passed!
+const reactionDirective = "::pet "
+
+// triggerReaction has the companion react to news from a plugin or an
+// integration, showing what it has to say about it
+func (a *App) triggerReaction(kind pet.Reaction, intensity float64, message string) {
+	// the message comes from outside, it can't restyle the shell
+	message = strings.Join(strings.Fields(ansi.Strip(message)), " ")
+	if line := a.pet.TriggerReaction(kind, intensity, message); line != "" {
+		a.output = append(a.output, a.petLine(line))
+	}
+}
+
+// showPluginLines shows the lines a plugin printed, the companion reacting
+// to the reaction directives among them
+func (a *App) showPluginLines(lines []string) {
+	for _, line := range lines {
+		text, ok := strings.CutPrefix(line, reactionDirective)
+		if !ok {
+			a.output = append(a.output, line)
+			continue
+		}
+		kind, intensity, message, err := pet.ParseReactionArgs(strings.Fields(text))
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			continue
+		}
+		a.triggerReaction(kind, intensity, message)
+	}
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// RecordedGIFMsg is sent once a recording was turned into a GIF
+type RecordedGIFMsg struct {
+	Path string
+	Err  error
+}
+
+// SetOutput sets the terminal the UI is drawn on, which sessions are
+// recorded from
+func (a *App) SetOutput(out *shell.TerminalOutput) {
+	a.out = out
+}
+
+// terminal returns where to send the bells and sequences that aren't part
+// of the UI: the terminal it's drawn on, so they don't cut into a frame and
+// make it into recordings
+func (a *App) terminal() io.Writer {
+	if a.out == nil {
+		return os.Stdout
+	}
+	return a.out
+}
+
+// handleRecordCommand runs record start [file] and record stop [--gif],
+// returning whether the command was one of them
+func (a *App) handleRecordCommand(command string) bool {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) == 0 || words[0].Value != "record" {
+		return false
+	}
+	args := make([]string, 0, len(words)-1)
+	for _, word := range words[1:] {
+		args = append(args, word.Value)
+	}
+
+	switch {
+	case len(args) == 0:
+		if a.recording == nil {
+			a.output = append(a.output, a.theme.Styles.Info.Render("🎬 Not recording, record start begins"))
+		} else {
+			a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+				"🔴 Recording to %s for %s, record stop ends it", a.recording.Path, a.recording.Duration().Round(time.Second))))
+		}
+	case args[0] == "start" && len(args) <= 2:
+		a.startRecording(args[1:])
+	case args[0] == "stop" && (len(args) == 1 || len(args) == 2 && args[1] == "--gif"):
+		a.stopRecording(len(args) == 2)
+	default:
+		a.output = append(a.output, "🥺 Oops: try record start [file.cast] or record stop [--gif]")
+	}
+	return true
+}
+
+// startRecording records what's on the terminal to an asciicast file, named
+// after the time unless given
+func (a *App) startRecording(args []string) {
+	if a.out == nil {
+		a.output = append(a.output, "🥺 Oops: recording needs a terminal")
+		return
+	}
+	if a.recording != nil {
+		a.output = append(a.output, "🥺 Oops: already recording to "+a.recording.Path)
+		return
+	}
+	path := "kawaii-" + time.Now().Format("20060102-150405") + ".cast"
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.cwd, path)
+	}
+
+	recording, err := shell.StartRecording(path, a.width, a.height)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.recording = recording
+	a.out.Record(recording)
+	a.output = append(a.output, a.theme.Styles.Success.Render("🔴 Recording to "+path+", record stop ends it"))
+	// draw the whole screen again, the recording starts from scratch
+	a.cmds = append(a.cmds, tea.ClearScreen)
+}
+
+// stopRecording saves the recording, and turns it into a GIF if asked to
+func (a *App) stopRecording(gif bool) {
+	recording := a.recording
+	if recording == nil {
+		a.output = append(a.output, "🥺 Oops: nothing is being recorded")
+		return
+	}
+	a.out.Record(nil)
+	a.recording = nil
+	if err := recording.Close(); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"🎬 Saved %s of your session to %s, asciinema play shows it", recording.Duration().Round(time.Second), recording.Path)))
+	if !gif {
+		return
+	}
+
+	path := strings.TrimSuffix(recording.Path, filepath.Ext(recording.Path)) + ".gif"
+	a.output = append(a.output, a.theme.Styles.Info.Render("🎞️  Making a GIF of it..."))
+	a.cmds = append(a.cmds, func() tea.Msg {
+		return RecordedGIFMsg{Path: path, Err: shell.ExportGIF(recording.Path, path)}
+	})
+}
+
+// showRecordedGIF tells how making the GIF went
+func (a *App) showRecordedGIF(msg RecordedGIFMsg) {
+	if msg.Err != nil {
+		a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render("🎞️  Your GIF is ready: "+msg.Path))
+	a.pet.Celebrate()
+}
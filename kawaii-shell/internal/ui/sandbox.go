@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// handleSandboxCommand runs sandbox, sandbox on and sandbox off, returning
+// whether the command was one of them. sandbox followed by a command is
+// left to sandboxCommand.
+func (a *App) handleSandboxCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != "sandbox" || len(fields) > 2 {
+		return false
+	}
+	switch strings.Join(fields[1:], " ") {
+	case "":
+		if a.sandbox == nil {
+			a.output = append(a.output, a.theme.Styles.Info.Render(
+				"🏖️ The sandbox is off, sandbox on tries everything out safely and sandbox <command> just one command"))
+			break
+		}
+		a.output = append(a.output, a.theme.Styles.Info.Render("🏖️ Everything runs in the "+a.sandbox.Tool+" sandbox, sandbox off leaves it"))
+	case "on":
+		if a.remoteOnly("sandbox") {
+			break
+		}
+		sandbox, err := a.findSandbox()
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			break
+		}
+		a.sandbox = sandbox
+		a.output = append(a.output, a.theme.Styles.Success.Render(
+			"🏖️ Sandbox on with "+sandbox.Tool+"! Only "+shortenPath(a.cwd)+" can change and there's no network"))
+	case "off":
+		if a.sandbox == nil {
+			a.output = append(a.output, a.theme.Styles.Info.Render("🏖️ The sandbox is already off"))
+			break
+		}
+		a.sandbox = nil
+		a.output = append(a.output, a.theme.Styles.Info.Render("🏠 Sandbox off, commands run for real again"))
+	default:
+		return false
+	}
+	return true
+}
+
+// sandboxCommand returns the command to send to the shell for the command,
+// wrapped in the sandbox when it's on or the command starts with sandbox.
+// An empty command means it couldn't be.
+func (a *App) sandboxCommand(command string) string {
+	inner, once := strings.CutPrefix(strings.TrimSpace(command), "sandbox ")
+	if !once && a.sandbox == nil {
+		return command
+	}
+	if a.remoteOnly("sandbox") {
+		return ""
+	}
+	sandbox := a.sandbox
+	if once {
+		command = strings.TrimSpace(inner)
+		var err error
+		if sandbox, err = a.findSandbox(); err != nil {
+			a.output = append(a.output, "🥺 Oops: "+err.Error())
+			return ""
+		}
+	}
+	return sandbox.Wrap(command, a.cwd)
+}
+
+// sandboxed reports whether the command runs in the sandbox
+func (a *App) sandboxed(command string) bool {
+	return a.sandbox != nil || strings.HasPrefix(strings.TrimSpace(command), "sandbox ")
+}
+
+// findSandbox finds the sandbox tool set in the config, or any installed one
+func (a *App) findSandbox() (*shell.Sandbox, error) {
+	return shell.FindSandbox(a.config.Sandbox.Tool, a.config.Sandbox.Image)
+}
+
+// sandboxTool is the tool of the sandbox when it's on, for the prompt
+func (a *App) sandboxTool() string {
+	if a.sandbox == nil {
+		return ""
+	}
+	return a.sandbox.Tool
+}
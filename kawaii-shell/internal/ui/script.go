@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+// scriptProgressWidth is the widest the script progress bar gets
+const scriptProgressWidth = 40
+
+// scriptRun is a kawaii script or the command queue being run a command at
+// a time
+type scriptRun struct {
+	name  string
+	steps []shell.ScriptStep
+
+	// unit is what the steps are numbered in, like line for scripts
+	unit string
+
+	// keepGoing carries on with the next commands when one fails
+	keepGoing bool
+	failed    int
+
+	// pane is where the script runs
+	pane *pane
+	next int
+
+	progress     *components.ProgressBar
+	progressLine int
+
+	// stepLine is the output line telling how the current step is going
+	stepLine int
+}
+
+// handleScriptCommand runs kawaii run <script>, returning whether the
+// command was that
+func (a *App) handleScriptCommand(command string) bool {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) < 2 || words[0].Value != "kawaii" || words[1].Value != "run" {
+		return false
+	}
+	if len(words) != 3 {
+		a.output = append(a.output, "🥺 Oops: which script? Try kawaii run deploy"+shell.ScriptExt)
+		return true
+	}
+	if a.script != nil {
+		a.output = append(a.output, "🥺 Oops: "+a.script.name+" is still running")
+		return true
+	}
+
+	path := words[2].Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.cwd, path)
+	}
+	steps, err := shell.LoadScript(path)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return true
+	}
+	if len(steps) == 0 {
+		a.output = append(a.output, "🥺 Oops: "+filepath.Base(path)+" has nothing to run")
+		return true
+	}
+
+	a.startScript(filepath.Base(path), "line", steps, false)
+	return true
+}
+
+// startScript runs the steps one after the other, below a progress bar in
+// the colors of the theme
+func (a *App) startScript(name, unit string, steps []shell.ScriptStep, keepGoing bool) {
+	progress := components.NewProgressBar(name, 0, 0, max(min(scriptProgressWidth, a.width-8), 10), float64(len(steps)))
+	progress.Style = a.theme.GradientBorder(progress.Style.UnsetBackground(), time.Now())
+	a.script = &scriptRun{
+		name:      name,
+		steps:     steps,
+		unit:      unit,
+		keepGoing: keepGoing,
+		pane:      a.pane,
+		progress:  progress,
+	}
+	a.script.progressLine = len(a.output)
+	a.output = append(a.output, progress.Render())
+	a.advanceScript()
+}
+
+// advanceScript starts the next commands of the script, until one of them
+// runs in the shell and its exit code has to be waited for
+func (a *App) advanceScript() {
+	for a.script != nil && a.pendingCommand < 0 && a.danger == nil && a.guarded == nil {
+		script := a.script
+		script.progress.SetProgress(float64(script.next))
+		script.progress.Label = fmt.Sprintf("%s %d/%d", script.name, script.next, len(script.steps))
+		a.paintProgress(script.progress, time.Now())
+		a.setOutput(script.progressLine, script.progress.Render())
+
+		if script.next == len(script.steps) {
+			a.finishScript()
+			return
+		}
+
+		step := script.steps[script.next]
+		script.next++
+		script.stepLine = len(a.output)
+		a.output = append(a.output, a.stepStatus("⏳", ""))
+		if !a.executeCommand(a.aliases().Expand(step.Command)) {
+			a.setOutput(script.stepLine, a.stepStatus("⛔", ""))
+			a.stopScript(fmt.Sprintf("%s %d wasn't run", script.unit, step.Line))
+			return
+		}
+	}
+}
+
+// stepStatus renders the line telling how the current step of the script
+// went
+func (a *App) stepStatus(emoji, indicator string) string {
+	script := a.script
+	step := script.steps[script.next-1]
+	annotation := step.Annotation
+	if annotation == "" {
+		annotation = strings.SplitN(step.Command, "\n", 2)[0]
+	}
+	status := a.theme.Styles.Help.Render(fmt.Sprintf("%s [%d/%d] %s", emoji, script.next, len(script.steps), annotation))
+	if indicator != "" {
+		status += " " + indicator
+	}
+	return status
+}
+
+// scriptStepDone continues the script once its command finished, or stops it
+// when the command failed unless it keeps going
+func (a *App) scriptStepDone(code int) {
+	script := a.script
+	if script == nil || script.pane != a.pane {
+		return
+	}
+	if script.stepLine < len(a.output) {
+		if code == 0 {
+			a.setOutput(script.stepLine, a.stepStatus("✅", a.theme.Styles.ExitSuccess.Render("✔")))
+		} else {
+			a.setOutput(script.stepLine, a.stepStatus("❌", a.theme.Styles.ExitFailure.Render(fmt.Sprintf("✘ %d", code))))
+		}
+	}
+	if code != 0 {
+		script.failed++
+		if !script.keepGoing {
+			step := script.steps[script.next-1]
+			a.stopScript(fmt.Sprintf("%s %d failed with exit code %d", script.unit, step.Line, code))
+			return
+		}
+	}
+	a.advanceScript()
+}
+
+// finishScript tells how the script went once all its steps ran
+func (a *App) finishScript() {
+	script := a.script
+	a.script = nil
+	if script.failed == 0 {
+		a.output = append(a.output, a.theme.Styles.Success.Render("🎉 "+script.name+" finished, yay!"))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Error.Render(
+		fmt.Sprintf("💔 %s finished, %d of %d failed", script.name, script.failed, len(script.steps))))
+}
+
+// stopScript stops the script before its end
+func (a *App) stopScript(reason string) {
+	if a.script == nil {
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Error.Render("💔 "+a.script.name+" stopped, "+reason))
+	a.script = nil
+}
@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// wheelLines is how far a turn of the mouse wheel scrolls
+const wheelLines = 3
+
+// scrollSearch is the search through the scrollback started with /
+type scrollSearch struct {
+	query string
+
+	// match is the scrollback line of the current match, -1 when there's none
+	match int
+}
+
+// scrollbackLines returns every line of the output, including the one being
+// written. Only the output that came in since the last call is split, the
+// lines are shared with the next call and aren't to be changed.
+func (a *App) scrollbackLines() []string {
+	for _, entry := range a.output[len(a.lineStarts):] {
+		a.lineStarts = append(a.lineStarts, len(a.lines))
+		a.lines = append(a.lines, strings.Split(entry, "\n")...)
+	}
+	current := a.screen.Current()
+	if a.foreground != nil {
+		current = a.foreground.Current()
+	}
+	if current != "" {
+		// the line being written, such as a progress bar or the shell prompt
+		return append(a.lines, current)
+	}
+	return a.lines
+}
+
+// setOutput replaces an entry of the output, which gets split into lines
+// again along with the ones after it
+func (a *App) setOutput(i int, entry string) {
+	a.output[i] = entry
+	if i < len(a.lineStarts) {
+		a.lines = a.lines[:a.lineStarts[i]]
+		a.lineStarts = a.lineStarts[:i]
+	}
+}
+
+// trimScrollback drops the oldest output once there are more lines than the
+// scrollback keeps. The lines searching and copy mode are on would move, so
+// it waits for them to close, and the running command and script keep
+// their lines.
+func (a *App) trimScrollback() {
+	limit := a.config.Scrollback
+	if limit <= 0 || a.search != nil || a.copy != nil {
+		return
+	}
+	a.scrollbackLines()
+	// going a quarter past the limit keeps from trimming on every line
+	if len(a.lines) <= limit+limit/4 {
+		return
+	}
+
+	// the latest entry stays even when it's longer than the limit
+	drop := sort.Search(len(a.lineStarts)-1, func(i int) bool {
+		return len(a.lines)-a.lineStarts[i] <= limit
+	})
+	script := a.script
+	if script != nil && script.pane != a.pane {
+		script = nil
+	}
+	if a.pendingCommand >= 0 {
+		drop = min(drop, a.pendingCommand)
+	}
+	if script != nil {
+		drop = min(drop, script.progressLine, script.stepLine)
+	}
+	if drop <= 0 {
+		return
+	}
+
+	// copied so the dropped output can be let go of
+	dropped := a.lineStarts[drop]
+	a.output = slices.Clone(a.output[drop:])
+	a.lines = slices.Clone(a.lines[dropped:])
+	starts := make([]int, len(a.lineStarts)-drop)
+	for i, start := range a.lineStarts[drop:] {
+		starts[i] = start - dropped
+	}
+	a.lineStarts = starts
+	if a.pendingCommand >= 0 {
+		a.pendingCommand -= drop
+	}
+	if script != nil {
+		script.progressLine -= drop
+		script.stepLine -= drop
+	}
+}
+
+// outputRows returns the number of output lines that fit in the output box
+func (a *App) outputRows(height int) int {
+	box := a.theme.Styles.OutputBox
+	return max(height-box.GetVerticalBorderSize()-box.GetVerticalPadding(), 1)
+}
+
+// scrollBy scrolls the output back by the given number of lines, or forward
+// when it's negative. Scrolling all the way forward follows the output again.
+func (a *App) scrollBy(lines int) {
+	total := len(a.scrollbackLines())
+	limit := max(total-a.outputRows(a.outputBoxHeight()), 0)
+	a.scroll = min(max(a.scroll+lines, 0), limit)
+}
+
+// keepScroll keeps the scrolled back output in place while new lines come
+// in, and the output under the copy mode cursor too
+func (a *App) keepScroll(before int) {
+	if a.scroll > 0 || a.copy != nil {
+		a.scrollBy(len(a.scrollbackLines()) - before)
+	}
+}
+
+// handleScrollKey handles the keys paging through the output, reporting
+// whether the key was one of them
+func (a *App) handleScrollKey(msg tea.KeyMsg) bool {
+	page := max(a.outputRows(a.outputBoxHeight())-1, 1)
+	switch msg.String() {
+	case "pgup":
+		a.scrollBy(page)
+	case "pgdown":
+		a.scrollBy(-page)
+	case "shift+up":
+		a.scrollBy(1)
+	case "shift+down":
+		a.scrollBy(-1)
+	case "ctrl+f":
+		a.search = &scrollSearch{match: -1}
+	case "ctrl+g":
+		a.openFilter("")
+	case "ctrl+o":
+		a.startCopyMode()
+	case "/":
+		// typing a path is more common than searching, so / only searches
+		// while reading the scrollback
+		if a.scroll == 0 {
+			return false
+		}
+		a.search = &scrollSearch{match: -1}
+	default:
+		return false
+	}
+	return true
+}
+
+// handleScrollMouse scrolls the output with the mouse wheel
+func (a *App) handleScrollMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		a.scrollBy(wheelLines)
+	case tea.MouseButtonWheelDown:
+		a.scrollBy(-wheelLines)
+	}
+}
+
+// handleSearchKey handles keys while searching, the query takes the typing
+// until the search is closed
+func (a *App) handleSearchKey(msg tea.KeyMsg) {
+	search := a.search
+	switch msg.String() {
+	case "esc":
+		a.search = nil
+	case "enter", "up", "ctrl+p":
+		a.findMatch(search.match-1, -1)
+	case "down", "ctrl+n":
+		a.findMatch(search.match+1, 1)
+	case "backspace":
+		if search.query != "" {
+			_, size := utf8.DecodeLastRuneInString(search.query)
+			search.query = search.query[:len(search.query)-size]
+			a.findMatch(-1, -1)
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			search.query += string(msg.Runes)
+			// searching again from the bottom finds the latest match
+			a.findMatch(-1, -1)
+		}
+	}
+}
+
+// findMatch moves to the next line matching the query, starting from the
+// given line and going in the given direction. Starting from -1 going up
+// starts from the bottom. It wraps around at either end.
+func (a *App) findMatch(from, direction int) {
+	search := a.search
+	lines := a.scrollbackLines()
+	if search.query == "" || len(lines) == 0 {
+		search.match = -1
+		return
+	}
+	if from < 0 {
+		from = len(lines) - 1
+	}
+	query := strings.ToLower(search.query)
+	for i := range len(lines) {
+		line := ((from+direction*i)%len(lines) + len(lines)) % len(lines)
+		if strings.Contains(strings.ToLower(ansi.Strip(lines[line])), query) {
+			search.match = line
+			// center the match in the output box
+			rows := a.outputRows(a.outputBoxHeight())
+			a.scroll = 0
+			a.scrollBy(len(lines) - 1 - line - rows/2)
+			return
+		}
+	}
+	search.match = -1
+}
+
+// visibleOutput returns the output lines shown in a box of the given height,
+// with the search matches highlighted
+func (a *App) visibleOutput(height int) string {
+	lines := a.scrollbackLines()
+	rows := a.outputRows(height)
+	end := max(len(lines)-a.scroll, 0)
+	start := max(end-rows, 0)
+	// the lines are shared, the highlights and the indicator go on a copy
+	visible := slices.Clone(lines[start:end])
+
+	switch {
+	case a.search != nil && a.search.query != "":
+		for i, line := range visible {
+			visible[i] = a.highlightMatches(line, start+i == a.search.match)
+		}
+	case a.copy != nil:
+		for i, line := range visible {
+			visible[i] = a.copyModeLine(start+i, line)
+		}
+	}
+	if a.scroll > 0 && len(visible) > 0 {
+		// replace the last line with where we are
+		indicator := fmt.Sprintf("📜 %d lines back", a.scroll)
+		if a.copy == nil {
+			indicator += " • pgdown to go back down"
+		}
+		if a.search == nil && a.copy == nil {
+			indicator += " • / to search"
+		}
+		visible[len(visible)-1] = a.theme.Styles.Info.Render(indicator)
+	}
+	return strings.Join(visible, "\n")
+}
+
+// highlightMatches highlights where the query appears in the line. The
+// line's own colors are dropped when it has matches, they would get in the
+// way of the highlights.
+func (a *App) highlightMatches(line string, current bool) string {
+	plain := ansi.Strip(line)
+	lower := strings.ToLower(plain)
+	query := strings.ToLower(a.search.query)
+	if len(lower) != len(plain) || len(query) != len(a.search.query) {
+		// lowercasing changed the offsets, match the case as typed
+		lower, query = plain, a.search.query
+	}
+	if !strings.Contains(lower, query) {
+		return line
+	}
+
+	style := a.theme.Styles.Highlight
+	if current {
+		style = style.Reverse(true)
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(plain)
+			return b.String()
+		}
+		b.WriteString(plain[:i])
+		b.WriteString(style.Render(plain[i : i+len(query)]))
+		plain = plain[i+len(query):]
+		lower = lower[i+len(query):]
+	}
+}
+
+// searchView renders the search bar shown in place of the input
+func (a *App) searchView() string {
+	status := "no matches"
+	if a.search.query == "" {
+		status = "type to search"
+	} else if a.search.match >= 0 {
+		status = "enter/↑ older • ↓ newer"
+	}
+	return a.theme.Styles.Prompt.Render("🔍 /") +
+		a.theme.Styles.Input.Render(a.search.query) +
+		a.theme.Styles.Cursor.Render(" ") +
+		lipgloss.NewStyle().Faint(true).Render("  "+status+" • esc to close")
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// SoundPlayedMsg is sent once a pet sound finished playing
+type SoundPlayedMsg struct {
+	Err error
+}
+
+// playSound has the pet be heard, when sounds are on and the shell isn't
+// quiet
+func (a *App) playSound(sound shell.Sound) {
+	how := a.config.Pet.Sounds
+	if a.quiet || how == "" || how == "off" || a.petSettings.Settings.Verbosity == pet.VerbosityQuiet {
+		return
+	}
+	terminal := a.terminal()
+	a.cmds = append(a.cmds, func() tea.Msg {
+		return SoundPlayedMsg{Err: shell.PlaySound(terminal, how, sound)}
+	})
+}
+
+// cheerLevelUp is heard when the companion levels up
+func (a *App) cheerLevelUp() {
+	if a.pet.LeveledUp() {
+		a.playSound(shell.SoundLevelUp)
+	}
+}
+
+// toggleQuiet turns the quiet mode, where the shell makes no sound, on or
+// off
+func (a *App) toggleQuiet() {
+	a.quiet = !a.quiet
+	if a.quiet {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🤫 Shh... quiet mode is on, no bells and no pet sounds"))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("🔔 Quiet mode is off, sounds are back as configured"))
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// sshCommand turns kawaii ssh [options] host into the command opening the
+// session, reporting whether the command was that. An empty command means it
+// couldn't be opened.
+func (a *App) sshCommand(command string) (string, bool) {
+	words, ok := shell.SplitWords(command)
+	if !ok || len(words) < 2 || words[0].Value != "kawaii" || words[1].Value != "ssh" {
+		return "", false
+	}
+	args := make([]string, 0, len(words)-2)
+	for _, word := range words[2:] {
+		args = append(args, word.Value)
+	}
+	ssh, err := a.shell.SSHCommand(args)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return "", true
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(
+		"🌐 Off to "+args[len(args)-1]+"! I'll come along, exit brings us back home"))
+	return ssh, true
+}
+
+// remoteOnly refuses kawaii commands that only know about this computer
+// while the shell is on another host, reporting whether it did
+func (a *App) remoteOnly(name string) bool {
+	if a.remote == "" {
+		return false
+	}
+	a.output = append(a.output, "🥺 Oops: "+name+" only works on this computer, we're on "+a.remote+" right now")
+	return true
+}
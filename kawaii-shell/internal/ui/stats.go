@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+const (
+	// statsRows is the number of commands in the tables of the dashboard
+	statsRows = 5
+
+	// statsCommandWidth is where long commands get cut in the tables
+	statsCommandWidth = 40
+)
+
+// recordStats adds the finished command to the statistics
+func (a *App) recordStats(command string, started time.Time, took time.Duration, code int) {
+	if a.stats == nil {
+		return
+	}
+	err := a.stats.Record(shell.StatsEntry{
+		Command:  command,
+		Started:  started,
+		Duration: took,
+		ExitCode: code,
+	})
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+}
+
+// showStats renders the dashboard of the commands run so far: the most used
+// ones, the slowest ones and the hours of the day they were run at
+func (a *App) showStats() {
+	if a.stats == nil {
+		return
+	}
+	summary := a.stats.Summarize(statsRows)
+	if summary.Total == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("📊 No commands yet, run a few and come back!"))
+		return
+	}
+
+	help := a.theme.Styles.Help
+	info := a.theme.Styles.Info
+	header := info.Bold(true)
+
+	a.output = append(a.output,
+		"",
+		help.Render("🌸 ✨ Kawaii Shell Stats ✨ 🌸"),
+		"",
+		info.Render(fmt.Sprintf("📊 %d commands in %s, %d of them failed",
+			summary.Total, formatDuration(summary.TotalTime), summary.Failed)),
+		"",
+		help.Render("🏆 Top commands"),
+	)
+	top := components.NewTable([]string{"#", "Command", "Runs", ""}, header, info)
+	for i, command := range summary.Top {
+		bar := strings.Repeat("█", max(command.Count*20/summary.Top[0].Count, 1))
+		top.AddRow(fmt.Sprint(i+1), command.Name, fmt.Sprint(command.Count), bar)
+	}
+	a.output = append(a.output, top.Render()...)
+
+	a.output = append(a.output, "", help.Render("🐢 Slowest commands"))
+	slowest := components.NewTable([]string{"#", "Command", "Took", "When"}, header, info)
+	slowest.MaxWidth = statsCommandWidth
+	for i, entry := range summary.Slowest {
+		slowest.AddRow(fmt.Sprint(i+1), entry.Command, formatDuration(entry.Duration),
+			entry.Started.Local().Format("Jan 2 15:04"))
+	}
+	a.output = append(a.output, slowest.Render()...)
+
+	byHour := make([]float64, len(summary.ByHour))
+	busiest := 0
+	for hour, count := range summary.ByHour {
+		byHour[hour] = float64(count)
+		if count > summary.ByHour[busiest] {
+			busiest = hour
+		}
+	}
+	a.output = append(a.output,
+		"",
+		help.Render(fmt.Sprintf("🕐 Usage by hour, busiest at %02d:00", busiest)),
+		components.NewSparkline(byHour, info).Render(),
+		info.Render("0     6     12    18   23"),
+		"",
+	)
+}
+
+// formatDuration rounds the duration to what's worth showing
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
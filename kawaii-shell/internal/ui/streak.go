@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// cheerStreak tells how the streak went on the first command of the day.
+// A broken streak is nothing to feel bad about, the pet is just happy to
+// see you again.
+func (a *App) cheerStreak() {
+	day, ok := a.pets.StreakNews()
+	if !ok {
+		return
+	}
+	name := a.pet.Name
+	if day.Broken > 0 {
+		a.output = append(a.output, a.petLine(fmt.Sprintf(
+			"💕 Welcome back! %s missed you. Our %d day streak is safe in the memory book, let's start a new one together",
+			name, day.Broken)))
+		return
+	}
+	if day.Streak < 2 {
+		return
+	}
+	if day.Rested {
+		a.output = append(a.output, a.petLine(fmt.Sprintf(
+			"💤 Hope you had a nice day off! %s kept the streak warm for you", name)))
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+		"📅 Day %d of our streak! +%d XP and +%d 💖 for %s", day.Streak, day.XP, day.Happiness, name)))
+}
+
+// streakView renders the streak for the pet box, empty until it's at least
+// two days long
+func (a *App) streakView(now time.Time) string {
+	streak := a.pets.DayStreak(now)
+	if streak < 2 {
+		return ""
+	}
+	return a.petText(fmt.Sprintf("📅 %d days", streak))
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+)
+
+const (
+	// systemInterval is how often the robot checks the CPU and memory
+	systemInterval = 2 * time.Second
+
+	// systemHistory is how many CPU readings the sparkline shows
+	systemHistory = 8
+)
+
+// systemMonitor is the robot's ability, keeping an eye on how busy the CPU
+// is and how much memory is used. It shows nothing where the system doesn't
+// tell, only Linux does.
+type systemMonitor struct {
+	noAbility
+
+	checkedAt time.Time
+	times     shell.CPUTimes
+	cpu       []float64
+
+	used, total uint64
+	ok          bool
+}
+
+func (m *systemMonitor) name() string {
+	return "🤖 System monitor, CPU and memory next to the pet"
+}
+
+func (m *systemMonitor) tick(_ *App, now time.Time) {
+	if now.Sub(m.checkedAt) < systemInterval {
+		return
+	}
+	m.checkedAt = now
+	times, ok := shell.ReadCPUTimes()
+	if ok && m.times.Total > 0 {
+		m.cpu = append(m.cpu, times.Usage(m.times))
+		m.cpu = m.cpu[max(len(m.cpu)-systemHistory, 0):]
+	}
+	m.times = times
+	m.used, m.total, m.ok = shell.ReadMemory()
+}
+
+func (m *systemMonitor) view(a *App) []string {
+	if !m.ok || len(m.cpu) == 0 {
+		return nil
+	}
+	spark := components.NewSparkline(append([]float64{1}, m.cpu...), lipgloss.NewStyle())
+	// the 1 up front keeps the scale at 100%, it's cut off again
+	bars := []rune(spark.Render())
+	return []string{
+		"🤖 System",
+		fmt.Sprintf("⚙️ CPU %3.0f%% %s", m.cpu[len(m.cpu)-1]*100, string(bars[1:])),
+		fmt.Sprintf("🧠 RAM %3.0f%% %s/%s", float64(m.used)/float64(m.total)*100, gigabytes(m.used), gigabytes(m.total)),
+	}
+}
+
+// gigabytes formats the bytes in gigabytes, like 7.8G
+func gigabytes(bytes uint64) string {
+	return fmt.Sprintf("%.1fG", float64(bytes)/(1<<30))
+}
@@ -0,0 +1,17 @@
+package ui
+
+import "github.com/pcstyle/kawaii-shell/internal/ui/pet"
+
+// petText returns what the pet has to say, without the emoji when the pet
+// is text only
+func (a *App) petText(s string) string {
+	if a.pet.TextOnly() {
+		return pet.Plain(s)
+	}
+	return s
+}
+
+// petLine renders a line of what the pet has to say for the output
+func (a *App) petLine(s string) string {
+	return a.theme.Styles.Pet.Render(a.petText(s))
+}
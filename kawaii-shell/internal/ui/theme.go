@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"go/parser"
+	"go/token"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+	"github.com/pcstyle/kawaii-shell/internal/ui/components"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// ThemeInstalledMsg is sent once theme install is done, with the theme or
+// why it couldn't be installed
+type ThemeInstalledMsg struct {
+	Theme *themes.KawaiiTheme
+	Err   error
+}
+
+// TerminalColorsMsg is sent once the terminal's colors were synced to the
+// theme
+type TerminalColorsMsg struct {
+	Err error
+}
+
+// syncTerminalColors gives the terminal the background and the foreground
+// of the theme once it changed, with sync_background on
+func (a *App) syncTerminalColors() {
+	if !a.config.SyncBackground || a.theme == a.syncedTheme {
+		return
+	}
+	a.syncedTheme = a.theme
+	bg, fg := a.theme.TerminalColors()
+	terminal := a.terminal()
+	a.cmds = append(a.cmds, func() tea.Msg {
+		return TerminalColorsMsg{Err: shell.SetTerminalColors(terminal, bg, fg)}
+	})
+}
+
+// RestoreTerminalColors gives the terminal its own colors back once the
+// shell is done, when they were synced to the theme
+func (a *App) RestoreTerminalColors() error {
+	if a.syncedTheme == nil {
+		return nil
+	}
+	return shell.ResetTerminalColors(a.terminal())
+}
+
+// styleComponents has the components take the focus look and the roles of
+// the theme, whichever it is now
+func (a *App) styleComponents() {
+	components.SetFocusStyles(a.theme.Styles.Focus, a.theme.Styles.FocusRing)
+	components.SetRoles(a.theme.Roles)
+}
+
+// dressUp dresses the themes, the particles and the pets up for the season,
+// or takes the costumes off for nil
+func dressUp(season *themes.Season) {
+	themes.SetSeason(season)
+	if season == nil {
+		components.SetSeasonParticles(nil)
+		pet.SetSeason("")
+		return
+	}
+	components.SetSeasonParticles(season.Particles)
+	pet.SetSeason(season.ID)
+}
+
+// themePicker lists the themes under the input, the shell looking like
+// the selected one until the pick is made or called off
+type themePicker struct {
+	themes   []*themes.KawaiiTheme
+	selected int
+
+	// before is the theme to go back to when the pick is called off
+	before *themes.KawaiiTheme
+}
+
+// handleThemeCommand runs theme, which opens the picker, theme gallery,
+// theme auto, theme install <url|name>, theme surprise, theme save <name>,
+// theme export-fang <name> [file] and theme <name>, returning false for
+// other commands
+func (a *App) handleThemeCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) >= 2 && fields[0] == "theme" && fields[1] == "export-fang" {
+		a.exportFangTheme(fields[2:])
+		return true
+	}
+	if len(fields) == 3 && fields[0] == "theme" && fields[1] == "install" {
+		a.installTheme(fields[2])
+		return true
+	}
+	if len(fields) == 3 && fields[0] == "theme" && fields[1] == "save" {
+		a.saveSurprise(fields[2])
+		return true
+	}
+	if len(fields) == 0 || fields[0] != "theme" || len(fields) > 2 {
+		return false
+	}
+	switch {
+	case len(fields) == 1:
+		a.openThemePicker()
+		return true
+	case fields[1] == "gallery":
+		a.openThemeGallery()
+		return true
+	case fields[1] == "auto":
+		a.resumeThemeSchedule()
+		return true
+	case fields[1] == "install":
+		a.output = append(a.output, "🥺 Oops: theme install needs a URL or the name of a theme")
+		return true
+	case fields[1] == "surprise":
+		a.surpriseTheme()
+		return true
+	case fields[1] == "save":
+		a.output = append(a.output, "🥺 Oops: theme save needs a name for the theme")
+		return true
+	}
+	theme := themes.GetTheme(fields[1])
+	if theme == nil {
+		a.output = append(a.output, "🥺 Oops: there's no "+fields[1]+" theme, theme lists them")
+		return true
+	}
+	a.pickTheme(theme)
+	return true
+}
+
+// installTheme downloads the theme in the background
+func (a *App) installTheme(source string) {
+	a.output = append(a.output, a.theme.Styles.Info.Render("🎨 Fetching "+source+"..."))
+	registry, dir := a.config.ThemeRegistry, filepath.Join(config.Dir(), "themes")
+	a.cmds = append(a.cmds, func() tea.Msg {
+		theme, err := themes.Install(source, registry, dir)
+		return ThemeInstalledMsg{Theme: theme, Err: err}
+	})
+}
+
+// showInstalledTheme adds the installed theme to the others, or tells why
+// it couldn't be installed
+func (a *App) showInstalledTheme(msg ThemeInstalledMsg) {
+	if msg.Err != nil {
+		a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		return
+	}
+	themes.AddTheme(msg.Theme)
+	a.output = append(a.output, a.theme.Styles.Success.Render(
+		"🎨 Installed "+msg.Theme.Name+", theme "+msg.Theme.ID()+" switches to it"))
+}
+
+// surpriseTheme has the shell look like a theme made up on the spot, which
+// is kept until the shell exits unless it's saved
+func (a *App) surpriseTheme() {
+	file := themes.Surprise(rand.New(rand.NewSource(time.Now().UnixNano())))
+	theme, err := file.Theme("surprise")
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.surprise = &file
+	a.theme = theme.Dressed()
+	if a.themeSchedule != nil {
+		a.themeSchedule.override = true
+	}
+	a.output = append(a.output,
+		a.theme.Styles.Success.Render("🎁 Surprise! Say hi to "+theme.Name),
+		a.theme.Styles.Help.Render("🎁 theme save <name> keeps it, theme surprise makes up another one"))
+}
+
+// saveSurprise saves the last surprise theme with the other theme files
+// under the name, and keeps it on
+func (a *App) saveSurprise(name string) {
+	if a.surprise == nil {
+		a.output = append(a.output, "🥺 Oops: there's no surprise theme to save, theme surprise makes one up")
+		return
+	}
+	id := strings.ToLower(name)
+	if err := a.surprise.Save(filepath.Join(config.Dir(), "themes"), id); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	theme, err := a.surprise.Theme(id)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.surprise = nil
+	themes.AddTheme(theme)
+	a.output = append(a.output, a.theme.Styles.Info.Render("🎨 Saved "+theme.Name+" as the "+id+" theme"))
+	a.pickTheme(theme.Dressed())
+}
+
+// exportFangTheme writes the theme by its name as a fang color scheme to
+// the file, or to <name>-fang.json: Go code declaring it for .go files and
+// JSON otherwise
+func (a *App) exportFangTheme(args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		a.output = append(a.output, "🥺 Oops: theme export-fang needs the name of a theme, and maybe the file to write")
+		return
+	}
+	theme := themes.GetTheme(args[0])
+	if theme == nil {
+		a.output = append(a.output, "🥺 Oops: there's no "+args[0]+" theme, theme lists them")
+		return
+	}
+	path := theme.ID() + "-fang.json"
+	if len(args) == 2 {
+		path = args[1]
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.cwd, path)
+	}
+	var data []byte
+	var err error
+	if filepath.Ext(path) == ".go" {
+		data, err = theme.ExportFangGo(goPackage(filepath.Dir(path)))
+	} else {
+		data, err = theme.ExportFangJSON()
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render("🦇 Exported "+theme.Name+" as a fang color scheme to "+path))
+}
+
+// goPackage returns the package of the Go files in the directory, main
+// when there are none
+func goPackage(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err == nil && !strings.HasSuffix(f.Name.Name, "_test") {
+			return f.Name.Name
+		}
+	}
+	return "main"
+}
+
+// openThemePicker opens the picker on the current theme
+func (a *App) openThemePicker() {
+	picker := &themePicker{themes: themes.GetThemes(), before: a.theme}
+	for i, theme := range picker.themes {
+		if theme.ID() == a.theme.ID() {
+			picker.selected = i
+		}
+	}
+	a.themePicker = picker
+}
+
+// handleThemePickerKey handles the keys of the theme picker, previewing
+// the selected theme. Nothing else gets them until it's closed.
+func (a *App) handleThemePickerKey(msg tea.KeyMsg) {
+	picker := a.themePicker
+	switch msg.String() {
+	case "esc", "q":
+		a.theme = picker.before
+		a.themePicker = nil
+		return
+	case "up", "k", "ctrl+p", "shift+tab":
+		picker.selected--
+	case "down", "j", "ctrl+n", "tab":
+		picker.selected++
+	case "enter", " ":
+		a.themePicker = nil
+		a.pickTheme(picker.themes[picker.selected])
+		return
+	}
+	picker.selected = (picker.selected + len(picker.themes)) % len(picker.themes)
+	a.theme = picker.themes[picker.selected]
+}
+
+// pickTheme has the shell look like the theme from now on, saving it in
+// the config. The theme schedule keeps it until it switches next.
+func (a *App) pickTheme(theme *themes.KawaiiTheme) {
+	a.theme = theme
+	if a.themeSchedule != nil {
+		a.themeSchedule.override = true
+	}
+	a.config.Theme = theme.ID()
+	if err := config.SaveTheme(a.config.Theme); err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render("🎨 Say hi to "+theme.Name+"!"))
+	if a.themeSchedule != nil {
+		a.output = append(a.output, a.theme.Styles.Help.Render("🎨 It stays until the schedule switches next, theme auto goes back to it"))
+	}
+}
+
+// themePickerView renders the themes under the input, the selected one
+// highlighted in its own colors
+func (a *App) themePickerView() string {
+	input := a.theme.Styles.Input
+	itemStyle := lipgloss.NewStyle().
+		Foreground(input.GetForeground()).
+		Background(input.GetBackground()).
+		Padding(0, 1)
+	rows := make([]string, len(a.themePicker.themes))
+	for i, theme := range a.themePicker.themes {
+		if i == a.themePicker.selected {
+			rows[i] = a.theme.Styles.Highlight.Padding(0, 1).Render("▶ " + theme.Name)
+			continue
+		}
+		rows[i] = itemStyle.Render("  " + theme.Name)
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(a.theme.Roles.Accent).
+		BorderBackground(input.GetBackground()).
+		Background(input.GetBackground()).
+		MarginLeft(lipgloss.Width(a.theme.Styles.Prompt.Render(a.prompt))).
+		Render(strings.Join(rows, "\n"))
+}
+
+// themePickerInputView renders the help of the theme picker in place of
+// the input
+func (a *App) themePickerInputView() string {
+	return a.theme.Styles.Prompt.Render("🎨 theme") +
+		lipgloss.NewStyle().Faint(true).Render("  ↑↓ preview • enter to keep it • esc go back")
+}
+
+// titleView renders the title in the colors of the theme's gradient,
+// flowing along when it's rendered again
+func (a *App) titleView(title string) string {
+	return a.theme.Styles.Help.Render(a.theme.GradientText(title, a.theme.Styles.Help, time.Now()))
+}
+
+// paintProgress colors the filled part of the bar along the theme's
+// gradient, the whole of it showing once the bar is full
+func (a *App) paintProgress(bar *components.ProgressBar, now time.Time) {
+	bar.Colors = a.theme.GradientAt(bar.Width-4, now)
+}
+
+// downsample turns the colors of the view into the nearest ones the
+// terminal can show, or takes them out on terminals without colors
+func (a *App) downsample(view string) string {
+	if a.profile == colorprofile.TrueColor || a.profile == colorprofile.NoTTY {
+		return view
+	}
+	var b strings.Builder
+	w := colorprofile.Writer{Forward: &b, Profile: a.profile}
+	_, _ = w.WriteString(view)
+	return b.String()
+}
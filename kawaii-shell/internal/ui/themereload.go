@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"cmp"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+)
+
+// ThemeFilesChangedMsg is sent when the config file or a theme file
+// changed, or with why they're not watched anymore
+type ThemeFilesChangedMsg struct {
+	Change config.Change
+	Err    error
+}
+
+// watchThemeFiles waits for the config file or a theme file to change in
+// the background, nil when they're not watched
+func (a *App) watchThemeFiles() tea.Cmd {
+	w := a.themeWatcher
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		change, err := w.Next()
+		return ThemeFilesChangedMsg{Change: change, Err: err}
+	}
+}
+
+// reloadThemeFiles loads the config and the theme files again once they
+// changed, the shell taking the new look of its theme on the next frame.
+// Whatever's wrong in them is told, and stays as it was until it's fixed.
+func (a *App) reloadThemeFiles(msg ThemeFilesChangedMsg) tea.Cmd {
+	if msg.Err != nil {
+		a.output = append(a.output, "🥺 Oops: "+msg.Err.Error())
+		return nil
+	}
+	cfg := a.config
+	if msg.Change.Config {
+		loaded, err := config.Load()
+		if err != nil {
+			a.output = append(a.output, "🥺 Oops! Couldn't reload config: "+err.Error())
+		} else {
+			cfg = loaded
+		}
+	}
+	if !msg.Change.Themes && reflect.DeepEqual(cfg, a.config) {
+		// like the config saved by picking a theme
+		return a.watchThemeFiles()
+	}
+
+	// the terminal isn't asked for its background again while the shell
+	// runs, so going back to asking keeps the themes as they are
+	if cfg.Background != a.config.Background && cfg.Background != "" {
+		themes.SetLight(cfg.Background == "light")
+	}
+	if cfg.Seasons != a.config.Seasons {
+		var season *themes.Season
+		if cfg.Seasons {
+			season = themes.SeasonAt(time.Now())
+		}
+		dressUp(season)
+	}
+	if cfg.ColorVision != a.config.ColorVision {
+		if err := themes.SetColorVision(themes.ColorVision(cfg.ColorVision)); err != nil {
+			a.output = append(a.output, "🥺 Oops! Couldn't reload the color vision: "+err.Error())
+		}
+	}
+	if err := themes.SetOverrides(cfg.Components); err != nil {
+		a.output = append(a.output, "🥺 Oops! Couldn't reload the component styles: "+err.Error())
+	}
+	if err := themes.ReloadThemes(filepath.Join(config.Dir(), "themes")); err != nil {
+		a.output = append(a.output, "🥺 Oops! Couldn't reload themes: "+err.Error())
+	}
+	if !cfg.SyncBackground && a.syncedTheme != nil {
+		a.syncedTheme = nil
+		terminal := a.terminal()
+		a.cmds = append(a.cmds, func() tea.Msg {
+			return TerminalColorsMsg{Err: shell.ResetTerminalColors(terminal)}
+		})
+	}
+	a.reloadThemeSchedule(cfg.ThemeSchedule)
+
+	id := a.theme.ID()
+	if cfg.Theme != a.config.Theme {
+		id = cmp.Or(cfg.Theme, "sakura")
+	}
+	a.config = cfg
+	switch s := a.themeSchedule; {
+	case s != nil && s.started && !s.override:
+		a.theme = s.theme()
+	case themes.GetTheme(id) != nil:
+		a.theme = themes.GetTheme(id)
+	case a.surprise != nil && id == "surprise":
+		// checked when it was made up
+		theme, _ := a.surprise.Theme(id)
+		a.theme = theme.Dressed()
+	default:
+		a.output = append(a.output, "🥺 Oops: there's no "+id+" theme anymore, keeping it until there is")
+	}
+	a.refreshThemeLists()
+	a.completer.SetAliases(a.aliases())
+	a.refreshPrompt(time.Now())
+	a.resizePanes()
+	a.output = append(a.output, a.theme.Styles.Info.Render("🎨 Theme files reloaded"))
+	return a.watchThemeFiles()
+}
+
+// reloadThemeSchedule follows the theme schedule of the config again, with
+// its themes as they're now, keeping whose turn it is
+func (a *App) reloadThemeSchedule(cfg config.ThemeScheduleConfig) {
+	schedule, err := newThemeSchedule(cfg)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops! Couldn't reload the theme schedule: "+err.Error())
+		return
+	}
+	if s := a.themeSchedule; s != nil && schedule != nil {
+		schedule.dark, schedule.started, schedule.override = s.dark, s.started, s.override
+		schedule.checkedAt, schedule.pending = s.checkedAt, s.pending
+	}
+	a.themeSchedule = schedule
+}
+
+// refreshThemeLists has the open theme picker and gallery list the themes
+// as they're now, on the same ones
+func (a *App) refreshThemeLists() {
+	if p := a.themePicker; p != nil {
+		p.themes, p.selected = refreshThemes(p.themes, p.selected)
+		a.theme = p.themes[p.selected]
+		if before := themes.GetTheme(p.before.ID()); before != nil {
+			p.before = before
+		}
+	}
+	if g := a.gallery; g != nil {
+		g.themes, g.selected = refreshThemes(g.themes, g.selected)
+	}
+}
+
+// refreshThemes returns the themes as they're now, and where the selected
+// one is among them, the first one when it's gone
+func refreshThemes(list []*themes.KawaiiTheme, selected int) ([]*themes.KawaiiTheme, int) {
+	id := list[selected].ID()
+	list = themes.GetThemes()
+	return list, max(slices.IndexFunc(list, func(t *themes.KawaiiTheme) bool { return t.ID() == id }), 0)
+}
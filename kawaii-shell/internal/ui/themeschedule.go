@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/config"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+	"github.com/pcstyle/kawaii-shell/internal/themes"
+)
+
+// themeScheduleCheck is how often the theme schedule looks at the clock,
+// or at the system's dark mode
+const themeScheduleCheck = time.Minute
+
+// AppearanceMsg carries whether the system was in dark mode at the time,
+// OK being false when it couldn't be told
+type AppearanceMsg struct {
+	Time time.Time
+	Dark bool
+	OK   bool
+}
+
+// themeSchedule switches between a day and a night theme, by the hours or
+// the system's dark mode
+type themeSchedule struct {
+	day, night *themes.KawaiiTheme
+	hours      config.Hours
+	system     bool
+
+	// dark is whether it's the night theme's turn, once started
+	dark, started bool
+
+	// override is set while a theme picked by hand is kept, until the
+	// schedule switches next
+	override bool
+
+	checkedAt time.Time
+	pending   bool
+}
+
+// newThemeSchedule returns the theme schedule of the config, or nil when
+// there's none
+func newThemeSchedule(cfg config.ThemeScheduleConfig) (*themeSchedule, error) {
+	if cfg.Day == "" && cfg.Night == "" {
+		return nil, nil
+	}
+	if cfg.Day == "" || cfg.Night == "" {
+		return nil, errors.New("the theme schedule needs both a day and a night theme")
+	}
+	day, night := themes.GetTheme(cfg.Day), themes.GetTheme(cfg.Night)
+	switch {
+	case day == nil:
+		return nil, fmt.Errorf("there's no %s theme for the day", cfg.Day)
+	case night == nil:
+		return nil, fmt.Errorf("there's no %s theme for the night", cfg.Night)
+	}
+	return &themeSchedule{day: day, night: night, hours: cfg.DayHours, system: cfg.System}, nil
+}
+
+// theme returns the theme it's the turn of
+func (s *themeSchedule) theme() *themes.KawaiiTheme {
+	if s.dark {
+		return s.night
+	}
+	return s.day
+}
+
+// followThemeSchedule switches the theme when the day or the night starts,
+// checking every themeScheduleCheck. The system's dark mode is read in the
+// background.
+func (a *App) followThemeSchedule(now time.Time) tea.Cmd {
+	s := a.themeSchedule
+	if s == nil || s.pending || now.Sub(s.checkedAt) < themeScheduleCheck {
+		return nil
+	}
+	if a.themePicker != nil || a.gallery != nil {
+		// not while themes are being tried on
+		return nil
+	}
+	s.checkedAt = now
+	if !s.system {
+		a.switchThemeFor(!s.hours.Contains(now))
+		return nil
+	}
+	s.pending = true
+	return func() tea.Msg {
+		dark, ok := shell.ReadAppearance()
+		return AppearanceMsg{Time: now, Dark: dark, OK: ok}
+	}
+}
+
+// updateAppearance switches the theme by the system's dark mode, going by
+// the hours when it couldn't be told
+func (a *App) updateAppearance(msg AppearanceMsg) {
+	s := a.themeSchedule
+	s.pending = false
+	if !msg.OK {
+		msg.Dark = !s.hours.Contains(msg.Time)
+	}
+	a.switchThemeFor(msg.Dark)
+}
+
+// switchThemeFor switches to the day or the night theme when it's not the
+// one already, a theme picked by hand being kept until then
+func (a *App) switchThemeFor(dark bool) {
+	s := a.themeSchedule
+	if s.started && s.dark == dark {
+		return
+	}
+	first := !s.started
+	s.started, s.dark = true, dark
+	s.override = false
+	a.theme = s.theme()
+	if first {
+		return
+	}
+	if dark {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🌙 Good evening! Switching to "+a.theme.Name+" for the night"))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render("☀️ Good morning! Switching to "+a.theme.Name+" for the day"))
+}
+
+// resumeThemeSchedule goes back to the theme of the schedule after one was
+// picked by hand
+func (a *App) resumeThemeSchedule() {
+	s := a.themeSchedule
+	if s == nil {
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			"🎨 There's no theme schedule yet, set a day and a night theme in theme_schedule in config.yaml"))
+		return
+	}
+	s.override = false
+	if s.started {
+		a.theme = s.theme()
+	}
+	a.output = append(a.output, a.theme.Styles.Success.Render("🎨 Back on schedule with "+a.theme.Name))
+}
+
+// themeScheduleView renders whether it's the day or the night theme for
+// the breadcrumb bar, with a hand when one was picked by hand
+func (a *App) themeScheduleView() string {
+	s := a.themeSchedule
+	if s == nil || !s.started {
+		return ""
+	}
+	view := "☀️"
+	if s.dark {
+		view = "🌙"
+	}
+	if s.override {
+		view += "✋"
+	}
+	return view
+}
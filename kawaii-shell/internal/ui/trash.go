@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// maxTrashNames is the number of file names listed after trashing them
+const maxTrashNames = 3
+
+// handleTrashCommand moves the targets of rm commands to the trash and runs
+// the undo, restore and trash kawaii commands, returning whether the command
+// was handled
+func (a *App) handleTrashCommand(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch fields[0] {
+	case "undo":
+		restored, err := a.trash.Undo()
+		a.showRestored(restored, err)
+	case "restore":
+		if len(fields) == 1 {
+			a.output = append(a.output, "🥺 Oops: restore what? Try trash to see what's there")
+			break
+		}
+		for _, name := range fields[1:] {
+			restored, err := a.trash.Restore(name, a.shellCwd())
+			a.showRestored(restored, err)
+		}
+	case "trash":
+		a.showTrash()
+	case "rm":
+		rm, ok := shell.ParseRm(command, a.shellCwd())
+		if !ok {
+			return false
+		}
+		a.trashFiles(rm)
+	default:
+		return false
+	}
+	return true
+}
+
+// trashFiles moves the targets of the rm command to the trash, complaining
+// about the same things rm would
+func (a *App) trashFiles(rm shell.RmCommand) {
+	var targets []string
+	for _, path := range rm.Paths {
+		info, err := os.Lstat(path)
+		switch {
+		case err != nil && rm.Force:
+		case err != nil:
+			a.output = append(a.output, "🥺 Oops: "+filepath.Base(path)+" doesn't exist")
+		case info.IsDir() && !rm.Recursive:
+			a.output = append(a.output, "🥺 Oops: "+filepath.Base(path)+" is a folder, use rm -r")
+		default:
+			targets = append(targets, path)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	moved, err := a.trash.Move(targets)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	if len(moved) > 0 {
+		a.pets.CountCleanup(len(moved), time.Now())
+		a.output = append(a.output, a.theme.Styles.Success.Render(
+			"🗑️ Moved "+trashNames(moved)+" to the trash! Type undo to bring it back 💕",
+		))
+	}
+}
+
+// showRestored reports the entries brought back from the trash
+func (a *App) showRestored(restored []shell.TrashEntry, err error) {
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+	}
+	if len(restored) > 0 {
+		a.output = append(a.output, a.theme.Styles.Success.Render("✨ Brought back "+trashNames(restored)+"!"))
+	}
+}
+
+// showTrash lists what's in the trash, most recent first
+func (a *App) showTrash() {
+	entries := a.trash.Entries()
+	if len(entries) == 0 {
+		a.output = append(a.output, a.theme.Styles.Info.Render("🗑️ The trash is empty and sparkly ✨"))
+		return
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		a.output = append(a.output, a.theme.Styles.Info.Render(
+			fmt.Sprintf("%s  %s  %s", e.DeletedAt.Format("Jan 02 15:04"), e.ID, e.Path),
+		))
+	}
+	a.output = append(a.output, a.theme.Styles.Help.Render("Use restore <name or id> to bring something back"))
+}
+
+// trashNames lists the names of the entries for messages
+func trashNames(entries []shell.TrashEntry) string {
+	names := make([]string, 0, maxTrashNames)
+	for _, e := range entries[:min(len(entries), maxTrashNames)] {
+		names = append(names, filepath.Base(e.Path))
+	}
+	if more := len(entries) - len(names); more > 0 {
+		return strings.Join(names, ", ") + fmt.Sprintf(" and %d more", more)
+	}
+	return strings.Join(names, ", ")
+}
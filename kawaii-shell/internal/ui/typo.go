@@ -0,0 +1,50 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
+)
+
+// offerCorrection shows the banner fixing a misspelled command name, when
+// there's a known command close enough
+func (a *App) offerCorrection(command string) {
+	correction, ok := shell.Correct(command, a.completer.Commands(), a.history.Entries())
+	if !ok {
+		return
+	}
+	a.correction = &correction
+}
+
+// handleCorrectionKey runs the corrected command with enter and dismisses it
+// with esc, while nothing has been typed. It reports whether the key was
+// used.
+func (a *App) handleCorrectionKey(msg tea.KeyMsg) bool {
+	if a.input.Value() != "" {
+		return false
+	}
+	switch msg.String() {
+	case "enter":
+		command := a.correction.Command
+		a.correction = nil
+		a.submit(command)
+	case "esc":
+		a.correction = nil
+	default:
+		return false
+	}
+	return true
+}
+
+// correctionView renders the banner offering the correction, it takes the
+// place of the blank line above the input
+func (a *App) correctionView() string {
+	if a.correction == nil {
+		return ""
+	}
+	accent := lipgloss.NewStyle().Foreground(a.theme.Roles.Accent).Bold(true)
+	return lipgloss.NewStyle().MaxWidth(a.width).Render(
+		"🤔 Did you mean " + accent.Render(a.correction.From+" → "+a.correction.To) + "? " +
+			lipgloss.NewStyle().Faint(true).Render("enter to run "+a.correction.Command+" • esc to dismiss"),
+	)
+}
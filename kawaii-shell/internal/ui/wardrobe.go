@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
+)
+
+// wardrobePanel lists the accessories in place of the output, to dress up
+// the companion
+type wardrobePanel struct {
+	selected int
+}
+
+// toggleAccessory puts the accessory on the companion, or takes it off
+func (a *App) toggleAccessory(accessory pet.Accessory) {
+	wearing, err := a.pets.ToggleAccessory(accessory)
+	if err != nil {
+		a.output = append(a.output, "🥺 Oops: "+err.Error())
+		return
+	}
+	if wearing {
+		a.output = append(a.output, a.theme.Styles.Success.Render(fmt.Sprintf(
+			"%s %s looks adorable in the %s!", accessory.Emoji, a.pet.Name, strings.ToLower(accessory.Name))))
+		return
+	}
+	a.output = append(a.output, a.theme.Styles.Info.Render(fmt.Sprintf(
+		"%s %s took off the %s", accessory.Emoji, a.pet.Name, strings.ToLower(accessory.Name))))
+}
+
+// handleWardrobeKey handles the keys of the wardrobe, nothing else gets
+// them until it's closed
+func (a *App) handleWardrobeKey(msg tea.KeyMsg) {
+	w := a.wardrobe
+	switch msg.String() {
+	case "esc", "q":
+		a.wardrobe = nil
+		return
+	case "up", "k", "ctrl+p":
+		w.selected--
+	case "down", "j", "ctrl+n":
+		w.selected++
+	case "enter", " ":
+		// the wardrobe stays open to try on something else
+		a.toggleAccessory(pet.Accessories[w.selected])
+	}
+	w.selected = min(max(w.selected, 0), len(pet.Accessories)-1)
+}
+
+// wardrobeView renders the accessories, the locked ones telling how to
+// earn them
+func (a *App) wardrobeView(height int) string {
+	cols, _ := a.outputSize()
+	title := fmt.Sprintf("👗 Wardrobe • %d of %d unlocked • %s is wearing", len(a.pets.Wardrobe), len(pet.Accessories), a.pet.Name)
+	worn := 0
+	for _, accessory := range pet.Accessories {
+		if a.pet.Wears(accessory) {
+			title += " " + accessory.Emoji
+			worn++
+		}
+	}
+	if worn == 0 {
+		title += " nothing yet"
+	}
+	lines := []string{a.theme.Styles.Help.Render(title)}
+
+	rows := max(a.outputRows(height)-1, 1)
+	start := max(a.wardrobe.selected-rows+1, 0)
+	for i, accessory := range pet.Accessories[start:min(start+rows, len(pet.Accessories))] {
+		var line string
+		switch {
+		case !a.pets.Unlocked(accessory):
+			earnedBy := accessory.EarnedBy()
+			line = fmt.Sprintf("🔒 %s · earned by %s %s", accessory.Name, earnedBy.Emoji, earnedBy.Name)
+		case a.pet.Wears(accessory):
+			line = fmt.Sprintf("%s %s · 💕 wearing", accessory.Emoji, accessory.Name)
+		default:
+			line = fmt.Sprintf("%s %s", accessory.Emoji, accessory.Name)
+		}
+		if start+i == a.wardrobe.selected {
+			lines = append(lines, a.theme.Styles.Highlight.Render(ansi.Truncate("▶ "+line, cols-2, "…")))
+			continue
+		}
+		if !a.pets.Unlocked(accessory) {
+			line = lipgloss.NewStyle().Faint(true).Render(line)
+		}
+		lines = append(lines, ansi.Truncate("  "+line, cols, "…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wardrobeInputView renders the help of the wardrobe in place of the input
+func (a *App) wardrobeInputView() string {
+	return a.theme.Styles.Prompt.Render("👗 wardrobe") +
+		lipgloss.NewStyle().Faint(true).Render("  ↑↓ pick • enter to put it on or take it off • esc close")
+}
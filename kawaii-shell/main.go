@@ -6,7 +6,9 @@ import (
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pcstyle/kawaii-shell/internal/shell"
 	"github.com/pcstyle/kawaii-shell/internal/ui"
+	"github.com/pcstyle/kawaii-shell/internal/ui/pet"
 )
 
 func main() {
@@ -14,19 +16,58 @@ func main() {
 		fmt.Println("🌸 Kawaii Shell v0.1.0 - Making terminals adorable! ✨")
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "react" {
+		os.Exit(react(os.Args[2:]))
+	}
 
 	// Create the main Bubble Tea application
 	app := ui.NewApp()
+	out := shell.NewTerminalOutput(os.Stdout)
+	app.SetOutput(out)
 
 	// Initialize Bubble Tea program
 	p := tea.NewProgram(
 		app,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithReportFocus(),
+		tea.WithOutput(out),
 	)
 
 	// Start the program
-	if _, err := p.Run(); err != nil {
+	_, err := p.Run()
+	// the terminal gets its own colors back, however the shell ended
+	if err := app.RestoreTerminalColors(); err != nil {
+		log.Printf("🥺 Oops: %v", err)
+	}
+	if err != nil {
 		log.Fatalf("🥺 Oops! Something went wrong: %v", err)
 	}
 }
+
+// react has the pets of the running kawaii shells react to news, for CI
+// webhooks, build watchers and the like:
+//
+//	kawaii-shell react celebrate 0.8 Build passed!
+func react(args []string) int {
+	kind, intensity, message, err := pet.ParseReactionArgs(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "🥺 Oops: "+err.Error())
+		return 2
+	}
+	reached, err := shell.React(shell.DefaultNeighborsDir(), shell.Reaction{Kind: string(kind), Intensity: intensity, Message: message})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "🥺 Oops: "+err.Error())
+		return 1
+	}
+	if reached == 0 {
+		fmt.Fprintln(os.Stderr, "🥺 No kawaii shells are listening, is one running with neighbors on?")
+		return 1
+	}
+	if reached == 1 {
+		fmt.Println("🐾 The pet of 1 kawaii shell heard the news")
+		return 0
+	}
+	fmt.Printf("🐾 The pets of %d kawaii shells heard the news\n", reached)
+	return 0
+}
@@ -0,0 +1,155 @@
+package fang
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/spf13/cobra"
+)
+
+const logoTemplateFunc = "fangLogo"
+
+// logos holds the logo renderers for each root command executed with
+// [WithLogoHeader], so they can be rendered lazily by the version template.
+var logos sync.Map // map[*cobra.Command]func(colorprofile.Profile) Logo
+
+func init() {
+	cobra.AddTemplateFunc(logoTemplateFunc, func(c *cobra.Command) string {
+		fn, ok := logos.Load(c.Root())
+		if !ok {
+			return ""
+		}
+		var buf bytes.Buffer
+		w := &colorprofile.Writer{
+			Forward: &buf,
+			Profile: newWriter(c.OutOrStdout()).Profile,
+		}
+		writeLogo(w, c.Root().Name(), fn.(func(colorprofile.Profile) Logo)(w.Profile))
+		_, _ = w.Write([]byte("\n"))
+		return buf.String()
+	})
+}
+
+// logoGlyphs is a small figlet-like font, three rows high.
+var logoGlyphs = map[rune][3]string{
+	'a': {"┏━┓", "┣━┫", "╹ ╹"},
+	'b': {"┏┓ ", "┣┻┓", "┗━┛"},
+	'c': {"┏━╸", "┃  ", "┗━╸"},
+	'd': {"╺┳┓", " ┃┃", "╺┻┛"},
+	'e': {"┏━╸", "┣╸ ", "┗━╸"},
+	'f': {"┏━╸", "┣╸ ", "╹  "},
+	'g': {"┏━╸", "┃╺┓", "┗━┛"},
+	'h': {"╻ ╻", "┣━┫", "╹ ╹"},
+	'i': {"╻", "┃", "╹"},
+	'j': {"  ╻", "  ┃", "┗━┛"},
+	'k': {"╻┏ ", "┣┻┓", "╹ ╹"},
+	'l': {"╻  ", "┃  ", "┗━╸"},
+	'm': {"┏┳┓", "┃┃┃", "╹ ╹"},
+	'n': {"┏┓╻", "┃┗┫", "╹ ╹"},
+	'o': {"┏━┓", "┃ ┃", "┗━┛"},
+	'p': {"┏━┓", "┣━┛", "╹  "},
+	'q': {"┏━┓", "┃┓┃", "┗┻┛"},
+	'r': {"┏━┓", "┣┳┛", "╹┗╸"},
+	's': {"┏━┓", "┗━┓", "┗━┛"},
+	't': {"╺┳╸", " ┃ ", " ╹ "},
+	'u': {"╻ ╻", "┃ ┃", "┗━┛"},
+	'v': {"╻ ╻", "┃┏┛", "┗┛ "},
+	'w': {"╻ ╻", "┃╻┃", "┗┻┛"},
+	'x': {"╻ ╻", "┏╋┛", "╹ ╹"},
+	'y': {"╻ ╻", "┗┳┛", " ╹ "},
+	'z': {"╺━┓", "┏━┛", "┗━╸"},
+	'0': {"┏━┓", "┃┃┃", "┗━┛"},
+	'1': {"╺┓ ", " ┃ ", "╺┻╸"},
+	'2': {"┏━┓", "┏━┛", "┗━╸"},
+	'3': {"┏━┓", "╺━┫", "┗━┛"},
+	'4': {"╻ ╻", "┗━┫", "  ╹"},
+	'5': {"┏━╸", "┗━┓", "┗━┛"},
+	'6': {"┏━┓", "┣━┓", "┗━┛"},
+	'7': {"┏━┓", "  ┃", "  ╹"},
+	'8': {"┏━┓", "┣━┫", "┗━┛"},
+	'9': {"┏━┓", "┗━┫", "┗━┛"},
+	'-': {"   ", "╺━╸", "   "},
+	'_': {"   ", "   ", "╺━╸"},
+	'.': {" ", " ", "╹"},
+	' ': {"  ", "  ", "  "},
+	'?': {"┏━┓", " ┏┛", " ╹ "},
+}
+
+// logoLines renders the given text in the logo font.
+func logoLines(text string) [3][]rune {
+	var lines [3][]rune
+	for i, r := range text {
+		glyph, ok := logoGlyphs[unicode.ToLower(r)]
+		if !ok {
+			glyph = logoGlyphs['?']
+		}
+		for row := range lines {
+			if i > 0 {
+				lines[row] = append(lines[row], ' ')
+			}
+			lines[row] = append(lines[row], []rune(glyph[row])...)
+		}
+	}
+	return lines
+}
+
+// gradient returns size colors blended between the given start and end
+// colors.
+func gradient(size int, start, end color.Color) []color.Color {
+	colors := make([]color.Color, size)
+	from, ok1 := colorful.MakeColor(start)
+	to, ok2 := colorful.MakeColor(end)
+	for i := range colors {
+		if !ok1 || !ok2 || size == 1 {
+			colors[i] = start
+			continue
+		}
+		colors[i] = from.BlendLuv(to, float64(i)/float64(size-1)).Clamped()
+	}
+	return colors
+}
+
+// renderLogo renders the given program name in a large font, with a
+// horizontal gradient.
+func renderLogo(name string, styles Logo) string {
+	lines := logoLines(name)
+	width := len(lines[0])
+	if width == 0 {
+		return ""
+	}
+
+	cells := make([]lipgloss.Style, width)
+	if styles.Gradient[0] != nil && styles.Gradient[1] != nil {
+		for i, c := range gradient(width, styles.Gradient[0], styles.Gradient[1]) {
+			cells[i] = lipgloss.NewStyle().Foreground(c)
+		}
+	}
+
+	rows := make([]string, len(lines))
+	for row, line := range lines {
+		var sb strings.Builder
+		for col, r := range line {
+			if r == ' ' {
+				sb.WriteRune(r)
+				continue
+			}
+			sb.WriteString(cells[col].Render(string(r)))
+		}
+		rows[row] = sb.String()
+	}
+	return styles.Base.Render(strings.Join(rows, "\n"))
+}
+
+func writeLogo(w *colorprofile.Writer, name string, styles Logo) {
+	logo := renderLogo(name, styles)
+	if logo == "" {
+		return
+	}
+	_, _ = w.Write([]byte("\n" + logo + "\n"))
+}
@@ -0,0 +1,109 @@
+package fang
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/charmtone"
+	"github.com/spf13/cobra"
+)
+
+const defaultScreenshotWidth = 80
+
+// docsCmd creates the hidden `docs` command, which holds the documentation
+// generators.
+func docsCmd(opts settings) *cobra.Command {
+	docs := &cobra.Command{
+		Use:                   "docs",
+		Short:                 "Generates documentation",
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Hidden:                true,
+		Args:                  cobra.NoArgs,
+	}
+	docs.AddCommand(screenshotsCmd(opts))
+	return docs
+}
+
+// screenshotsCmd creates the `docs screenshots` command, which renders the
+// help of every command as a SVG image.
+func screenshotsCmd(opts settings) *cobra.Command {
+	var output string
+	var cols int
+	var light bool
+	cmd := &cobra.Command{
+		Use:   "screenshots",
+		Short: "Renders the help of every command as SVG images",
+		Long: "Renders the help of every command as SVG images, at a fixed width " +
+			"and in true color, so they can be embedded in READMEs.\n" +
+			"Images are named after the command path, e.g. `app-sub.svg`.",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := os.MkdirAll(output, 0o750); err != nil {
+				return fmt.Errorf("could not create output directory: %w", err)
+			}
+
+			// render at the requested width, regardless of the terminal.
+			cs := opts.colorscheme(lipgloss.LightDark(!light))
+			styles := opts.stylesFor(cs, colorprofile.TrueColor, false, cols)
+			svg := svgOptions{
+				Columns:    cols,
+				Foreground: cs.Base,
+				Background: lipgloss.LightDark(!light)(charmtone.Salt, charmtone.Pepper),
+			}
+
+			for _, c := range screenshotCommands(cmd.Root()) {
+				var buf bytes.Buffer
+				w := &colorprofile.Writer{
+					Forward: &buf,
+					Profile: colorprofile.TrueColor,
+				}
+				opts.writeHelp(c, w, styles, cols)
+
+				name := filepath.Join(output, strings.ReplaceAll(c.CommandPath(), " ", "-")+".svg")
+				if err := writeScreenshot(name, buf.String(), svg); err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "screenshots", "Directory to write the images to")
+	cmd.Flags().IntVar(&cols, "width", defaultScreenshotWidth, "Width of the help, in columns")
+	cmd.Flags().BoolVar(&light, "light", false, "Use the light variant of the colorscheme")
+	return cmd
+}
+
+// screenshotCommands returns the given command and all its available sub
+// commands and help topics.
+func screenshotCommands(c *cobra.Command) []*cobra.Command {
+	cmds := []*cobra.Command{c}
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() || sub.IsAdditionalHelpTopicCommand() {
+			cmds = append(cmds, screenshotCommands(sub)...)
+		}
+	}
+	return cmds
+}
+
+func writeScreenshot(name, help string, opts svgOptions) error {
+	f, err := os.Create(name) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("could not create screenshot: %w", err)
+	}
+	if err := renderSVG(f, help, opts); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("could not write screenshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("could not write screenshot: %w", err)
+	}
+	return nil
+}
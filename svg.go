@@ -0,0 +1,235 @@
+package fang
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgLineHeight = 20
+	svgPadding    = 20
+	svgTitleBar   = 28
+)
+
+// svgOptions configures the rendering of ANSI text as SVG.
+type svgOptions struct {
+	Columns    int
+	Foreground color.Color
+	Background color.Color
+}
+
+// svgStyle is the state of the SGR attributes of a cell.
+type svgStyle struct {
+	fg, bg        color.Color
+	bold          bool
+	faint         bool
+	italic        bool
+	underline     bool
+	strikethrough bool
+	reverse       bool
+}
+
+// svgRun is a sequence of cells sharing the same style.
+type svgRun struct {
+	text  string
+	col   int
+	width int
+	style svgStyle
+}
+
+// parseANSI splits the given ANSI text into lines of styled runs.
+func parseANSI(s string) [][]svgRun {
+	var lines [][]svgRun
+	var line []svgRun
+	var style svgStyle
+	var col int
+	var state byte
+
+	p := ansi.GetParser()
+	defer ansi.PutParser(p)
+
+	for len(s) > 0 {
+		p.Reset()
+		seq, width, n, newState := ansi.DecodeSequence(s, state, p)
+		s, state = s[n:], newState
+
+		switch {
+		case seq == "\n":
+			lines = append(lines, line)
+			line, col = nil, 0
+		case ansi.HasCsiPrefix(seq) && p.Command() == 'm':
+			style = applySgr(style, p.Params())
+		case width > 0:
+			if last := len(line) - 1; last >= 0 && line[last].style == style {
+				line[last].text += seq
+				line[last].width += width
+			} else {
+				line = append(line, svgRun{text: seq, col: col, width: width, style: style})
+			}
+			col += width
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func applySgr(style svgStyle, params ansi.Params) svgStyle {
+	if len(params) == 0 {
+		return svgStyle{}
+	}
+	for i := 0; i < len(params); i++ {
+		switch param := params[i].Param(0); param {
+		case 0:
+			style = svgStyle{}
+		case 1:
+			style.bold = true
+		case 2:
+			style.faint = true
+		case 3:
+			style.italic = true
+		case 4:
+			style.underline = true
+		case 7:
+			style.reverse = true
+		case 9:
+			style.strikethrough = true
+		case 22:
+			style.bold, style.faint = false, false
+		case 23:
+			style.italic = false
+		case 24:
+			style.underline = false
+		case 27:
+			style.reverse = false
+		case 29:
+			style.strikethrough = false
+		case 30, 31, 32, 33, 34, 35, 36, 37:
+			style.fg = ansi.BasicColor(param - 30) //nolint:gosec
+		case 38, 48:
+			var c color.Color
+			if n := ansi.ReadStyleColor(params[i:], &c); n > 0 {
+				i += n - 1
+			}
+			if param == 38 {
+				style.fg = c
+			} else {
+				style.bg = c
+			}
+		case 39:
+			style.fg = nil
+		case 40, 41, 42, 43, 44, 45, 46, 47:
+			style.bg = ansi.BasicColor(param - 40) //nolint:gosec
+		case 49:
+			style.bg = nil
+		case 90, 91, 92, 93, 94, 95, 96, 97:
+			style.fg = ansi.BasicColor(param - 90 + 8) //nolint:gosec
+		case 100, 101, 102, 103, 104, 105, 106, 107:
+			style.bg = ansi.BasicColor(param - 100 + 8) //nolint:gosec
+		}
+	}
+	return style
+}
+
+// renderSVG renders the given ANSI text as a SVG image of a terminal window.
+func renderSVG(w io.Writer, s string, opts svgOptions) error {
+	lines := parseANSI(s)
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	cols := opts.Columns
+	for _, line := range lines {
+		if len(line) > 0 {
+			last := line[len(line)-1]
+			cols = max(cols, last.col+last.width)
+		}
+	}
+
+	width := float64(cols)*svgCellWidth + 2*svgPadding
+	height := len(lines)*svgLineHeight + 2*svgPadding + svgTitleBar
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%d" viewBox="0 0 %g %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" rx="8" fill="%s"/>`+"\n", svgColor(opts.Background))
+	for i, c := range []string{"#FF5F57", "#FEBC2E", "#28C840"} {
+		fmt.Fprintf(&sb, `<circle cx="%d" cy="%d" r="6" fill="%s"/>`+"\n", svgPadding+i*20, svgTitleBar/2+4, c)
+	}
+	fmt.Fprintf(&sb, `<g font-family="ui-monospace,SFMono-Regular,Menlo,Consolas,monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+
+	for row, line := range lines {
+		y := svgTitleBar + svgPadding + row*svgLineHeight
+		for _, run := range line {
+			fg, bg := run.style.fg, run.style.bg
+			if fg == nil {
+				fg = opts.Foreground
+			}
+			if run.style.reverse {
+				fg, bg = cmpColor(bg, opts.Background), fg
+			}
+			x := svgPadding + float64(run.col)*svgCellWidth
+			if bg != nil {
+				fmt.Fprintf(&sb, `<rect x="%g" y="%d" width="%g" height="%d" fill="%s"/>`+"\n", x, y, float64(run.width)*svgCellWidth, svgLineHeight, svgColor(bg))
+			}
+			if strings.TrimSpace(run.text) == "" && !run.style.underline && !run.style.strikethrough {
+				continue
+			}
+			fmt.Fprintf(&sb, `<text x="%g" y="%d" fill="%s"%s>%s</text>`+"\n", x, y+svgLineHeight*3/4, svgColor(fg), svgAttrs(run.style), html.EscapeString(run.text))
+		}
+	}
+
+	sb.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err //nolint:wrapcheck
+}
+
+func svgAttrs(style svgStyle) string {
+	var attrs []string
+	if style.bold {
+		attrs = append(attrs, `font-weight="bold"`)
+	}
+	if style.italic {
+		attrs = append(attrs, `font-style="italic"`)
+	}
+	if style.faint {
+		attrs = append(attrs, `opacity="0.6"`)
+	}
+	var decorations []string
+	if style.underline {
+		decorations = append(decorations, "underline")
+	}
+	if style.strikethrough {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		attrs = append(attrs, `text-decoration="`+strings.Join(decorations, " ")+`"`)
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " " + strings.Join(attrs, " ")
+}
+
+func svgColor(c color.Color) string {
+	cc, ok := colorful.MakeColor(c)
+	if !ok {
+		return "none"
+	}
+	return cc.Hex()
+}
+
+func cmpColor(c, fallback color.Color) color.Color {
+	if c == nil {
+		return fallback
+	}
+	return c
+}
@@ -0,0 +1,25 @@
+package fang
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+func TestRenderSVG(t *testing.T) {
+	text := "\n  " + lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#6B50FF")).Render("USAGE") +
+		"\n\n  " + lipgloss.NewStyle().Background(lipgloss.Color("#2D2C35")).Render(" app <cmd> ") +
+		"\n  \x1b[4;31mfoo\x1b[24m & <bar>\x1b[m\n\n"
+
+	var buf bytes.Buffer
+	if err := renderSVG(&buf, text, svgOptions{
+		Columns:    20,
+		Foreground: lipgloss.Color("#DFDBDD"),
+		Background: lipgloss.Color("#201F26"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	golden.RequireEqual(t, buf.Bytes())
+}
@@ -30,6 +30,7 @@ type ColorScheme struct {
 	Dash           color.Color
 	ErrorHeader    [2]color.Color // 0=fg 1=bg
 	ErrorDetails   color.Color
+	Logo           [2]color.Color // gradient: 0=start 1=end
 }
 
 // DefaultTheme is the default colorscheme.
@@ -58,6 +59,10 @@ func DefaultColorScheme(c lipgloss.LightDarkFunc) ColorScheme {
 			charmtone.Butter,
 			charmtone.Cherry,
 		},
+		Logo: [2]color.Color{
+			charmtone.Charple,
+			charmtone.Dolly,
+		},
 	}
 }
 
@@ -78,6 +83,7 @@ func AnsiColorScheme(c lipgloss.LightDarkFunc) ColorScheme {
 		Dash:         base,
 		ErrorHeader:  [2]color.Color{lipgloss.Black, lipgloss.Red},
 		ErrorDetails: lipgloss.Red,
+		Logo:         [2]color.Color{lipgloss.Blue, lipgloss.Magenta},
 	}
 }
 
@@ -85,13 +91,16 @@ func AnsiColorScheme(c lipgloss.LightDarkFunc) ColorScheme {
 type Styles struct {
 	Text            lipgloss.Style
 	Title           lipgloss.Style
+	Banner          lipgloss.Style
 	Span            lipgloss.Style
 	ErrorHeader     lipgloss.Style
 	ErrorText       lipgloss.Style
+	HelpFooter      lipgloss.Style
 	FlagDescription lipgloss.Style
 	FlagDefault     lipgloss.Style
 	Codeblock       Codeblock
 	Program         Program
+	Logo            Logo
 }
 
 // Codeblock styles.
@@ -102,6 +111,12 @@ type Codeblock struct {
 	Comment lipgloss.Style
 }
 
+// Logo styles.
+type Logo struct {
+	Base     lipgloss.Style
+	Gradient [2]color.Color // 0=start 1=end
+}
+
 // Program name, args, flags, styling.
 type Program struct {
 	Name           lipgloss.Style
@@ -120,7 +135,11 @@ func mustColorscheme(cs func(lipgloss.LightDarkFunc) ColorScheme) ColorScheme {
 	return cs(lipgloss.LightDark(isDark))
 }
 
-func makeStyles(cs ColorScheme) Styles {
+func makeStyles(cs ColorScheme, width int) Styles {
+	logo := cs.Logo
+	if logo[0] == nil || logo[1] == nil {
+		logo = [2]color.Color{cs.Title, cs.Program}
+	}
 	//nolint:mnd
 	return Styles{
 		Text: lipgloss.NewStyle().Foreground(cs.Base),
@@ -130,6 +149,9 @@ func makeStyles(cs ColorScheme) Styles {
 			Transform(strings.ToUpper).
 			Padding(1, 0).
 			Margin(0, 2),
+		Banner: lipgloss.NewStyle().
+			Foreground(cs.Title).
+			MarginLeft(shortPad),
 		FlagDescription: lipgloss.NewStyle().
 			Foreground(cs.Description).
 			Transform(titleFirstWord),
@@ -185,8 +207,17 @@ func makeStyles(cs ColorScheme) Styles {
 			Background(cs.Codeblock),
 		ErrorText: lipgloss.NewStyle().
 			MarginLeft(2).
-			Width(width() - 4).
+			Width(width - 4).
 			Transform(titleFirstWord),
+		HelpFooter: lipgloss.NewStyle().
+			Foreground(cs.Comment).
+			MarginLeft(shortPad),
+		Logo: Logo{
+			Base: lipgloss.NewStyle().
+				Bold(true).
+				MarginLeft(shortPad),
+			Gradient: logo,
+		},
 		ErrorHeader: lipgloss.NewStyle().
 			Foreground(cs.ErrorHeader[0]).
 			Background(cs.ErrorHeader[1]).
@@ -198,6 +229,32 @@ func makeStyles(cs ColorScheme) Styles {
 	}
 }
 
+// withoutBackgrounds removes the background fills from the given styles,
+// replacing the codeblock background with a left border.
+func withoutBackgrounds(styles Styles, cs ColorScheme) Styles {
+	unset := func(p Program) Program {
+		return Program{
+			Name:           p.Name.UnsetBackground(),
+			Command:        p.Command.UnsetBackground(),
+			Flag:           p.Flag.UnsetBackground(),
+			Argument:       p.Argument.UnsetBackground(),
+			DimmedArgument: p.DimmedArgument.UnsetBackground(),
+			QuotedString:   p.QuotedString.UnsetBackground(),
+		}
+	}
+	styles.Span = styles.Span.UnsetBackground()
+	styles.Codeblock = Codeblock{
+		Base: styles.Codeblock.Base.
+			UnsetBackground().
+			Border(lipgloss.ThickBorder(), false, false, false, true).
+			BorderForeground(cs.Comment),
+		Program: unset(styles.Codeblock.Program),
+		Text:    styles.Codeblock.Text.UnsetBackground(),
+		Comment: styles.Codeblock.Comment.UnsetBackground(),
+	}
+	return styles
+}
+
 func titleFirstWord(s string) string {
 	words := strings.Fields(s)
 	if len(words) == 0 {
@@ -0,0 +1,58 @@
+package fang
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/charmtone"
+)
+
+func TestDownsample(t *testing.T) {
+	cs := DefaultColorScheme(lipgloss.LightDark(true))
+
+	t.Run("truecolor", func(t *testing.T) {
+		if got := cs.Downsample(colorprofile.TrueColor); got != cs {
+			t.Errorf("expected colorscheme to be unchanged, got %+v", got)
+		}
+	})
+
+	t.Run("ansi256", func(t *testing.T) {
+		got := cs.Downsample(colorprofile.ANSI256)
+		for _, c := range []color.Color{got.Base, got.Title, got.Codeblock, got.Flag, got.Logo[0]} {
+			ec, ok := c.(ansi.ExtendedColor)
+			if !ok {
+				t.Fatalf("expected an extended color, got %T", c)
+			}
+			if ec < 16 {
+				t.Errorf("expected a color outside of the terminal's theme, got %d", ec)
+			}
+		}
+		if got.Help != nil {
+			t.Errorf("expected nil to be kept, got %v", got.Help)
+		}
+	})
+
+	t.Run("ansi", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			in       color.Color
+			expected color.Color
+		}{
+			"red":          {lipgloss.Color("#ff0000"), ansi.BrightRed},
+			"dark red":     {lipgloss.Color("#800000"), ansi.Red},
+			"green":        {charmtone.Guac, ansi.BrightGreen},
+			"light gray":   {charmtone.Ash, ansi.White},
+			"dark gray":    {charmtone.Charcoal, ansi.Black},
+			"already ansi": {ansi.Magenta, ansi.Magenta},
+			"extended":     {ansi.ExtendedColor(196), ansi.BrightRed},
+		} {
+			t.Run(name, func(t *testing.T) {
+				if got := downsampleColor(tt.in, colorprofile.ANSI); got != tt.expected {
+					t.Errorf("expected %v, got %v", tt.expected, got)
+				}
+			})
+		}
+	})
+}